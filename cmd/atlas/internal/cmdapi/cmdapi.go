@@ -177,10 +177,12 @@ func (v *Vars) Type() string {
 }
 
 const (
+	flagAcceptRenames  = "accept-renames"
 	flagAllowDirty     = "allow-dirty"
 	flagAutoApprove    = "auto-approve"
 	flagBaseline       = "baseline"
 	flagConfig         = "config"
+	flagDenyChanges    = "deny-changes"
 	flagDevURL         = "dev-url"
 	flagDirURL         = "dir"
 	flagDirFormat      = "dir-format"
@@ -196,15 +198,19 @@ const (
 	flagLatest         = "latest"
 	flagLockTimeout    = "lock-timeout"
 	flagLog            = "log"
+	flagPrune          = "prune"
 	flagRevisionSchema = "revisions-schema"
 	flagSchema         = "schema"
 	flagSchemaShort    = "s"
+	flagSkipChanges    = "skip-changes"
 	flagTo             = "to"
+	flagToVersion      = "to-version"
 	flagTxMode         = "tx-mode"
 	flagURL            = "url"
 	flagURLShort       = "u"
 	flagVar            = "var"
 	flagQualifier      = "qualifier"
+	flagReorderColumns = "reorder-columns"
 )
 
 func addGlobalFlags(set *pflag.FlagSet) {
@@ -213,10 +219,18 @@ func addGlobalFlags(set *pflag.FlagSet) {
 	set.StringVarP(&GlobalFlags.ConfigURL, flagConfig, "c", projectFileName, "select config (project) file using URL format")
 }
 
+func addFlagAcceptRenames(set *pflag.FlagSet, target *bool) {
+	set.BoolVar(target, flagAcceptRenames, false, "plan detected renames without prompting for confirmation")
+}
+
 func addFlagAutoApprove(set *pflag.FlagSet, target *bool) {
 	set.BoolVar(target, flagAutoApprove, false, "apply changes without prompting for approval")
 }
 
+func addFlagDenyChanges(set *pflag.FlagSet, target *[]string) {
+	set.StringSliceVar(target, flagDenyChanges, nil, "list of change types (e.g. drop_table) that fail the diff")
+}
+
 func addFlagDirFormat(set *pflag.FlagSet, target *string) {
 	set.StringVar(target, flagDirFormat, "atlas", "select migration file format")
 }
@@ -272,6 +286,14 @@ func addFlagLog(set *pflag.FlagSet, target *string) {
 	set.StringVar(target, flagLog, "", "go template to use to format logs")
 }
 
+func addFlagReorderColumns(set *pflag.FlagSet, target *bool) {
+	set.BoolVar(target, flagReorderColumns, false, "move existing columns to their position in the desired schema (MySQL only)")
+}
+
+func addFlagPrune(set *pflag.FlagSet, target *bool) {
+	set.BoolVar(target, flagPrune, false, "drop views, functions, procedures, triggers and objects (e.g. sequences) that are missing from the desired state")
+}
+
 func addFlagRevisionSchema(set *pflag.FlagSet, target *string) {
 	set.StringVar(target, flagRevisionSchema, "", "name of the schema the revisions table resides in")
 }
@@ -285,6 +307,10 @@ func addFlagSchemas(set *pflag.FlagSet, target *[]string) {
 	)
 }
 
+func addFlagSkipChanges(set *pflag.FlagSet, target *[]string) {
+	set.StringSliceVar(target, flagSkipChanges, nil, "list of change types (e.g. drop_column) to omit from the diff")
+}
+
 // addFlagURL adds a URL flag. If given, args[0] override the name, args[1] the shorthand.
 func addFlagURL(set *pflag.FlagSet, target *string, args ...string) {
 	name, short := flagURL, flagURLShort
@@ -342,6 +368,93 @@ func maySetFlag(cmd *cobra.Command, name, envVal string) error {
 	return cmd.Flags().Set(name, envVal)
 }
 
+// changeTypes maps the change types used by the diff policy to their schema changes.
+var changeTypes = map[string]schema.Change{
+	"add_schema":         &schema.AddSchema{},
+	"drop_schema":        &schema.DropSchema{},
+	"modify_schema":      &schema.ModifySchema{},
+	"add_table":          &schema.AddTable{},
+	"drop_table":         &schema.DropTable{},
+	"modify_table":       &schema.ModifyTable{},
+	"rename_table":       &schema.RenameTable{},
+	"add_column":         &schema.AddColumn{},
+	"drop_column":        &schema.DropColumn{},
+	"modify_column":      &schema.ModifyColumn{},
+	"rename_column":      &schema.RenameColumn{},
+	"add_index":          &schema.AddIndex{},
+	"drop_index":         &schema.DropIndex{},
+	"modify_index":       &schema.ModifyIndex{},
+	"rename_index":       &schema.RenameIndex{},
+	"add_foreign_key":    &schema.AddForeignKey{},
+	"drop_foreign_key":   &schema.DropForeignKey{},
+	"modify_foreign_key": &schema.ModifyForeignKey{},
+	"add_check":          &schema.AddCheck{},
+	"drop_check":         &schema.DropCheck{},
+	"modify_check":       &schema.ModifyCheck{},
+	"add_view":           &schema.AddView{},
+	"drop_view":          &schema.DropView{},
+	"modify_view":        &schema.ModifyView{},
+	"add_func":           &schema.AddFunc{},
+	"drop_func":          &schema.DropFunc{},
+	"modify_func":        &schema.ModifyFunc{},
+	"add_proc":           &schema.AddProc{},
+	"drop_proc":          &schema.DropProc{},
+	"modify_proc":        &schema.ModifyProc{},
+	"add_trigger":        &schema.AddTrigger{},
+	"drop_trigger":       &schema.DropTrigger{},
+	"modify_trigger":     &schema.ModifyTrigger{},
+	"add_object":         &schema.AddObject{},
+	"drop_object":        &schema.DropObject{},
+	"modify_object":      &schema.ModifyObject{},
+	"add_role":           &schema.AddRole{},
+	"drop_role":          &schema.DropRole{},
+	"modify_role":        &schema.ModifyRole{},
+	"grant_privilege":    &schema.Grant{},
+	"revoke_privilege":   &schema.Revoke{},
+}
+
+// pruneChanges lists the removals of views, functions, procedures, triggers and driver-specific
+// objects. They are omitted from the diff unless the --prune flag is set, as these resources may
+// exist in the database without being defined in the desired state (e.g. a tables-only schema).
+var pruneChanges = []schema.Change{
+	&schema.DropView{},
+	&schema.DropFunc{},
+	&schema.DropProc{},
+	&schema.DropTrigger{},
+	&schema.DropObject{},
+}
+
+// diffPolicy returns the DiffOptions for the skipped and denied change types.
+func diffPolicy(skip, deny []string) ([]schema.DiffOption, error) {
+	changes := func(names []string) ([]schema.Change, error) {
+		cs := make([]schema.Change, 0, len(names))
+		for _, n := range names {
+			c, ok := changeTypes[strings.ToLower(strings.TrimSpace(n))]
+			if !ok {
+				return nil, fmt.Errorf("unknown change type %q", n)
+			}
+			cs = append(cs, c)
+		}
+		return cs, nil
+	}
+	var opts []schema.DiffOption
+	if len(skip) > 0 {
+		cs, err := changes(skip)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, schema.DiffSkipChanges(cs...))
+	}
+	if len(deny) > 0 {
+		cs, err := changes(deny)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, schema.DiffDenyChanges(cs...))
+	}
+	return opts, nil
+}
+
 // resetFromEnv traverses the command flags, records what flags
 // were not set by the user and returns a callback to clear them
 // after it was set by the current environment.
@@ -451,9 +564,10 @@ func stateReader(ctx context.Context, config *stateReaderConfig) (*stateReadClos
 			}
 			sr, err := ex.Replay(ctx, func() migrate.StateReader {
 				if config.dev.URL.Schema != "" {
-					return migrate.SchemaConn(config.dev, "", nil)
+					return migrate.SchemaConn(config.dev, "", &schema.InspectOptions{Mode: schema.InspectAll})
 				}
 				return migrate.RealmConn(config.dev, &schema.InspectRealmOption{
+					Mode:    schema.InspectAll,
 					Schemas: config.schemas,
 					Exclude: config.exclude,
 				})
@@ -478,11 +592,12 @@ func stateReader(ctx context.Context, config *stateReaderConfig) (*stateReadClos
 		switch c.URL.Schema {
 		case "":
 			sr = migrate.RealmConn(c.Driver, &schema.InspectRealmOption{
+				Mode:    schema.InspectAll,
 				Schemas: config.schemas,
 				Exclude: config.exclude,
 			})
 		default:
-			sr = migrate.SchemaConn(c.Driver, c.URL.Schema, &schema.InspectOptions{Exclude: config.exclude})
+			sr = migrate.SchemaConn(c.Driver, c.URL.Schema, &schema.InspectOptions{Mode: schema.InspectAll, Exclude: config.exclude})
 		}
 		return &stateReadCloser{
 			StateReader: sr,
@@ -11,8 +11,10 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 	"testing"
 
+	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlite"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
@@ -100,3 +102,67 @@ func openSQLite(t *testing.T, seed string) string {
 	}
 	return fmt.Sprintf("sqlite://%s", dsn)
 }
+
+func TestDiffPolicy_ChangeTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		want schema.Change
+	}{
+		{"add_schema", &schema.AddSchema{}},
+		{"drop_schema", &schema.DropSchema{}},
+		{"modify_schema", &schema.ModifySchema{}},
+		{"add_table", &schema.AddTable{}},
+		{"drop_table", &schema.DropTable{}},
+		{"modify_table", &schema.ModifyTable{}},
+		{"rename_table", &schema.RenameTable{}},
+		{"add_column", &schema.AddColumn{}},
+		{"drop_column", &schema.DropColumn{}},
+		{"modify_column", &schema.ModifyColumn{}},
+		{"rename_column", &schema.RenameColumn{}},
+		{"add_index", &schema.AddIndex{}},
+		{"drop_index", &schema.DropIndex{}},
+		{"modify_index", &schema.ModifyIndex{}},
+		{"rename_index", &schema.RenameIndex{}},
+		{"add_foreign_key", &schema.AddForeignKey{}},
+		{"drop_foreign_key", &schema.DropForeignKey{}},
+		{"modify_foreign_key", &schema.ModifyForeignKey{}},
+		{"add_check", &schema.AddCheck{}},
+		{"drop_check", &schema.DropCheck{}},
+		{"modify_check", &schema.ModifyCheck{}},
+		{"add_view", &schema.AddView{}},
+		{"drop_view", &schema.DropView{}},
+		{"modify_view", &schema.ModifyView{}},
+		{"add_func", &schema.AddFunc{}},
+		{"drop_func", &schema.DropFunc{}},
+		{"modify_func", &schema.ModifyFunc{}},
+		{"add_proc", &schema.AddProc{}},
+		{"drop_proc", &schema.DropProc{}},
+		{"modify_proc", &schema.ModifyProc{}},
+		{"add_trigger", &schema.AddTrigger{}},
+		{"drop_trigger", &schema.DropTrigger{}},
+		{"modify_trigger", &schema.ModifyTrigger{}},
+		{"add_object", &schema.AddObject{}},
+		{"drop_object", &schema.DropObject{}},
+		{"modify_object", &schema.ModifyObject{}},
+		{"add_role", &schema.AddRole{}},
+		{"drop_role", &schema.DropRole{}},
+		{"modify_role", &schema.ModifyRole{}},
+		{"grant_privilege", &schema.Grant{}},
+		{"revoke_privilege", &schema.Revoke{}},
+	}
+	require.Len(t, changeTypes, len(tests), "all change types should be covered")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := diffPolicy([]string{tt.name}, []string{" " + strings.ToUpper(tt.name)})
+			require.NoError(t, err)
+			var o schema.DiffOptions
+			for _, opt := range opts {
+				opt(&o)
+			}
+			require.Equal(t, []schema.Change{tt.want}, o.SkipChanges)
+			require.Equal(t, []schema.Change{tt.want}, o.DenyChanges)
+		})
+	}
+	_, err := diffPolicy([]string{"add_unknown"}, nil)
+	require.EqualError(t, err, `unknown change type "add_unknown"`)
+}
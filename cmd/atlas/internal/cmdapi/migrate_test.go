@@ -751,6 +751,126 @@ func TestMigrate_Diff(t *testing.T) {
 	require.ErrorIs(t, err, errLock)
 }
 
+func TestMigrate_DiffRenames(t *testing.T) {
+	terminal := isTerminal
+	t.Cleanup(func() { isTerminal = terminal })
+	isTerminal = func() bool { return false }
+	to := openSQLite(t, "CREATE TABLE `t` (`b` int NOT NULL);")
+	diff := func(args ...string) string {
+		p := t.TempDir()
+		dir, err := migrate.NewLocalDir(p)
+		require.NoError(t, err)
+		require.NoError(t, dir.WriteFile("1_init.sql", []byte("CREATE TABLE `t` (`a` int NOT NULL);\n")))
+		sum, err := dir.Checksum()
+		require.NoError(t, err)
+		require.NoError(t, migrate.WriteSumFile(dir, sum))
+		_, err = runCmd(migrateDiffCmd(), append([]string{
+			"renames",
+			"--dir", "file://" + p,
+			"--dev-url", openSQLite(t, ""),
+			"--to", to,
+		}, args...)...)
+		require.NoError(t, err)
+		files, err := dir.Files()
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		return string(files[1].Bytes())
+	}
+
+	// Renames are not prompted if the input is not a terminal.
+	s := diff()
+	require.NotContains(t, s, "RENAME COLUMN")
+	require.Contains(t, s, "`b`")
+
+	s = diff("--accept-renames")
+	require.Contains(t, s, "ALTER TABLE `t` RENAME COLUMN `a` TO `b`;")
+}
+
+func TestMigrate_DiffDownFiles(t *testing.T) {
+	p := t.TempDir()
+	dir, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	diff := func(to string) {
+		_, err := runCmd(
+			migrateDiffCmd(),
+			"--dir", "file://"+p,
+			"--dir-format", formatAtlasDown,
+			"--dev-url", openSQLite(t, ""),
+			"--to", to,
+		)
+		require.NoError(t, err)
+	}
+
+	// Reversible changes.
+	diff(openSQLite(t, "CREATE TABLE `t1` (`a` int NOT NULL);"))
+	files, err := dir.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	down := files[0].(*migrate.LocalFile).Down()
+	require.NotNil(t, down)
+	require.False(t, down.IsIrreversible())
+	require.Contains(t, string(down.Bytes()), "DROP TABLE `t1`;")
+
+	// Irreversible changes only, no down file is written.
+	time.Sleep(time.Second)
+	diff(openSQLite(t, ""))
+	files, err = dir.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Nil(t, files[1].(*migrate.LocalFile).Down())
+
+	// Partially reversible changes are marked.
+	time.Sleep(time.Second)
+	require.NoError(t, dir.WriteFile(time.Now().UTC().Format("20060102150405")+"_t2.sql", []byte("CREATE TABLE `t2` (`a` int NOT NULL);\n")))
+	time.Sleep(time.Second)
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+	diff(openSQLite(t, "CREATE TABLE `t3` (`a` int NOT NULL);"))
+	files, err = dir.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+	down = files[3].(*migrate.LocalFile).Down()
+	require.NotNil(t, down)
+	require.True(t, down.IsIrreversible())
+	require.Contains(t, string(down.Bytes()), "DROP TABLE `t3`;")
+}
+
+func TestMigrate_Checkpoint(t *testing.T) {
+	p := t.TempDir()
+	dir, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	require.NoError(t, dir.WriteFile("1_init.sql", []byte("CREATE TABLE `t` (`a` int NOT NULL);\n")))
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+
+	// Only the atlas directory format supports checkpoints.
+	for _, f := range []string{formatGolangMigrate, formatGoose, formatFlyway, formatLiquibase, formatDBMate} {
+		s, err := runCmd(
+			migrateCheckpointCmd(),
+			"--dir", "file://"+p,
+			"--dir-format", f,
+			"--dev-url", openSQLite(t, ""),
+		)
+		require.EqualError(t, err, fmt.Sprintf("checkpoint files are supported only by the \"atlas\" directory format, got %q", f))
+		require.NotZero(t, s)
+	}
+
+	s, err := runCmd(
+		migrateCheckpointCmd(),
+		"--dir", "file://"+p,
+		"--dev-url", openSQLite(t, ""),
+	)
+	require.NoError(t, err)
+	require.Zero(t, s)
+	files, err := dir.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.True(t, migrate.IsCheckpoint(files[1]))
+	require.Contains(t, string(files[1].Bytes()), "CREATE TABLE `t`")
+}
+
 func TestMigrate_StatusJSON(t *testing.T) {
 	p := t.TempDir()
 	s, err := runCmd(
@@ -70,6 +70,9 @@ type (
 
 		// Log of the environment.
 		Log Log `spec:"log"`
+
+		// Diff policy of the environment.
+		Diff Diff `spec:"diff"`
 		schemahcl.DefaultExtension
 	}
 
@@ -97,6 +100,21 @@ type (
 		schemahcl.DefaultExtension
 	}
 
+	// Diff represents the diff policy of an environment. For example:
+	//
+	//	env "prod" {
+	//	  diff {
+	//	    skip = ["drop_table", "drop_column"]
+	//	    deny = ["drop_schema"]
+	//	  }
+	//	}
+	Diff struct {
+		// Skip configures the --skip-changes option.
+		Skip []string `spec:"skip"`
+		// Deny configures the --deny-changes option.
+		Deny []string `spec:"deny"`
+	}
+
 	// Log represents a logging configuration of an environment.
 	Log struct {
 		Migrate struct {
@@ -199,7 +217,7 @@ func (e *Env) asMap() (map[string]string, error) {
 }
 
 var hclState = schemahcl.New(
-	schemahcl.WithScopedEnums("env.migration.format", formatAtlas, formatFlyway, formatLiquibase, formatGoose, formatGolangMigrate),
+	schemahcl.WithScopedEnums("env.migration.format", formatAtlas, formatAtlasDown, formatFlyway, formatLiquibase, formatGoose, formatGolangMigrate),
 	schemahcl.WithDataSource("sql", func(ctx *hcl.EvalContext, b *hclsyntax.Block) (cty.Value, error) {
 		return (&sqlsrc{ctx: ctx, block: b}).exec()
 	}),
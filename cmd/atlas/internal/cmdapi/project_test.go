@@ -49,6 +49,10 @@ env "local" {
 	lint {
 		latest = 1
 	}
+	diff {
+		skip = ["drop_table"]
+		deny = ["drop_schema", "drop_column"]
+	}
 	
 	bool = true
 	integer = 42
@@ -108,6 +112,10 @@ env "multi" {
 					},
 				},
 			},
+			Diff: Diff{
+				Skip: []string{"drop_table"},
+				Deny: []string{"drop_schema", "drop_column"},
+			},
 			DefaultExtension: schemahcl.DefaultExtension{
 				Extra: schemahcl.Resource{
 					Attrs: []*schemahcl.Attr{
@@ -303,6 +303,32 @@ env "local" {
 	}, lines)
 }
 
+func TestCmdSchemaApply_Prune(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "schema.hcl")
+	err := os.WriteFile(p, []byte(`
+schema "main" {}
+
+table "users" {
+  schema = schema.main
+  column "id" {
+    type = int
+  }
+}
+`), 0600)
+	require.NoError(t, err)
+	u := openSQLite(t, "CREATE TABLE `users` (`id` int NOT NULL); CREATE VIEW `active` AS SELECT `id` FROM `users`;")
+
+	// Views that are missing from a tables-only schema are kept.
+	s, err := runCmd(schemaApplyCmd(), "-u", u, "--to", "file://"+p, "--dry-run")
+	require.NoError(t, err)
+	require.Equal(t, "Schema is synced, no changes to be made\n", s)
+
+	// Unless pruning is requested.
+	s, err = runCmd(schemaApplyCmd(), "-u", u, "--to", "file://"+p, "--dry-run", "--prune")
+	require.NoError(t, err)
+	require.Contains(t, s, "DROP VIEW `active`")
+}
+
 func TestFmt(t *testing.T) {
 	for _, tt := range []struct {
 		name          string
@@ -189,7 +189,11 @@ func (d *DevLoader) LoadChanges(ctx context.Context, base, files []migrate.File)
 			err = err2
 		}
 	}()
-	// Bring the dev environment to the base point.
+	// Bring the dev environment to the base point. Replaying starts from
+	// the latest checkpoint, as it holds the full state of its preceding files.
+	if i := migrate.FilesLastIndex(base, migrate.IsCheckpoint); i != -1 {
+		base = base[i:]
+	}
 	for _, f := range base {
 		stmt, err := f.Stmts()
 		if err != nil {
@@ -214,6 +218,11 @@ func (d *DevLoader) LoadChanges(ctx context.Context, base, files []migrate.File)
 			File:   f,
 			Parser: sqlparse.ParserFor(d.Dev.Name),
 		}
+		// Checkpoint files hold the state of their preceding files, and are
+		// executed only on an empty dev environment (e.g. first new file).
+		if migrate.IsCheckpoint(f) && (len(base) > 0 || i > 0) {
+			continue
+		}
 		stmts, err := f.StmtDecls()
 		if err != nil {
 			return nil, &FileError{File: f.Name(), Err: fmt.Errorf("scanning statements: %w", err)}
@@ -259,7 +268,7 @@ func (d *DevLoader) mayFix(stmt string, changes schema.Changes) schema.Changes {
 
 // inspect the realm and filter by schema if we are connected to one.
 func (d *DevLoader) inspect(ctx context.Context) (*schema.Realm, error) {
-	opts := &schema.InspectRealmOption{}
+	opts := &schema.InspectRealmOption{Mode: schema.InspectAll}
 	if d.Dev.URL.Schema != "" {
 		opts.Schemas = append(opts.Schemas, d.Dev.URL.Schema)
 	}
@@ -189,35 +189,41 @@ func (r *StatusReporter) Report(ctx context.Context) error {
 	if err != nil && !schema.IsNotExistError(err) {
 		return err
 	}
+	var (
+		rrw  migrate.RevisionReadWriter = &nopRevisions{schema: r.Schema}
+		opts []migrate.ExecutorOption
+	)
 	if schema.IsNotExistError(err) || func() bool { _, ok := sch.Table(revision.Table); return !ok }() {
-		// Either schema or table does not exist.
-		rep.Pending = rep.Available
+		// Either schema or table does not exist. The pending files are computed
+		// as if no revisions were applied, regardless of the database contents.
+		opts = append(opts, migrate.WithAllowDirty(true))
 	} else {
 		// Both exist, fetch their data.
-		rrw, err := NewEntRevisions(ctx, r.Client, WithSchema(r.Schema))
-		if err != nil {
-			return err
-		}
-		if err := rrw.Migrate(ctx); err != nil {
-			return err
-		}
-		ex, err := migrate.NewExecutor(r.Client.Driver, r.Dir, rrw)
+		erw, err := NewEntRevisions(ctx, r.Client, WithSchema(r.Schema))
 		if err != nil {
 			return err
 		}
-		rep.Pending, err = ex.Pending(ctx)
-		if err != nil && !errors.Is(err, migrate.ErrNoPendingFiles) {
+		if err := erw.Migrate(ctx); err != nil {
 			return err
 		}
-		rep.Applied, err = rrw.ReadRevisions(ctx)
-		if err != nil {
+		if rep.Applied, err = erw.ReadRevisions(ctx); err != nil {
 			return err
 		}
+		rrw = erw
 	}
-	switch len(rep.Pending) {
-	case len(rep.Available):
+	// The executor decides which files are pending,
+	// e.g. starting from the latest checkpoint.
+	ex, err := migrate.NewExecutor(r.Client.Driver, r.Dir, rrw, opts...)
+	if err != nil {
+		return err
+	}
+	rep.Pending, err = ex.Pending(ctx)
+	if err != nil && !errors.Is(err, migrate.ErrNoPendingFiles) {
+		return err
+	}
+	if len(rep.Applied) == 0 {
 		rep.Current = "No migration applied yet"
-	default:
+	} else {
 		rep.Current = rep.Applied[len(rep.Applied)-1].Version
 	}
 	if len(rep.Pending) == 0 {
@@ -250,6 +256,18 @@ func (r *StatusReporter) Report(ctx context.Context) error {
 	return r.ReportWriter.WriteReport(rep)
 }
 
+// nopRevisions is a RevisionReadWriter with no revisions,
+// used for databases that have no revisions table (yet).
+type nopRevisions struct {
+	migrate.NopRevisionReadWriter
+	schema string
+}
+
+// Ident implements the migrate.RevisionReadWriter interface.
+func (r *nopRevisions) Ident() *migrate.TableIdent {
+	return &migrate.TableIdent{Name: revision.Table, Schema: r.schema}
+}
+
 // Left returns the amount of statements left to apply (if any).
 func (r *StatusReport) Left() int { return r.Total - r.Count }
 
@@ -282,7 +300,7 @@ func table(report *StatusReport) (string, error) {
 	}
 	for i, f := range report.Pending {
 		var c string
-		if i == 0 {
+		if i == 0 && len(report.Applied) > 0 {
 			if r := report.Applied[len(report.Applied)-1]; f.Version() == r.Version && r.Applied < r.Total {
 				stmts, err := f.Stmts()
 				if err != nil {
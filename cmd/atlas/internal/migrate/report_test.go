@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"text/template"
 
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/sqlclient"
@@ -114,3 +115,91 @@ Last migration attempt had errors:
   -- Pending Files:   0
 `, buf.String())
 }
+
+func TestReporter_StatusCheckpoint(t *testing.T) {
+	var (
+		buf strings.Builder
+		ctx = context.Background()
+	)
+	dir, err := migrate.NewLocalDir(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, dir.WriteFile("1.sql", []byte("CREATE TABLE t1(c int);")))
+	require.NoError(t, dir.WriteFile("2_checkpoint.sql", []byte("-- atlas:checkpoint\n\nCREATE TABLE t1(c int);")))
+	require.NoError(t, dir.WriteFile("3.sql", []byte("CREATE TABLE t2(c int);")))
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+	c, err := sqlclient.Open(ctx, "sqlite://?mode=memory")
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Without revisions table, a fresh database starts from the checkpoint.
+	require.NoError(t, (&StatusReporter{
+		Client:       c,
+		Dir:          dir,
+		ReportWriter: &TemplateWriter{T: DefaultStatusTemplate, W: &buf},
+	}).Report(ctx))
+	require.Equal(t, `Migration Status: PENDING
+  -- Current Version: No migration applied yet
+  -- Next Version:    2
+  -- Executed Files:  0
+  -- Pending Files:   2
+`, buf.String())
+	buf.Reset()
+	tmpl := template.Must(template.New("").Funcs(StatusTemplateFuncs).Parse("{{ table . }}"))
+	require.NoError(t, (&StatusReporter{
+		Client:       c,
+		Dir:          dir,
+		ReportWriter: &TemplateWriter{T: tmpl, W: &buf},
+	}).Report(ctx))
+	require.Contains(t, buf.String(), "pending")
+
+	// Empty revisions table.
+	buf.Reset()
+	rrw, err := NewEntRevisions(ctx, c)
+	require.NoError(t, err)
+	require.NoError(t, rrw.Migrate(ctx))
+	require.NoError(t, (&StatusReporter{
+		Client:       c,
+		Dir:          dir,
+		ReportWriter: &TemplateWriter{T: DefaultStatusTemplate, W: &buf},
+	}).Report(ctx))
+	require.Equal(t, `Migration Status: PENDING
+  -- Current Version: No migration applied yet
+  -- Next Version:    2
+  -- Executed Files:  0
+  -- Pending Files:   2
+`, buf.String())
+
+	// Applied the checkpoint.
+	buf.Reset()
+	ex, err := migrate.NewExecutor(c.Driver, dir, rrw)
+	require.NoError(t, err)
+	require.NoError(t, ex.ExecuteN(ctx, 1))
+	require.NoError(t, (&StatusReporter{
+		Client:       c,
+		Dir:          dir,
+		ReportWriter: &TemplateWriter{T: DefaultStatusTemplate, W: &buf},
+	}).Report(ctx))
+	require.Equal(t, `Migration Status: PENDING
+  -- Current Version: 2
+  -- Next Version:    3
+  -- Executed Files:  1
+  -- Pending Files:   1
+`, buf.String())
+
+	// Applied all.
+	buf.Reset()
+	require.NoError(t, ex.ExecuteN(ctx, 1))
+	require.NoError(t, (&StatusReporter{
+		Client:       c,
+		Dir:          dir,
+		ReportWriter: &TemplateWriter{T: DefaultStatusTemplate, W: &buf},
+	}).Report(ctx))
+	require.Equal(t, `Migration Status: OK
+  -- Current Version: 3
+  -- Next Version:    Already at latest version
+  -- Executed Files:  2
+  -- Pending Files:   0
+`, buf.String())
+}
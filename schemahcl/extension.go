@@ -405,6 +405,9 @@ func setSliceAttr(field reflect.Value, attr *Attr) error {
 				slc = reflect.Append(slc, reflect.ValueOf(v.EncapsulatedValue().(*Ref)))
 			case isRef(v):
 				slc = reflect.Append(slc, reflect.ValueOf(&Ref{V: v.GetAttr("__ref").AsString()}))
+			// Scoped enums are evaluated to their string values.
+			case v.Type() == cty.String:
+				slc = reflect.Append(slc, reflect.ValueOf(&Ref{V: v.AsString()}))
 			default:
 				return fmt.Errorf("schemahcl: unsupported type %s in slice", v.Type().FriendlyName())
 			}
@@ -21,6 +21,7 @@ import (
 // List of convert function types.
 type (
 	ConvertTableFunc      func(*sqlspec.Table, *schema.Schema) (*schema.Table, error)
+	ConvertViewFunc       func(*sqlspec.View, *schema.Schema) (*schema.View, error)
 	ConvertColumnFunc     func(*sqlspec.Column, *schema.Table) (*schema.Column, error)
 	ConvertTypeFunc       func(*sqlspec.Column) (schema.Type, error)
 	ConvertPrimaryKeyFunc func(*sqlspec.PrimaryKey, *schema.Table) (*schema.Index, error)
@@ -29,6 +30,12 @@ type (
 	ColumnSpecFunc        func(*schema.Column, *schema.Table) (*sqlspec.Column, error)
 	ColumnTypeSpecFunc    func(schema.Type) (*sqlspec.Column, error)
 	TableSpecFunc         func(*schema.Table) (*sqlspec.Table, error)
+	ViewSpecFunc          func(*schema.View) (*sqlspec.View, error)
+	ConvertFuncFunc       func(*sqlspec.Func, *schema.Schema) (*schema.Func, error)
+	ConvertProcFunc       func(*sqlspec.Proc, *schema.Schema) (*schema.Proc, error)
+	ConvertTriggerFunc    func(*sqlspec.Trigger, *schema.Table) (*schema.Trigger, error)
+	FuncSpecFunc          func(*schema.Func) (*sqlspec.Func, error)
+	ProcSpecFunc          func(*schema.Proc) (*sqlspec.Proc, error)
 	PrimaryKeySpecFunc    func(*schema.Index) (*sqlspec.PrimaryKey, error)
 	IndexSpecFunc         func(*schema.Index) (*sqlspec.Index, error)
 	ForeignKeySpecFunc    func(*schema.ForeignKey) (*sqlspec.ForeignKey, error)
@@ -70,6 +77,97 @@ func Scan(r *schema.Realm, schemas []*sqlspec.Schema, tables []*sqlspec.Table, c
 	return nil
 }
 
+// ScanViews populates the schemas of the Realm with the given view specs.
+func ScanViews(r *schema.Realm, views []*sqlspec.View, convertView ConvertViewFunc) error {
+	for _, viewSpec := range views {
+		name, err := SchemaName(viewSpec.Schema)
+		if err != nil {
+			return fmt.Errorf("specutil: cannot extract schema name for view %q: %w", viewSpec.Name, err)
+		}
+		sch, ok := r.Schema(name)
+		if !ok {
+			return fmt.Errorf("specutil: schema %q was not found for view %q", name, viewSpec.Name)
+		}
+		v, err := convertView(viewSpec, sch)
+		if err != nil {
+			return err
+		}
+		sch.Views = append(sch.Views, v)
+	}
+	return nil
+}
+
+// ScanFuncs populates the schemas of the Realm with the given function and procedure specs.
+func ScanFuncs(r *schema.Realm, funcs []*sqlspec.Func, procs []*sqlspec.Proc, convertFunc ConvertFuncFunc, convertProc ConvertProcFunc) error {
+	for _, funcSpec := range funcs {
+		sch, err := routineSchema(r, funcSpec.Schema, "function", funcSpec.Name)
+		if err != nil {
+			return err
+		}
+		f, err := convertFunc(funcSpec, sch)
+		if err != nil {
+			return err
+		}
+		sch.Funcs = append(sch.Funcs, f)
+	}
+	for _, procSpec := range procs {
+		sch, err := routineSchema(r, procSpec.Schema, "procedure", procSpec.Name)
+		if err != nil {
+			return err
+		}
+		p, err := convertProc(procSpec, sch)
+		if err != nil {
+			return err
+		}
+		sch.Procs = append(sch.Procs, p)
+	}
+	return nil
+}
+
+// ScanTriggers populates the tables of the Realm with the triggers defined in their specs.
+// It should be called after the functions of the Realm were scanned, as triggers may
+// reference them.
+func ScanTriggers(r *schema.Realm, tables []*sqlspec.Table, convertTrigger ConvertTriggerFunc) error {
+	for _, tableSpec := range tables {
+		if len(tableSpec.Triggers) == 0 {
+			continue
+		}
+		name, err := SchemaName(tableSpec.Schema)
+		if err != nil {
+			return fmt.Errorf("specutil: cannot extract schema name for table %q: %w", tableSpec.Name, err)
+		}
+		sch, ok := r.Schema(name)
+		if !ok {
+			return fmt.Errorf("specutil: schema %q was not found for table %q", name, tableSpec.Name)
+		}
+		tbl, ok := sch.Table(tableSpec.Name)
+		if !ok {
+			return fmt.Errorf("specutil: table %q was not found in schema %q", tableSpec.Name, name)
+		}
+		for _, spec := range tableSpec.Triggers {
+			tg, err := convertTrigger(spec, tbl)
+			if err != nil {
+				return err
+			}
+			tbl.AddTriggers(tg)
+		}
+	}
+	return nil
+}
+
+// routineSchema returns the schema of the Realm referenced by a function or a procedure spec.
+func routineSchema(r *schema.Realm, ref *schemahcl.Ref, typ, name string) (*schema.Schema, error) {
+	sname, err := SchemaName(ref)
+	if err != nil {
+		return nil, fmt.Errorf("specutil: cannot extract schema name for %s %q: %w", typ, name, err)
+	}
+	sch, ok := r.Schema(sname)
+	if !ok {
+		return nil, fmt.Errorf("specutil: schema %q was not found for %s %q", sname, typ, name)
+	}
+	return sch, nil
+}
+
 // findTableSpec searches tableSpecs for a spec of a table named tableName in a schema named schemaName.
 func findTableSpec(tableSpecs []*sqlspec.Table, schemaName, tableName string) (*sqlspec.Table, error) {
 	for _, tbl := range tableSpecs {
@@ -124,9 +222,196 @@ func Table(spec *sqlspec.Table, parent *schema.Schema, convertColumn ConvertColu
 	if err := convertCommentFromSpec(spec, &tbl.Attrs); err != nil {
 		return nil, err
 	}
+	if err := convertRenamedFromSpec(spec, &tbl.Attrs); err != nil {
+		return nil, err
+	}
 	return tbl, nil
 }
 
+// View converts a sqlspec.View to a schema.View.
+func View(spec *sqlspec.View, parent *schema.Schema, conv ConvertTypeFunc) (*schema.View, error) {
+	if spec.As == "" {
+		return nil, fmt.Errorf("missing definition (as) for view %q", spec.Name)
+	}
+	v := &schema.View{
+		Name:   spec.Name,
+		Def:    spec.As,
+		Schema: parent,
+	}
+	for _, csp := range spec.Columns {
+		col, err := Column(csp, conv)
+		if err != nil {
+			return nil, err
+		}
+		v.Columns = append(v.Columns, col)
+	}
+	if a, ok := spec.Attr("check_option"); ok {
+		o, err := a.String()
+		if err != nil {
+			return nil, err
+		}
+		v.Attrs = append(v.Attrs, &schema.ViewCheckOption{V: o})
+	}
+	if err := convertCommentFromSpec(spec, &v.Attrs); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Func converts a sqlspec.Func to a schema.Func.
+func Func(spec *sqlspec.Func, parent *schema.Schema, conv ConvertTypeFunc) (*schema.Func, error) {
+	if spec.As == "" {
+		return nil, fmt.Errorf("missing definition (as) for function %q", spec.Name)
+	}
+	if spec.Return == nil {
+		return nil, fmt.Errorf("missing return type for function %q", spec.Name)
+	}
+	args, err := FuncArgs(spec.Args, conv)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := conv(&sqlspec.Column{Type: spec.Return})
+	if err != nil {
+		return nil, err
+	}
+	f := &schema.Func{
+		Name:   spec.Name,
+		Schema: parent,
+		Args:   args,
+		Ret:    ret,
+		Lang:   spec.Lang,
+		Body:   spec.As,
+	}
+	if err := convertCommentFromSpec(spec, &f.Attrs); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Proc converts a sqlspec.Proc to a schema.Proc.
+func Proc(spec *sqlspec.Proc, parent *schema.Schema, conv ConvertTypeFunc) (*schema.Proc, error) {
+	if spec.As == "" {
+		return nil, fmt.Errorf("missing definition (as) for procedure %q", spec.Name)
+	}
+	args, err := FuncArgs(spec.Args, conv)
+	if err != nil {
+		return nil, err
+	}
+	p := &schema.Proc{
+		Name:   spec.Name,
+		Schema: parent,
+		Args:   args,
+		Lang:   spec.Lang,
+		Body:   spec.As,
+	}
+	if err := convertCommentFromSpec(spec, &p.Attrs); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// FuncArgs converts the argument specs of a function or a procedure to []*schema.FuncArg.
+func FuncArgs(specs []*sqlspec.FuncArg, conv ConvertTypeFunc) ([]*schema.FuncArg, error) {
+	args := make([]*schema.FuncArg, 0, len(specs))
+	for _, spec := range specs {
+		if spec.Type == nil {
+			return nil, fmt.Errorf("missing type for argument %q", spec.Name)
+		}
+		// Arguments share the type and default
+		// value conversion logic of columns.
+		c, err := Column(&sqlspec.Column{Name: spec.Name, Type: spec.Type, Default: spec.Default}, conv)
+		if err != nil {
+			return nil, err
+		}
+		a := &schema.FuncArg{Name: spec.Name, Type: c.Type.Type, Default: c.Default}
+		if m, ok := spec.Attr("mode"); ok {
+			if a.Mode, err = m.String(); err != nil {
+				return nil, err
+			}
+		}
+		args = append(args, a)
+	}
+	return args, nil
+}
+
+// Trigger converts a sqlspec.Trigger to a schema.Trigger. The given forEach
+// is used as the trigger level in case it was not defined in the spec.
+func Trigger(spec *sqlspec.Trigger, parent *schema.Table, forEach string) (*schema.Trigger, error) {
+	tg := schema.NewTrigger(spec.Name).
+		SetTable(parent).
+		SetFor(forEach).
+		SetBody(spec.As)
+	if spec.Timing == nil {
+		return nil, fmt.Errorf("missing timing for trigger %q", spec.Name)
+	}
+	tg.SetActionTime(FromVar(spec.Timing.V))
+	if len(spec.Events) == 0 {
+		return nil, fmt.Errorf("missing events for trigger %q", spec.Name)
+	}
+	var updateOf bool
+	for _, e := range spec.Events {
+		event := schema.TriggerEvent{Name: strings.ToUpper(e.V)}
+		if event.Name == schema.TriggerEventUpdate {
+			updateOf = true
+			for _, ref := range spec.UpdateOf {
+				c, err := ColumnByRef(parent, ref)
+				if err != nil {
+					return nil, err
+				}
+				event.Columns = append(event.Columns, c)
+			}
+		}
+		tg.AddEvents(event)
+	}
+	if len(spec.UpdateOf) > 0 && !updateOf {
+		return nil, fmt.Errorf("update_of is defined for trigger %q without an UPDATE event", spec.Name)
+	}
+	if spec.ForEach != nil {
+		tg.SetFor(spec.ForEach.V)
+	}
+	if spec.Execute != nil {
+		f, err := triggerFunc(parent, spec.Execute)
+		if err != nil {
+			return nil, fmt.Errorf("trigger %q: %w", spec.Name, err)
+		}
+		tg.SetFunc(f)
+	}
+	return tg, nil
+}
+
+// triggerFunc returns the function referenced by a trigger of the given table.
+// Unqualified references are searched in the table schema first.
+func triggerFunc(t *schema.Table, ref *schemahcl.Ref) (*schema.Func, error) {
+	var qualifier, name string
+	switch path := strings.Split(ref.V, "."); {
+	case len(path) == 2 && path[0] == "$function":
+		name = path[1]
+	case len(path) == 3 && path[0] == "$function":
+		qualifier, name = path[1], path[2]
+	default:
+		return nil, fmt.Errorf("specutil: expected ref format of $function.name, got %q", ref.V)
+	}
+	schemas := []*schema.Schema{t.Schema}
+	switch {
+	case t.Schema == nil:
+		return nil, fmt.Errorf("specutil: missing schema for table %q", t.Name)
+	case qualifier != "" && t.Schema.Realm != nil:
+		s, ok := t.Schema.Realm.Schema(qualifier)
+		if !ok {
+			return nil, fmt.Errorf("specutil: schema %q was not found for function %q", qualifier, name)
+		}
+		schemas = []*schema.Schema{s}
+	case t.Schema.Realm != nil:
+		schemas = append(schemas, t.Schema.Realm.Schemas...)
+	}
+	for _, s := range schemas {
+		if f, ok := s.Func(name); ok {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("specutil: function %q was not found", name)
+}
+
 // Column converts a sqlspec.Column into a schema.Column.
 func Column(spec *sqlspec.Column, conv ConvertTypeFunc) (*schema.Column, error) {
 	out := &schema.Column{
@@ -161,6 +446,9 @@ func Column(spec *sqlspec.Column, conv ConvertTypeFunc) (*schema.Column, error)
 	if err := convertCommentFromSpec(spec, &out.Attrs); err != nil {
 		return nil, err
 	}
+	if err := convertRenamedFromSpec(spec, &out.Attrs); err != nil {
+		return nil, err
+	}
 	return out, err
 }
 
@@ -218,6 +506,9 @@ func Index(spec *sqlspec.Index, parent *schema.Table, partFns ...func(*sqlspec.I
 	if err := convertCommentFromSpec(spec, &i.Attrs); err != nil {
 		return nil, err
 	}
+	if err := convertRenamedFromSpec(spec, &i.Attrs); err != nil {
+		return nil, err
+	}
 	return i, nil
 }
 
@@ -271,7 +562,7 @@ func linkForeignKeys(tbl *schema.Table, sch *schema.Schema, table *sqlspec.Table
 			fk.Columns = append(fk.Columns, c)
 		}
 		for i, ref := range spec.RefColumns {
-			t, c, err := externalRef(ref, sch)
+			t, c, err := ColumnByExternalRef(ref, sch)
 			if isLocalRef(ref) {
 				t = fk.Table
 				c, err = ColumnByRef(fk.Table, ref)
@@ -309,6 +600,132 @@ func FromSchema(s *schema.Schema, fn TableSpecFunc) (*sqlspec.Schema, []*sqlspec
 	return spec, tables, nil
 }
 
+// FromViews converts the views of the schema.Schema into []sqlspec.View.
+func FromViews(s *schema.Schema, fn ViewSpecFunc) ([]*sqlspec.View, error) {
+	views := make([]*sqlspec.View, 0, len(s.Views))
+	for _, v := range s.Views {
+		view, err := fn(v)
+		if err != nil {
+			return nil, err
+		}
+		if s.Name != "" {
+			view.Schema = SchemaRef(s.Name)
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// FromView converts a schema.View to a sqlspec.View.
+func FromView(v *schema.View, columnTypeSpec ColumnTypeSpecFunc) (*sqlspec.View, error) {
+	spec := &sqlspec.View{
+		Name: v.Name,
+		As:   v.Def,
+	}
+	for _, c := range v.Columns {
+		col, err := FromColumn(c, columnTypeSpec)
+		if err != nil {
+			return nil, err
+		}
+		spec.Columns = append(spec.Columns, col)
+	}
+	if o := (schema.ViewCheckOption{}); sqlx.Has(v.Attrs, &o) && o.V != "" && !strings.EqualFold(o.V, schema.ViewCheckOptionNone) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, VarAttr("check_option", strings.ToUpper(o.V)))
+	}
+	convertCommentFromSchema(v.Attrs, &spec.Extra.Attrs)
+	return spec, nil
+}
+
+// FromFuncs converts the functions and procedures of the schema.Schema into
+// []sqlspec.Func and []sqlspec.Proc. A nil converter skips the conversion.
+func FromFuncs(s *schema.Schema, funcFn FuncSpecFunc, procFn ProcSpecFunc) ([]*sqlspec.Func, []*sqlspec.Proc, error) {
+	var (
+		funcs []*sqlspec.Func
+		procs []*sqlspec.Proc
+	)
+	for i := 0; i < len(s.Funcs) && funcFn != nil; i++ {
+		f, err := funcFn(s.Funcs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		if s.Name != "" {
+			f.Schema = SchemaRef(s.Name)
+		}
+		funcs = append(funcs, f)
+	}
+	for i := 0; i < len(s.Procs) && procFn != nil; i++ {
+		p, err := procFn(s.Procs[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		if s.Name != "" {
+			p.Schema = SchemaRef(s.Name)
+		}
+		procs = append(procs, p)
+	}
+	return funcs, procs, nil
+}
+
+// FromFunc converts a schema.Func to a sqlspec.Func.
+func FromFunc(f *schema.Func, columnTypeSpec ColumnTypeSpecFunc) (*sqlspec.Func, error) {
+	args, err := FromFuncArgs(f.Args, columnTypeSpec)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := columnTypeSpec(f.Ret)
+	if err != nil {
+		return nil, err
+	}
+	spec := &sqlspec.Func{
+		Name:   f.Name,
+		Args:   args,
+		Return: ret.Type,
+		Lang:   f.Lang,
+		As:     f.Body,
+	}
+	convertCommentFromSchema(f.Attrs, &spec.Extra.Attrs)
+	return spec, nil
+}
+
+// FromProc converts a schema.Proc to a sqlspec.Proc.
+func FromProc(p *schema.Proc, columnTypeSpec ColumnTypeSpecFunc) (*sqlspec.Proc, error) {
+	args, err := FromFuncArgs(p.Args, columnTypeSpec)
+	if err != nil {
+		return nil, err
+	}
+	spec := &sqlspec.Proc{
+		Name: p.Name,
+		Args: args,
+		Lang: p.Lang,
+		As:   p.Body,
+	}
+	convertCommentFromSchema(p.Attrs, &spec.Extra.Attrs)
+	return spec, nil
+}
+
+// FromFuncArgs converts the arguments of a function or a procedure to []*sqlspec.FuncArg.
+func FromFuncArgs(args []*schema.FuncArg, columnTypeSpec ColumnTypeSpecFunc) ([]*sqlspec.FuncArg, error) {
+	specs := make([]*sqlspec.FuncArg, 0, len(args))
+	for _, a := range args {
+		ct, err := columnTypeSpec(a.Type)
+		if err != nil {
+			return nil, err
+		}
+		spec := &sqlspec.FuncArg{Name: a.Name, Type: ct.Type}
+		if a.Default != nil {
+			if spec.Default, err = ExprValue(a.Default); err != nil {
+				return nil, err
+			}
+		}
+		// The IN mode is the default, and therefore, omitted.
+		if a.Mode != "" && !strings.EqualFold(a.Mode, schema.FuncArgModeIn) {
+			spec.Extra.Attrs = append(spec.Extra.Attrs, VarAttr("mode", strings.ToUpper(a.Mode)))
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
 // FromTable converts a schema.Table to a sqlspec.Table.
 func FromTable(t *schema.Table, colFn ColumnSpecFunc, pkFn PrimaryKeySpecFunc, idxFn IndexSpecFunc,
 	fkFn ForeignKeySpecFunc, ckFn CheckSpecFunc) (*sqlspec.Table, error) {
@@ -348,10 +765,35 @@ func FromTable(t *schema.Table, colFn ColumnSpecFunc, pkFn PrimaryKeySpecFunc, i
 			spec.Checks = append(spec.Checks, ckFn(c))
 		}
 	}
+	for _, tg := range t.Triggers {
+		spec.Triggers = append(spec.Triggers, FromTrigger(tg))
+	}
 	convertCommentFromSchema(t.Attrs, &spec.Extra.Attrs)
 	return spec, nil
 }
 
+// FromTrigger converts a schema.Trigger to a sqlspec.Trigger.
+func FromTrigger(t *schema.Trigger) *sqlspec.Trigger {
+	spec := &sqlspec.Trigger{
+		Name:   t.Name,
+		Timing: &schemahcl.Ref{V: Var(strings.ToUpper(t.ActionTime))},
+		As:     strings.TrimSpace(t.Body),
+	}
+	for _, e := range t.Events {
+		spec.Events = append(spec.Events, &schemahcl.Ref{V: strings.ToUpper(e.Name)})
+		for _, c := range e.Columns {
+			spec.UpdateOf = append(spec.UpdateOf, ColumnRef(c.Name))
+		}
+	}
+	if t.For != "" {
+		spec.ForEach = &schemahcl.Ref{V: strings.ToUpper(t.For)}
+	}
+	if t.Func != nil {
+		spec.Execute = &schemahcl.Ref{V: "$function." + t.Func.Name}
+	}
+	return spec
+}
+
 // FromPrimaryKey converts schema.Index to a sqlspec.PrimaryKey.
 func FromPrimaryKey(s *schema.Index) (*sqlspec.PrimaryKey, error) {
 	c := make([]*schemahcl.Ref, 0, len(s.Parts))
@@ -577,7 +1019,10 @@ func ColumnByRef(t *schema.Table, ref *schemahcl.Ref) (*schema.Column, error) {
 	return c, nil
 }
 
-func externalRef(ref *schemahcl.Ref, sch *schema.Schema) (*schema.Table, *schema.Column, error) {
+// ColumnByExternalRef returns the table and the column referenced by ref,
+// e.g. $table.users.$column.id. The table is searched in the provided
+// schema, or in the other schemas of its realm if it is qualified.
+func ColumnByExternalRef(ref *schemahcl.Ref, sch *schema.Schema) (*schema.Table, *schema.Column, error) {
 	tbl, err := findTable(ref, sch)
 	if err != nil {
 		return nil, nil, err
@@ -685,6 +1130,19 @@ func convertCommentFromSpec(spec Attrer, attrs *[]schema.Attr) error {
 	return nil
 }
 
+// convertRenamedFromSpec converts the renamed_from attribute of a table,
+// column or index spec to a schema.Renamed hint for the schema differ.
+func convertRenamedFromSpec(spec Attrer, attrs *[]schema.Attr) error {
+	if a, ok := spec.Attr("renamed_from"); ok {
+		s, err := a.String()
+		if err != nil {
+			return fmt.Errorf("parsing renamed_from: %w", err)
+		}
+		*attrs = append(*attrs, &schema.Renamed{From: s})
+	}
+	return nil
+}
+
 // convertCommentFromSchema converts a schema element comment attribute to a spec comment attribute.
 func convertCommentFromSchema(src []schema.Attr, trgt *[]*schemahcl.Attr) {
 	var c schema.Comment
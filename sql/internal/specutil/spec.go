@@ -22,12 +22,17 @@ func VarAttr(k, v string) *schemahcl.Attr {
 
 type doc struct {
 	Tables  []*sqlspec.Table  `spec:"table"`
+	Views   []*sqlspec.View   `spec:"view"`
+	Funcs   []*sqlspec.Func   `spec:"function"`
+	Procs   []*sqlspec.Proc   `spec:"procedure"`
 	Schemas []*sqlspec.Schema `spec:"schema"`
 }
 
 // Marshal marshals v into an Atlas DDL document using a schemahcl.Marshaler. Marshal uses the given
-// schemaSpec function to convert a *schema.Schema into *sqlspec.Schema and []*sqlspec.Table.
-func Marshal(v any, marshaler schemahcl.Marshaler, schemaSpec func(schem *schema.Schema) (*sqlspec.Schema, []*sqlspec.Table, error)) ([]byte, error) {
+// schemaSpec function to convert a *schema.Schema into *sqlspec.Schema and []*sqlspec.Table, the
+// viewSpec function to convert its views into []*sqlspec.View, and the funcSpec and procSpec functions
+// to convert its functions and procedures. The latter may be nil if the driver does not support them.
+func Marshal(v any, marshaler schemahcl.Marshaler, schemaSpec func(schem *schema.Schema) (*sqlspec.Schema, []*sqlspec.Table, error), viewSpec ViewSpecFunc, funcSpec FuncSpecFunc, procSpec ProcSpecFunc) ([]byte, error) {
 	d := &doc{}
 	switch s := v.(type) {
 	case *schema.Schema:
@@ -35,7 +40,18 @@ func Marshal(v any, marshaler schemahcl.Marshaler, schemaSpec func(schem *schema
 		if err != nil {
 			return nil, fmt.Errorf("specutil: failed converting schema to spec: %w", err)
 		}
+		views, err := FromViews(s, viewSpec)
+		if err != nil {
+			return nil, fmt.Errorf("specutil: failed converting views to spec: %w", err)
+		}
+		funcs, procs, err := FromFuncs(s, funcSpec, procSpec)
+		if err != nil {
+			return nil, fmt.Errorf("specutil: failed converting functions to spec: %w", err)
+		}
 		d.Tables = tables
+		d.Views = views
+		d.Funcs = funcs
+		d.Procs = procs
 		d.Schemas = []*sqlspec.Schema{spec}
 	case *schema.Realm:
 		for _, s := range s.Schemas {
@@ -43,15 +59,35 @@ func Marshal(v any, marshaler schemahcl.Marshaler, schemaSpec func(schem *schema
 			if err != nil {
 				return nil, fmt.Errorf("specutil: failed converting schema to spec: %w", err)
 			}
+			views, err := FromViews(s, viewSpec)
+			if err != nil {
+				return nil, fmt.Errorf("specutil: failed converting views to spec: %w", err)
+			}
+			funcs, procs, err := FromFuncs(s, funcSpec, procSpec)
+			if err != nil {
+				return nil, fmt.Errorf("specutil: failed converting functions to spec: %w", err)
+			}
 			d.Tables = append(d.Tables, tables...)
+			d.Views = append(d.Views, views...)
+			d.Funcs = append(d.Funcs, funcs...)
+			d.Procs = append(d.Procs, procs...)
 			d.Schemas = append(d.Schemas, spec)
 		}
 		if err := QualifyDuplicates(d.Tables); err != nil {
 			return nil, err
 		}
+		if err := QualifyViewDuplicates(d.Views); err != nil {
+			return nil, err
+		}
+		if err := QualifyFuncDuplicates(d.Funcs, d.Procs); err != nil {
+			return nil, err
+		}
 		if err := QualifyReferences(d.Tables, s); err != nil {
 			return nil, err
 		}
+		if err := QualifyTriggerReferences(d.Tables, d.Funcs, s); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("specutil: failed marshaling spec. %T is not supported", v)
 	}
@@ -80,6 +116,66 @@ func QualifyDuplicates(tableSpecs []*sqlspec.Table) error {
 	return nil
 }
 
+// QualifyViewDuplicates sets the Qualified field equal to the schema name in any views
+// with duplicate names in the provided view specs.
+func QualifyViewDuplicates(viewSpecs []*sqlspec.View) error {
+	seen := make(map[string]*sqlspec.View, len(viewSpecs))
+	for _, v := range viewSpecs {
+		if s, ok := seen[v.Name]; ok {
+			schemaName, err := SchemaName(s.Schema)
+			if err != nil {
+				return err
+			}
+			s.Qualifier = schemaName
+			schemaName, err = SchemaName(v.Schema)
+			if err != nil {
+				return err
+			}
+			v.Qualifier = schemaName
+		}
+		seen[v.Name] = v
+	}
+	return nil
+}
+
+// QualifyFuncDuplicates sets the Qualified field equal to the schema name in any functions
+// or procedures with duplicate names in the provided specs.
+func QualifyFuncDuplicates(funcSpecs []*sqlspec.Func, procSpecs []*sqlspec.Proc) error {
+	seenF := make(map[string]*sqlspec.Func, len(funcSpecs))
+	for _, f := range funcSpecs {
+		if s, ok := seenF[f.Name]; ok {
+			schemaName, err := SchemaName(s.Schema)
+			if err != nil {
+				return err
+			}
+			s.Qualifier = schemaName
+			schemaName, err = SchemaName(f.Schema)
+			if err != nil {
+				return err
+			}
+			f.Qualifier = schemaName
+		}
+		seenF[f.Name] = f
+	}
+	seenP := make(map[string]*sqlspec.Proc, len(procSpecs))
+	for _, p := range procSpecs {
+		if s, ok := seenP[p.Name]; ok {
+			schemaName, err := SchemaName(s.Schema)
+			if err != nil {
+				return err
+			}
+			s.Qualifier = schemaName
+			schemaName, err = SchemaName(p.Schema)
+			if err != nil {
+				return err
+			}
+			p.Qualifier = schemaName
+		}
+		seenP[p.Name] = p
+	}
+	return nil
+}
+
 // QualifyReferences qualifies any reference with qualifier.
 func QualifyReferences(tableSpecs []*sqlspec.Table, realm *schema.Realm) error {
 	type cref struct{ s, t string }
@@ -123,6 +219,47 @@ func QualifyReferences(tableSpecs []*sqlspec.Table, realm *schema.Realm) error {
 	return nil
 }
 
+// QualifyTriggerReferences qualifies the function references of the triggers
+// in the given table specs, in case the referenced functions were qualified.
+func QualifyTriggerReferences(tableSpecs []*sqlspec.Table, funcSpecs []*sqlspec.Func, realm *schema.Realm) error {
+	qualified := make(map[string]bool)
+	for _, f := range funcSpecs {
+		if f.Qualifier != "" {
+			qualified[f.Name] = true
+		}
+	}
+	if len(qualified) == 0 {
+		return nil
+	}
+	for _, t := range tableSpecs {
+		if len(t.Triggers) == 0 {
+			continue
+		}
+		sname, err := SchemaName(t.Schema)
+		if err != nil {
+			return err
+		}
+		s1, ok := realm.Schema(sname)
+		if !ok {
+			return fmt.Errorf("schema %q was not found in realm", sname)
+		}
+		t1, ok := s1.Table(t.Name)
+		if !ok {
+			return fmt.Errorf("table %q.%q was not found in realm", sname, t.Name)
+		}
+		for _, tg := range t.Triggers {
+			tg1, ok := t1.Trigger(tg.Name)
+			if !ok {
+				return fmt.Errorf("trigger %q.%q.%q was not found in realm", sname, t.Name, tg.Name)
+			}
+			if f := tg1.Func; f != nil && f.Schema != nil && qualified[f.Name] {
+				tg.Execute = &schemahcl.Ref{V: "$function." + f.Schema.Name + "." + f.Name}
+			}
+		}
+	}
+	return nil
+}
+
 // HCLBytesFunc returns a helper that evaluates an HCL document from a byte slice instead
 // of from an hclparse.Parser instance.
 func HCLBytesFunc(ev schemahcl.Evaluator) func(b []byte, v any, inp map[string]cty.Value) error {
@@ -45,6 +45,7 @@ func (d *DevDriver) NormalizeRealm(ctx context.Context, r *schema.Realm) (nr *sc
 		changes = make([]schema.Change, 0, len(r.Schemas))
 		reverse = make([]schema.Change, 0, len(r.Schemas))
 		opts    = &schema.InspectRealmOption{
+			Mode:    schema.InspectAll,
 			Schemas: make([]string, 0, len(r.Schemas)),
 		}
 	)
@@ -61,6 +62,11 @@ func (d *DevDriver) NormalizeRealm(ctx context.Context, r *schema.Realm) (nr *sc
 		st := schema.New(dev).AddAttrs(s.Attrs...)
 		changes = append(changes, &schema.AddSchema{S: st})
 		reverse = append(reverse, &schema.DropSchema{S: st, Extra: append(d.DropClause, &schema.IfExists{})})
+		// Objects (e.g. sequences or domains) are created
+		// first, as tables may use or depend on them.
+		for _, o := range s.Objects {
+			changes = append(changes, &schema.AddObject{O: o})
+		}
 		for _, t := range s.Tables {
 			// If objects are not strongly connected.
 			if t.Schema != s {
@@ -76,6 +82,24 @@ func (d *DevDriver) NormalizeRealm(ctx context.Context, r *schema.Realm) (nr *sc
 			}
 			changes = append(changes, &schema.AddTable{T: t})
 		}
+		for _, v := range s.Views {
+			if v.Schema != s {
+				v.Schema = s
+			}
+			changes = append(changes, &schema.AddView{V: v})
+		}
+		for _, f := range s.Funcs {
+			if f.Schema != s {
+				f.Schema = s
+			}
+			changes = append(changes, &schema.AddFunc{F: f})
+		}
+		for _, p := range s.Procs {
+			if p.Schema != s {
+				p.Schema = s
+			}
+			changes = append(changes, &schema.AddProc{P: p})
+		}
 	}
 	patch := func(r *schema.Realm) {
 		for _, s := range r.Schemas {
@@ -57,6 +57,52 @@ type (
 		ReferenceChanged(from, to schema.ReferenceOption) bool
 	}
 
+	// A FuncDiffer wraps the methods for diffing functions and procedures. If the DiffDriver
+	// implements the FuncDiffer interface, SchemaDiff diffs also the functions and procedures
+	// of the schemas. See FuncChanged for a helper function that can be used by drivers.
+	FuncDiffer interface {
+		// FuncChanged reports if the function definition was changed.
+		FuncChanged(from, to *schema.Func) (bool, error)
+
+		// ProcChanged reports if the procedure definition was changed.
+		ProcChanged(from, to *schema.Proc) (bool, error)
+	}
+
+	// An ObjectDiffer wraps the ObjectDiff method for diffing driver-specific schema
+	// objects, like PostgreSQL sequences. If the DiffDriver implements the ObjectDiffer
+	// interface, SchemaDiff and RealmDiff diff also the objects of the schemas.
+	ObjectDiffer interface {
+		// ObjectDiff returns a changeset for migrating the schema objects
+		// from one state to the other. For example, modifying a sequence.
+		ObjectDiff(from, to *schema.Schema) ([]schema.Change, error)
+	}
+
+	// A RoleDiffer wraps the RoleChanged method for diffing database roles. If the DiffDriver
+	// implements the RoleDiffer interface, RealmDiff diffs also the roles and the privileges
+	// of the realms. Note, roles are inspected only if schema.InspectRoles is set.
+	RoleDiffer interface {
+		// RoleChanged reports if the role attributes were changed.
+		RoleChanged(from, to *schema.Role) (bool, error)
+	}
+
+	// A ForeignKeyAttrDiffer wraps the ForeignKeyAttrChanged method for diffing driver-specific
+	// attributes of foreign keys. For example, PostgreSQL deferrable constraints. If the DiffDriver
+	// implements the ForeignKeyAttrDiffer interface, attribute changes are reported by TableDiff
+	// as schema.ModifyForeignKey changes with the schema.ChangeAttr kind.
+	ForeignKeyAttrDiffer interface {
+		// ForeignKeyAttrChanged reports if the foreign key attributes were changed.
+		ForeignKeyAttrChanged(from, to []schema.Attr) bool
+	}
+
+	// A ColumnPositioner wraps the SupportsColumnPosition method. If the DiffDriver implements
+	// the ColumnPositioner interface and the schema.DiffOptions.ColumnPosition option is set,
+	// TableDiff reports columns that were moved as schema.ModifyColumn changes with the
+	// schema.ChangePosition kind.
+	ColumnPositioner interface {
+		// SupportsColumnPosition reports if the driver supports changing column positions.
+		SupportsColumnPosition() bool
+	}
+
 	// A Normalizer wraps the Normalize method for normalizing the from and to tables before
 	// running diffing. The "from" usually represents the inspected database state (current),
 	// and the second represents the desired state.
@@ -71,7 +117,15 @@ type (
 // RealmDiff implements the schema.Differ for Realm objects and returns a list of changes
 // that need to be applied in order to move a database from the current state to the desired.
 func (d *Diff) RealmDiff(from, to *schema.Realm) ([]schema.Change, error) {
-	var changes []schema.Change
+	return d.RealmDiffWithOptions(from, to)
+}
+
+// RealmDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Diff) RealmDiffWithOptions(from, to *schema.Realm, opts ...schema.DiffOption) ([]schema.Change, error) {
+	var (
+		changes []schema.Change
+		o       = schema.NewDiffOptions(opts...)
+	)
 	// Drop or modify schema.
 	for _, s1 := range from.Schemas {
 		s2, ok := to.Schema(s1.Name)
@@ -79,7 +133,7 @@ func (d *Diff) RealmDiff(from, to *schema.Realm) ([]schema.Change, error) {
 			changes = append(changes, &schema.DropSchema{S: s1})
 			continue
 		}
-		change, err := d.SchemaDiff(s1, s2)
+		change, err := d.SchemaDiffWithOptions(s1, s2, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -94,16 +148,156 @@ func (d *Diff) RealmDiff(from, to *schema.Realm) ([]schema.Change, error) {
 		for _, t := range s1.Tables {
 			changes = append(changes, &schema.AddTable{T: t})
 		}
+		for _, v := range s1.Views {
+			changes = append(changes, &schema.AddView{V: v})
+		}
+		if _, ok := d.DiffDriver.(FuncDiffer); ok {
+			for _, f := range s1.Funcs {
+				changes = append(changes, &schema.AddFunc{F: f})
+			}
+			for _, p := range s1.Procs {
+				changes = append(changes, &schema.AddProc{P: p})
+			}
+		}
+		if od, ok := d.DiffDriver.(ObjectDiffer); ok {
+			objs, err := od.ObjectDiff(schema.New(s1.Name), s1)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, objs...)
+		}
+	}
+	if rd, ok := d.DiffDriver.(RoleDiffer); ok {
+		roles, err := roleDiff(rd, from, to)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, roles...)
+	}
+	return filterChanges(changes, o, nil)
+}
+
+// roleDiff returns the changes for migrating the roles and the privileges of realm
+// "from" to the ones of realm "to".
+func roleDiff(rd RoleDiffer, from, to *schema.Realm) ([]schema.Change, error) {
+	var changes []schema.Change
+	// Drop or modify roles.
+	for _, r1 := range from.Roles {
+		r2, ok := to.Role(r1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropRole{R: r1})
+			continue
+		}
+		changed, err := rd.RoleChanged(r1, r2)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyRole{From: r1, To: r2})
+		}
+	}
+	// Add roles.
+	for _, r2 := range to.Roles {
+		if _, ok := from.Role(r2.Name); !ok {
+			changes = append(changes, &schema.AddRole{R: r2})
+		}
+	}
+	// Privileges of objects that were dropped are not revoked, as they are
+	// dropped with them. The ones of dropped roles are revoked, as some
+	// databases (e.g. PostgreSQL) do not allow dropping roles with privileges.
+	for _, p := range missingPrivs(from.Privileges, to.Privileges) {
+		if privObjectExists(to, p) {
+			changes = append(changes, &schema.Revoke{P: p})
+		}
+	}
+	for _, p := range missingPrivs(to.Privileges, from.Privileges) {
+		changes = append(changes, &schema.Grant{P: p})
 	}
 	return changes, nil
 }
 
+// missingPrivs returns the privileges in ps1 that were not granted in ps2. Privileges
+// that were partially granted are returned with their missing privilege types only.
+func missingPrivs(ps1, ps2 []*schema.Privilege) []*schema.Privilege {
+	var (
+		missing []*schema.Privilege
+		granted = make(map[string]bool)
+	)
+	for _, p := range ps2 {
+		for _, t := range p.Privs {
+			granted[privKey(p, t)] = true
+		}
+	}
+	for _, p := range ps1 {
+		var privs []string
+		for _, t := range p.Privs {
+			if !granted[privKey(p, t)] {
+				privs = append(privs, t)
+			}
+		}
+		switch {
+		case len(privs) == len(p.Privs):
+			missing = append(missing, p)
+		case len(privs) > 0:
+			p1 := *p
+			p1.Privs = privs
+			missing = append(missing, &p1)
+		}
+	}
+	return missing
+}
+
+// privKey returns a key that identifies a single privilege type granted to a role on an object.
+func privKey(p *schema.Privilege, t string) string {
+	var grantee, ns, obj string
+	if p.Grantee != nil {
+		grantee = p.Grantee.Name
+	}
+	if p.Schema != nil {
+		ns = p.Schema.Name
+	}
+	switch {
+	case p.Table != nil:
+		obj = "table:" + p.Table.Name
+	case p.View != nil:
+		obj = "view:" + p.View.Name
+	}
+	return fmt.Sprintf("%q.%q.%q.%s.%t", grantee, ns, obj, strings.ToUpper(t), p.Grantable)
+}
+
+// privObjectExists reports if the object that the privileges were granted on exists in the realm.
+func privObjectExists(r *schema.Realm, p *schema.Privilege) bool {
+	if p.Schema == nil {
+		return true
+	}
+	s, ok := r.Schema(p.Schema.Name)
+	switch {
+	case !ok:
+		return false
+	case p.Table != nil:
+		_, ok = s.Table(p.Table.Name)
+	case p.View != nil:
+		_, ok = s.View(p.View.Name)
+	}
+	return ok
+}
+
 // SchemaDiff implements the schema.Differ interface and returns a list of
 // changes that need to be applied in order to move from one state to the other.
 func (d *Diff) SchemaDiff(from, to *schema.Schema) ([]schema.Change, error) {
+	return d.SchemaDiffWithOptions(from, to)
+}
+
+// SchemaDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Diff) SchemaDiffWithOptions(from, to *schema.Schema, opts ...schema.DiffOption) ([]schema.Change, error) {
 	if from.Name != to.Name {
 		return nil, fmt.Errorf("mismatched schema names: %q != %q", from.Name, to.Name)
 	}
+	o := schema.NewDiffOptions(opts...)
+	renamed, err := d.tableRenames(from, to, o)
+	if err != nil {
+		return nil, err
+	}
 	var changes []schema.Change
 	// Drop or modify attributes (collations, charset, etc).
 	if change := d.SchemaAttrDiff(from, to); len(change) > 0 {
@@ -113,14 +307,18 @@ func (d *Diff) SchemaDiff(from, to *schema.Schema) ([]schema.Change, error) {
 		})
 	}
 
-	// Drop or modify tables.
+	// Drop, rename or modify tables.
 	for _, t1 := range from.Tables {
 		t2, ok := to.Table(t1.Name)
 		if !ok {
-			changes = append(changes, &schema.DropTable{T: t1})
-			continue
+			if t2, ok = renamed[t1]; !ok {
+				changes = append(changes, &schema.DropTable{T: t1})
+				continue
+			}
+			changes = append(changes, &schema.RenameTable{From: t1, To: t2})
+			t1 = renamedTable(t1, t2)
 		}
-		change, err := d.TableDiff(t1, t2)
+		change, err := d.TableDiffWithOptions(t1, t2, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -133,16 +331,102 @@ func (d *Diff) SchemaDiff(from, to *schema.Schema) ([]schema.Change, error) {
 	}
 	// Add tables.
 	for _, t1 := range to.Tables {
-		if _, ok := from.Table(t1.Name); !ok {
+		if _, ok := from.Table(t1.Name); !ok && !isRenamed(renamed, t1) {
 			changes = append(changes, &schema.AddTable{T: t1})
 		}
 	}
+
+	// Drop or modify views.
+	for _, v1 := range from.Views {
+		v2, ok := to.View(v1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropView{V: v1})
+			continue
+		}
+		if ViewChanged(v1, v2) || d.viewIndexesChanged(v1, v2) {
+			changes = append(changes, &schema.ModifyView{From: v1, To: v2})
+		}
+	}
+	// Add views.
+	for _, v1 := range to.Views {
+		if _, ok := from.View(v1.Name); !ok {
+			changes = append(changes, &schema.AddView{V: v1})
+		}
+	}
+	if fd, ok := d.DiffDriver.(FuncDiffer); ok {
+		funcs, err := funcsDiff(fd, from, to)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, funcs...)
+	}
+	if od, ok := d.DiffDriver.(ObjectDiffer); ok {
+		objs, err := od.ObjectDiff(from, to)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, objs...)
+	}
+	return filterChanges(changes, o, nil)
+}
+
+// funcsDiff returns the changes for migrating the functions
+// and procedures of schema "from" to the ones of schema "to".
+func funcsDiff(fd FuncDiffer, from, to *schema.Schema) ([]schema.Change, error) {
+	var changes []schema.Change
+	// Drop or modify functions.
+	for _, f1 := range from.Funcs {
+		f2, ok := to.Func(f1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropFunc{F: f1})
+			continue
+		}
+		changed, err := fd.FuncChanged(f1, f2)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyFunc{From: f1, To: f2})
+		}
+	}
+	// Add functions.
+	for _, f1 := range to.Funcs {
+		if _, ok := from.Func(f1.Name); !ok {
+			changes = append(changes, &schema.AddFunc{F: f1})
+		}
+	}
+	// Drop or modify procedures.
+	for _, p1 := range from.Procs {
+		p2, ok := to.Proc(p1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropProc{P: p1})
+			continue
+		}
+		changed, err := fd.ProcChanged(p1, p2)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyProc{From: p1, To: p2})
+		}
+	}
+	// Add procedures.
+	for _, p1 := range to.Procs {
+		if _, ok := from.Proc(p1.Name); !ok {
+			changes = append(changes, &schema.AddProc{P: p1})
+		}
+	}
 	return changes, nil
 }
 
 // TableDiff implements the schema.TableDiffer interface and returns a list of
 // changes that need to be applied in order to move from one state to the other.
 func (d *Diff) TableDiff(from, to *schema.Table) ([]schema.Change, error) {
+	return d.TableDiffWithOptions(from, to)
+}
+
+// TableDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Diff) TableDiffWithOptions(from, to *schema.Table, opts ...schema.DiffOption) ([]schema.Change, error) {
 	if from.Name != to.Name {
 		return nil, fmt.Errorf("mismatched table names: %q != %q", from.Name, to.Name)
 	}
@@ -168,17 +452,32 @@ func (d *Diff) TableDiff(from, to *schema.Table) ([]schema.Change, error) {
 	}
 	changes = append(changes, change...)
 
-	// Drop or modify columns.
+	o := schema.NewDiffOptions(opts...)
+	renamed, err := d.columnRenames(from, to, o)
+	if err != nil {
+		return nil, err
+	}
+	var moved map[*schema.Column]bool
+	if p, ok := d.DiffDriver.(ColumnPositioner); ok && o.ColumnPosition && p.SupportsColumnPosition() {
+		moved = movedColumns(from, to, renamed)
+	}
+	// Drop, rename or modify columns.
 	for _, c1 := range from.Columns {
 		c2, ok := to.Column(c1.Name)
 		if !ok {
-			changes = append(changes, &schema.DropColumn{C: c1})
-			continue
+			if c2, ok = renamed[c1]; !ok {
+				changes = append(changes, &schema.DropColumn{C: c1})
+				continue
+			}
+			changes = append(changes, &schema.RenameColumn{From: c1, To: c2})
 		}
 		change, err := d.ColumnChange(from, c1, c2)
 		if err != nil {
 			return nil, err
 		}
+		if moved[c2] {
+			change |= schema.ChangePosition
+		}
 		if change != schema.NoChange {
 			changes = append(changes, &schema.ModifyColumn{
 				From:   c1,
@@ -189,13 +488,17 @@ func (d *Diff) TableDiff(from, to *schema.Table) ([]schema.Change, error) {
 	}
 	// Add columns.
 	for _, c1 := range to.Columns {
-		if _, ok := from.Column(c1.Name); !ok {
+		if _, ok := from.Column(c1.Name); !ok && !isRenamed(renamed, c1) {
 			changes = append(changes, &schema.AddColumn{C: c1})
 		}
 	}
 
 	// Index changes.
-	changes = append(changes, d.indexDiff(from, to)...)
+	change, err = d.indexDiff(from, to, renamed, o)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, change...)
 
 	// Drop or modify foreign-keys.
 	for _, fk1 := range from.ForeignKeys {
@@ -218,22 +521,106 @@ func (d *Diff) TableDiff(from, to *schema.Table) ([]schema.Change, error) {
 			changes = append(changes, &schema.AddForeignKey{F: fk1})
 		}
 	}
-	return changes, nil
+
+	// Trigger changes.
+	changes = append(changes, triggersDiff(from, to)...)
+	return filterChanges(changes, o, to)
+}
+
+// filterChanges applies the diff policy on the given changes. i.e. it omits
+// the skipped changes and fails if one of the remaining changes is denied.
+// The table argument is set for the changes of a table, and nil otherwise.
+func filterChanges(changes []schema.Change, opts *schema.DiffOptions, t *schema.Table) ([]schema.Change, error) {
+	if len(opts.SkipChanges) == 0 && len(opts.DenyChanges) == 0 {
+		return changes, nil
+	}
+	var filtered []schema.Change
+	for _, c := range changes {
+		switch {
+		case opts.Skipped(c):
+		case opts.Denied(c):
+			return nil, &schema.DeniedChangeError{C: c, T: t}
+		default:
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
+}
+
+// triggersDiff returns the schema changes (if any) for migrating
+// table triggers from current state to the desired state.
+func triggersDiff(from, to *schema.Table) []schema.Change {
+	var changes []schema.Change
+	// Drop or modify triggers.
+	for _, t1 := range from.Triggers {
+		t2, ok := to.Trigger(t1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropTrigger{T: t1})
+			continue
+		}
+		if TriggerChanged(t1, t2) {
+			changes = append(changes, &schema.ModifyTrigger{From: t1, To: t2})
+		}
+	}
+	// Add triggers.
+	for _, t1 := range to.Triggers {
+		if _, ok := from.Trigger(t1.Name); !ok {
+			changes = append(changes, &schema.AddTrigger{T: t1})
+		}
+	}
+	return changes
+}
+
+// TriggerChanged reports if the trigger definition was changed.
+func TriggerChanged(from, to *schema.Trigger) bool {
+	if !strings.EqualFold(from.ActionTime, to.ActionTime) || !strings.EqualFold(from.For, to.For) {
+		return true
+	}
+	if strings.TrimSpace(from.Body) != strings.TrimSpace(to.Body) {
+		return true
+	}
+	if (from.Func != nil) != (to.Func != nil) || from.Func != nil && (from.Func.Name != to.Func.Name || schemaName(from.Func.Schema) != schemaName(to.Func.Schema)) {
+		return true
+	}
+	if len(from.Events) != len(to.Events) {
+		return true
+	}
+	events := make(map[string][]*schema.Column, len(from.Events))
+	for _, e := range from.Events {
+		events[strings.ToUpper(e.Name)] = e.Columns
+	}
+	for _, e := range to.Events {
+		columns, ok := events[strings.ToUpper(e.Name)]
+		if !ok || len(columns) != len(e.Columns) {
+			return true
+		}
+		for i := range columns {
+			if columns[i].Name != e.Columns[i].Name {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // indexDiff returns the schema changes (if any) for migrating table
-// indexes from current state to the desired state.
-func (d *Diff) indexDiff(from, to *schema.Table) []schema.Change {
+// indexes from current state to the desired state. Index parts that
+// reference renamed columns are compared by their new state.
+func (d *Diff) indexDiff(from, to *schema.Table, columns map[*schema.Column]*schema.Column, opts *schema.DiffOptions) ([]schema.Change, error) {
 	var (
 		changes []schema.Change
 		exists  = make(map[*schema.Index]bool)
 	)
-	// Drop or modify indexes.
+	renamed, err := d.indexRenames(from, to, columns, opts)
+	if err != nil {
+		return nil, err
+	}
+	// Drop, rename or modify indexes.
 	for _, idx1 := range from.Indexes {
 		idx2, ok := to.Index(idx1.Name)
 		// Found directly.
 		if ok {
-			if change := d.indexChange(idx1, idx2); change != schema.NoChange {
+			if change := d.indexChange(renamedParts(idx1, columns), idx2); change != schema.NoChange {
 				changes = append(changes, &schema.ModifyIndex{
 					From:   idx1,
 					To:     idx2,
@@ -250,6 +637,12 @@ func (d *Diff) indexDiff(from, to *schema.Table) []schema.Change {
 				continue
 			}
 		}
+		// Renamed.
+		if idx2, ok := renamed[idx1]; ok {
+			changes = append(changes, &schema.RenameIndex{From: idx1, To: idx2})
+			exists[idx2] = true
+			continue
+		}
 		// Not found.
 		changes = append(changes, &schema.DropIndex{I: idx1})
 	}
@@ -262,7 +655,262 @@ func (d *Diff) indexDiff(from, to *schema.Table) []schema.Change {
 			changes = append(changes, &schema.AddIndex{I: idx})
 		}
 	}
-	return changes
+	return changes, nil
+}
+
+// tableRenames returns the renamed tables of the schema, mapped from their current
+// state to the desired state. Renamed tables must have identical definitions, unless
+// the rename was declared explicitly.
+func (d *Diff) tableRenames(from, to *schema.Schema, opts *schema.DiffOptions) (map[*schema.Table]*schema.Table, error) {
+	var dropped, added []*schema.Table
+	for _, t1 := range from.Tables {
+		if _, ok := to.Table(t1.Name); !ok {
+			dropped = append(dropped, t1)
+		}
+	}
+	for _, t2 := range to.Tables {
+		if _, ok := from.Table(t2.Name); !ok {
+			added = append(added, t2)
+		}
+	}
+	return (&renamer[*schema.Table]{
+		name:  func(t *schema.Table) string { return t.Name },
+		attrs: func(t *schema.Table) []schema.Attr { return t.Attrs },
+		same: func(t1, t2 *schema.Table) (bool, error) {
+			changes, err := d.TableDiff(renamedTable(t1, t2), t2)
+			if err != nil {
+				return false, err
+			}
+			return len(changes) == 0, nil
+		},
+		change: func(t1, t2 *schema.Table) schema.Change { return &schema.RenameTable{From: t1, To: t2} },
+	}).renames(dropped, added, opts)
+}
+
+// columnRenames returns the renamed columns of the table, mapped from their
+// current state to the desired state. Renamed columns that were declared
+// explicitly may be modified as well.
+func (d *Diff) columnRenames(from, to *schema.Table, opts *schema.DiffOptions) (map[*schema.Column]*schema.Column, error) {
+	var dropped, added []*schema.Column
+	for _, c1 := range from.Columns {
+		if _, ok := to.Column(c1.Name); !ok {
+			dropped = append(dropped, c1)
+		}
+	}
+	for _, c2 := range to.Columns {
+		if _, ok := from.Column(c2.Name); !ok {
+			added = append(added, c2)
+		}
+	}
+	return (&renamer[*schema.Column]{
+		name:  func(c *schema.Column) string { return c.Name },
+		attrs: func(c *schema.Column) []schema.Attr { return c.Attrs },
+		same: func(c1, c2 *schema.Column) (bool, error) {
+			change, err := d.ColumnChange(from, c1, c2)
+			return change == schema.NoChange, err
+		},
+		change: func(c1, c2 *schema.Column) schema.Change { return &schema.RenameColumn{From: c1, To: c2} },
+	}).renames(dropped, added, opts)
+}
+
+// indexRenames returns the renamed indexes of the table, mapped from their current
+// state to the desired state. Indexes are renamed only if their definitions are
+// identical, as changing an index requires recreating it.
+func (d *Diff) indexRenames(from, to *schema.Table, columns map[*schema.Column]*schema.Column, opts *schema.DiffOptions) (map[*schema.Index]*schema.Index, error) {
+	var dropped, added []*schema.Index
+	for _, idx1 := range from.Indexes {
+		if _, ok := to.Index(idx1.Name); !ok {
+			dropped = append(dropped, idx1)
+		}
+	}
+	for _, idx2 := range to.Indexes {
+		if _, ok := from.Index(idx2.Name); !ok {
+			added = append(added, idx2)
+		}
+	}
+	renames, err := (&renamer[*schema.Index]{
+		name:  func(idx *schema.Index) string { return idx.Name },
+		attrs: func(idx *schema.Index) []schema.Attr { return idx.Attrs },
+		same: func(idx1, idx2 *schema.Index) (bool, error) {
+			return d.indexChange(renamedParts(idx1, columns), idx2) == schema.NoChange, nil
+		},
+		change: func(idx1, idx2 *schema.Index) schema.Change { return &schema.RenameIndex{From: idx1, To: idx2} },
+	}).renames(dropped, added, opts)
+	if err != nil {
+		return nil, err
+	}
+	for idx1, idx2 := range renames {
+		if d.indexChange(renamedParts(idx1, columns), idx2) != schema.NoChange {
+			delete(renames, idx1)
+		}
+	}
+	return renames, nil
+}
+
+// renamer detects renames between the dropped and the added objects of type T.
+type renamer[T comparable] struct {
+	name   func(T) string
+	attrs  func(T) []schema.Attr
+	same   func(from, to T) (bool, error) // Reports if the definitions are identical.
+	change func(from, to T) schema.Change
+}
+
+// renames returns a mapping from the renamed objects in "dropped" to their new state in
+// "added". Renames are either declared using the schema.Renamed attribute, or detected by
+// identical definitions and confirmed using the AskRename option. Objects that have more
+// than one candidate are not considered as renamed.
+func (r *renamer[T]) renames(dropped, added []T, opts *schema.DiffOptions) (map[T]T, error) {
+	var (
+		renames = make(map[T]T)
+		targets = make(map[T]bool)
+	)
+	for _, to := range added {
+		var h schema.Renamed
+		if !Has(r.attrs(to), &h) {
+			continue
+		}
+		for _, from := range dropped {
+			if _, ok := renames[from]; !ok && r.name(from) == h.From {
+				renames[from], targets[to] = to, true
+				break
+			}
+		}
+	}
+	if opts == nil || opts.AskRename == nil {
+		return renames, nil
+	}
+	candidates := func(v T, objs []T, skip func(T) bool, same func(T, T) (bool, error)) ([]T, error) {
+		var matched []T
+		for _, o := range objs {
+			if skip(o) {
+				continue
+			}
+			ok, err := same(v, o)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matched = append(matched, o)
+			}
+		}
+		return matched, nil
+	}
+	for _, from := range dropped {
+		if _, ok := renames[from]; ok {
+			continue
+		}
+		tos, err := candidates(from, added, func(to T) bool { return targets[to] }, r.same)
+		if err != nil {
+			return nil, err
+		}
+		if len(tos) != 1 {
+			continue
+		}
+		froms, err := candidates(tos[0], dropped, func(from T) bool {
+			_, ok := renames[from]
+			return ok
+		}, func(to, from T) (bool, error) { return r.same(from, to) })
+		if err != nil {
+			return nil, err
+		}
+		if len(froms) != 1 {
+			continue
+		}
+		ok, err := opts.AskRename(r.change(from, tos[0]))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			renames[from], targets[tos[0]] = tos[0], true
+		}
+	}
+	return renames, nil
+}
+
+// isRenamed reports if v is the new state of a renamed object.
+func isRenamed[T comparable](renames map[T]T, v T) bool {
+	for _, to := range renames {
+		if to == v {
+			return true
+		}
+	}
+	return false
+}
+
+// movedColumns returns the columns of the desired table that need to be moved in order
+// to keep the relative order of the existing columns identical to the desired one. The
+// columns that form the longest increasing subsequence of their current positions are
+// kept in place, and the rest are reported as moved.
+func movedColumns(from, to *schema.Table, renamed map[*schema.Column]*schema.Column) map[*schema.Column]bool {
+	pos := make(map[*schema.Column]int, len(from.Columns))
+	for i, c1 := range from.Columns {
+		if c2, ok := to.Column(c1.Name); ok {
+			pos[c2] = i
+		} else if c2, ok := renamed[c1]; ok {
+			pos[c2] = i
+		}
+	}
+	var cols []*schema.Column
+	for _, c2 := range to.Columns {
+		if _, ok := pos[c2]; ok {
+			cols = append(cols, c2)
+		}
+	}
+	// Longest increasing subsequence in O(n^2), as tables
+	// are not expected to have a large number of columns.
+	var (
+		best = -1
+		size = make([]int, len(cols))
+		prev = make([]int, len(cols))
+	)
+	for i := range cols {
+		size[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if pos[cols[j]] < pos[cols[i]] && size[j]+1 > size[i] {
+				size[i], prev[i] = size[j]+1, j
+			}
+		}
+		if best == -1 || size[i] > size[best] {
+			best = i
+		}
+	}
+	kept := make(map[*schema.Column]bool, len(cols))
+	for i := best; i != -1; i = prev[i] {
+		kept[cols[i]] = true
+	}
+	moved := make(map[*schema.Column]bool)
+	for _, c := range cols {
+		if !kept[c] {
+			moved[c] = true
+		}
+	}
+	return moved
+}
+
+// renamedParts returns a copy of the index with its parts referencing the
+// new state of the renamed columns, used for diffing index definitions.
+func renamedParts(idx *schema.Index, columns map[*schema.Column]*schema.Column) *schema.Index {
+	if len(columns) == 0 {
+		return idx
+	}
+	i := *idx
+	i.Parts = make([]*schema.IndexPart, len(idx.Parts))
+	for j, p := range idx.Parts {
+		c := *p
+		if p.C != nil && columns[p.C] != nil {
+			c.C = columns[p.C]
+		}
+		i.Parts[j] = &c
+	}
+	return &i
+}
+
+// renamedTable returns a copy of the table t1 with the name of t2,
+// used for diffing the definitions of renamed tables.
+func renamedTable(t1, t2 *schema.Table) *schema.Table {
+	t := *t1
+	t.Name = t2.Name
+	return &t
 }
 
 // pkChange returns the schema changes (if any) for migrating one primary key to the other.
@@ -343,6 +991,9 @@ func (d *Diff) fkChange(from, to *schema.ForeignKey) schema.ChangeKind {
 	if d.ReferenceChanged(from.OnDelete, to.OnDelete) {
 		change |= schema.ChangeDeleteAction
 	}
+	if fd, ok := d.DiffDriver.(ForeignKeyAttrDiffer); ok && fd.ForeignKeyAttrChanged(from.Attrs, to.Attrs) {
+		change |= schema.ChangeAttr
+	}
 	return change
 }
 
@@ -359,6 +1010,130 @@ func (d *Diff) similarUnnamedIndex(t *schema.Table, idx1 *schema.Index) (*schema
 	return nil, false
 }
 
+// ViewChanged reports if the view definition, its kind, its check option or its comment
+// were changed. Definitions are compared after trimming their whitespaces and trailing
+// semicolons, as databases usually store them slightly different from their input.
+func ViewChanged(from, to *schema.View) bool {
+	if viewDef(from.Def) != viewDef(to.Def) || from.Materialized() != to.Materialized() {
+		return true
+	}
+	var c1, c2 schema.ViewCheckOption
+	Has(from.Attrs, &c1)
+	Has(to.Attrs, &c2)
+	// NONE and missing check option are equivalent.
+	if strings.TrimPrefix(strings.ToUpper(c1.V), schema.ViewCheckOptionNone) != strings.TrimPrefix(strings.ToUpper(c2.V), schema.ViewCheckOptionNone) {
+		return true
+	}
+	return CommentChange(from.Attrs, to.Attrs) != schema.NoChange
+}
+
+// viewIndexesChanged reports if the indexes of the materialized views were changed.
+func (d *Diff) viewIndexesChanged(from, to *schema.View) bool {
+	if len(from.Indexes) != len(to.Indexes) {
+		return true
+	}
+	for _, idx1 := range from.Indexes {
+		idx2, ok := to.Index(idx1.Name)
+		if !ok || d.indexChange(idx1, idx2) != schema.NoChange {
+			return true
+		}
+	}
+	return false
+}
+
+// FuncChanged reports if the function arguments, return type, language, body or comment
+// were changed. The typeChanged function is used for comparing the argument and return types,
+// and is usually implemented by the driver. Attributes other than the comment are expected
+// to be compared by the caller.
+func FuncChanged(from, to *schema.Func, typeChanged func(from, to schema.Type) (bool, error)) (bool, error) {
+	if changed, err := retChanged(from.Ret, to.Ret, typeChanged); changed || err != nil {
+		return changed, err
+	}
+	return routineChanged(
+		&routine{args: from.Args, lang: from.Lang, body: from.Body, attrs: from.Attrs},
+		&routine{args: to.Args, lang: to.Lang, body: to.Body, attrs: to.Attrs},
+		typeChanged,
+	)
+}
+
+// ProcChanged reports if the procedure arguments, language, body or comment were changed.
+// See FuncChanged for more info.
+func ProcChanged(from, to *schema.Proc, typeChanged func(from, to schema.Type) (bool, error)) (bool, error) {
+	return routineChanged(
+		&routine{args: from.Args, lang: from.Lang, body: from.Body, attrs: from.Attrs},
+		&routine{args: to.Args, lang: to.Lang, body: to.Body, attrs: to.Attrs},
+		typeChanged,
+	)
+}
+
+// ArgsChanged reports if the argument list was changed. Argument types are
+// compared using the given typeChanged function, and defaults are compared
+// by their raw representation.
+func ArgsChanged(from, to []*schema.FuncArg, typeChanged func(from, to schema.Type) (bool, error)) (bool, error) {
+	if len(from) != len(to) {
+		return true, nil
+	}
+	for i := range from {
+		a1, a2 := from[i], to[i]
+		if a1.Name != a2.Name || argMode(a1.Mode) != argMode(a2.Mode) {
+			return true, nil
+		}
+		if changed, err := retChanged(a1.Type, a2.Type, typeChanged); changed || err != nil {
+			return changed, err
+		}
+		x1, _ := DefaultValue(&schema.Column{Default: a1.Default})
+		x2, _ := DefaultValue(&schema.Column{Default: a2.Default})
+		if strings.TrimSpace(x1) != strings.TrimSpace(x2) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// routine holds the common parts of functions and procedures.
+type routine struct {
+	args       []*schema.FuncArg
+	lang, body string
+	attrs      []schema.Attr
+}
+
+func routineChanged(from, to *routine, typeChanged func(from, to schema.Type) (bool, error)) (bool, error) {
+	if !strings.EqualFold(from.lang, to.lang) || strings.TrimSpace(from.body) != strings.TrimSpace(to.body) {
+		return true, nil
+	}
+	if changed, err := ArgsChanged(from.args, to.args, typeChanged); changed || err != nil {
+		return changed, err
+	}
+	return CommentChange(from.attrs, to.attrs) != schema.NoChange, nil
+}
+
+// retChanged reports if the two types are different. A nil type
+// equals only to another nil type.
+func retChanged(from, to schema.Type, typeChanged func(from, to schema.Type) (bool, error)) (bool, error) {
+	switch {
+	case from == nil && to == nil:
+		return false, nil
+	case from == nil || to == nil:
+		return true, nil
+	default:
+		return typeChanged(from, to)
+	}
+}
+
+// argMode returns the normalized form of an argument mode,
+// as IN is the default mode for arguments.
+func argMode(m string) string {
+	if m = strings.ToUpper(m); m == "" {
+		return schema.FuncArgModeIn
+	}
+	return m
+}
+
+// viewDef returns the normalized form of a view definition.
+func viewDef(s string) string {
+	return strings.Join(strings.Fields(strings.TrimRight(strings.TrimSpace(s), ";")), " ")
+}
+
 // CommentChange reports if the element comment was changed.
 func CommentChange(from, to []schema.Attr) schema.ChangeKind {
 	var c1, c2 schema.Comment
@@ -533,3 +1308,11 @@ func SingleQuote(s string) (string, error) {
 		return "'" + strings.ReplaceAll(s, "'", "''") + "'", nil
 	}
 }
+
+// schemaName returns the name of the given schema, or an empty string if it is nil.
+func schemaName(s *schema.Schema) string {
+	if s == nil {
+		return ""
+	}
+	return s.Name
+}
@@ -109,6 +109,19 @@ func excludeS(s *schema.Schema, glob []string) error {
 		tables = append(tables, t)
 	}
 	s.Tables = tables
+	var err error
+	if s.Views, err = excludeNamed(s.Views, glob, func(v *schema.View) string { return v.Name }); err != nil {
+		return err
+	}
+	if s.Funcs, err = excludeNamed(s.Funcs, glob, func(f *schema.Func) string { return f.Name }); err != nil {
+		return err
+	}
+	if s.Procs, err = excludeNamed(s.Procs, glob, func(p *schema.Proc) string { return p.Name }); err != nil {
+		return err
+	}
+	if s.Objects, err = excludeNamed(s.Objects, glob, objectName); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -128,19 +141,64 @@ func excludeT(t *schema.Table, pattern string) (err error) {
 		}
 		return true, nil
 	})
+	if err != nil {
+		return err
+	}
 	t.Indexes, err = filter(t.Indexes, func(idx *schema.Index) (bool, error) {
 		if _, ok := ex[idx]; ok {
 			return true, nil
 		}
 		return filepath.Match(pattern, idx.Name)
 	})
+	if err != nil {
+		return err
+	}
 	t.ForeignKeys, err = filter(t.ForeignKeys, func(fk *schema.ForeignKey) (bool, error) {
 		if _, ok := ef[fk]; ok {
 			return true, nil
 		}
 		return filepath.Match(pattern, fk.Symbol)
 	})
-	return
+	if err != nil {
+		return err
+	}
+	t.Triggers, err = filter(t.Triggers, func(tg *schema.Trigger) (bool, error) {
+		return filepath.Match(pattern, tg.Name)
+	})
+	return err
+}
+
+// excludeNamed filters the schema resources that are matched by their names only,
+// such as views or functions. Hence, globs with more than one part never match them.
+func excludeNamed[T any](s []T, glob []string, name func(T) string) ([]T, error) {
+	if len(glob) > 1 {
+		return s, nil
+	}
+	return filter(s, func(v T) (bool, error) {
+		n := name(v)
+		if n == "" {
+			return false, nil
+		}
+		return filepath.Match(glob[0], n)
+	})
+}
+
+// An ObjectNamer is implemented by driver-specific schema
+// objects that can be excluded from inspection by their names.
+type ObjectNamer interface {
+	ObjectName() string
+}
+
+// objectName returns the name of the given schema object, or
+// an empty string if the object does not expose its name.
+func objectName(o schema.Object) string {
+	switch o := o.(type) {
+	case *schema.EnumType:
+		return o.T
+	case ObjectNamer:
+		return o.ObjectName()
+	}
+	return ""
 }
 
 func filter[T any](s []T, f func(T) (bool, error)) ([]T, error) {
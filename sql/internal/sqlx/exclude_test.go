@@ -195,3 +195,57 @@ func TestExcludeSchema(t *testing.T) {
 	require.Len(t, r.Schemas, 1)
 	require.Len(t, r.Schemas[0].Tables, 1)
 }
+
+type namedObject struct {
+	schema.Object
+	name string
+}
+
+func (o *namedObject) ObjectName() string { return o.name }
+
+func TestExcludeRealm_Named(t *testing.T) {
+	var (
+		seq  = &namedObject{name: "seq"}
+		enum = &schema.EnumType{T: "status", Values: []string{"on", "off"}}
+		r    = schema.NewRealm(schema.New("s1").AddTables(schema.NewTable("t1")))
+		s    = r.Schemas[0]
+	)
+	s.AddViews(schema.NewView("v1", "SELECT 1"), schema.NewView("v2", "SELECT 2"))
+	s.AddFuncs(schema.NewFunc("f1"))
+	s.AddProcs(schema.NewProc("p1"))
+	s.AddObjects(seq, enum, &namedObject{name: "other"})
+
+	// Globs with more than one part do not match named resources.
+	r, err := ExcludeRealm(r, []string{"s1.*.*"})
+	require.NoError(t, err)
+	require.Len(t, s.Views, 2)
+	require.Len(t, s.Funcs, 1)
+	require.Len(t, s.Procs, 1)
+	require.Len(t, s.Objects, 3)
+
+	r, err = ExcludeRealm(r, []string{"s1.v1", "s1.f*", "s1.p1", "s1.seq", "s1.status"})
+	require.NoError(t, err)
+	require.Len(t, s.Tables, 1)
+	require.Len(t, s.Views, 1)
+	require.Equal(t, "v2", s.Views[0].Name)
+	require.Empty(t, s.Funcs)
+	require.Empty(t, s.Procs)
+	require.Len(t, s.Objects, 1)
+	require.Equal(t, "other", s.Objects[0].(*namedObject).name)
+
+	// Objects without a name are never excluded.
+	s.AddObjects(&struct{ schema.Object }{})
+	_, err = ExcludeRealm(r, []string{"s1.*"})
+	require.NoError(t, err)
+	require.Empty(t, s.Tables)
+	require.Empty(t, s.Views)
+	require.Len(t, s.Objects, 1)
+}
+
+func TestExcludeRealm_Error(t *testing.T) {
+	r := schema.NewRealm(schema.New("s1").AddTables(schema.NewTable("t1").AddColumns(schema.NewIntColumn("c1", "int"))))
+	_, err := ExcludeRealm(r, []string{"s1.t1.["})
+	require.Error(t, err)
+	_, err = ExcludeRealm(r, []string{"s1.["})
+	require.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 
 	"ariga.io/atlas/sql/migrate"
@@ -51,12 +52,389 @@ func DetachCycles(changes []schema.Change) ([]schema.Change, error) {
 	}
 	planned := make([]schema.Change, len(changes))
 	copy(planned, changes)
-	sort.Slice(planned, func(i, j int) bool {
+	sort.SliceStable(planned, func(i, j int) bool {
 		return sorted[table(planned[i])] < sorted[table(planned[j])]
 	})
 	return planned, nil
 }
 
+// DetachViews splits the given changes into three groups: the view removals that
+// should be planned before the rest of the changes, the rest of the changes, and
+// the view creations and modifications that should be planned after them, as views
+// depend on the tables (and views) they select from.
+//
+// Views are ordered by the references between them: a view is created after the
+// views it selects from, and dropped before them. View modifications that select
+// from tables whose columns are dropped, modified or renamed by the rest of the
+// changes are split into a drop that is planned before the rest of the changes,
+// and a creation that is planned after them.
+func DetachViews(changes []schema.Change) (before, rest, after []schema.Change) {
+	var modify []*schema.ModifyView
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddView, *schema.DropView:
+		case *schema.ModifyView:
+			modify = append(modify, c)
+		default:
+			rest = append(rest, c)
+		}
+	}
+	affected := affectedViews(modify, alteredTables(rest))
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.DropView:
+			before = append(before, c)
+		case *schema.AddView:
+			after = append(after, c)
+		case *schema.ModifyView:
+			if !affected[c] {
+				after = append(after, c)
+				break
+			}
+			before = append(before, &schema.DropView{V: c.From})
+			after = append(after, &schema.AddView{V: c.To})
+		}
+	}
+	// Dropping views in reverse order ensures
+	// dependent views are dropped first.
+	before = sortViews(before)
+	for i, j := 0, len(before)-1; i < j; i, j = i+1, j-1 {
+		before[i], before[j] = before[j], before[i]
+	}
+	return before, rest, sortViews(after)
+}
+
+// alteredTables returns the names of the tables that are dropped, or their
+// columns are dropped, modified or renamed by the given changes.
+func alteredTables(changes []schema.Change) map[string]bool {
+	names := make(map[string]bool)
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.DropTable:
+			names[c.T.Name] = true
+		case *schema.ModifyTable:
+			for _, c1 := range c.Changes {
+				switch c1.(type) {
+				case *schema.DropColumn, *schema.ModifyColumn, *schema.RenameColumn:
+					names[c.T.Name] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+// affectedViews returns the view modifications that select from the given
+// tables, or from views that are affected themselves.
+func affectedViews(modify []*schema.ModifyView, tables map[string]bool) map[*schema.ModifyView]bool {
+	var (
+		refs     = make(viewRefs)
+		affected = make(map[*schema.ModifyView]bool)
+	)
+	for _, m := range modify {
+		for name := range tables {
+			if refs.refersTo(m.From.Def, name) {
+				affected[m] = true
+				break
+			}
+		}
+	}
+	for changed := len(affected) > 0; changed; {
+		changed = false
+		for _, m := range modify {
+			if affected[m] {
+				continue
+			}
+			for a := range affected {
+				if refs.refersTo(m.From.Def, a.From.Name) {
+					affected[m], changed = true, true
+					break
+				}
+			}
+		}
+	}
+	return affected
+}
+
+// sortViews sorts the given view changes such that each view is placed
+// after the views it references. The original order is kept otherwise.
+func sortViews(changes []schema.Change) []schema.Change {
+	view := func(c schema.Change) *schema.View {
+		switch c := c.(type) {
+		case *schema.AddView:
+			return c.V
+		case *schema.DropView:
+			return c.V
+		case *schema.ModifyView:
+			return c.To
+		}
+		return nil
+	}
+	var (
+		visit   func(int)
+		refs    = make(viewRefs)
+		visited = make(map[int]bool)
+		sorted  = make([]schema.Change, 0, len(changes))
+	)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+		for j := range changes {
+			if j != i && refs.refersTo(view(changes[i]).Def, view(changes[j]).Name) {
+				visit(j)
+			}
+		}
+		sorted = append(sorted, changes[i])
+	}
+	for i := range changes {
+		visit(i)
+	}
+	return sorted
+}
+
+// viewRefs holds the compiled patterns of the names that are searched in view
+// definitions, such that each pattern is compiled once.
+type viewRefs map[string]*regexp.Regexp
+
+// refersTo reports if the given view definition references the given name.
+func (r viewRefs) refersTo(def, name string) bool {
+	re, ok := r[name]
+	if !ok {
+		re = regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+		r[name] = re
+	}
+	return re.MatchString(def)
+}
+
+// DetachFuncs splits the given changes into three groups: the function and procedure
+// creations and modifications that should be planned before the rest of the changes,
+// the rest of the changes, and the function and procedure removals that should be
+// planned after them, as tables may reference functions in their defaults or checks.
+func DetachFuncs(changes []schema.Change) (before, rest, after []schema.Change) {
+	for _, c := range changes {
+		switch c.(type) {
+		case *schema.AddFunc, *schema.ModifyFunc, *schema.AddProc, *schema.ModifyProc:
+			before = append(before, c)
+		case *schema.DropFunc, *schema.DropProc:
+			after = append(after, c)
+		default:
+			rest = append(rest, c)
+		}
+	}
+	return before, rest, after
+}
+
+// DetachObjects splits the given changes into three groups: the object creations and
+// modifications that should be planned before the rest of the changes, the rest of the
+// changes, and the object removals that should be planned after them, as tables may
+// depend on objects (e.g. sequences in column defaults).
+func DetachObjects(changes []schema.Change) (before, rest, after []schema.Change) {
+	for _, c := range changes {
+		switch c.(type) {
+		case *schema.AddObject, *schema.ModifyObject:
+			before = append(before, c)
+		case *schema.DropObject:
+			after = append(after, c)
+		default:
+			rest = append(rest, c)
+		}
+	}
+	return before, rest, after
+}
+
+// DetachRoles splits the given changes into three groups: the role creations and modifications,
+// and the privilege revocations, that should be planned before the rest of the changes, the rest of
+// the changes, and the privilege grants and the role removals that should be planned after them, as
+// privileges are granted on objects that may be created by the changes.
+func DetachRoles(changes []schema.Change) (before, rest, after []schema.Change) {
+	var drops []schema.Change
+	for _, c := range changes {
+		switch c.(type) {
+		case *schema.AddRole, *schema.ModifyRole, *schema.Revoke:
+			before = append(before, c)
+		case *schema.Grant:
+			after = append(after, c)
+		case *schema.DropRole:
+			drops = append(drops, c)
+		default:
+			rest = append(rest, c)
+		}
+	}
+	return before, rest, append(after, drops...)
+}
+
+// MergeTableChanges merges the ModifyTable changes of each table into a single change,
+// allowing drivers to plan them as one ALTER TABLE statement. A change is merged into a
+// previous change of its table only if the changes planned between them do not reference
+// its table, it does not reference their tables, and both changes do not touch the same
+// table objects (e.g. dropping and re-adding a foreign key), as some databases do not
+// support mixing them in one statement.
+func MergeTableChanges(changes []schema.Change) []schema.Change {
+	merged := make([]schema.Change, 0, len(changes))
+Next:
+	for _, c := range changes {
+		m, ok := c.(*schema.ModifyTable)
+		if !ok {
+			merged = append(merged, c)
+			continue
+		}
+		for i := len(merged) - 1; i >= 0; i-- {
+			if p, ok := merged[i].(*schema.ModifyTable); ok && sameTable(p.T, m.T) {
+				if !mergeable(p.Changes, m.Changes) {
+					break
+				}
+				merged[i] = &schema.ModifyTable{
+					T:       p.T,
+					Changes: append(append(make([]schema.Change, 0, len(p.Changes)+len(m.Changes)), p.Changes...), m.Changes...),
+				}
+				continue Next
+			}
+			if !independent(merged[i], m) {
+				break
+			}
+		}
+		merged = append(merged, c)
+	}
+	return merged
+}
+
+// mergeable reports if the two lists of table changes touch different table objects.
+func mergeable(from, to []schema.Change) bool {
+	keys := make(map[string]bool)
+	for _, c := range from {
+		ks, ok := objectKeys(c)
+		if !ok {
+			return false
+		}
+		for _, k := range ks {
+			keys[k] = true
+		}
+	}
+	for _, c := range to {
+		ks, ok := objectKeys(c)
+		if !ok {
+			return false
+		}
+		for _, k := range ks {
+			if keys[k] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// objectKeys returns the keys of the table objects touched by the given change,
+// or false if the change is not supported by the MergeTableChanges pass.
+func objectKeys(c schema.Change) ([]string, bool) {
+	switch c := c.(type) {
+	case *schema.AddColumn:
+		return []string{"column:" + c.C.Name}, true
+	case *schema.DropColumn:
+		return []string{"column:" + c.C.Name}, true
+	case *schema.ModifyColumn:
+		return []string{"column:" + c.From.Name, "column:" + c.To.Name}, true
+	case *schema.RenameColumn:
+		return []string{"column:" + c.From.Name, "column:" + c.To.Name}, true
+	case *schema.AddIndex:
+		return []string{"index:" + c.I.Name}, true
+	case *schema.DropIndex:
+		return []string{"index:" + c.I.Name}, true
+	case *schema.ModifyIndex:
+		return []string{"index:" + c.From.Name, "index:" + c.To.Name}, true
+	case *schema.RenameIndex:
+		return []string{"index:" + c.From.Name, "index:" + c.To.Name}, true
+	case *schema.AddForeignKey:
+		return []string{"fk:" + c.F.Symbol}, true
+	case *schema.DropForeignKey:
+		return []string{"fk:" + c.F.Symbol}, true
+	case *schema.ModifyForeignKey:
+		return []string{"fk:" + c.From.Symbol, "fk:" + c.To.Symbol}, true
+	case *schema.AddCheck:
+		return []string{"check:" + c.C.Name}, true
+	case *schema.DropCheck:
+		return []string{"check:" + c.C.Name}, true
+	case *schema.ModifyCheck:
+		return []string{"check:" + c.From.Name, "check:" + c.To.Name}, true
+	case *schema.AddAttr:
+		return []string{fmt.Sprintf("attr:%T", c.A)}, true
+	case *schema.DropAttr:
+		return []string{fmt.Sprintf("attr:%T", c.A)}, true
+	case *schema.ModifyAttr:
+		return []string{fmt.Sprintf("attr:%T", c.From)}, true
+	default:
+		return nil, false
+	}
+}
+
+// independent reports if the given table modification can be
+// planned before the given change, which was planned before it.
+func independent(c schema.Change, m *schema.ModifyTable) bool {
+	var (
+		t   *schema.Table
+		fks []*schema.ForeignKey
+	)
+	switch c := c.(type) {
+	case *schema.AddTable:
+		t, fks = c.T, c.T.ForeignKeys
+	case *schema.DropTable:
+		t, fks = c.T, c.T.ForeignKeys
+	case *schema.ModifyTable:
+		t, fks = c.T, changedFKs(c.Changes)
+	default:
+		return false
+	}
+	if sameTable(t, m.T) {
+		return false
+	}
+	for _, fk := range fks {
+		if fk.RefTable == nil || sameTable(fk.RefTable, m.T) {
+			return false
+		}
+	}
+	for _, fk := range changedFKs(m.Changes) {
+		if fk.RefTable == nil || sameTable(fk.RefTable, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// changedFKs returns the foreign keys that are added, dropped or modified by the changes.
+func changedFKs(changes []schema.Change) (fks []*schema.ForeignKey) {
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddForeignKey:
+			fks = append(fks, c.F)
+		case *schema.DropForeignKey:
+			fks = append(fks, c.F)
+		case *schema.ModifyForeignKey:
+			fks = append(fks, c.From, c.To)
+		}
+	}
+	return fks
+}
+
+// sameTable reports if the two tables have the same qualified name.
+func sameTable(t1, t2 *schema.Table) bool {
+	if t1 == t2 {
+		return true
+	}
+	if t1.Name != t2.Name {
+		return false
+	}
+	var s1, s2 string
+	if t1.Schema != nil {
+		s1 = t1.Schema.Name
+	}
+	if t2.Schema != nil {
+		s2 = t2.Schema.Name
+	}
+	return s1 == s2
+}
+
 // detachReferences detaches all table references.
 func detachReferences(changes []schema.Change) []schema.Change {
 	var planned, deferred []schema.Change
@@ -235,6 +613,42 @@ func table(change schema.Change) (t string) {
 		t = change.T.Name
 	case *schema.ModifyTable:
 		t = change.T.Name
+	// Renames are sorted with the changes of the
+	// table they rename to, and planned before them.
+	case *schema.RenameTable:
+		t = change.To.Name
+	}
+	return
+}
+
+// view extracts a view from the given change.
+func view(change schema.Change) (v *schema.View) {
+	switch change := change.(type) {
+	case *schema.AddView:
+		v = change.V
+	case *schema.DropView:
+		v = change.V
+	case *schema.ModifyView:
+		v = change.To
+	}
+	return
+}
+
+// funcSchema extracts the schema of the function or procedure from the given change.
+func funcSchema(change schema.Change) (s *schema.Schema) {
+	switch change := change.(type) {
+	case *schema.AddFunc:
+		s = change.F.Schema
+	case *schema.DropFunc:
+		s = change.F.Schema
+	case *schema.ModifyFunc:
+		s = change.To.Schema
+	case *schema.AddProc:
+		s = change.P.Schema
+	case *schema.DropProc:
+		s = change.P.Schema
+	case *schema.ModifyProc:
+		s = change.To.Schema
 	}
 	return
 }
@@ -256,7 +670,7 @@ func CheckChangesScope(changes []schema.Change) error {
 	for _, c := range changes {
 		var t *schema.Table
 		switch c := c.(type) {
-		case *schema.AddSchema, *schema.ModifySchema, *schema.DropSchema:
+		case *schema.AddSchema, *schema.ModifySchema, *schema.DropSchema, *schema.AddRole, *schema.ModifyRole, *schema.DropRole:
 			return fmt.Errorf("%T is not allowed when migration plan is scoped to one schema", c)
 		case *schema.AddTable:
 			t = c.T
@@ -264,6 +678,16 @@ func CheckChangesScope(changes []schema.Change) error {
 			t = c.T
 		case *schema.DropTable:
 			t = c.T
+		case *schema.AddView, *schema.DropView, *schema.ModifyView:
+			if v := view(c); v.Schema != nil && v.Schema.Name != "" {
+				names[v.Schema.Name] = struct{}{}
+			}
+			continue
+		case *schema.AddFunc, *schema.DropFunc, *schema.ModifyFunc, *schema.AddProc, *schema.DropProc, *schema.ModifyProc:
+			if s := funcSchema(c); s != nil && s.Name != "" {
+				names[s.Name] = struct{}{}
+			}
+			continue
 		default:
 			continue
 		}
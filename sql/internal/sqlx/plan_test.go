@@ -93,6 +93,139 @@ func TestDetachCycles(t *testing.T) {
 	require.Equal(t, deletion, planned[2:])
 }
 
+func TestMergeTableChanges(t *testing.T) {
+	var (
+		users      = schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("pet_id", "int"))
+		pets       = schema.NewTable("pets").AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("owner_id", "int"))
+		posts      = schema.NewTable("posts").AddColumns(schema.NewIntColumn("id", "int"))
+		fk         = schema.NewForeignKey("pet").SetTable(users).AddColumns(users.Columns[1]).SetRefTable(pets).AddRefColumns(pets.Columns[0])
+		name, age  = schema.NewStringColumn("name", "text"), schema.NewIntColumn("age", "int")
+		idx        = schema.NewIndex("users_name").AddColumns(name)
+		addName    = &schema.AddColumn{C: name}
+		addAge     = &schema.AddColumn{C: age}
+		addIdx     = &schema.AddIndex{I: idx}
+		addFK      = &schema.AddForeignKey{F: fk}
+		dropFK     = &schema.DropForeignKey{F: fk}
+		addPosts   = &schema.AddTable{T: posts}
+		modifyPets = &schema.ModifyTable{T: pets, Changes: []schema.Change{&schema.AddColumn{C: pets.Columns[1]}}}
+	)
+	// Consecutive changes are merged.
+	planned := MergeTableChanges([]schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{addName}},
+		&schema.ModifyTable{T: users, Changes: []schema.Change{addIdx}},
+	})
+	require.Equal(t, []schema.Change{&schema.ModifyTable{T: users, Changes: []schema.Change{addName, addIdx}}}, planned)
+
+	// Unrelated changes in between are skipped.
+	planned = MergeTableChanges([]schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{addName}},
+		addPosts,
+		&schema.ModifyTable{T: users, Changes: []schema.Change{addAge, addFK}},
+	})
+	require.Equal(t, []schema.Change{&schema.ModifyTable{T: users, Changes: []schema.Change{addName, addAge, addFK}}, addPosts}, planned)
+
+	// Foreign keys are not moved before changes of the tables they reference.
+	changes := []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{addName}},
+		modifyPets,
+		&schema.ModifyTable{T: users, Changes: []schema.Change{addFK}},
+	}
+	require.Equal(t, changes, MergeTableChanges(changes))
+
+	// Changes are not moved before changes that reference their table.
+	changes = []schema.Change{
+		&schema.ModifyTable{T: pets, Changes: []schema.Change{&schema.AddIndex{I: schema.NewIndex("pets_owner")}}},
+		&schema.ModifyTable{T: users, Changes: []schema.Change{addFK}},
+		modifyPets,
+	}
+	require.Equal(t, changes, MergeTableChanges(changes))
+
+	// Changes that touch the same objects are not merged.
+	changes = []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{dropFK}},
+		&schema.ModifyTable{T: users, Changes: []schema.Change{addFK}},
+	}
+	require.Equal(t, changes, MergeTableChanges(changes))
+
+	// Changes of tables with the same name in different schemas are not merged.
+	other := schema.NewTable("users").SetSchema(schema.New("other"))
+	changes = []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{addName}},
+		&schema.ModifyTable{T: other, Changes: []schema.Change{addAge}},
+	}
+	require.Equal(t, changes, MergeTableChanges(changes))
+}
+
+func TestDetachViews(t *testing.T) {
+	var (
+		v1, v2, v3 = schema.NewView("v1", "SELECT 1"), schema.NewView("v2", "SELECT 2"), schema.NewView("v3", "SELECT 3")
+		users      = schema.NewTable("users")
+		changes    = []schema.Change{
+			&schema.AddView{V: v1},
+			&schema.DropView{V: v2},
+			&schema.AddTable{T: users},
+			&schema.DropView{V: v3},
+			&schema.ModifyView{From: v1, To: v1},
+		}
+	)
+	before, rest, after := DetachViews(changes)
+	require.Equal(t, []schema.Change{&schema.DropView{V: v3}, &schema.DropView{V: v2}}, before)
+	require.Equal(t, []schema.Change{&schema.AddTable{T: users}}, rest)
+	require.Equal(t, []schema.Change{&schema.AddView{V: v1}, &schema.ModifyView{From: v1, To: v1}}, after)
+
+	// Views are created after the views they select
+	// from, and dropped before them.
+	var (
+		active = schema.NewView("active", "SELECT * FROM users WHERE active")
+		admins = schema.NewView("admins", "SELECT * FROM active WHERE admin")
+	)
+	before, _, after = DetachViews([]schema.Change{
+		&schema.AddView{V: admins},
+		&schema.DropView{V: active},
+		&schema.AddView{V: active},
+		&schema.DropView{V: admins},
+	})
+	require.Equal(t, []schema.Change{&schema.DropView{V: admins}, &schema.DropView{V: active}}, before)
+	require.Equal(t, []schema.Change{&schema.AddView{V: active}, &schema.AddView{V: admins}}, after)
+
+	// Modified views that select from tables with dropped columns, and
+	// the views that select from them, are dropped before the table changes.
+	var (
+		dropC    = &schema.ModifyTable{T: users, Changes: []schema.Change{&schema.DropColumn{C: schema.NewColumn("active")}}}
+		active2  = schema.NewView("active", "SELECT * FROM users WHERE enabled")
+		admins2  = schema.NewView("admins", "SELECT * FROM active WHERE admin AND enabled")
+		modifyV3 = &schema.ModifyView{From: v3, To: v3}
+	)
+	before, rest, after = DetachViews([]schema.Change{
+		&schema.ModifyView{From: admins, To: admins2},
+		dropC,
+		&schema.ModifyView{From: active, To: active2},
+		modifyV3,
+	})
+	require.Equal(t, []schema.Change{&schema.DropView{V: admins}, &schema.DropView{V: active}}, before)
+	require.Equal(t, []schema.Change{dropC}, rest)
+	require.Equal(t, []schema.Change{&schema.AddView{V: active2}, &schema.AddView{V: admins2}, modifyV3}, after)
+}
+
+func TestDetachFuncs(t *testing.T) {
+	var (
+		f1, f2  = schema.NewFunc("f1"), schema.NewFunc("f2")
+		p1, p2  = schema.NewProc("p1"), schema.NewProc("p2")
+		users   = schema.NewTable("users")
+		changes = []schema.Change{
+			&schema.DropFunc{F: f2},
+			&schema.AddFunc{F: f1},
+			&schema.AddTable{T: users},
+			&schema.ModifyProc{From: p1, To: p1},
+			&schema.DropProc{P: p2},
+		}
+	)
+	before, rest, after := DetachFuncs(changes)
+	require.Equal(t, []schema.Change{&schema.AddFunc{F: f1}, &schema.ModifyProc{From: p1, To: p1}}, before)
+	require.Equal(t, []schema.Change{&schema.AddTable{T: users}}, rest)
+	require.Equal(t, []schema.Change{&schema.DropFunc{F: f2}, &schema.DropProc{P: p2}}, after)
+}
+
 func TestCheckChangesScope(t *testing.T) {
 	err := CheckChangesScope([]schema.Change{
 		&schema.AddSchema{},
@@ -94,9 +94,16 @@ func ScanStrings(rows *sql.Rows) ([]string, error) {
 // Reference elements are added as stubs and should be linked manually by the
 // caller.
 func SchemaFKs(s *schema.Schema, rows *sql.Rows) error {
+	return ScanFKs(s, rows, nil, nil)
+}
+
+// ScanFKs is like SchemaFKs, but allows drivers to scan additional columns that
+// follow the standard ones into the given destinations. The optional attrs function
+// is called with the foreign key of each row after it was scanned.
+func ScanFKs(s *schema.Schema, rows *sql.Rows, extra []any, attrs func(*schema.ForeignKey)) error {
 	for rows.Next() {
 		var name, table, column, tSchema, refTable, refColumn, refSchema, updateRule, deleteRule string
-		if err := rows.Scan(&name, &table, &column, &tSchema, &refTable, &refColumn, &refSchema, &updateRule, &deleteRule); err != nil {
+		if err := rows.Scan(append([]any{&name, &table, &column, &tSchema, &refTable, &refColumn, &refSchema, &updateRule, &deleteRule}, extra...)...); err != nil {
 			return err
 		}
 		t, ok := s.Table(table)
@@ -145,6 +152,9 @@ func SchemaFKs(s *schema.Schema, rows *sql.Rows) error {
 		if _, ok := fk.RefColumn(rc.Name); !ok {
 			fk.RefColumns = append(fk.RefColumns, rc)
 		}
+		if attrs != nil {
+			attrs(fk)
+		}
 	}
 	return nil
 }
@@ -250,6 +260,40 @@ func (b *Builder) Ident(s string) *Builder {
 // Table writes the table identifier to the builder, prefixed
 // with the schema name if exists.
 func (b *Builder) Table(t *schema.Table) *Builder {
+	return b.Object(t.Schema, t.Name)
+}
+
+// View writes the view identifier to the builder, prefixed
+// with the schema name if exists.
+func (b *Builder) View(v *schema.View) *Builder {
+	return b.Object(v.Schema, v.Name)
+}
+
+// Func writes the function identifier to the builder, prefixed
+// with the schema name if exists.
+func (b *Builder) Func(f *schema.Func) *Builder {
+	return b.Object(f.Schema, f.Name)
+}
+
+// Proc writes the procedure identifier to the builder, prefixed
+// with the schema name if exists.
+func (b *Builder) Proc(p *schema.Proc) *Builder {
+	return b.Object(p.Schema, p.Name)
+}
+
+// Trigger writes the trigger identifier to the builder, prefixed
+// with the schema name of its table if exists.
+func (b *Builder) Trigger(t *schema.Trigger) *Builder {
+	var s *schema.Schema
+	if t.Table != nil {
+		s = t.Table.Schema
+	}
+	return b.Object(s, t.Name)
+}
+
+// Object writes the identifier of a schema object (e.g. table)
+// to the builder, prefixed with the schema name if exists.
+func (b *Builder) Object(s *schema.Schema, name string) *Builder {
 	switch {
 	// Custom qualifier.
 	case b.Schema != nil:
@@ -259,11 +303,11 @@ func (b *Builder) Table(t *schema.Table) *Builder {
 			b.rewriteLastByte('.')
 		}
 	// Default schema qualifier.
-	case t.Schema != nil && t.Schema.Name != "":
-		b.Ident(t.Schema.Name)
+	case s != nil && s.Name != "":
+		b.Ident(s.Name)
 		b.rewriteLastByte('.')
 	}
-	b.Ident(t.Name)
+	b.Ident(name)
 	return b
 }
 
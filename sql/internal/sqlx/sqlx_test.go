@@ -21,6 +21,12 @@ func TestModeInspectRealm(t *testing.T) {
 	m = ModeInspectRealm(&schema.InspectRealmOption{})
 	require.True(t, m.Is(schema.InspectSchemas))
 	require.True(t, m.Is(schema.InspectTables))
+	require.False(t, m.Is(schema.InspectViews|schema.InspectFuncs|schema.InspectTriggers|schema.InspectObjects|schema.InspectRoles))
+
+	m = ModeInspectRealm(&schema.InspectRealmOption{Mode: schema.InspectAll})
+	require.True(t, m.Is(schema.InspectViews))
+	require.True(t, m.Is(schema.InspectObjects))
+	require.False(t, m.Is(schema.InspectRoles))
 
 	m = ModeInspectRealm(&schema.InspectRealmOption{Mode: schema.InspectSchemas})
 	require.True(t, m.Is(schema.InspectSchemas))
@@ -35,6 +41,12 @@ func TestModeInspectSchema(t *testing.T) {
 	m = ModeInspectSchema(&schema.InspectOptions{})
 	require.True(t, m.Is(schema.InspectSchemas))
 	require.True(t, m.Is(schema.InspectTables))
+	require.False(t, m.Is(schema.InspectViews|schema.InspectFuncs|schema.InspectTriggers|schema.InspectObjects|schema.InspectRoles))
+
+	m = ModeInspectSchema(&schema.InspectOptions{Mode: schema.InspectAll})
+	require.True(t, m.Is(schema.InspectViews))
+	require.True(t, m.Is(schema.InspectObjects))
+	require.False(t, m.Is(schema.InspectRoles))
 
 	m = ModeInspectSchema(&schema.InspectOptions{Mode: schema.InspectSchemas})
 	require.True(t, m.Is(schema.InspectSchemas))
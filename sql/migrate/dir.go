@@ -14,6 +14,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -57,6 +58,15 @@ type (
 		// StmtDecls returns the set of SQL statements this file holds alongside its preceding comments.
 		StmtDecls() ([]*Stmt, error)
 	}
+
+	// CheckpointFile is implemented by migration files that can be marked as checkpoints.
+	// A checkpoint file holds the full schema at its version, and allows executing the
+	// migration directory on fresh databases without executing the files preceding it.
+	CheckpointFile interface {
+		File
+		// IsCheckpoint reports whether the file is a checkpoint file.
+		IsCheckpoint() bool
+	}
 )
 
 // LocalDir implements Dir for a local migration
@@ -89,12 +99,20 @@ func (d *LocalDir) Open(name string) (fs.File, error) {
 	return os.Open(filepath.Join(d.path, name))
 }
 
-// WriteFile implements Dir.WriteFile.
+// WriteFile implements Dir.WriteFile. Parent directories
+// of the named file (e.g. "down") are created if missing.
 func (d *LocalDir) WriteFile(name string, b []byte) error {
-	return os.WriteFile(filepath.Join(d.path, name), b, 0644)
+	p := filepath.Join(d.path, name)
+	if filepath.Dir(name) != "." {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(p, b, 0644)
 }
 
 // Files implements Dir.Files. It looks for all files with .sql suffix and orders them by filename.
+// Down files, stored in the "down" directory, are attached to their migration files. See LocalFile.Down.
 func (d *LocalDir) Files() ([]File, error) {
 	names, err := fs.Glob(d, "*.sql")
 	if err != nil {
@@ -110,7 +128,24 @@ func (d *LocalDir) Files() ([]File, error) {
 		if err != nil {
 			return nil, fmt.Errorf("sql/migrate: read file %q: %w", n, err)
 		}
-		ret[i] = NewLocalFile(n, b)
+		f := NewLocalFile(n, b)
+		switch b, err := fs.ReadFile(d, path.Join(downDir, n)); {
+		case err == nil:
+			f.d = NewLocalFile(path.Join(downDir, n), b)
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, fmt.Errorf("sql/migrate: read down file of %q: %w", n, err)
+		}
+		ret[i] = f
+	}
+	// Down files cannot exist without their migration files.
+	downs, err := fs.Glob(d, path.Join(downDir, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range downs {
+		if i := sort.SearchStrings(names, path.Base(n)); i == len(names) || names[i] != path.Base(n) {
+			return nil, fmt.Errorf("sql/migrate: down file %q has no matching migration file", n)
+		}
 	}
 	return ret, nil
 }
@@ -137,6 +172,16 @@ func (d *LocalDir) Checksum() (HashFile, error) {
 			return nil, err
 		}
 		hs = append(hs, struct{ N, H string }{f.Name(), base64.StdEncoding.EncodeToString(h.Sum(nil))})
+		// Down files are summed right after their migration files.
+		if f, ok := f.(*LocalFile); ok && f.d != nil {
+			if _, err = h.Write([]byte(f.d.Name())); err != nil {
+				return nil, err
+			}
+			if _, err = h.Write(f.d.Bytes()); err != nil {
+				return nil, err
+			}
+			hs = append(hs, struct{ N, H string }{f.d.Name(), base64.StdEncoding.EncodeToString(h.Sum(nil))})
+		}
 	}
 	return hs, nil
 }
@@ -145,9 +190,13 @@ func (d *LocalDir) Checksum() (HashFile, error) {
 type LocalFile struct {
 	n string
 	b []byte
+	d *LocalFile // optional down file
 }
 
-var _ File = (*LocalFile)(nil)
+var (
+	_ File           = (*LocalFile)(nil)
+	_ CheckpointFile = (*LocalFile)(nil)
+)
 
 // NewLocalFile returns a new local file.
 func NewLocalFile(name string, data []byte) *LocalFile {
@@ -161,7 +210,7 @@ func (f LocalFile) Name() string {
 
 // Desc implements File.Desc.
 func (f LocalFile) Desc() string {
-	parts := strings.SplitN(f.n, "_", 2)
+	parts := strings.SplitN(path.Base(f.n), "_", 2)
 	if len(parts) == 1 {
 		return ""
 	}
@@ -170,7 +219,27 @@ func (f LocalFile) Desc() string {
 
 // Version implements File.Version.
 func (f LocalFile) Version() string {
-	return strings.SplitN(strings.TrimSuffix(f.n, ".sql"), "_", 2)[0]
+	return strings.SplitN(strings.TrimSuffix(path.Base(f.n), ".sql"), "_", 2)[0]
+}
+
+// IsCheckpoint implements CheckpointFile.IsCheckpoint. A file is considered
+// a checkpoint if its header holds the "atlas:checkpoint" directive.
+func (f LocalFile) IsCheckpoint() bool {
+	_, ok := directive(string(f.b), directiveCheckpoint, directivePrefixSQL)
+	return ok
+}
+
+// Down returns the down file of the migration file, holding the statements
+// for reverting it, or nil if the file has no down file. See DownFormatter.
+func (f LocalFile) Down() *LocalFile {
+	return f.d
+}
+
+// IsIrreversible reports whether the file is a down file that cannot fully revert its
+// migration file. That is, it starts with the "atlas:irreversible" directive.
+func (f LocalFile) IsIrreversible() bool {
+	_, ok := directive(string(f.b), directiveIrreversible, directivePrefixSQL)
+	return ok
 }
 
 // Stmts returns the SQL statement exists in the local file.
@@ -198,7 +267,10 @@ func (f LocalFile) Bytes() []byte {
 
 var (
 	// templateFuncs contains the template.FuncMap for the DefaultFormatter.
-	templateFuncs = template.FuncMap{"now": func() string { return time.Now().UTC().Format("20060102150405") }}
+	templateFuncs = template.FuncMap{
+		"now": func() string { return time.Now().UTC().Format("20060102150405") },
+		"rev": reverse,
+	}
 	// DefaultFormatter is a default implementation for Formatter.
 	DefaultFormatter = &TemplateFormatter{
 		templates: []struct{ N, C *template.Template }{
@@ -212,8 +284,72 @@ var (
 			},
 		},
 	}
+	// DownFormatter is like DefaultFormatter, but pairs each migration file with a down file
+	// holding the reverse statements of the plan. Down files are written to the "down" directory
+	// under the name of their migration file. For example, "1_init.sql" and "down/1_init.sql".
+	//
+	// Plans without reverse statements have no down files. If only some of the changes can
+	// be reversed, the down file is marked with the "atlas:irreversible" directive.
+	DownFormatter Formatter = &downFormatter{
+		up: DefaultFormatter,
+		down: template.Must(template.New("").Funcs(templateFuncs).Parse(
+			`{{ range rev .Changes }}{{ if .Reverse }}{{ with .Comment }}-- reverse: {{ println . }}{{ end }}{{ printf "%s;\n" .Reverse }}{{ end }}{{ end }}`,
+		)),
+	}
 )
 
+// downDir is the directory holding the down files of the migration directory.
+const downDir = "down"
+
+// downFormatter formats a plan using the up Formatter, and
+// writes a down file for each of the generated migration files.
+type downFormatter struct {
+	up   Formatter
+	down *template.Template
+}
+
+// Format implements the Formatter interface.
+func (f *downFormatter) Format(plan *Plan) ([]File, error) {
+	files, err := f.up.Format(plan)
+	if err != nil {
+		return nil, err
+	}
+	var reversible, irreversible bool
+	for _, c := range plan.Changes {
+		if c.Reverse != "" {
+			reversible = true
+		} else {
+			irreversible = true
+		}
+	}
+	if !reversible {
+		return files, nil
+	}
+	var b bytes.Buffer
+	if irreversible {
+		b.WriteString(directivePrefixSQL + "atlas:" + directiveIrreversible + "\n\n")
+	}
+	if err := f.down.Execute(&b, plan); err != nil {
+		return nil, err
+	}
+	for _, up := range files {
+		files = append(files, &LocalFile{
+			n: path.Join(downDir, up.Name()),
+			b: b.Bytes(),
+		})
+	}
+	return files, nil
+}
+
+// reverse returns the changes in reverse order.
+func reverse(changes []*Change) []*Change {
+	rev := make([]*Change, len(changes))
+	for i, c := range changes {
+		rev[len(changes)-i-1] = c
+	}
+	return rev
+}
+
 // TemplateFormatter implements Formatter by using templates.
 type TemplateFormatter struct {
 	templates []struct{ N, C *template.Template }
@@ -370,28 +506,51 @@ func FilesLastIndex(files []File, f func(File) bool) int {
 	return -1
 }
 
+// IsCheckpoint reports whether the given file is a checkpoint file. See CheckpointFile.
+func IsCheckpoint(f File) bool {
+	ck, ok := f.(CheckpointFile)
+	return ok && ck.IsCheckpoint()
+}
+
 const (
 	// atlas:sum directive.
 	directiveSum  = "sum"
 	sumModeIgnore = "ignore"
 	// atlas:delimiter directive.
 	directiveDelimiter = "delimiter"
-	directivePrefixSQL = "-- "
+	// atlas:checkpoint directive.
+	directiveCheckpoint = "checkpoint"
+	// atlas:irreversible directive.
+	directiveIrreversible = "irreversible"
+	directivePrefixSQL    = "-- "
 )
 
 var reDirective = regexp.MustCompile(`^([ -~]*)atlas:(\w+)(?: +([ -~]*))*`)
 
-// directive searches in the content a line that matches a directive
-// with the given prefix and name. For example:
+// directive searches in the content header (i.e. its leading directive, comment
+// and empty lines) a line that matches a directive with the given prefix and name.
+// For example:
 //
 //	directive(c, "delimiter", "-- ")	// '-- atlas:delimiter.*'
 //	directive(c, "sum", "")				// 'atlas:sum.*'
 //	directive(c, "sum")					// '.*atlas:sum'
 func directive(content, name string, prefix ...string) (string, bool) {
-	m := reDirective.FindStringSubmatch(content)
-	// In case the prefix was provided ensures it is matched.
-	if len(m) == 4 && m[2] == name && (len(prefix) == 0 || prefix[0] == m[1]) {
-		return m[3], true
+	for content != "" {
+		line := content
+		if i := strings.IndexByte(content, '\n'); i != -1 {
+			line, content = content[:i], content[i+1:]
+		} else {
+			content = ""
+		}
+		m := reDirective.FindStringSubmatch(line)
+		switch {
+		// In case the prefix was provided ensures it is matched.
+		case len(m) == 4 && m[2] == name && (len(prefix) == 0 || prefix[0] == m[1]):
+			return m[3], true
+		// The header ends at the first line that is not a directive, a comment or empty.
+		case len(m) == 0 && strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "--") && !strings.HasPrefix(line, "#"):
+			return "", false
+		}
 	}
 	return "", false
 }
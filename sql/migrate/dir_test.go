@@ -195,3 +195,65 @@ func TestLocalDir(t *testing.T) {
 	require.Equal(t, "2.10.x-20", files[1].Version())
 	require.Equal(t, "description", files[1].Desc())
 }
+
+func TestLocalDir_Down(t *testing.T) {
+	p := t.TempDir()
+	d, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	pl := migrate.NewPlanner(nil, d, migrate.PlanFormat(migrate.DownFormatter))
+	require.NoError(t, pl.WritePlan(&migrate.Plan{
+		Version: "1",
+		Name:    "init",
+		Changes: []*migrate.Change{
+			{Cmd: "CREATE TABLE t1(c int)", Reverse: "DROP TABLE t1", Comment: "create \"t1\" table"},
+			{Cmd: "CREATE TABLE t2(c int)", Reverse: "DROP TABLE t2"},
+			{Cmd: "INSERT INTO t1 VALUES (1)"},
+		},
+	}))
+	requireFileEqual(t, d, "1_init.sql", "-- create \"t1\" table\nCREATE TABLE t1(c int);\nCREATE TABLE t2(c int);\nINSERT INTO t1 VALUES (1);\n")
+	requireFileEqual(t, d, "down/1_init.sql", "-- atlas:irreversible\n\nDROP TABLE t2;\n-- reverse: create \"t1\" table\nDROP TABLE t1;\n")
+	// No down file is written if no change can be reversed.
+	require.NoError(t, pl.WritePlan(&migrate.Plan{Version: "2", Name: "next", Changes: []*migrate.Change{{Cmd: "SELECT 1"}}}))
+	requireFileEqual(t, d, "2_next.sql", "SELECT 1;\n")
+	_, err = os.Stat(filepath.Join(p, "down", "2_next.sql"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.NoError(t, pl.WritePlan(&migrate.Plan{Version: "3", Name: "last", Changes: []*migrate.Change{{Cmd: "CREATE TABLE t3(c int)", Reverse: "DROP TABLE t3"}}}))
+	requireFileEqual(t, d, "down/3_last.sql", "DROP TABLE t3;\n")
+
+	// Down files are attached to their migration files.
+	files, err := d.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	require.Nil(t, files[1].(*migrate.LocalFile).Down())
+	require.False(t, files[2].(*migrate.LocalFile).Down().IsIrreversible())
+	down := files[0].(*migrate.LocalFile).Down()
+	require.NotNil(t, down)
+	require.True(t, down.IsIrreversible())
+	require.Equal(t, "down/1_init.sql", down.Name())
+	require.Equal(t, "1", down.Version())
+	require.Equal(t, "init", down.Desc())
+	stmts, err := down.Stmts()
+	require.NoError(t, err)
+	require.Equal(t, []string{"DROP TABLE t2;", "DROP TABLE t1;"}, stmts)
+
+	// Down files are covered by the sum file.
+	sum, err := d.Checksum()
+	require.NoError(t, err)
+	require.Len(t, sum, 5)
+	require.Equal(t, "down/1_init.sql", sum[1].N)
+	require.NoError(t, migrate.Validate(d))
+	require.NoError(t, d.WriteFile("down/1_init.sql", []byte("DROP TABLE t1;\n")))
+	require.ErrorIs(t, migrate.Validate(d), migrate.ErrChecksumMismatch)
+
+	// Down files without migration files are not allowed.
+	require.NoError(t, d.WriteFile("down/4_orphan.sql", nil))
+	_, err = d.Files()
+	require.EqualError(t, err, `sql/migrate: down file "down/4_orphan.sql" has no matching migration file`)
+
+	// Files without down files.
+	d, err = migrate.NewLocalDir("testdata/migrate/sub")
+	require.NoError(t, err)
+	files, err = d.Files()
+	require.NoError(t, err)
+	require.Nil(t, files[0].(*migrate.LocalFile).Down())
+}
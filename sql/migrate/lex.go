@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -69,6 +70,11 @@ type lex struct {
 	comments []string // collected comments
 }
 
+// reRoutine matches the definition of objects that may have compound statement bodies.
+// The object keyword is expected right after the CREATE (or ALTER) command and its
+// optional modifiers, e.g. CREATE OR REPLACE FUNCTION or CREATE DEFINER=... TRIGGER.
+var reRoutine = regexp.MustCompile(`(?is)^(?:CREATE(?:\s+OR\s+REPLACE)?(?:\s+DEFINER\s*=\s*\S+)?(?:\s+TEMP|\s+TEMPORARY)?|ALTER)\s+(?:TRIGGER|FUNCTION|PROCEDURE|EVENT)\b`)
+
 const (
 	eos          = -1
 	delimiter    = ";"
@@ -81,19 +87,22 @@ func newLex(input string) (*lex, error) {
 		if err := l.setDelim(d); err != nil {
 			return nil, err
 		}
-		parts := strings.SplitN(input, "\n", 2)
-		if len(parts) == 1 {
+		// Remove the directive line from the input, as it may
+		// follow other directives in the header (e.g. checkpoint).
+		i := strings.Index(input, directivePrefixSQL+"atlas:"+directiveDelimiter)
+		j := strings.IndexByte(input[i:], '\n')
+		if j == -1 {
 			return nil, fmt.Errorf("no input found after delimiter %q", d)
 		}
-		l.input = parts[1]
+		l.input = input[:i] + input[i+j+1:]
 	}
 	return l, nil
 }
 
 func (l *lex) stmt() (*Stmt, error) {
 	var (
-		depth int
-		text  string
+		depth, blocks int
+		text          string
 	)
 	l.skipSpaces()
 Scan:
@@ -103,6 +112,8 @@ Scan:
 			switch {
 			case depth > 0:
 				return nil, errors.New("unclosed parentheses")
+			case blocks > 0:
+				return nil, errors.New("unclosed BEGIN ... END block")
 			case l.pos > 0:
 				text = l.input
 				break Scan
@@ -128,10 +139,18 @@ Scan:
 				return nil, err
 			}
 		// Delimiters take precedence over comments.
-		case depth == 0 && strings.HasPrefix(l.input[l.pos-l.width:], l.delim):
+		case depth == 0 && blocks == 0 && strings.HasPrefix(l.input[l.pos-l.width:], l.delim):
 			l.addPos(len(l.delim) - l.width)
 			text = l.input[:l.pos]
 			break Scan
+		// Dollar-quoted strings and compound statements are scanned only if the default
+		// delimiter is used, as custom delimiters are used to wrap them (e.g. in MySQL).
+		case r == '$' && l.delim == delimiter:
+			if err := l.skipDollarQuote(); err != nil {
+				return nil, err
+			}
+		case isWordStart(r) && l.delim == delimiter:
+			blocks = l.block(blocks, depth)
 		case r == '#':
 			l.comment("#", "\n")
 		case r == '-' && l.next() == '-':
@@ -178,6 +197,90 @@ func (l *lex) skipQuote(quote rune) error {
 	}
 }
 
+// skipDollarQuote skips the PostgreSQL dollar-quoted string that starts at the
+// current position, if there is one. e.g. $$ body $$ or $tag$ body $tag$.
+// See: postgresql.org/docs/current/sql-syntax-lexical.html#SQL-SYNTAX-DOLLAR-QUOTING.
+func (l *lex) skipDollarQuote() error {
+	start := l.pos - l.width
+	// Dollar signs can be part of identifiers, e.g. "a$b".
+	if start > 0 && isWordPart(l.input[start-1]) {
+		return nil
+	}
+	name := l.word(start + 1)
+	end := start + 1 + len(name)
+	// Not a dollar quote, e.g. a positional parameter ($1).
+	if end == len(l.input) || l.input[end] != '$' || name != "" && !isWordStart(rune(name[0])) && name[0] != '_' {
+		return nil
+	}
+	tag := l.input[start : end+1]
+	i := strings.Index(l.input[end+1:], tag)
+	if i == -1 {
+		return fmt.Errorf("unclosed dollar-quoted string %s", tag)
+	}
+	l.addPos(end + 1 + i + len(tag) - l.pos)
+	return nil
+}
+
+// block scans the word that starts at the current position, and returns the
+// depth of the compound statement blocks (e.g. BEGIN ... END) after reading it.
+// The parens argument holds the parentheses depth at the current position.
+func (l *lex) block(depth, parens int) int {
+	start := l.pos - l.width
+	// Not the start of a word.
+	if start > 0 && isWordPart(l.input[start-1]) {
+		return depth
+	}
+	w := l.word(start)
+	switch l.addPos(len(w) - l.width); {
+	// BEGIN starts a compound statement only in the body of triggers, functions,
+	// procedures and events. e.g. CREATE TRIGGER ... BEGIN ... END, or BEGIN ATOMIC
+	// ... END. Otherwise, it is the transaction command or an identifier (e.g. a
+	// column name inside parentheses).
+	case strings.EqualFold(w, "BEGIN") && parens == 0:
+		if depth > 0 || reRoutine.MatchString(l.input[:start]) {
+			depth++
+		}
+	// Inside blocks, CASE expressions and statements
+	// are terminated by END (or END CASE).
+	case strings.EqualFold(w, "CASE") && depth > 0:
+		depth++
+	case strings.EqualFold(w, "END") && depth > 0:
+		i := l.pos + len(l.input[l.pos:]) - len(strings.TrimLeftFunc(l.input[l.pos:], unicode.IsSpace))
+		switch next := l.word(i); {
+		// Control-flow statements that are not counted as blocks.
+		case strings.EqualFold(next, "IF"), strings.EqualFold(next, "LOOP"), strings.EqualFold(next, "WHILE"), strings.EqualFold(next, "REPEAT"):
+			l.addPos(i + len(next) - l.pos)
+		case strings.EqualFold(next, "CASE"):
+			l.addPos(i + len(next) - l.pos)
+			depth--
+		default:
+			depth--
+		}
+	}
+	return depth
+}
+
+// word returns the word that starts at the given position.
+func (l *lex) word(i int) string {
+	j := i
+	for j < len(l.input) && isWordPart(l.input[j]) && l.input[j] != '$' {
+		j++
+	}
+	return l.input[i:j]
+}
+
+// isWordStart reports if the rune can start a keyword.
+func isWordStart(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+// isWordPart reports if the byte can be part of an identifier. Bytes of
+// multibyte characters are treated as identifier bytes, as they can be
+// used in unquoted identifiers.
+func isWordPart(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '_' || b == '$' || b >= utf8.RuneSelf
+}
+
 func (l *lex) comment(left, right string) {
 	i := strings.Index(l.input[l.pos:], right)
 	// Not a comment.
@@ -99,3 +99,32 @@ cmd6;
 	require.Equal(t, []string{"error"}, stmts[6].Directive("lint"))
 	require.Equal(t, []string{"DS101"}, stmts[6].Directive("nolint"))
 }
+
+func TestLocalFile_StmtDeclsBodies(t *testing.T) {
+	f := `CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE SQL;
+
+-- comment
+CREATE TRIGGER t AFTER INSERT ON users BEGIN
+  SELECT 1;
+END;
+SELECT $1;
+`
+	stmts, err := NewLocalFile("f", []byte(f)).StmtDecls()
+	require.NoError(t, err)
+	require.Len(t, stmts, 3)
+
+	require.Equal(t, "CREATE FUNCTION f() RETURNS int AS $$ SELECT 1; $$ LANGUAGE SQL;", stmts[0].Text)
+	require.Equal(t, 0, stmts[0].Pos)
+
+	require.Equal(t, "CREATE TRIGGER t AFTER INSERT ON users BEGIN\n  SELECT 1;\nEND;", stmts[1].Text)
+	require.Equal(t, strings.Index(f, "CREATE TRIGGER"), stmts[1].Pos)
+	require.Equal(t, []string{"-- comment\n"}, stmts[1].Comments)
+
+	require.Equal(t, "SELECT $1;", stmts[2].Text)
+	require.Equal(t, strings.Index(f, "SELECT $1;"), stmts[2].Pos)
+
+	_, err = Stmts("CREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $$ LANGUAGE SQL;")
+	require.EqualError(t, err, "unclosed dollar-quoted string $body$")
+	_, err = Stmts("CREATE TRIGGER t AFTER INSERT ON users BEGIN SELECT 1;")
+	require.EqualError(t, err, "unclosed BEGIN ... END block")
+}
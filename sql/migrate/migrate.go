@@ -85,11 +85,27 @@ type (
 		// PlanWithSchemaQualifier allows setting a custom schema to prefix
 		// tables and other resources. An empty string indicates no qualifier.
 		SchemaQualifier *string
+
+		// OnlineDDL configures the planning of online schema changes. Drivers that support
+		// it (e.g. MySQL) add the least blocking algorithm and locking clauses to the planned
+		// statements, based on the changes and the database version.
+		OnlineDDL OnlineDDL
+
+		// DeferValidation plans the creation of constraints that scan the existing rows of
+		// their tables (e.g. foreign keys and checks) in two phases, on drivers that support
+		// it (e.g. PostgreSQL): the constraints are added without validating the existing
+		// rows, and then validated by a separate statement that holds a weaker lock. Plans
+		// with deferred validations are not transactional, as the lock taken by adding the
+		// constraints is otherwise held until the end of the validation.
+		DeferValidation bool
 	}
 
 	// PlanOption allows configuring a drivers' plan using functional arguments.
 	PlanOption func(*PlanOptions)
 
+	// OnlineDDL defines the modes for planning online schema changes.
+	OnlineDDL uint
+
 	// StateReader wraps the method for reading a database/schema state.
 	// The types below provides a few builtin options for reading a state
 	// from a migration directory, a static object (e.g. a parsed file).
@@ -107,6 +123,18 @@ func (f StateReaderFunc) ReadState(ctx context.Context) (*schema.Realm, error) {
 	return f(ctx)
 }
 
+// List of OnlineDDL modes.
+const (
+	// OnlineDDLNone disables the planning of online schema changes.
+	OnlineDDLNone OnlineDDL = iota
+	// OnlineDDLAllowCopy plans online schema changes where possible, and
+	// marks the changes that require a table copy in their comments.
+	OnlineDDLAllowCopy
+	// OnlineDDLRequire plans online schema changes, and fails the
+	// planning if one of the changes requires a table copy.
+	OnlineDDLRequire
+)
+
 // ErrNoPlan is returned by Plan when there is no change between the two states.
 var ErrNoPlan = errors.New("sql/migrate: no plan for matched states")
 
@@ -156,6 +184,7 @@ type (
 		fmt  Formatter    // how to format a plan to migration files
 		sum  bool         // whether to create a sum file for the migration directory
 		opts []PlanOption // driver options
+		diff []schema.DiffOption
 	}
 
 	// PlannerOption allows managing a Planner using functional arguments.
@@ -286,6 +315,34 @@ func PlanWithSchemaQualifier(q string) PlannerOption {
 	}
 }
 
+// PlanWithDiffOptions allows setting the options
+// passed to the Differ when computing the changes.
+func PlanWithDiffOptions(opts ...schema.DiffOption) PlannerOption {
+	return func(p *Planner) {
+		p.diff = append(p.diff, opts...)
+	}
+}
+
+// PlanWithOnlineDDL allows setting the mode for
+// planning online schema changes. See OnlineDDL.
+func PlanWithOnlineDDL(m OnlineDDL) PlannerOption {
+	return func(p *Planner) {
+		p.opts = append(p.opts, func(o *PlanOptions) {
+			o.OnlineDDL = m
+		})
+	}
+}
+
+// PlanWithDeferValidation allows planning the creation of constraints
+// in two phases (add and validate). See PlanOptions.DeferValidation.
+func PlanWithDeferValidation(b bool) PlannerOption {
+	return func(p *Planner) {
+		p.opts = append(p.opts, func(o *PlanOptions) {
+			o.DeferValidation = b
+		})
+	}
+}
+
 // PlanFormat sets the Formatter of a Planner.
 func PlanFormat(fmt Formatter) PlannerOption {
 	return func(p *Planner) {
@@ -329,11 +386,11 @@ func (p *Planner) plan(ctx context.Context, name string, to StateReader, realmSc
 	}
 	current, err := from.Replay(ctx, func() StateReader {
 		if realmScope {
-			return RealmConn(p.drv, nil)
+			return RealmConn(p.drv, &schema.InspectRealmOption{Mode: schema.InspectAll})
 		}
 		// In case the scope is the schema connection,
 		// inspect it and return its connected realm.
-		return SchemaConn(p.drv, "", nil)
+		return SchemaConn(p.drv, "", &schema.InspectOptions{Mode: schema.InspectAll})
 	}())
 	if err != nil {
 		return nil, err
@@ -345,7 +402,7 @@ func (p *Planner) plan(ctx context.Context, name string, to StateReader, realmSc
 	var changes []schema.Change
 	switch {
 	case realmScope:
-		changes, err = p.drv.RealmDiff(current, desired)
+		changes, err = schema.RealmDiff(p.drv, current, desired, p.diff...)
 	default:
 		switch n, m := len(current.Schemas), len(desired.Schemas); {
 		case n == 0:
@@ -363,7 +420,7 @@ func (p *Planner) plan(ctx context.Context, name string, to StateReader, realmSc
 			if s1.Name != s2.Name {
 				s1.Name = s2.Name
 			}
-			changes, err = p.drv.SchemaDiff(&s1, &s2)
+			changes, err = schema.SchemaDiff(p.drv, &s1, &s2, p.diff...)
 		}
 	}
 	if err != nil {
@@ -375,6 +432,52 @@ func (p *Planner) plan(ctx context.Context, name string, to StateReader, realmSc
 	return p.drv.PlanChanges(ctx, name, changes, p.opts...)
 }
 
+// Checkpoint replays the migration directory and returns a Plan for creating its
+// current state from scratch. Use WriteCheckpoint to write it as a checkpoint file.
+func (p *Planner) Checkpoint(ctx context.Context, name string) (*Plan, error) {
+	return p.checkpoint(ctx, name, true)
+}
+
+// CheckpointSchema is like Checkpoint but limits its scope to the schema connection.
+// Note, the operation fails in case the connection was not set to a schema.
+func (p *Planner) CheckpointSchema(ctx context.Context, name string) (*Plan, error) {
+	return p.checkpoint(ctx, name, false)
+}
+
+func (p *Planner) checkpoint(ctx context.Context, name string, realmScope bool) (*Plan, error) {
+	ex, err := NewExecutor(p.drv, p.dir, NopRevisionReadWriter{})
+	if err != nil {
+		return nil, err
+	}
+	current, err := ex.Replay(ctx, func() StateReader {
+		if realmScope {
+			return RealmConn(p.drv, &schema.InspectRealmOption{Mode: schema.InspectAll})
+		}
+		return SchemaConn(p.drv, "", &schema.InspectOptions{Mode: schema.InspectAll})
+	}())
+	if err != nil {
+		return nil, err
+	}
+	var changes []schema.Change
+	switch n := len(current.Schemas); {
+	case realmScope:
+		changes, err = schema.RealmDiff(p.drv, schema.NewRealm(), current, p.diff...)
+	case n == 0:
+		return nil, errors.New("no schema was found in current state after replaying migration directory")
+	case n > 1:
+		return nil, fmt.Errorf("%d schemas were found in current state after replaying migration directory", n)
+	default:
+		changes, err = schema.SchemaDiff(p.drv, schema.New(current.Schemas[0].Name), current.Schemas[0], p.diff...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, ErrNoPlan
+	}
+	return p.drv.PlanChanges(ctx, name, changes, p.opts...)
+}
+
 // WritePlan writes the given Plan to the Dir based on the configured Formatter.
 func (p *Planner) WritePlan(plan *Plan) error {
 	// Format the plan into files.
@@ -382,7 +485,25 @@ func (p *Planner) WritePlan(plan *Plan) error {
 	if err != nil {
 		return err
 	}
-	// Store the files in the migration directory.
+	return p.writeFiles(files)
+}
+
+// WriteCheckpoint is like WritePlan, but marks the first formatted file (e.g. the up
+// file) with the "atlas:checkpoint" directive. See CheckpointFile for more info.
+func (p *Planner) WriteCheckpoint(plan *Plan) error {
+	files, err := p.fmt.Format(plan)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("sql/migrate: checkpoint: no files were formatted")
+	}
+	files[0] = NewLocalFile(files[0].Name(), append([]byte(directivePrefixSQL+"atlas:"+directiveCheckpoint+"\n\n"), files[0].Bytes()...))
+	return p.writeFiles(files)
+}
+
+// writeFiles stores the given files in the migration directory.
+func (p *Planner) writeFiles(files []File) error {
 	for _, f := range files {
 		if err := p.dir.WriteFile(f.Name(), f.Bytes()); err != nil {
 			return err
@@ -499,7 +620,16 @@ func WithOperatorVersion(v string) ExecutorOption {
 }
 
 // Pending returns all pending (not fully applied) migration files in the migration directory.
+//
+// On fresh databases, execution starts from the latest checkpoint file (if exists), and files
+// preceding it are skipped. Otherwise, checkpoint files are skipped. See CheckpointFile.
 func (e *Executor) Pending(ctx context.Context) ([]File, error) {
+	return e.pending(ctx, "")
+}
+
+// pending returns the pending migration files. If the version is
+// not empty, checkpoints succeeding it are not used as starting point.
+func (e *Executor) pending(ctx context.Context, version string) ([]File, error) {
 	// Don't operate with a broken migration directory.
 	if err := Validate(e.dir); err != nil {
 		return nil, fmt.Errorf("sql/migrate: execute: validate migration directory: %w", err)
@@ -530,6 +660,13 @@ func (e *Executor) Pending(ctx context.Context) ([]File, error) {
 			return nil, fmt.Errorf("%w. baseline version or allow-dirty is required", cerr)
 		}
 		pending = migrations
+		// Start from the latest checkpoint, as it holds
+		// the full schema state of the files preceding it.
+		if idx := FilesLastIndex(migrations, func(f File) bool {
+			return IsCheckpoint(f) && (version == "" || f.Version() <= version)
+		}); idx != -1 && e.baselineVer == "" {
+			pending = migrations[idx:]
+		}
 		if e.baselineVer != "" {
 			baseline := FilesLastIndex(migrations, func(f File) bool {
 				return f.Version() == e.baselineVer
@@ -542,7 +679,7 @@ func (e *Executor) Pending(ctx context.Context) ([]File, error) {
 			if err := e.writeRevision(ctx, &Revision{Version: f.Version(), Description: f.Desc(), Type: RevisionTypeBaseline}); err != nil {
 				return nil, err
 			}
-			pending = migrations[baseline+1:]
+			pending = skipCheckpoints(migrations[baseline+1:], false)
 		}
 	// Not the first time we execute and a custom starting point was provided.
 	case e.fromVer != "":
@@ -552,7 +689,7 @@ func (e *Executor) Pending(ctx context.Context) ([]File, error) {
 		if idx == -1 {
 			return nil, fmt.Errorf("starting point version %q not found in the migration directory", e.fromVer)
 		}
-		pending = migrations[idx:]
+		pending = skipCheckpoints(migrations[idx:], true)
 	default:
 		var (
 			last      = revs[len(revs)-1]
@@ -574,13 +711,16 @@ func (e *Executor) Pending(ctx context.Context) ([]File, error) {
 				return nil, &MissingMigrationError{last.Version, last.Description}
 			}
 			// All migrations have a higher version than the latest revision. Take every migration file as pending.
-			return migrations, nil
+			pending = skipCheckpoints(migrations, false)
+			break
 		}
 		// If this file was not partially applied, take the next one.
 		if last.Applied == last.Total {
 			idx++
 		}
-		pending = migrations[idx:]
+		// A partially applied checkpoint file is
+		// continued from where it was left off.
+		pending = skipCheckpoints(migrations[idx:], partially)
 	}
 	if len(pending) == 0 {
 		return nil, ErrNoPendingFiles
@@ -588,6 +728,19 @@ func (e *Executor) Pending(ctx context.Context) ([]File, error) {
 	return pending, nil
 }
 
+// skipCheckpoints returns the files without the checkpoint files, as they are
+// not executed on databases with revisions. If keepFirst is true, the first file
+// is kept, even if it is a checkpoint.
+func skipCheckpoints(files []File, keepFirst bool) []File {
+	skipped := make([]File, 0, len(files))
+	for i, f := range files {
+		if !IsCheckpoint(f) || keepFirst && i == 0 {
+			skipped = append(skipped, f)
+		}
+	}
+	return skipped
+}
+
 // Execute executes the given migration file on the database. If it sees a file, that has been partially applied, it
 // will continue with the next statement in line.
 func (e *Executor) Execute(ctx context.Context, m File) (err error) {
@@ -708,7 +861,7 @@ func (e *Executor) ExecuteN(ctx context.Context, n int) (err error) {
 
 // ExecuteTo executes all pending migration files up to and including version.
 func (e *Executor) ExecuteTo(ctx context.Context, version string) (err error) {
-	pending, err := e.Pending(ctx)
+	pending, err := e.pending(ctx, version)
 	if err != nil {
 		return err
 	}
@@ -785,6 +938,182 @@ func (e *Executor) Replay(ctx context.Context, r StateReader, opts ...ReplayOpti
 	return r.ReadState(ctx)
 }
 
+type (
+	downConfig struct {
+		version string // to which version to revert (exclusive)
+		n       int    // amount of revisions to revert
+	}
+	// DownOption configures the planning of reverting applied migration files.
+	DownOption func(*downConfig)
+
+	// DownPlan describes the plan for reverting applied migration files. See Executor.PlanDown.
+	DownPlan struct {
+		// To is the version the database is reverted to. An empty
+		// string indicates that all revisions are reverted.
+		To string
+		// Revisions are the reverted revisions, starting from the last applied one.
+		Revisions []*Revision
+		// Plan holds the changes for reverting the database to the To version.
+		// It is nil if the reverted migrations did not change the schema.
+		Plan *Plan
+	}
+)
+
+// DownToVersion configures the version to revert the database to. All revisions
+// that come after the given version are reverted, and an empty string reverts
+// all applied revisions.
+func DownToVersion(v string) DownOption {
+	return func(c *downConfig) {
+		c.version, c.n = v, 0
+	}
+}
+
+// DownN configures the amount of the last applied revisions to revert.
+func DownN(n int) DownOption {
+	return func(c *downConfig) {
+		c.version, c.n = "", n
+	}
+}
+
+// ErrNoAppliedFiles is returned by PlanDown if there are no applied migration files to revert.
+var ErrNoAppliedFiles = errors.New("sql/migrate: down: nothing to revert")
+
+// PlanDown computes the plan for reverting applied migration files. By default, the last applied
+// revision is reverted. The desired state is computed by replaying the migration directory on the
+// dev database (using the dev Executor and its devState reader) up to the target version, and the
+// plan migrates the current state of the connected database to it. Note, the migration history
+// table is not considered part of the current state.
+func (e *Executor) PlanDown(ctx context.Context, dev *Executor, devState, current StateReader, opts ...DownOption) (*DownPlan, error) {
+	c := &downConfig{n: 1}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if err := Validate(e.dir); err != nil {
+		return nil, fmt.Errorf("sql/migrate: down: validate migration directory: %w", err)
+	}
+	revs, err := e.rrw.ReadRevisions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sql/migrate: down: read revisions: %w", err)
+	}
+	p := &DownPlan{}
+	switch {
+	case c.n > 0:
+		if c.n < len(revs) {
+			p.To = revs[len(revs)-c.n-1].Version
+		}
+	case c.version != "":
+		files, err := e.dir.Files()
+		if err != nil {
+			return nil, fmt.Errorf("sql/migrate: down: select migration files: %w", err)
+		}
+		if FilesLastIndex(files, func(f File) bool { return f.Version() == c.version }) == -1 {
+			return nil, fmt.Errorf("sql/migrate: down: migration with version %q not found", c.version)
+		}
+		p.To = c.version
+	}
+	for i := len(revs) - 1; i >= 0 && (p.To == "" || revs[i].Version > p.To); i-- {
+		if revs[i].Type.Has(RevisionTypeBaseline) {
+			return nil, fmt.Errorf("sql/migrate: down: cannot revert baseline revision %q", revs[i].Version)
+		}
+		p.Revisions = append(p.Revisions, revs[i])
+	}
+	if len(p.Revisions) == 0 {
+		return nil, ErrNoAppliedFiles
+	}
+	desired, err := func() (_ *schema.Realm, err error) {
+		if p.To != "" {
+			return dev.Replay(ctx, devState, ReplayToVersion(p.To))
+		}
+		// Reverting all revisions means reverting to the initial state of
+		// the dev database, which is required to be clean as in Replay.
+		restore, err := dev.drv.(Snapshoter).Snapshot(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("sql/migrate: taking database snapshot: %w", err)
+		}
+		defer func() {
+			if err2 := restore(ctx); err2 != nil {
+				err = wrap(err2, err)
+			}
+		}()
+		return devState.ReadState(ctx)
+	}()
+	if err != nil {
+		return nil, fmt.Errorf("sql/migrate: down: read desired state: %w", err)
+	}
+	from, err := current.ReadState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sql/migrate: down: read current state: %w", err)
+	}
+	// In case the scope is limited to one schema, and the desired
+	// state was replayed on a schema with a different name.
+	single := len(from.Schemas) == 1 && len(desired.Schemas) == 1
+	if single {
+		desired.Schemas[0].Name = from.Schemas[0].Name
+	}
+	excludeRevisions(from, desired, e.rrw.Ident())
+	var changes []schema.Change
+	if single {
+		changes, err = e.drv.SchemaDiff(from.Schemas[0], desired.Schemas[0])
+	} else {
+		changes, err = e.drv.RealmDiff(from, desired)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sql/migrate: down: diff states: %w", err)
+	}
+	if len(changes) > 0 {
+		if p.Plan, err = e.drv.PlanChanges(ctx, "down", changes); err != nil {
+			return nil, fmt.Errorf("sql/migrate: down: plan changes: %w", err)
+		}
+	}
+	return p, nil
+}
+
+// ExecuteDown executes the given down plan on the database,
+// and deletes the reverted revisions from the history table.
+func (e *Executor) ExecuteDown(ctx context.Context, p *DownPlan) error {
+	if p.Plan != nil {
+		for _, c := range p.Plan.Changes {
+			e.log.Log(LogStmt{SQL: c.Cmd})
+			if _, err := e.drv.ExecContext(ctx, c.Cmd, c.Args...); err != nil {
+				e.log.Log(LogError{SQL: c.Cmd, Error: err})
+				return fmt.Errorf("sql/migrate: down: executing statement %q: %w", c.Cmd, err)
+			}
+		}
+	}
+	for _, r := range p.Revisions {
+		if err := e.rrw.DeleteRevision(ctx, r.Version); err != nil {
+			return fmt.Errorf("sql/migrate: down: delete revision %q: %w", r.Version, err)
+		}
+	}
+	e.log.Log(LogDone{})
+	return nil
+}
+
+// excludeRevisions removes the revisions table from the current state. Its schema is
+// removed as well, if it contains no other objects and does not exist in the desired
+// state (e.g. the default schema used by the Atlas CLI).
+func excludeRevisions(current, desired *schema.Realm, ident *TableIdent) {
+	if ident == nil {
+		return
+	}
+	for i := 0; i < len(current.Schemas); i++ {
+		s := current.Schemas[i]
+		if ident.Schema != "" && s.Name != ident.Schema {
+			continue
+		}
+		for j, t := range s.Tables {
+			if t.Name == ident.Name {
+				s.Tables = append(s.Tables[:j], s.Tables[j+1:]...)
+				break
+			}
+		}
+		if _, ok := desired.Schema(s.Name); !ok && ident.Schema != "" && len(s.Tables) == 0 && len(s.Views) == 0 && len(s.Objects) == 0 && len(s.Funcs) == 0 && len(s.Procs) == 0 {
+			current.Schemas = append(current.Schemas[:i], current.Schemas[i+1:]...)
+			i--
+		}
+	}
+}
+
 type (
 	// Snapshoter wraps the Snapshot method.
 	Snapshoter interface {
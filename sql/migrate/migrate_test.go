@@ -145,6 +145,148 @@ func TestPlanner_PlanSchema(t *testing.T) {
 	require.Nil(t, plan)
 }
 
+func TestPlanner_Checkpoint(t *testing.T) {
+	var (
+		drv = &mockDriver{}
+		ctx = context.Background()
+	)
+	d, err := migrate.NewLocalDir(t.TempDir())
+	require.NoError(t, err)
+
+	// Nothing to checkpoint.
+	pl := migrate.NewPlanner(drv, d)
+	plan, err := pl.Checkpoint(ctx, "checkpoint")
+	require.ErrorIs(t, err, migrate.ErrNoPlan)
+	require.Nil(t, plan)
+
+	// Schema is missing in dev connection.
+	plan, err = pl.CheckpointSchema(ctx, "checkpoint")
+	require.EqualError(t, err, `not found`)
+	require.Nil(t, plan)
+
+	drv.realm = *schema.NewRealm(schema.New("test"))
+	drv.changes = []schema.Change{&schema.AddTable{T: schema.NewTable("t1").AddColumns(schema.NewIntColumn("c", "int"))}}
+	drv.plan = &migrate.Plan{
+		Version: "2",
+		Name:    "checkpoint",
+		Changes: []*migrate.Change{{Cmd: "CREATE TABLE t1(c int)", Reverse: "DROP TABLE t1"}},
+	}
+	plan, err = pl.CheckpointSchema(ctx, "checkpoint")
+	require.NoError(t, err)
+	require.Equal(t, drv.plan, plan)
+
+	// Checkpoint files are marked with a directive.
+	require.NoError(t, d.WriteFile("1_init.sql", []byte("CREATE TABLE t1(c int);\n")))
+	require.NoError(t, pl.WriteCheckpoint(plan))
+	requireFileEqual(t, d, "2_checkpoint.sql", "-- atlas:checkpoint\n\nCREATE TABLE t1(c int);\n")
+	require.NoError(t, migrate.Validate(d))
+	files, err := d.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.False(t, migrate.IsCheckpoint(files[0]))
+	require.True(t, migrate.IsCheckpoint(files[1]))
+
+	// Only the up file is marked.
+	pl = migrate.NewPlanner(drv, d, migrate.PlanFormat(migrate.DownFormatter))
+	plan.Version = "3"
+	require.NoError(t, pl.WriteCheckpoint(plan))
+	requireFileEqual(t, d, "3_checkpoint.sql", "-- atlas:checkpoint\n\nCREATE TABLE t1(c int);\n")
+	requireFileEqual(t, d, "down/3_checkpoint.sql", "DROP TABLE t1;\n")
+
+	// Checkpoint files keep the other directives of their header.
+	f, err := migrate.NewTemplateFormatter(
+		template.Must(template.New("").Parse("{{ .Version }}_{{ .Name }}.sql")),
+		template.Must(template.New("").Parse("-- atlas:delimiter \\\n\n{{ range .Changes }}{{ .Cmd }}\n\\\n{{ end }}")),
+	)
+	require.NoError(t, err)
+	pl = migrate.NewPlanner(drv, d, migrate.PlanFormat(f))
+	plan.Version = "4"
+	plan.Changes = []*migrate.Change{{Cmd: "CREATE TRIGGER t1_ai AFTER INSERT ON t1 BEGIN SELECT 1; END"}}
+	require.NoError(t, pl.WriteCheckpoint(plan))
+	files, err = d.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+	require.Equal(t, "4_checkpoint.sql", files[3].Name())
+	require.True(t, migrate.IsCheckpoint(files[3]))
+	stmts, err := files[3].Stmts()
+	require.NoError(t, err)
+	require.Equal(t, []string{"CREATE TRIGGER t1_ai AFTER INSERT ON t1 BEGIN SELECT 1; END"}, stmts)
+}
+
+func TestExecutor_Checkpoint(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		drv  = &mockDriver{}
+		rrw  = &mockRevisionReadWriter{}
+		rev1 = &migrate.Revision{Version: "1", Description: "first", Applied: 1, Total: 1}
+		rev3 = &migrate.Revision{Version: "3", Description: "checkpoint", Applied: 2, Total: 2}
+	)
+	dir, err := migrate.NewLocalDir(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, dir.WriteFile("1_first.sql", []byte("CREATE TABLE t1(c int);\n")))
+	require.NoError(t, dir.WriteFile("2_second.sql", []byte("CREATE TABLE t2(c int);\n")))
+	require.NoError(t, dir.WriteFile("3_checkpoint.sql", []byte("-- atlas:checkpoint\n\nCREATE TABLE t1(c int);\nCREATE TABLE t2(c int);\n")))
+	require.NoError(t, dir.WriteFile("4_fourth.sql", []byte("CREATE TABLE t4(c int);\n")))
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+	ex, err := migrate.NewExecutor(drv, dir, rrw)
+	require.NoError(t, err)
+
+	// Fresh databases start from the latest checkpoint.
+	p, err := ex.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, p, 2)
+	require.Equal(t, "3_checkpoint.sql", p[0].Name())
+	require.Equal(t, "4_fourth.sql", p[1].Name())
+
+	// Databases with revisions skip checkpoints.
+	*rrw = mockRevisionReadWriter{rev1}
+	p, err = ex.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, p, 2)
+	require.Equal(t, "2_second.sql", p[0].Name())
+	require.Equal(t, "4_fourth.sql", p[1].Name())
+
+	*rrw = mockRevisionReadWriter{rev3}
+	p, err = ex.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, p, 1)
+	require.Equal(t, "4_fourth.sql", p[0].Name())
+
+	// Partially applied checkpoints are continued.
+	*rrw = mockRevisionReadWriter{{Version: "3", Description: "checkpoint", Applied: 1, Total: 2}}
+	p, err = ex.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, p, 2)
+	require.Equal(t, "3_checkpoint.sql", p[0].Name())
+
+	// Baseline databases skip checkpoints.
+	*rrw = mockRevisionReadWriter{}
+	ex, err = migrate.NewExecutor(drv, dir, rrw, migrate.WithBaselineVersion("1"))
+	require.NoError(t, err)
+	p, err = ex.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, p, 2)
+	require.Equal(t, "2_second.sql", p[0].Name())
+	require.Equal(t, "4_fourth.sql", p[1].Name())
+
+	// Replaying the directory starts from the checkpoint, unless it succeeds the target version.
+	ex, err = migrate.NewExecutor(drv, dir, migrate.NopRevisionReadWriter{})
+	require.NoError(t, err)
+	_, err = ex.Replay(ctx, migrate.RealmConn(drv, nil))
+	require.NoError(t, err)
+	require.Equal(t, []string{"CREATE TABLE t1(c int);", "CREATE TABLE t2(c int);", "CREATE TABLE t4(c int);"}, drv.executed)
+	*drv = mockDriver{}
+	_, err = ex.Replay(ctx, migrate.RealmConn(drv, nil), migrate.ReplayToVersion("3"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"CREATE TABLE t1(c int);", "CREATE TABLE t2(c int);"}, drv.executed)
+	*drv = mockDriver{}
+	_, err = ex.Replay(ctx, migrate.RealmConn(drv, nil), migrate.ReplayToVersion("2"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"CREATE TABLE t1(c int);", "CREATE TABLE t2(c int);"}, drv.executed)
+}
+
 func TestExecutor_Replay(t *testing.T) {
 	ctx := context.Background()
 	d, err := migrate.NewLocalDir(filepath.FromSlash("testdata/migrate"))
@@ -531,6 +673,109 @@ func TestExecutor_FromVersion(t *testing.T) {
 	require.Nil(t, files)
 }
 
+func TestExecutor_PlanDown(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		drv  = &mockDriver{}
+		dev  = &mockDriver{}
+		log  = &mockLogger{}
+		rev1 = &migrate.Revision{Version: "1.a", Description: "sub.up", Type: migrate.RevisionTypeExecute, Applied: 2, Total: 2}
+		rev2 = &migrate.Revision{Version: "2.10.x-20", Description: "description", Type: migrate.RevisionTypeExecute, Applied: 1, Total: 1}
+		rrw  = &mockRevisionReadWriter{rev1, rev2}
+	)
+	dir, err := migrate.NewLocalDir(filepath.Join("testdata", "migrate", "sub"))
+	require.NoError(t, err)
+	ex, err := migrate.NewExecutor(drv, dir, rrw, migrate.WithLogger(log))
+	require.NoError(t, err)
+	devEx, err := migrate.NewExecutor(dev, dir, migrate.NopRevisionReadWriter{})
+	require.NoError(t, err)
+
+	// By default, the last revision is reverted.
+	drv.changes = []schema.Change{&schema.DropTable{T: schema.NewTable("t")}}
+	drv.plan = &migrate.Plan{Changes: []*migrate.Change{{Cmd: "ALTER TABLE t_sub DROP c2"}}}
+	p, err := ex.PlanDown(ctx, devEx, migrate.RealmConn(dev, nil), migrate.RealmConn(drv, nil))
+	require.NoError(t, err)
+	require.Equal(t, "1.a", p.To)
+	require.Equal(t, []*migrate.Revision{rev2}, p.Revisions)
+	require.Equal(t, drv.plan, p.Plan)
+	require.Equal(t, []string{"CREATE TABLE t_sub(c int);", "ALTER TABLE t_sub ADD c1 int;"}, dev.executed)
+
+	// Revert all revisions.
+	*dev = mockDriver{}
+	p, err = ex.PlanDown(ctx, devEx, migrate.RealmConn(dev, nil), migrate.RealmConn(drv, nil), migrate.DownN(5))
+	require.NoError(t, err)
+	require.Empty(t, p.To)
+	require.Equal(t, []*migrate.Revision{rev2, rev1}, p.Revisions)
+	require.Empty(t, dev.executed)
+
+	// The dev database must be clean when reverting all revisions.
+	dev.dirty = true
+	dev.realm = schema.Realm{Schemas: []*schema.Schema{{Name: "schema"}}}
+	_, err = ex.PlanDown(ctx, devEx, migrate.RealmConn(dev, nil), migrate.RealmConn(drv, nil), migrate.DownN(5))
+	require.ErrorAs(t, err, &migrate.NotCleanError{})
+	*dev = mockDriver{}
+
+	// Revert to a specific version.
+	p, err = ex.PlanDown(ctx, devEx, migrate.RealmConn(dev, nil), migrate.RealmConn(drv, nil), migrate.DownToVersion("1.a"))
+	require.NoError(t, err)
+	require.Equal(t, "1.a", p.To)
+	require.Equal(t, []*migrate.Revision{rev2}, p.Revisions)
+	_, err = ex.PlanDown(ctx, devEx, migrate.RealmConn(dev, nil), migrate.RealmConn(drv, nil), migrate.DownToVersion("4"))
+	require.EqualError(t, err, `sql/migrate: down: migration with version "4" not found`)
+	_, err = ex.PlanDown(ctx, devEx, migrate.RealmConn(dev, nil), migrate.RealmConn(drv, nil), migrate.DownToVersion("2.10.x-20"))
+	require.ErrorIs(t, err, migrate.ErrNoAppliedFiles)
+
+	// No changes, no plan.
+	drv.changes = nil
+	p, err = ex.PlanDown(ctx, devEx, migrate.RealmConn(dev, nil), migrate.RealmConn(drv, nil))
+	require.NoError(t, err)
+	require.Nil(t, p.Plan)
+
+	// Baseline revisions cannot be reverted.
+	*rrw = []*migrate.Revision{{Version: "1.a", Type: migrate.RevisionTypeBaseline}, rev2}
+	_, err = ex.PlanDown(ctx, devEx, migrate.RealmConn(dev, nil), migrate.RealmConn(drv, nil), migrate.DownN(2))
+	require.EqualError(t, err, `sql/migrate: down: cannot revert baseline revision "1.a"`)
+
+	// Nothing to revert.
+	*rrw = mockRevisionReadWriter{}
+	_, err = ex.PlanDown(ctx, devEx, migrate.RealmConn(dev, nil), migrate.RealmConn(drv, nil))
+	require.ErrorIs(t, err, migrate.ErrNoAppliedFiles)
+}
+
+func TestExecutor_ExecuteDown(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		drv  = &mockDriver{}
+		log  = &mockLogger{}
+		rev1 = &migrate.Revision{Version: "1.a", Description: "sub.up", Type: migrate.RevisionTypeExecute, Applied: 2, Total: 2}
+		rev2 = &migrate.Revision{Version: "2.10.x-20", Description: "description", Type: migrate.RevisionTypeExecute, Applied: 1, Total: 1}
+		rrw  = &mockRevisionReadWriter{rev1, rev2}
+	)
+	dir, err := migrate.NewLocalDir(filepath.Join("testdata", "migrate", "sub"))
+	require.NoError(t, err)
+	ex, err := migrate.NewExecutor(drv, dir, rrw, migrate.WithLogger(log))
+	require.NoError(t, err)
+	require.NoError(t, ex.ExecuteDown(ctx, &migrate.DownPlan{
+		To:        "1.a",
+		Revisions: []*migrate.Revision{rev2},
+		Plan:      &migrate.Plan{Changes: []*migrate.Change{{Cmd: "ALTER TABLE t_sub DROP c2"}}},
+	}))
+	require.Equal(t, []string{"ALTER TABLE t_sub DROP c2"}, drv.executed)
+	requireEqualRevisions(t, []*migrate.Revision{rev1}, *rrw)
+	require.Equal(t, []migrate.LogEntry{migrate.LogStmt{SQL: "ALTER TABLE t_sub DROP c2"}, migrate.LogDone{}}, []migrate.LogEntry(*log))
+
+	// Revisions are kept on failure.
+	*rrw = mockRevisionReadWriter{rev1, rev2}
+	*drv = mockDriver{}
+	drv.failOn(1, errors.New("this is an error"))
+	err = ex.ExecuteDown(ctx, &migrate.DownPlan{
+		Revisions: []*migrate.Revision{rev2, rev1},
+		Plan:      &migrate.Plan{Changes: []*migrate.Change{{Cmd: "DROP TABLE t_sub"}}},
+	})
+	require.EqualError(t, err, `sql/migrate: down: executing statement "DROP TABLE t_sub": this is an error`)
+	requireEqualRevisions(t, []*migrate.Revision{rev1, rev2}, *rrw)
+}
+
 type (
 	mockDriver struct {
 		migrate.Driver
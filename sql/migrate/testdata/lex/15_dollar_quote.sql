@@ -0,0 +1,21 @@
+-- PostgreSQL dollar-quoted function bodies.
+CREATE FUNCTION add(integer, integer) RETURNS integer AS $$
+    SELECT $1 + $2;
+$$ LANGUAGE SQL;
+
+CREATE OR REPLACE FUNCTION audit() RETURNS trigger AS $body$
+BEGIN
+    -- Nested dollar quotes are kept as-is.
+    EXECUTE $q$INSERT INTO logs VALUES ('$$;')$q$;
+    RETURN NEW;
+END;
+$body$ LANGUAGE plpgsql;
+
+DO $$
+BEGIN
+    RAISE NOTICE 'done;';
+END
+$$;
+
+PREPARE q(int) AS SELECT * FROM t WHERE id = $1;
+CREATE TABLE "a$b" (c$1 int, d$ int);
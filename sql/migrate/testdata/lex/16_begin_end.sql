@@ -0,0 +1,39 @@
+-- Transaction commands are not compound statements.
+BEGIN;
+CREATE TABLE t1 (id int, begin int, "end" int);
+CREATE TABLE t2 (event int, begin int);
+END;
+
+-- PostgreSQL SQL-standard function bodies.
+CREATE FUNCTION add(a integer, b integer) RETURNS integer
+    LANGUAGE SQL
+    IMMUTABLE
+BEGIN ATOMIC
+    SELECT a + b;
+END;
+
+-- MySQL stored programs without custom delimiters.
+CREATE PROCEDURE p(IN n int)
+BEGIN
+    DECLARE i int DEFAULT 0;
+    loop1: LOOP
+        SET i = i + 1;
+        IF i >= n THEN
+            LEAVE loop1;
+        END IF;
+    END LOOP loop1;
+    CASE n
+        WHEN 1 THEN SELECT CASE WHEN i > 0 THEN 'one' ELSE 'none' END;
+        ELSE BEGIN
+            SELECT 'many';
+        END;
+    END CASE;
+END;
+
+CREATE DEFINER=`root`@`localhost` TRIGGER t1_bi BEFORE INSERT ON t1 FOR EACH ROW
+BEGIN
+    WHILE NEW.id < 0 DO
+        SET NEW.id = NEW.id + 1;
+    END WHILE;
+END;
+SELECT CASE WHEN id > 0 THEN 1 END FROM t1;
@@ -0,0 +1,9 @@
+CREATE TABLE users (id integer, name text);
+CREATE TRIGGER IF NOT EXISTS users_ai AFTER INSERT ON users
+WHEN NEW.name IS NULL
+BEGIN
+    UPDATE users SET name = 'unknown' WHERE id = NEW.id;
+    INSERT INTO logs (message) VALUES ('inserted; ' || NEW.id);
+END;
+CREATE TEMP TRIGGER users_ad AFTER DELETE ON users BEGIN DELETE FROM logs WHERE user_id = OLD.id; END;
+DROP TRIGGER users_ad;
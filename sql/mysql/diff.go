@@ -33,6 +33,12 @@ type diff struct {
 	}
 }
 
+// SupportsColumnPosition reports if the driver supports changing the
+// position of columns. i.e. using "MODIFY COLUMN ... AFTER" clauses.
+func (*diff) SupportsColumnPosition() bool {
+	return true
+}
+
 // SchemaAttrDiff returns a changeset for migrating schema attributes from one state to the other.
 func (d *diff) SchemaAttrDiff(from, to *schema.Schema) []schema.Change {
 	var (
@@ -68,6 +74,9 @@ func (d *diff) TableAttrDiff(from, to *schema.Table) ([]schema.Change, error) {
 	if change := d.collationChange(from.Attrs, from.Schema.Attrs, to.Attrs); change != noChange {
 		changes = append(changes, change)
 	}
+	if change := partitionChange(from.Attrs, to.Attrs); change != noChange {
+		changes = append(changes, change)
+	}
 	if !d.SupportsCheck() && sqlx.Has(to.Attrs, &schema.Check{}) {
 		return nil, fmt.Errorf("version %q does not support CHECK constraints", d.V)
 	}
@@ -350,6 +359,109 @@ func enforced(attr []schema.Attr) bool {
 	return true
 }
 
+// FuncChanged reports if the function definition was changed.
+func (d *diff) FuncChanged(from, to *schema.Func) (bool, error) {
+	if deterministic(from.Attrs) != deterministic(to.Attrs) {
+		return true, nil
+	}
+	return sqlx.FuncChanged(from, to, d.routineTypeChanged)
+}
+
+// ProcChanged reports if the procedure definition was changed.
+func (d *diff) ProcChanged(from, to *schema.Proc) (bool, error) {
+	if deterministic(from.Attrs) != deterministic(to.Attrs) {
+		return true, nil
+	}
+	return sqlx.ProcChanged(from, to, d.routineTypeChanged)
+}
+
+// RoleChanged reports if the role attributes were changed.
+func (d *diff) RoleChanged(from, to *schema.Role) (bool, error) {
+	return from.Login != to.Login, nil
+}
+
+// routineTypeChanged reports if the type of routine parameter (or its return type) was changed.
+func (d *diff) routineTypeChanged(from, to schema.Type) (bool, error) {
+	u1, ok1 := from.(*schema.UnsupportedType)
+	u2, ok2 := to.(*schema.UnsupportedType)
+	if ok1 || ok2 {
+		return !ok1 || !ok2 || !strings.EqualFold(u1.T, u2.T), nil
+	}
+	return d.typeChanged(
+		&schema.Column{Type: &schema.ColumnType{Type: from}},
+		&schema.Column{Type: &schema.ColumnType{Type: to}},
+	)
+}
+
+// deterministic reports if the routine was defined as DETERMINISTIC.
+func deterministic(attrs []schema.Attr) bool {
+	d := &Deterministic{}
+	return sqlx.Has(attrs, d) && d.V
+}
+
+// partitionChange returns the schema change for changing the table partitioning, if it was changed.
+func partitionChange(from, to []schema.Attr) schema.Change {
+	var fromP, toP Partition
+	switch fromHas, toHas := sqlx.Has(from, &fromP), sqlx.Has(to, &toP); {
+	case !fromHas && toHas:
+		return &schema.AddAttr{A: &toP}
+	case fromHas && !toHas:
+		return &schema.DropAttr{A: &fromP}
+	case fromHas && toHas && (partitionKeyChanged(&fromP.Key, &toP.Key) || partitionSubChanged(&fromP, &toP) || partitionDefsChanged(&fromP, &toP)):
+		return &schema.ModifyAttr{From: &fromP, To: &toP}
+	}
+	return noChange
+}
+
+// partitionKeyChanged reports if the partitioning key was changed.
+func partitionKeyChanged(from, to *PartitionKey) bool {
+	if !strings.EqualFold(from.T, to.T) || from.Linear != to.Linear || normalizeExpr(from.Expr) != normalizeExpr(to.Expr) || len(from.Columns) != len(to.Columns) {
+		return true
+	}
+	for i := range from.Columns {
+		if from.Columns[i].Name != to.Columns[i].Name {
+			return true
+		}
+	}
+	return false
+}
+
+// partitionSubChanged reports if the subpartitioning key was changed.
+func partitionSubChanged(from, to *Partition) bool {
+	switch {
+	case from.Sub == nil && to.Sub == nil:
+		return false
+	case from.Sub == nil || to.Sub == nil:
+		return true
+	default:
+		return partitionKeyChanged(from.Sub, to.Sub)
+	}
+}
+
+// partitionDefsChanged reports if the partition definitions were changed.
+func partitionDefsChanged(from, to *Partition) bool {
+	if len(from.Defs) != len(to.Defs) {
+		return true
+	}
+	for i := range from.Defs {
+		if partitionDefChanged(from.Defs[i], to.Defs[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// partitionDefChanged reports if the partition definition was changed.
+func partitionDefChanged(from, to *PartitionDef) bool {
+	return from.Name != to.Name || normalizeExpr(from.Values) != normalizeExpr(to.Values) || from.Comment != to.Comment || strings.Join(from.Subs, ",") != strings.Join(to.Subs, ",")
+}
+
+// normalizeExpr normalizes the given expression for comparison by
+// removing identifier quotes and spaces, and lowering its case.
+func normalizeExpr(x string) string {
+	return strings.ToLower(strings.NewReplacer("`", "", " ", "").Replace(x))
+}
+
 // noChange describes a zero change.
 var noChange struct{ schema.Change }
 
@@ -60,6 +60,42 @@ func TestDiff_TableDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add partitioning",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}},
+			to:   &schema.Table{Name: "users", Attrs: []schema.Attr{&Partition{Key: PartitionKey{T: PartitionTypeHash, Expr: "id"}}}},
+			wantChanges: []schema.Change{
+				&schema.AddAttr{
+					A: &Partition{Key: PartitionKey{T: PartitionTypeHash, Expr: "id"}},
+				},
+			},
+		},
+		{
+			name: "same partitioning",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{&Partition{Key: PartitionKey{T: PartitionTypeList, Expr: "`id`"}, Defs: []*PartitionDef{{Name: "p0", Values: "1,2"}}}}},
+			to:   &schema.Table{Name: "users", Attrs: []schema.Attr{&Partition{Key: PartitionKey{T: PartitionTypeList, Expr: "id"}, Defs: []*PartitionDef{{Name: "p0", Values: "1, 2"}}}}},
+		},
+		{
+			name: "modify partitions",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{&Partition{Key: PartitionKey{T: PartitionTypeRange, Expr: "id"}, Defs: []*PartitionDef{{Name: "p0", Values: "10"}}}}},
+			to:   &schema.Table{Name: "users", Attrs: []schema.Attr{&Partition{Key: PartitionKey{T: PartitionTypeRange, Expr: "id"}, Defs: []*PartitionDef{{Name: "p0", Values: "10"}, {Name: "p1", Values: "20"}}}}},
+			wantChanges: []schema.Change{
+				&schema.ModifyAttr{
+					From: &Partition{Key: PartitionKey{T: PartitionTypeRange, Expr: "id"}, Defs: []*PartitionDef{{Name: "p0", Values: "10"}}},
+					To:   &Partition{Key: PartitionKey{T: PartitionTypeRange, Expr: "id"}, Defs: []*PartitionDef{{Name: "p0", Values: "10"}, {Name: "p1", Values: "20"}}},
+				},
+			},
+		},
+		{
+			name: "drop partitioning",
+			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{&Partition{Key: PartitionKey{T: PartitionTypeKey}}}},
+			to:   &schema.Table{Name: "users"},
+			wantChanges: []schema.Change{
+				&schema.DropAttr{
+					A: &Partition{Key: PartitionKey{T: PartitionTypeKey}},
+				},
+			},
+		},
 		{
 			name: "add collation",
 			from: &schema.Table{Name: "users", Schema: &schema.Schema{Name: "public"}, Attrs: []schema.Attr{&schema.Charset{V: "latin1"}}},
@@ -181,6 +217,31 @@ func TestDiff_TableDiff(t *testing.T) {
 				},
 			},
 		},
+		func() testcase {
+			var (
+				from = schema.NewTable("t1").SetSchema(schema.New("public"))
+				to   = schema.NewTable("t1")
+			)
+			from.AddTriggers(
+				schema.NewTrigger("t1_insert").SetActionTime(schema.TriggerTimeBefore).AddEvents(schema.TriggerEvent{Name: schema.TriggerEventInsert}).SetFor(schema.TriggerForRow).SetBody("SET NEW.c = 1"),
+				schema.NewTrigger("t1_update").SetActionTime(schema.TriggerTimeBefore).AddEvents(schema.TriggerEvent{Name: schema.TriggerEventUpdate}).SetFor(schema.TriggerForRow).SetBody("SET NEW.c = 1"),
+			)
+			to.AddTriggers(
+				// Keywords are case-insensitive, and the body is compared without its surrounding spaces.
+				schema.NewTrigger("t1_insert").SetActionTime("before").AddEvents(schema.TriggerEvent{Name: "insert"}).SetFor("row").SetBody(" SET NEW.c = 1\n"),
+				schema.NewTrigger("t1_update").SetActionTime(schema.TriggerTimeAfter).AddEvents(schema.TriggerEvent{Name: schema.TriggerEventUpdate}).SetFor(schema.TriggerForRow).SetBody("SET NEW.c = 1"),
+				schema.NewTrigger("t1_delete").SetActionTime(schema.TriggerTimeBefore).AddEvents(schema.TriggerEvent{Name: schema.TriggerEventDelete}).SetFor(schema.TriggerForRow).SetBody("SET @c = 1"),
+			)
+			return testcase{
+				name: "triggers",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyTrigger{From: from.Triggers[1], To: to.Triggers[1]},
+					&schema.AddTrigger{T: to.Triggers[2]},
+				},
+			}
+		}(),
 		func() testcase {
 			var (
 				from = &schema.Table{
@@ -557,6 +618,45 @@ func TestDiff_SchemaDiff(t *testing.T) {
 	}, changes)
 }
 
+func TestDiff_ColumnPosition(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("8.0.19")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := schema.NewTable("users").
+		AddColumns(
+			schema.NewIntColumn("a", "int"),
+			schema.NewIntColumn("b", "int"),
+			schema.NewIntColumn("c", "int"),
+		)
+	to := schema.NewTable("users").
+		AddColumns(
+			schema.NewIntColumn("c", "int"),
+			schema.NewIntColumn("a", "int"),
+			schema.NewIntColumn("n", "int"),
+			schema.NewIntColumn("b", "int"),
+		)
+	schema.New("public").AddTables(from)
+	schema.New("public").AddTables(to)
+	changes, err := drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{&schema.AddColumn{C: to.Columns[2]}}, changes)
+
+	changes, err = schema.TableDiff(drv, from, to, schema.DiffColumnPosition())
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyColumn{From: from.Columns[2], To: to.Columns[0], Change: schema.ChangePosition},
+		&schema.AddColumn{C: to.Columns[2]},
+	}, changes)
+
+	// Position changes are combined with other column changes.
+	to.Columns[0].SetNull(true)
+	changes, err = schema.TableDiff(drv, from, to, schema.DiffColumnPosition())
+	require.NoError(t, err)
+	require.Equal(t, schema.ChangeNull|schema.ChangePosition, changes[0].(*schema.ModifyColumn).Change)
+}
+
 func TestDiff_RealmDiff(t *testing.T) {
 	db, m, err := sqlmock.New()
 	require.NoError(t, err)
@@ -91,6 +91,21 @@ func (d *Driver) NormalizeSchema(ctx context.Context, s *schema.Schema) (*schema
 	return d.dev().NormalizeSchema(ctx, s)
 }
 
+// RealmDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Driver) RealmDiffWithOptions(from, to *schema.Realm, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return schema.RealmDiff(d.Differ, from, to, opts...)
+}
+
+// SchemaDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Driver) SchemaDiffWithOptions(from, to *schema.Schema, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return schema.SchemaDiff(d.Differ, from, to, opts...)
+}
+
+// TableDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Driver) TableDiffWithOptions(from, to *schema.Table, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return schema.TableDiff(d.Differ, from, to, opts...)
+}
+
 // Lock implements the schema.Locker interface.
 func (d *Driver) Lock(ctx context.Context, name string, timeout time.Duration) (schema.UnlockFunc, error) {
 	conn, err := sqlx.SingleConn(ctx, d.ExecQuerier)
@@ -120,7 +135,7 @@ func (d *Driver) Lock(ctx context.Context, name string, timeout time.Duration) (
 // Snapshot implements migrate.Snapshoter.
 func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 	// If the connection is bound to a schema, we can restore the state if the schema has no tables.
-	s, err := d.InspectSchema(ctx, "", nil)
+	s, err := d.InspectSchema(ctx, "", &schema.InspectOptions{Mode: schema.InspectAll})
 	if err != nil && !schema.IsNotExistError(err) {
 		return nil, err
 	}
@@ -129,8 +144,17 @@ func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 		if len(s.Tables) > 0 {
 			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found table %q in schema %q", s.Tables[0].Name, s.Name)}
 		}
+		if len(s.Views) > 0 {
+			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found view %q in schema %q", s.Views[0].Name, s.Name)}
+		}
+		if len(s.Funcs) > 0 {
+			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found function %q in schema %q", s.Funcs[0].Name, s.Name)}
+		}
+		if len(s.Procs) > 0 {
+			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found procedure %q in schema %q", s.Procs[0].Name, s.Name)}
+		}
 		return func(ctx context.Context) error {
-			current, err := d.InspectSchema(ctx, s.Name, nil)
+			current, err := d.InspectSchema(ctx, s.Name, &schema.InspectOptions{Mode: schema.InspectAll})
 			if err != nil {
 				return err
 			}
@@ -142,7 +166,7 @@ func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 		}, nil
 	}
 	// Otherwise, the database can not have any schema.
-	realm, err := d.InspectRealm(ctx, nil)
+	realm, err := d.InspectRealm(ctx, &schema.InspectRealmOption{Mode: schema.InspectAll})
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +174,7 @@ func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 		return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found schema %q", realm.Schemas[0].Name)}
 	}
 	return func(ctx context.Context) error {
-		current, err := d.InspectRealm(ctx, nil)
+		current, err := d.InspectRealm(ctx, &schema.InspectRealmOption{Mode: schema.InspectAll})
 		if err != nil {
 			return err
 		}
@@ -164,17 +188,26 @@ func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 
 // CheckClean implements migrate.CleanChecker.
 func (d *Driver) CheckClean(ctx context.Context, revT *migrate.TableIdent) error {
-	s, err := d.InspectSchema(ctx, "", nil)
+	s, err := d.InspectSchema(ctx, "", &schema.InspectOptions{Mode: schema.InspectAll})
 	if err != nil && !schema.IsNotExistError(err) {
 		return err
 	}
 	if s != nil {
+		if len(s.Views) > 0 {
+			return &migrate.NotCleanError{Reason: fmt.Sprintf("found view %q in schema %q", s.Views[0].Name, s.Name)}
+		}
+		if len(s.Funcs) > 0 {
+			return &migrate.NotCleanError{Reason: fmt.Sprintf("found function %q in schema %q", s.Funcs[0].Name, s.Name)}
+		}
+		if len(s.Procs) > 0 {
+			return &migrate.NotCleanError{Reason: fmt.Sprintf("found procedure %q in schema %q", s.Procs[0].Name, s.Name)}
+		}
 		if len(s.Tables) == 0 || (revT != nil && (revT.Schema == "" || s.Name == revT.Schema) && len(s.Tables) == 1 && s.Tables[0].Name == revT.Name) {
 			return nil
 		}
 		return &migrate.NotCleanError{Reason: fmt.Sprintf("found table %q in schema %q", s.Tables[0].Name, s.Name)}
 	}
-	r, err := d.InspectRealm(ctx, nil)
+	r, err := d.InspectRealm(ctx, &schema.InspectRealmOption{Mode: schema.InspectAll})
 	if err != nil {
 		return err
 	}
@@ -336,6 +369,11 @@ const (
 	IndexTypeFullText = "FULLTEXT"
 	IndexTypeSpatial  = "SPATIAL"
 
+	PartitionTypeRange = "RANGE"
+	PartitionTypeList  = "LIST"
+	PartitionTypeHash  = "HASH"
+	PartitionTypeKey   = "KEY"
+
 	currentTS     = "current_timestamp"
 	defaultGen    = "default_generated"
 	autoIncrement = "auto_increment"
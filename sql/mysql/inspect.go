@@ -31,13 +31,36 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 		opts = &schema.InspectRealmOption{}
 	}
 	r := schema.NewRealm(schemas...).SetCharset(i.charset).SetCollation(i.collate)
-	if len(schemas) == 0 || !sqlx.ModeInspectRealm(opts).Is(schema.InspectTables) {
+	if len(schemas) == 0 {
 		return r, nil
 	}
-	if err := i.inspectTables(ctx, r, nil); err != nil {
-		return nil, err
+	mode := sqlx.ModeInspectRealm(opts)
+	if mode.Is(schema.InspectTables) {
+		if err := i.inspectTables(ctx, r, nil); err != nil {
+			return nil, err
+		}
+		sqlx.LinkSchemaTables(schemas)
+	}
+	if mode.Is(schema.InspectViews) {
+		if err := i.inspectViews(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectFuncs) {
+		if err := i.inspectFuncs(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTables) && mode.Is(schema.InspectTriggers) {
+		if err := i.inspectTriggers(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectRoles) {
+		if err := i.inspectRoles(ctx, r); err != nil {
+			return nil, err
+		}
 	}
-	sqlx.LinkSchemaTables(schemas)
 	return sqlx.ExcludeRealm(r, opts.Exclude)
 }
 
@@ -58,12 +81,29 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 		opts = &schema.InspectOptions{}
 	}
 	r := schema.NewRealm(schemas...).SetCharset(i.charset).SetCollation(i.collate)
-	if sqlx.ModeInspectSchema(opts).Is(schema.InspectTables) {
+	mode := sqlx.ModeInspectSchema(opts)
+	if mode.Is(schema.InspectTables) {
 		if err := i.inspectTables(ctx, r, opts); err != nil {
 			return nil, err
 		}
 		sqlx.LinkSchemaTables(schemas)
 	}
+	// Views and functions are skipped in case the inspection was limited to a set of tables.
+	if mode.Is(schema.InspectViews) && len(opts.Tables) == 0 {
+		if err := i.inspectViews(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectFuncs) && len(opts.Tables) == 0 {
+		if err := i.inspectFuncs(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTables) && mode.Is(schema.InspectTriggers) {
+		if err := i.inspectTriggers(ctx, r); err != nil {
+			return nil, err
+		}
+	}
 	return sqlx.ExcludeSchema(r.Schemas[0], opts.Exclude)
 }
 
@@ -87,6 +127,9 @@ func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *sche
 		if err := i.checks(ctx, s); err != nil {
 			return err
 		}
+		if err := i.partitions(ctx, s); err != nil {
+			return err
+		}
 		if err := i.showCreate(ctx, s); err != nil {
 			return err
 		}
@@ -94,6 +137,135 @@ func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *sche
 	return nil
 }
 
+func (i *inspect) inspectViews(ctx context.Context, r *schema.Realm) error {
+	for _, s := range r.Schemas {
+		if err := i.views(ctx, s); err != nil {
+			return err
+		}
+		if len(s.Views) == 0 {
+			continue
+		}
+		if err := i.viewColumns(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *inspect) inspectFuncs(ctx context.Context, r *schema.Realm) error {
+	for _, s := range r.Schemas {
+		if err := i.routines(ctx, s); err != nil {
+			return err
+		}
+		if len(s.Funcs) == 0 && len(s.Procs) == 0 {
+			continue
+		}
+		if err := i.routineParams(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *inspect) inspectTriggers(ctx context.Context, r *schema.Realm) error {
+	for _, s := range r.Schemas {
+		if len(s.Tables) == 0 {
+			continue
+		}
+		if err := i.triggers(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inspectRoles queries and appends the user accounts and roles of the database, and the
+// global privileges that were granted to them, and their privileges on the realm schemas.
+// Roles are named by their account names, formatted as "user@host".
+func (i *inspect) inspectRoles(ctx context.Context, r *schema.Realm) error {
+	query := myRolesQuery
+	if i.Maria() {
+		query = marRolesQuery
+	}
+	rows, err := i.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("mysql: querying roles: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var user, host string
+		ro := &schema.Role{}
+		if err := rows.Scan(&user, &host, &ro.Login); err != nil {
+			return fmt.Errorf("mysql: scanning role: %w", err)
+		}
+		ro.Name = user + "@" + host
+		r.AddRoles(ro)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if len(r.Roles) == 0 {
+		return nil
+	}
+	return i.privileges(ctx, r)
+}
+
+// privileges queries and appends the privileges of the realm roles.
+func (i *inspect) privileges(ctx context.Context, r *schema.Realm) error {
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	// Schema names are used by both the schema and the table privileges queries.
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(privilegesQuery, nArgs(len(args))), append(args, args...)...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying privileges: %w", err)
+	}
+	defer rows.Close()
+	var last *schema.Privilege
+	for rows.Next() {
+		var grantee, priv, grantable string
+		var ns, name sql.NullString
+		if err := rows.Scan(&grantee, &ns, &name, &priv, &grantable); err != nil {
+			return fmt.Errorf("mysql: scanning privilege: %w", err)
+		}
+		ro, ok := r.Role(roleName(grantee))
+		if !ok {
+			continue
+		}
+		p := &schema.Privilege{Grantee: ro, Grantable: strings.EqualFold(grantable, "YES")}
+		if ns.Valid {
+			if p.Schema, ok = r.Schema(ns.String); !ok {
+				continue
+			}
+		}
+		if name.Valid {
+			if p.Table, ok = p.Schema.Table(name.String); !ok {
+				if p.View, ok = p.Schema.View(name.String); !ok {
+					continue
+				}
+			}
+		}
+		// Rows are ordered by their grantee, object and grant option.
+		if last == nil || last.Grantee != p.Grantee || last.Schema != p.Schema || last.Table != p.Table || last.View != p.View || last.Grantable != p.Grantable {
+			last = p
+			r.AddPrivileges(last)
+		}
+		last.Privs = append(last.Privs, priv)
+	}
+	return rows.Close()
+}
+
+// roleName returns the role name of the given
+// grantee. e.g. 'user'@'host' returns user@host.
+func roleName(grantee string) string {
+	user, host, ok := strings.Cut(grantee, "'@'")
+	if !ok {
+		return grantee
+	}
+	return strings.TrimPrefix(user, "'") + "@" + strings.TrimSuffix(host, "'")
+}
+
 // schemas returns the list of the schemas in the database.
 func (i *inspect) schemas(ctx context.Context, opts *schema.InspectRealmOption) ([]*schema.Schema, error) {
 	var (
@@ -205,6 +377,165 @@ func (i *inspect) tables(ctx context.Context, realm *schema.Realm, opts *schema.
 	return rows.Close()
 }
 
+// views queries and appends the views of the given schema.
+func (i *inspect) views(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, viewsQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("mysql: query schema %q views: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, def, option sql.NullString
+		if err := rows.Scan(&name, &def, &option); err != nil {
+			return fmt.Errorf("mysql: scan view information: %w", err)
+		}
+		v := schema.NewView(name.String, strings.TrimSpace(def.String))
+		s.AddViews(v)
+		if sqlx.ValidString(option) && option.String != schema.ViewCheckOptionNone {
+			v.SetCheckOption(option.String)
+		}
+	}
+	return rows.Close()
+}
+
+// viewColumns queries and appends the columns of the schema views.
+func (i *inspect) viewColumns(ctx context.Context, s *schema.Schema) error {
+	args := []any{s.Name}
+	for _, v := range s.Views {
+		args = append(args, v.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(viewColumnsQuery, nArgs(len(s.Views))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: query schema %q view columns: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var view, name, typ, nullable string
+		if err := rows.Scan(&view, &name, &typ, &nullable); err != nil {
+			return fmt.Errorf("mysql: scan view column: %w", err)
+		}
+		v, ok := s.View(view)
+		if !ok {
+			return fmt.Errorf("mysql: view %q was not found in schema", view)
+		}
+		t, err := ParseType(typ)
+		if err != nil {
+			t = &schema.UnsupportedType{T: typ}
+		}
+		v.AddColumns(&schema.Column{
+			Name: name,
+			Type: &schema.ColumnType{Type: t, Raw: typ, Null: nullable == "YES"},
+		})
+	}
+	return rows.Close()
+}
+
+// routines queries and appends the functions and procedures of the given schema.
+func (i *inspect) routines(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, routinesQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("mysql: query schema %q routines: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, typ, ret, body, deterministic, comment sql.NullString
+		if err := rows.Scan(&name, &typ, &ret, &body, &deterministic, &comment); err != nil {
+			return fmt.Errorf("mysql: scan routine information: %w", err)
+		}
+		var attrs []schema.Attr
+		if deterministic.String == "YES" {
+			attrs = append(attrs, &Deterministic{V: true})
+		}
+		if sqlx.ValidString(comment) {
+			attrs = append(attrs, &schema.Comment{Text: comment.String})
+		}
+		switch typ.String {
+		case "PROCEDURE":
+			s.AddProcs(schema.NewProc(name.String).SetBody(strings.TrimSpace(body.String)).AddAttrs(attrs...))
+		default:
+			f := schema.NewFunc(name.String).SetBody(strings.TrimSpace(body.String)).AddAttrs(attrs...)
+			if sqlx.ValidString(ret) {
+				f.SetReturnType(routineType(ret.String))
+			}
+			s.AddFuncs(f)
+		}
+	}
+	return rows.Close()
+}
+
+// triggers queries and appends the triggers of the schema tables.
+func (i *inspect) triggers(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, triggersQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("mysql: query schema %q triggers: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, table, timing, event, orientation, body string
+		if err := rows.Scan(&name, &table, &timing, &event, &orientation, &body); err != nil {
+			return fmt.Errorf("mysql: scan trigger information: %w", err)
+		}
+		t, ok := s.Table(table)
+		// Skip triggers of tables that were not inspected.
+		if !ok {
+			continue
+		}
+		t.AddTriggers(
+			schema.NewTrigger(name).
+				SetActionTime(timing).
+				AddEvents(schema.TriggerEvent{Name: event}).
+				SetFor(orientation).
+				SetBody(strings.TrimSpace(body)),
+		)
+	}
+	return rows.Close()
+}
+
+// routineParams queries and appends the parameters of the schema functions and procedures.
+func (i *inspect) routineParams(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, routineParamsQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("mysql: query schema %q routine parameters: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, typ, mode, param, dtd sql.NullString
+		if err := rows.Scan(&name, &typ, &mode, &param, &dtd); err != nil {
+			return fmt.Errorf("mysql: scan routine parameter: %w", err)
+		}
+		a := schema.NewFuncArg(param.String, routineType(dtd.String))
+		// IN is the default mode of procedure parameters,
+		// and function parameters are always IN parameters.
+		if sqlx.ValidString(mode) && mode.String != schema.FuncArgModeIn {
+			a.SetMode(mode.String)
+		}
+		switch typ.String {
+		case "PROCEDURE":
+			p, ok := s.Proc(name.String)
+			if !ok {
+				return fmt.Errorf("mysql: procedure %q was not found in schema", name.String)
+			}
+			p.AddArgs(a)
+		default:
+			f, ok := s.Func(name.String)
+			if !ok {
+				return fmt.Errorf("mysql: function %q was not found in schema", name.String)
+			}
+			f.AddArgs(a)
+		}
+	}
+	return rows.Close()
+}
+
+// routineType returns the schema.Type of a routine parameter or its return type.
+func routineType(t string) schema.Type {
+	typ, err := ParseType(t)
+	if err != nil {
+		return &schema.UnsupportedType{T: t}
+	}
+	return typ
+}
+
 // columns queries and appends the columns of the given table.
 func (i *inspect) columns(ctx context.Context, s *schema.Schema) error {
 	query := columnsQuery
@@ -448,6 +779,120 @@ func (i *inspect) checks(ctx context.Context, s *schema.Schema) error {
 	return rows.Err()
 }
 
+// partitions queries and appends the partitioning of the schema tables.
+func (i *inspect) partitions(ctx context.Context, s *schema.Schema) error {
+	args := []any{s.Name}
+	for _, t := range s.Tables {
+		if partitioned(t) {
+			args = append(args, t.Name)
+		}
+	}
+	// No partitioned tables in schema.
+	if len(args) == 1 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(partitionsQuery, nArgs(len(args)-1)), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying %q partitions: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, name, subName, method, subMethod, expr, subExpr, values, comment sql.NullString
+		if err := rows.Scan(&table, &name, &subName, &method, &subMethod, &expr, &subExpr, &values, &comment); err != nil {
+			return fmt.Errorf("mysql: %w", err)
+		}
+		t, ok := s.Table(table.String)
+		if !ok {
+			return fmt.Errorf("table %q was not found in schema", table.String)
+		}
+		var p *Partition
+		for _, a := range t.Attrs {
+			if tp, ok := a.(*Partition); ok {
+				p = tp
+			}
+		}
+		if p == nil {
+			p = &Partition{}
+			if err := p.Key.scan(t, method.String, expr.String); err != nil {
+				return err
+			}
+			if sqlx.ValidString(subMethod) {
+				p.Sub = &PartitionKey{}
+				if err := p.Sub.scan(t, subMethod.String, subExpr.String); err != nil {
+					return err
+				}
+			}
+			t.Attrs = append(t.Attrs, p)
+		}
+		if n := len(p.Defs); n == 0 || p.Defs[n-1].Name != name.String {
+			p.Defs = append(p.Defs, &PartitionDef{Name: name.String, Values: values.String, Comment: comment.String})
+		}
+		if sqlx.ValidString(subName) {
+			d := p.Defs[len(p.Defs)-1]
+			d.Subs = append(d.Subs, subName.String)
+		}
+	}
+	return rows.Close()
+}
+
+// partitioned reports if the table is partitioned, and removes the "partitioned"
+// flag from its CREATE_OPTIONS, as it is not a valid table option.
+func partitioned(t *schema.Table) bool {
+	for i, a := range t.Attrs {
+		o, ok := a.(*CreateOptions)
+		if !ok {
+			continue
+		}
+		var (
+			found bool
+			opts  []string
+		)
+		for _, f := range strings.Fields(o.V) {
+			if strings.EqualFold(f, "partitioned") {
+				found = true
+			} else {
+				opts = append(opts, f)
+			}
+		}
+		switch {
+		case !found:
+		case len(opts) == 0:
+			t.Attrs = append(t.Attrs[:i], t.Attrs[i+1:]...)
+		default:
+			o.V = strings.Join(opts, " ")
+		}
+		return found
+	}
+	return false
+}
+
+// scan sets the partitioning key from its inspected method and expression.
+// e.g. "LINEAR HASH" and "year(`c`)", or "RANGE COLUMNS" and "`a`,`b`".
+func (k *PartitionKey) scan(t *schema.Table, method, expr string) error {
+	method = strings.ToUpper(method)
+	if strings.HasPrefix(method, "LINEAR ") {
+		k.Linear, method = true, strings.TrimPrefix(method, "LINEAR ")
+	}
+	k.T = strings.TrimSuffix(method, " COLUMNS")
+	// The expression holds a list of columns
+	// for KEY and COLUMNS partitioning.
+	if k.T != PartitionTypeKey && k.T == method {
+		k.Expr = expr
+		return nil
+	}
+	for _, n := range strings.Split(expr, ",") {
+		if n = strings.Trim(strings.TrimSpace(n), "`"); n == "" {
+			continue
+		}
+		c, ok := t.Column(n)
+		if !ok {
+			return fmt.Errorf("mysql: partition column %q was not found in table %q", n, t.Name)
+		}
+		k.Columns = append(k.Columns, c)
+	}
+	return nil
+}
+
 // supportsCheck reports if the connected database supports
 // the CHECK clause, and return the querying for getting them.
 func (i *inspect) supportsCheck() (string, bool) {
@@ -708,6 +1153,7 @@ FROM
 	ON t1.TABLE_COLLATION = t2.COLLATION_NAME
 WHERE
 	TABLE_SCHEMA IN (%s)
+	AND t1.TABLE_TYPE = 'BASE TABLE'
 ORDER BY
 	TABLE_SCHEMA, TABLE_NAME
 `
@@ -728,11 +1174,40 @@ FROM
 WHERE
 	TABLE_SCHEMA IN (%s)
 	AND TABLE_NAME IN (%s)
+	AND t1.TABLE_TYPE = 'BASE TABLE'
 ORDER BY
 	TABLE_SCHEMA, TABLE_NAME
 `
 
+	// Query to list schema views.
+	viewsQuery = "SELECT `TABLE_NAME`, `VIEW_DEFINITION`, `CHECK_OPTION` FROM `INFORMATION_SCHEMA`.`VIEWS` WHERE `TABLE_SCHEMA` = ? ORDER BY `TABLE_NAME`"
+
+	// Query to list view columns.
+	viewColumnsQuery = "SELECT `TABLE_NAME`, `COLUMN_NAME`, `COLUMN_TYPE`, `IS_NULLABLE` FROM `INFORMATION_SCHEMA`.`COLUMNS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` IN (%s) ORDER BY `TABLE_NAME`, `ORDINAL_POSITION`"
+
+	// Query to list schema functions and procedures.
+	routinesQuery = "SELECT `ROUTINE_NAME`, `ROUTINE_TYPE`, `DTD_IDENTIFIER`, `ROUTINE_DEFINITION`, `IS_DETERMINISTIC`, `ROUTINE_COMMENT` FROM `INFORMATION_SCHEMA`.`ROUTINES` WHERE `ROUTINE_SCHEMA` = ? ORDER BY `ROUTINE_NAME`"
+
+	// Query to list the parameters of functions and procedures. The return value of functions is skipped.
+	routineParamsQuery = "SELECT `SPECIFIC_NAME`, `ROUTINE_TYPE`, `PARAMETER_MODE`, `PARAMETER_NAME`, `DTD_IDENTIFIER` FROM `INFORMATION_SCHEMA`.`PARAMETERS` WHERE `SPECIFIC_SCHEMA` = ? AND `ORDINAL_POSITION` > 0 ORDER BY `SPECIFIC_NAME`, `ORDINAL_POSITION`"
+
+	// Query to list table triggers.
+	triggersQuery = "SELECT `TRIGGER_NAME`, `EVENT_OBJECT_TABLE`, `ACTION_TIMING`, `EVENT_MANIPULATION`, `ACTION_ORIENTATION`, `ACTION_STATEMENT` FROM `INFORMATION_SCHEMA`.`TRIGGERS` WHERE `TRIGGER_SCHEMA` = ? ORDER BY `EVENT_OBJECT_TABLE`, `ACTION_ORDER`"
+
 	// Query to list table check constraints.
+	// Query to list the user accounts and roles of the database. The built-in
+	// accounts and root are ignored. Roles are accounts that are locked.
+	myRolesQuery = "SELECT `User`, `Host`, `account_locked` = 'N' AS `login` FROM `mysql`.`user` WHERE `User` NOT IN ('', 'root') AND `User` NOT LIKE 'mysql.%' ORDER BY `User`, `Host`"
+
+	// Query to list the user accounts and roles of a MariaDB database.
+	marRolesQuery = "SELECT `User`, `Host`, `is_role` = 'N' AS `login` FROM `mysql`.`user` WHERE `User` NOT IN ('', 'root') AND `User` NOT LIKE 'mysql.%' ORDER BY `User`, `Host`"
+
+	// Query to list the global privileges of the accounts, and their privileges on the given schemas and their tables.
+	privilegesQuery = "SELECT `GRANTEE`, NULL AS `TABLE_SCHEMA`, NULL AS `TABLE_NAME`, `PRIVILEGE_TYPE`, `IS_GRANTABLE` FROM `INFORMATION_SCHEMA`.`USER_PRIVILEGES` WHERE `PRIVILEGE_TYPE` <> 'USAGE' " +
+		"UNION ALL SELECT `GRANTEE`, `TABLE_SCHEMA`, NULL AS `TABLE_NAME`, `PRIVILEGE_TYPE`, `IS_GRANTABLE` FROM `INFORMATION_SCHEMA`.`SCHEMA_PRIVILEGES` WHERE `TABLE_SCHEMA` IN (%[1]s) " +
+		"UNION ALL SELECT `GRANTEE`, `TABLE_SCHEMA`, `TABLE_NAME`, `PRIVILEGE_TYPE`, `IS_GRANTABLE` FROM `INFORMATION_SCHEMA`.`TABLE_PRIVILEGES` WHERE `TABLE_SCHEMA` IN (%[1]s) " +
+		"ORDER BY 1, 2, 3, 5, 4"
+
 	myChecksQuery  = `SELECT t1.TABLE_NAME, t1.CONSTRAINT_NAME, t2.CHECK_CLAUSE, t1.ENFORCED` + checksQuery
 	marChecksQuery = `SELECT t1.TABLE_NAME, t1.CONSTRAINT_NAME, t2.CHECK_CLAUSE, "YES" AS ENFORCED` + checksQuery
 	checksQuery    = `
@@ -749,6 +1224,9 @@ ORDER BY
 	t1.CONSTRAINT_NAME
 `
 
+	// Query to list the partitions and subpartitions of tables.
+	partitionsQuery = "SELECT `TABLE_NAME`, `PARTITION_NAME`, `SUBPARTITION_NAME`, `PARTITION_METHOD`, `SUBPARTITION_METHOD`, `PARTITION_EXPRESSION`, `SUBPARTITION_EXPRESSION`, `PARTITION_DESCRIPTION`, `PARTITION_COMMENT` FROM `INFORMATION_SCHEMA`.`PARTITIONS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` IN (%s) AND `PARTITION_NAME` IS NOT NULL ORDER BY `TABLE_NAME`, `PARTITION_ORDINAL_POSITION`, `SUBPARTITION_ORDINAL_POSITION`"
+
 	// Query to list table foreign keys.
 	fksQuery = `
 SELECT
@@ -829,12 +1307,58 @@ type (
 		A string
 	}
 
+	// Deterministic attribute defines the DETERMINISTIC characteristic
+	// of functions and procedures.
+	Deterministic struct {
+		schema.Attr
+		V bool // V indicates if the routine is deterministic or not.
+	}
+
 	// IndexType represents an index type.
 	IndexType struct {
 		schema.Attr
 		T string // BTREE, HASH, FULLTEXT, SPATIAL, RTREE
 	}
 
+	// Partition describes the partitioning of a table (PARTITION BY clause).
+	// https://dev.mysql.com/doc/refman/8.0/en/partitioning-types.html
+	Partition struct {
+		schema.Attr
+		// Key describes how rows are assigned to partitions.
+		Key PartitionKey
+		// Sub describes the SUBPARTITION BY clause, if exists.
+		Sub *PartitionKey
+		// Defs holds the partition definitions. The definitions of
+		// HASH and KEY partitioned tables hold only their names.
+		Defs []*PartitionDef
+	}
+
+	// PartitionKey describes the partitioning type and its key.
+	PartitionKey struct {
+		// T defines the type of the partitioning.
+		// Can be one of: RANGE, LIST, HASH or KEY.
+		T string
+		// Linear indicates a LINEAR HASH or KEY partitioning.
+		Linear bool
+		// Expr is the partitioning expression. It is empty in case
+		// of KEY, RANGE COLUMNS and LIST COLUMNS partitioning.
+		Expr string
+		// Columns holds the columns of KEY, RANGE COLUMNS
+		// and LIST COLUMNS partitioning.
+		Columns []*schema.Column
+	}
+
+	// PartitionDef describes a partition definition.
+	PartitionDef struct {
+		Name string
+		// Values holds the VALUES LESS THAN (RANGE) or the
+		// VALUES IN (LIST) values. e.g. "10", "MAXVALUE", "1,2,3".
+		Values  string
+		Comment string
+		// Subs holds the names of the subpartitions, if exist.
+		Subs []string
+	}
+
 	// BitType represents a bit type.
 	BitType struct {
 		schema.Type
@@ -94,6 +94,59 @@ func TestDriver_InspectTable(t *testing.T) {
 				}, t.Columns)
 			},
 		},
+		{
+			name: "partitions",
+			before: func(m mock) {
+				m.ExpectQuery(queryTable).
+					WithArgs("public").
+					WillReturnRows(sqltest.Rows(`
++--------------+--------------+--------------------+--------------------+----------------+---------------+--------------------+
+| TABLE_SCHEMA | TABLE_NAME   | CHARACTER_SET_NAME | TABLE_COLLATION    | AUTO_INCREMENT | TABLE_COMMENT | CREATE_OPTIONS     |
++--------------+--------------+--------------------+--------------------+----------------+---------------+--------------------+
+| public       | events       | utf8mb4            | utf8mb4_0900_ai_ci | nil            |               | partitioned        |
++--------------+--------------+--------------------+--------------------+----------------+---------------+--------------------+
+`))
+				m.ExpectQuery(queryColumns).
+					WithArgs("public", "events").
+					WillReturnRows(sqltest.Rows(`
++--------------------+--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+---------------------------+
+| table_name         | column_name        | column_type          | column_comment       | is_nullable | column_key | column_default | extra          | character_set_name | collation_name | generation_expression     |
++--------------------+--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+---------------------------+
+| events             | id                 | int                  |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                      |
+| events             | created            | date                 |                      | NO          |            | NULL           |                | NULL               | NULL           | NULL                      |
++--------------------+--------------------+----------------------+----------------------+-------------+------------+----------------+----------------+--------------------+----------------+---------------------------+
+`))
+				m.ExpectQuery(queryIndexesExpr).
+					WithArgs("public", "events").
+					WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "non_unique", "key_part", "expression"}))
+				m.noFKs()
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(partitionsQuery, "?"))).
+					WithArgs("public", "events").
+					WillReturnRows(
+						sqlmock.NewRows([]string{"TABLE_NAME", "PARTITION_NAME", "SUBPARTITION_NAME", "PARTITION_METHOD", "SUBPARTITION_METHOD", "PARTITION_EXPRESSION", "SUBPARTITION_EXPRESSION", "PARTITION_DESCRIPTION", "PARTITION_COMMENT"}).
+							AddRow("events", "p0", "p0sp0", "RANGE", "LINEAR KEY", "year(`created`)", "`id`", "2020", "old").
+							AddRow("events", "p0", "p0sp1", "RANGE", "LINEAR KEY", "year(`created`)", "`id`", "2020", "old").
+							AddRow("events", "p1", "p1sp0", "RANGE", "LINEAR KEY", "year(`created`)", "`id`", "MAXVALUE", "").
+							AddRow("events", "p1", "p1sp1", "RANGE", "LINEAR KEY", "year(`created`)", "`id`", "MAXVALUE", ""),
+					)
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal("events", t.Name)
+				require.EqualValues([]schema.Attr{
+					&schema.Charset{V: "utf8mb4"},
+					&schema.Collation{V: "utf8mb4_0900_ai_ci"},
+					&Partition{
+						Key: PartitionKey{T: PartitionTypeRange, Expr: "year(`created`)"},
+						Sub: &PartitionKey{T: PartitionTypeKey, Linear: true, Columns: t.Columns[:1]},
+						Defs: []*PartitionDef{
+							{Name: "p0", Values: "2020", Comment: "old", Subs: []string{"p0sp0", "p0sp1"}},
+							{Name: "p1", Values: "MAXVALUE", Subs: []string{"p1sp0", "p1sp1"}},
+						},
+					},
+				}, t.Attrs)
+			},
+		},
 		{
 			name: "int types",
 			before: func(m mock) {
@@ -548,15 +601,6 @@ func TestDriver_InspectTable(t *testing.T) {
 +--------------+--------------+-------------+------------+--------------+--------------+---------+--------------+------------+------------------+
 `))
 				m.noFKs()
-				m.ExpectQuery(sqltest.Escape("SHOW CREATE TABLE `public`.`users`")).
-					WillReturnRows(sqltest.Rows(`
-+-------+---------------------------------------------------------------------------------------------------------------------------------------------+
-| Table | Create Table                                                                                                                                |
-+-------+---------------------------------------------------------------------------------------------------------------------------------------------+
-+-------+---------------------------------------------------------------------------------------------------------------------------------------------+
-| users | CREATE TABLE users (id bigint NOT NULL AUTO_INCREMENT) ENGINE=InnoDB AUTO_INCREMENT=55834574848 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin |
-+-------+---------------------------------------------------------------------------------------------------------------------------------------------+
-`))
 			},
 			expect: func(require *require.Assertions, t *schema.Table, err error) {
 				require.NoError(err)
@@ -702,14 +746,6 @@ func TestDriver_InspectTable(t *testing.T) {
 | users             | users_chk_4       | (c1 <> in (_latin1\'usa\',_latin1\'uk\')) |  YES       |
 | users             | users_chk_5       | (c1 <> _latin1\'\\\\\\\\\\\'\\\'\')       |  YES       |
 +-------------------+-------------------+-------------------------------------------+------------+
-`))
-				m.ExpectQuery(sqltest.Escape("SHOW CREATE TABLE `public`.`users`")).
-					WillReturnRows(sqltest.Rows(`
-+-------+------------------------+
-| Table | Create Table           |
-+-------+------------------------+
-| users | CREATE TABLE users()   |
-+-------+------------------------+
 `))
 			},
 			expect: func(require *require.Assertions, t *schema.Table, err error) {
@@ -881,6 +917,169 @@ func TestDriver_InspectSchema(t *testing.T) {
 				require.EqualValues(petsFKs, pets.ForeignKeys)
 			},
 		},
+		{
+			name:   "views",
+			schema: "public",
+			opts:   &schema.InspectOptions{Mode: schema.InspectAll},
+			before: func(m mock) {
+				m.version("8.0.13")
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= ?"))).
+					WithArgs("public").
+					WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| public      | utf8mb4                    | utf8mb4_unicode_ci     |
++-------------+----------------------------+------------------------+
+`))
+				m.tables("public")
+				m.ExpectQuery(sqltest.Escape(viewsQuery)).
+					WithArgs("public").
+					WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "VIEW_DEFINITION", "CHECK_OPTION"}).
+						AddRow("active", "select `public`.`users`.`id` AS `id` from `public`.`users`", "NONE").
+						AddRow("checked", "select `public`.`users`.`id` AS `id` from `public`.`users`", "CASCADED"))
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewColumnsQuery, "?, ?"))).
+					WithArgs("public", "active", "checked").
+					WillReturnRows(sqltest.Rows(`
++------------+-------------+-------------+-------------+
+| TABLE_NAME | COLUMN_NAME | COLUMN_TYPE | IS_NULLABLE |
++------------+-------------+-------------+-------------+
+| active     | id          | int         | NO          |
+| checked    | id          | int         | YES         |
++------------+-------------+-------------+-------------+
+`))
+				m.noFuncs("public")
+			},
+			expect: func(require *require.Assertions, s *schema.Schema, err error) {
+				require.NoError(err)
+				require.Empty(s.Tables)
+				require.Len(s.Views, 2)
+				active, checked := s.Views[0], s.Views[1]
+				require.Equal("active", active.Name)
+				require.Equal(s, active.Schema)
+				require.Equal("select `public`.`users`.`id` AS `id` from `public`.`users`", active.Def)
+				require.Empty(active.Attrs)
+				require.EqualValues([]*schema.Column{
+					{Name: "id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}}},
+				}, active.Columns)
+				require.Equal("checked", checked.Name)
+				require.Equal([]schema.Attr{&schema.ViewCheckOption{V: schema.ViewCheckOptionCascaded}}, checked.Attrs)
+				require.EqualValues([]*schema.Column{
+					{Name: "id", Type: &schema.ColumnType{Raw: "int", Type: &schema.IntegerType{T: "int"}, Null: true}},
+				}, checked.Columns)
+			},
+		},
+		{
+			name:   "routines",
+			schema: "public",
+			opts:   &schema.InspectOptions{Mode: schema.InspectAll},
+			before: func(m mock) {
+				m.version("8.0.13")
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= ?"))).
+					WithArgs("public").
+					WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| public      | utf8mb4                    | utf8mb4_unicode_ci     |
++-------------+----------------------------+------------------------+
+`))
+				m.tables("public")
+				m.noViews("public")
+				m.ExpectQuery(sqltest.Escape(routinesQuery)).
+					WithArgs("public").
+					WillReturnRows(sqlmock.NewRows([]string{"ROUTINE_NAME", "ROUTINE_TYPE", "DTD_IDENTIFIER", "ROUTINE_DEFINITION", "IS_DETERMINISTIC", "ROUTINE_COMMENT"}).
+						AddRow("add", "FUNCTION", "int", "RETURN a + b", "YES", "adds numbers").
+						AddRow("archive", "PROCEDURE", nil, "BEGIN SELECT id INTO n FROM users; END", "NO", ""))
+				m.ExpectQuery(sqltest.Escape(routineParamsQuery)).
+					WithArgs("public").
+					WillReturnRows(sqltest.Rows(`
++---------------+--------------+----------------+----------------+----------------+
+| SPECIFIC_NAME | ROUTINE_TYPE | PARAMETER_MODE | PARAMETER_NAME | DTD_IDENTIFIER |
++---------------+--------------+----------------+----------------+----------------+
+| add           | FUNCTION     | NULL           | a              | int            |
+| add           | FUNCTION     | NULL           | b              | int            |
+| archive       | PROCEDURE    | IN             | id             | bigint         |
+| archive       | PROCEDURE    | OUT            | n              | varchar(255)   |
++---------------+--------------+----------------+----------------+----------------+
+`))
+			},
+			expect: func(require *require.Assertions, s *schema.Schema, err error) {
+				require.NoError(err)
+				require.Len(s.Funcs, 1)
+				require.Len(s.Procs, 1)
+				add, archive := s.Funcs[0], s.Procs[0]
+				require.Equal(s, add.Schema)
+				require.Equal("RETURN a + b", add.Body)
+				require.Equal(&schema.IntegerType{T: "int"}, add.Ret)
+				require.Equal([]*schema.FuncArg{
+					{Name: "a", Type: &schema.IntegerType{T: "int"}},
+					{Name: "b", Type: &schema.IntegerType{T: "int"}},
+				}, add.Args)
+				require.Equal([]schema.Attr{&Deterministic{V: true}, &schema.Comment{Text: "adds numbers"}}, add.Attrs)
+				require.Equal("archive", archive.Name)
+				require.Equal([]*schema.FuncArg{
+					{Name: "id", Type: &schema.IntegerType{T: "bigint"}},
+					{Name: "n", Type: &schema.StringType{T: "varchar", Size: 255}, Mode: schema.FuncArgModeOut},
+				}, archive.Args)
+				require.Empty(archive.Attrs)
+			},
+		},
+		{
+			name:   "triggers",
+			schema: "public",
+			opts:   &schema.InspectOptions{Mode: schema.InspectAll},
+			before: func(m mock) {
+				m.version("8.0.13")
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= ?"))).
+					WithArgs("public").
+					WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| public      | utf8mb4                    | utf8mb4_unicode_ci     |
++-------------+----------------------------+------------------------+
+`))
+				m.tables("public", "users")
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsExprQuery, "?"))).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
++-------------+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+---------------------------+
+| TABLE_NAME  | COLUMN_NAME | COLUMN_TYPE  | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA          | CHARACTER_SET_NAME | COLLATION_NAME     | GENERATION_EXPRESSION     |
++-------------+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+---------------------------+
+| users       | id          | int          |                | NO          | PRI        | NULL           |                | NULL               | NULL               | NULL                      |
++-------------+-------------+--------------+----------------+-------------+------------+----------------+----------------+--------------------+--------------------+---------------------------+
+`))
+				m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesExprQuery, "?"))).
+					WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "non_unique", "key_part", "expression"}))
+				m.noFKs()
+				m.noViews("public")
+				m.noFuncs("public")
+				m.ExpectQuery(sqltest.Escape(triggersQuery)).
+					WithArgs("public").
+					WillReturnRows(sqlmock.NewRows([]string{"TRIGGER_NAME", "EVENT_OBJECT_TABLE", "ACTION_TIMING", "EVENT_MANIPULATION", "ACTION_ORIENTATION", "ACTION_STATEMENT"}).
+						AddRow("users_bi", "users", "BEFORE", "INSERT", "ROW", "SET NEW.id = NEW.id + 1").
+						AddRow("users_au", "users", "AFTER", "UPDATE", "ROW", "BEGIN\n  INSERT INTO logs VALUES (NEW.id);\nEND").
+						AddRow("pets_bi", "pets", "BEFORE", "INSERT", "ROW", "SET NEW.id = 1"))
+			},
+			expect: func(require *require.Assertions, s *schema.Schema, err error) {
+				require.NoError(err)
+				require.Len(s.Tables, 1)
+				users := s.Tables[0]
+				require.Len(users.Triggers, 2)
+				bi, au := users.Triggers[0], users.Triggers[1]
+				require.Equal(users, bi.Table)
+				require.Equal("users_bi", bi.Name)
+				require.Equal(schema.TriggerTimeBefore, bi.ActionTime)
+				require.Equal([]schema.TriggerEvent{{Name: schema.TriggerEventInsert}}, bi.Events)
+				require.Equal(schema.TriggerForRow, bi.For)
+				require.Equal("SET NEW.id = NEW.id + 1", bi.Body)
+				require.Equal("users_au", au.Name)
+				require.Equal(schema.TriggerTimeAfter, au.ActionTime)
+				require.Equal([]schema.TriggerEvent{{Name: schema.TriggerEventUpdate}}, au.Events)
+				require.Equal("BEGIN\n  INSERT INTO logs VALUES (NEW.id);\nEND", au.Body)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1030,6 +1229,65 @@ func TestInspectMode_InspectRealm(t *testing.T) {
 	}(), realm)
 }
 
+func TestDriver_InspectRoles(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.13")
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= ?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| test        | utf8mb4                    | utf8mb4_bin            |
++-------------+----------------------------+------------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(myRolesQuery)).
+		WillReturnRows(sqltest.Rows(`
++---------+-----------+-------+
+| User    | Host      | login |
++---------+-----------+-------+
+| app     | %         | 1     |
+| app     | localhost | 1     |
+| readers | %         | 0     |
++---------+-----------+-------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(privilegesQuery, "?"))).
+		WithArgs("test", "test").
+		WillReturnRows(sqltest.Rows(`
++-----------------+--------------+------------+----------------+--------------+
+| GRANTEE         | TABLE_SCHEMA | TABLE_NAME | PRIVILEGE_TYPE | IS_GRANTABLE |
++-----------------+--------------+------------+----------------+--------------+
+| 'app'@'%'       | NULL         | NULL       | PROCESS        | NO           |
+| 'app'@'%'       | test         | NULL       | INSERT         | YES          |
+| 'app'@'%'       | test         | NULL       | SELECT         | YES          |
+| 'readers'@'%'   | test         | NULL       | SELECT         | NO           |
+| 'readers'@'%'   | test         | users      | SELECT         | NO           |
+| 'root'@'%'      | NULL         | NULL       | SELECT         | YES          |
++-----------------+--------------+------------+----------------+--------------+
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	realm, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{
+		Schemas: []string{"test"},
+		Mode:    schema.InspectSchemas | schema.InspectRoles,
+	})
+	require.NoError(t, err)
+	require.Len(t, realm.Roles, 3)
+	app, readers := realm.Roles[0], realm.Roles[2]
+	require.Equal(t, &schema.Role{Name: "app@%", Realm: realm, Login: true}, app)
+	require.Equal(t, &schema.Role{Name: "app@localhost", Realm: realm, Login: true}, realm.Roles[1])
+	require.Equal(t, &schema.Role{Name: "readers@%", Realm: realm}, readers)
+	// Privileges of unknown accounts, or on tables that were not inspected, are ignored.
+	require.Equal(t, []*schema.Privilege{
+		{Grantee: app, Privs: []string{"PROCESS"}},
+		{Grantee: app, Schema: realm.Schemas[0], Privs: []string{"INSERT", "SELECT"}, Grantable: true},
+		{Grantee: readers, Schema: realm.Schemas[0], Privs: []string{"SELECT"}},
+	}, realm.Privileges)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
 type mock struct {
 	sqlmock.Sqlmock
 }
@@ -1055,6 +1313,24 @@ func (m mock) noFKs() {
 		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "CONSTRAINT_NAME", "TABLE_NAME", "COLUMN_NAME", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME", "REFERENCED_TABLE_SCHEMA", "UPDATE_RULE", "DELETE_RULE"}))
 }
 
+func (m mock) noViews(schema string) {
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "VIEW_DEFINITION", "CHECK_OPTION"}))
+}
+
+func (m mock) noFuncs(schema string) {
+	m.ExpectQuery(sqltest.Escape(routinesQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"ROUTINE_NAME", "ROUTINE_TYPE", "DTD_IDENTIFIER", "ROUTINE_DEFINITION", "IS_DETERMINISTIC", "ROUTINE_COMMENT"}))
+}
+
+func (m mock) noTriggers(schema string) {
+	m.ExpectQuery(sqltest.Escape(triggersQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"TRIGGER_NAME", "EVENT_OBJECT_TABLE", "ACTION_TIMING", "EVENT_MANIPULATION", "ACTION_ORIENTATION", "ACTION_STATEMENT"}))
+}
+
 func (m mock) tableExists(schema, table string, exists bool) {
 	rows := sqlmock.NewRows([]string{"table_schema", "table_name", "table_collation", "character_set", "auto_increment", "table_comment", "create_options"})
 	if exists {
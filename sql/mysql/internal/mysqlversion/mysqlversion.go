@@ -84,6 +84,52 @@ func (v V) SupportsIndexComment() bool {
 	return v.Maria() || v.GTE("5.5.3")
 }
 
+// SupportsReplaceRoutine reports if the version supports the
+// "CREATE OR REPLACE" syntax for functions and procedures.
+func (v V) SupportsReplaceRoutine() bool {
+	return v.Maria() && v.GTE("10.1.3")
+}
+
+// SupportsOnlineDDL reports if the version supports the
+// ALGORITHM and LOCK clauses in ALTER TABLE statements.
+func (v V) SupportsOnlineDDL() bool {
+	u := "5.6"
+	if v.Maria() {
+		u = "10.0"
+	}
+	return !v.TiDB() && v.GTE(u)
+}
+
+// SupportsInstantDDL reports if the version supports the INSTANT algorithm,
+// for example, for adding columns at the end of the table or changing defaults.
+func (v V) SupportsInstantDDL() bool {
+	u := "8.0.12"
+	if v.Maria() {
+		u = "10.3.2"
+	}
+	return v.GTE(u)
+}
+
+// SupportsInstantAnyPosition reports if the version supports adding, dropping
+// or moving columns at any position of the table using the INSTANT algorithm.
+func (v V) SupportsInstantAnyPosition() bool {
+	u := "8.0.29"
+	if v.Maria() {
+		u = "10.4"
+	}
+	return v.GTE(u)
+}
+
+// SupportsInstantRenameColumn reports if the version
+// supports renaming columns using the INSTANT algorithm.
+func (v V) SupportsInstantRenameColumn() bool {
+	u := "8.0.28"
+	if v.Maria() {
+		u = "10.5.2"
+	}
+	return v.GTE(u)
+}
+
 // CharsetToCollate returns the mapping from charset to its default collation.
 func (v V) CharsetToCollate() (map[string]string, error) {
 	name := "is/charset2collate"
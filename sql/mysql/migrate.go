@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -76,12 +77,44 @@ func (s *state) plan(changes []schema.Change) error {
 	if err != nil {
 		return err
 	}
+	rbefore, planned, rafter := sqlx.DetachRoles(planned)
+	before, planned, after := sqlx.DetachViews(planned)
+	fbefore, planned, fafter := sqlx.DetachFuncs(planned)
 	planned, err = sqlx.DetachCycles(planned)
 	if err != nil {
 		return err
 	}
-	for _, c := range planned {
+	planned = sqlx.MergeTableChanges(planned)
+	for _, c := range concat(rbefore, before, fbefore, planned, fafter, after, rafter) {
 		switch c := c.(type) {
+		case *schema.AddRole:
+			s.addRole(c)
+		case *schema.DropRole:
+			s.dropRole(c)
+		case *schema.ModifyRole:
+			s.modifyRole(c)
+		case *schema.Grant:
+			err = s.grant(c)
+		case *schema.Revoke:
+			err = s.revoke(c)
+		case *schema.AddView:
+			s.addView(c)
+		case *schema.DropView:
+			s.dropView(c)
+		case *schema.ModifyView:
+			s.modifyView(c)
+		case *schema.AddFunc:
+			err = s.addFunc(c)
+		case *schema.DropFunc:
+			err = s.dropFunc(c)
+		case *schema.ModifyFunc:
+			err = s.modifyFunc(c)
+		case *schema.AddProc:
+			err = s.addProc(c)
+		case *schema.DropProc:
+			err = s.dropProc(c)
+		case *schema.ModifyProc:
+			err = s.modifyProc(c)
 		case *schema.AddTable:
 			err = s.addTable(c)
 		case *schema.DropTable:
@@ -256,12 +289,22 @@ func (s *state) addTable(add *schema.AddTable) error {
 		return fmt.Errorf("create table %q: %s", add.T.Name, strings.Join(errs, ", "))
 	}
 	s.tableAttr(b, add, add.T.Attrs...)
+	if p := (Partition{}); sqlx.Has(add.T.Attrs, &p) {
+		if err := s.partitionBy(b, &p); err != nil {
+			return fmt.Errorf("create table %q: %w", add.T.Name, err)
+		}
+	}
 	s.append(&migrate.Change{
 		Cmd:     b.String(),
 		Source:  add,
 		Reverse: s.Build("DROP TABLE").Table(add.T).String(),
 		Comment: fmt.Sprintf("create %q table", add.T.Name),
 	})
+	for _, t := range add.T.Triggers {
+		if err := s.addTrigger(&schema.AddTrigger{T: t}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -280,15 +323,278 @@ func (s *state) dropTable(drop *schema.DropTable) {
 	})
 }
 
+// addView builds and appends the migrate.Change
+// for creating a view in a schema.
+func (s *state) addView(add *schema.AddView) {
+	s.append(&migrate.Change{
+		Cmd:     s.viewDef(s.Build("CREATE VIEW"), add.V),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q view", add.V.Name),
+		Reverse: s.Build("DROP VIEW").View(add.V).String(),
+	})
+}
+
+// dropView builds and appends the migrate.Change
+// for dropping a view from a schema.
+func (s *state) dropView(drop *schema.DropView) {
+	b := s.Build("DROP VIEW")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.View(drop.V).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q view", drop.V.Name),
+		Reverse: s.viewDef(s.Build("CREATE VIEW"), drop.V),
+	})
+}
+
+// modifyView builds and appends the migrate.Change
+// for bringing the view into its modified state.
+func (s *state) modifyView(modify *schema.ModifyView) {
+	s.append(&migrate.Change{
+		Cmd:     s.viewDef(s.Build("CREATE OR REPLACE VIEW"), modify.To),
+		Source:  modify,
+		Comment: fmt.Sprintf("modify %q view", modify.To.Name),
+		Reverse: s.viewDef(s.Build("CREATE OR REPLACE VIEW"), modify.From),
+	})
+}
+
+// viewDef writes the view name, its definition and options to the given builder.
+func (s *state) viewDef(b *sqlx.Builder, v *schema.View) string {
+	b.View(v).P("AS", strings.TrimSuffix(strings.TrimSpace(v.Def), ";"))
+	if c := (schema.ViewCheckOption{}); sqlx.Has(v.Attrs, &c) && c.V != "" && !strings.EqualFold(c.V, schema.ViewCheckOptionNone) {
+		b.P("WITH", strings.ToUpper(c.V), "CHECK OPTION")
+	}
+	return b.String()
+}
+
+// addFunc builds and appends the migrate.Change
+// for creating a function in a schema.
+func (s *state) addFunc(add *schema.AddFunc) error {
+	cmd, err := s.funcDef(s.Build("CREATE FUNCTION"), add.F)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q function", add.F.Name),
+		Reverse: s.Build("DROP FUNCTION").Func(add.F).String(),
+	})
+	return nil
+}
+
+// dropFunc builds and appends the migrate.Change
+// for dropping a function from a schema.
+func (s *state) dropFunc(drop *schema.DropFunc) error {
+	b := s.Build("DROP FUNCTION")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	create, err := s.funcDef(s.Build("CREATE FUNCTION"), drop.F)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Func(drop.F).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q function", drop.F.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyFunc builds and appends the migrate.Change for bringing the function
+// into its modified state. MySQL does not support replacing functions, and
+// therefore, they are dropped and created again.
+func (s *state) modifyFunc(modify *schema.ModifyFunc) error {
+	if !s.SupportsReplaceRoutine() {
+		if err := s.dropFunc(&schema.DropFunc{F: modify.From}); err != nil {
+			return err
+		}
+		return s.addFunc(&schema.AddFunc{F: modify.To})
+	}
+	cmd, err := s.funcDef(s.Build("CREATE OR REPLACE FUNCTION"), modify.To)
+	if err != nil {
+		return err
+	}
+	reverse, err := s.funcDef(s.Build("CREATE OR REPLACE FUNCTION"), modify.From)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  modify,
+		Comment: fmt.Sprintf("modify %q function", modify.To.Name),
+		Reverse: reverse,
+	})
+	return nil
+}
+
+// addProc builds and appends the migrate.Change
+// for creating a procedure in a schema.
+func (s *state) addProc(add *schema.AddProc) error {
+	cmd, err := s.procDef(s.Build("CREATE PROCEDURE"), add.P)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q procedure", add.P.Name),
+		Reverse: s.Build("DROP PROCEDURE").Proc(add.P).String(),
+	})
+	return nil
+}
+
+// dropProc builds and appends the migrate.Change
+// for dropping a procedure from a schema.
+func (s *state) dropProc(drop *schema.DropProc) error {
+	b := s.Build("DROP PROCEDURE")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	create, err := s.procDef(s.Build("CREATE PROCEDURE"), drop.P)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Proc(drop.P).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q procedure", drop.P.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyProc builds and appends the migrate.Change for bringing
+// the procedure into its modified state. See modifyFunc for more info.
+func (s *state) modifyProc(modify *schema.ModifyProc) error {
+	if !s.SupportsReplaceRoutine() {
+		if err := s.dropProc(&schema.DropProc{P: modify.From}); err != nil {
+			return err
+		}
+		return s.addProc(&schema.AddProc{P: modify.To})
+	}
+	cmd, err := s.procDef(s.Build("CREATE OR REPLACE PROCEDURE"), modify.To)
+	if err != nil {
+		return err
+	}
+	reverse, err := s.procDef(s.Build("CREATE OR REPLACE PROCEDURE"), modify.From)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  modify,
+		Comment: fmt.Sprintf("modify %q procedure", modify.To.Name),
+		Reverse: reverse,
+	})
+	return nil
+}
+
+// funcDef writes the function name, its parameters, return type, characteristics and body to the given builder.
+func (s *state) funcDef(b *sqlx.Builder, f *schema.Func) (string, error) {
+	if err := s.routineParams(b.Func(f), f.Args); err != nil {
+		return "", err
+	}
+	if f.Ret != nil {
+		t, err := routineTypeString(f.Ret)
+		if err != nil {
+			return "", err
+		}
+		b.P("RETURNS", t)
+	}
+	s.routineCharacteristics(b, f.Attrs)
+	return b.P(strings.TrimSpace(f.Body)).String(), nil
+}
+
+// procDef writes the procedure name, its parameters, characteristics and body to the given builder.
+func (s *state) procDef(b *sqlx.Builder, p *schema.Proc) (string, error) {
+	if err := s.routineParams(b.Proc(p), p.Args); err != nil {
+		return "", err
+	}
+	s.routineCharacteristics(b, p.Attrs)
+	return b.P(strings.TrimSpace(p.Body)).String(), nil
+}
+
+// routineParams writes the parameters list of a function or a procedure to the given builder.
+func (s *state) routineParams(b *sqlx.Builder, args []*schema.FuncArg) error {
+	var err error
+	b.Wrap(func(b *sqlx.Builder) {
+		err = b.MapCommaErr(args, func(i int, b *sqlx.Builder) error {
+			a := args[i]
+			if a.Mode != "" {
+				b.P(strings.ToUpper(a.Mode))
+			}
+			t, err := routineTypeString(a.Type)
+			if err != nil {
+				return err
+			}
+			b.Ident(a.Name).P(t)
+			return nil
+		})
+	})
+	return err
+}
+
+// routineCharacteristics writes the characteristics of a function or a procedure to the given builder.
+func (s *state) routineCharacteristics(b *sqlx.Builder, attrs []schema.Attr) {
+	if deterministic(attrs) {
+		b.P("DETERMINISTIC")
+	}
+	if c := (schema.Comment{}); sqlx.Has(attrs, &c) && c.Text != "" {
+		b.P("COMMENT", quote(c.Text))
+	}
+}
+
+// routineTypeString returns the string representation of a routine parameter or return type.
+func routineTypeString(t schema.Type) (string, error) {
+	if u, ok := t.(*schema.UnsupportedType); ok {
+		return u.T, nil
+	}
+	return FormatType(t)
+}
+
+// concat returns a new slice holding the given changes in order.
+func concat(changes ...[]schema.Change) []schema.Change {
+	var all []schema.Change
+	for _, c := range changes {
+		all = append(all, c...)
+	}
+	return all
+}
+
 // modifyTable builds and appends the migration changes for
 // bringing the table into its modified state.
 func (s *state) modifyTable(modify *schema.ModifyTable) error {
-	var changes [2][]schema.Change
+	var (
+		changes    [2][]schema.Change
+		triggers   [2][]schema.Change
+		partitions []schema.Change
+	)
 	if len(modify.T.Columns) == 0 {
 		return fmt.Errorf("table %q has no columns; drop the table instead", modify.T.Name)
 	}
 	for _, change := range skipAutoChanges(modify.Changes) {
+		// Partitioning changes cannot be combined with other ALTER TABLE
+		// specifications, and are executed after the table was altered.
+		if isPartitionChange(change) {
+			partitions = append(partitions, change)
+			continue
+		}
 		switch change := change.(type) {
+		// Triggers are dropped before the table is altered,
+		// and created after, as they may reference its columns.
+		case *schema.DropTrigger:
+			triggers[0] = append(triggers[0], change)
+		case *schema.AddTrigger:
+			triggers[1] = append(triggers[1], change)
+		// MySQL does not support replacing triggers.
+		case *schema.ModifyTrigger:
+			triggers[0] = append(triggers[0], &schema.DropTrigger{T: change.From})
+			triggers[1] = append(triggers[1], &schema.AddTrigger{T: change.To})
 		// Foreign-key modification is translated into 2 steps.
 		// Dropping the current foreign key and creating a new one.
 		case *schema.ModifyForeignKey:
@@ -323,6 +629,9 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			changes[1] = append(changes[1], change)
 		}
 	}
+	if err := s.triggers(triggers[0]); err != nil {
+		return err
+	}
 	for i := range changes {
 		if len(changes[i]) > 0 {
 			if err := s.alterTable(modify.T, changes[i]); err != nil {
@@ -330,9 +639,343 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			}
 		}
 	}
+	for _, c := range partitions {
+		if err := s.alterPartition(modify.T, c); err != nil {
+			return err
+		}
+	}
+	return s.triggers(triggers[1])
+}
+
+// alterPartition plans the change of the table partitioning. Partitions that were
+// added, dropped or modified are altered in place, if the partitioning key was not
+// changed. Otherwise, the table is repartitioned.
+func (s *state) alterPartition(t *schema.Table, c schema.Change) error {
+	var (
+		changes []*migrate.Change
+		// Repartitioning the table requires a table copy. Adding and dropping RANGE and
+		// LIST partitions permits concurrent DML, and the rest of the partition operations
+		// permit only concurrent reads.
+		copyAlg = ddlAlgorithm{alg: algCopy, reason: "repartitioning the table"}
+		inplace = ddlAlgorithm{alg: algInplace}
+		shared  = ddlAlgorithm{alg: algInplace, shared: true}
+		// The ALGORITHM and LOCK options precede the partition operations. The copy
+		// algorithm is used only with the PARTITION BY and REMOVE PARTITIONING clauses,
+		// which are not comma-separated from the options.
+		alter = func(a ddlAlgorithm) *sqlx.Builder {
+			b := s.Build("ALTER TABLE").Table(t)
+			if s.online() {
+				a.options(b)
+				if a.alg != algCopy {
+					b.Comma()
+				}
+			}
+			return b
+		}
+		unpart = func() string { return alter(copyAlg).P("REMOVE PARTITIONING").String() }
+		repart = func(p *Partition) (string, error) {
+			b := alter(copyAlg)
+			if err := s.partitionBy(b, p); err != nil {
+				return "", err
+			}
+			return b.String(), nil
+		}
+	)
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		cmd, err := repart(c.A.(*Partition))
+		if err != nil {
+			return err
+		}
+		changes = append(changes, &migrate.Change{Cmd: cmd, Reverse: unpart()})
+	case *schema.DropAttr:
+		reverse, err := repart(c.A.(*Partition))
+		if err != nil {
+			return err
+		}
+		changes = append(changes, &migrate.Change{Cmd: unpart(), Reverse: reverse})
+	case *schema.ModifyAttr:
+		from, to := c.From.(*Partition), c.To.(*Partition)
+		if partitionKeyChanged(&from.Key, &to.Key) || partitionSubChanged(from, to) || !inPlacePartition(from, to) {
+			cmd, err := repart(to)
+			if err != nil {
+				return err
+			}
+			reverse, err := repart(from)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, &migrate.Change{Cmd: cmd, Reverse: reverse})
+			break
+		}
+		switch n, m := len(from.Defs), len(to.Defs); strings.ToUpper(to.Key.T) {
+		// The number of HASH and KEY partitions is changed by adding or merging partitions.
+		case PartitionTypeHash, PartitionTypeKey:
+			switch {
+			case n < m:
+				changes = append(changes, &migrate.Change{
+					Cmd:     s.partitionDefs(alter(shared).P("ADD PARTITION"), to, to.Defs[n:]).String(),
+					Reverse: alter(shared).P("COALESCE PARTITION", strconv.Itoa(m-n)).String(),
+				})
+			case n > m:
+				changes = append(changes, &migrate.Change{
+					Cmd:     alter(shared).P("COALESCE PARTITION", strconv.Itoa(n-m)).String(),
+					Reverse: s.partitionDefs(alter(shared).P("ADD PARTITION"), from, from.Defs[m:]).String(),
+				})
+			}
+		default:
+			var drop, add []*PartitionDef
+			for _, d1 := range from.Defs {
+				switch d2, ok := partitionDef(to, d1.Name); {
+				case !ok:
+					drop = append(drop, d1)
+				case partitionDefChanged(d1, d2):
+					changes = append(changes, &migrate.Change{
+						Cmd:     s.partitionDefs(alter(shared).P("REORGANIZE PARTITION").Ident(d1.Name).P("INTO"), to, []*PartitionDef{d2}).String(),
+						Reverse: s.partitionDefs(alter(shared).P("REORGANIZE PARTITION").Ident(d2.Name).P("INTO"), from, []*PartitionDef{d1}).String(),
+					})
+				}
+			}
+			for _, d2 := range to.Defs {
+				if _, ok := partitionDef(from, d2.Name); !ok {
+					add = append(add, d2)
+				}
+			}
+			if len(drop) > 0 {
+				b := alter(inplace).P("DROP PARTITION")
+				b.MapComma(drop, func(i int, b *sqlx.Builder) {
+					b.Ident(drop[i].Name)
+				})
+				changes = append([]*migrate.Change{{Cmd: b.String(), Reverse: s.partitionDefs(alter(inplace).P("ADD PARTITION"), from, drop).String()}}, changes...)
+			}
+			if len(add) > 0 {
+				b := alter(inplace).P("DROP PARTITION")
+				b.MapComma(add, func(i int, b *sqlx.Builder) {
+					b.Ident(add[i].Name)
+				})
+				changes = append(changes, &migrate.Change{Cmd: s.partitionDefs(alter(inplace).P("ADD PARTITION"), to, add).String(), Reverse: b.String()})
+			}
+		}
+	}
+	// Repartitioning requires copying the table.
+	repartition := true
+	if m, ok := c.(*schema.ModifyAttr); ok {
+		from, to := m.From.(*Partition), m.To.(*Partition)
+		repartition = partitionKeyChanged(&from.Key, &to.Key) || partitionSubChanged(from, to) || !inPlacePartition(from, to)
+	}
+	for _, ch := range changes {
+		ch.Source = c
+		ch.Comment = fmt.Sprintf("modify %q table partitioning", t.Name)
+		if repartition {
+			if err := s.mayCopy(t, ch, copyAlg); err != nil {
+				return err
+			}
+		}
+		s.append(ch)
+	}
 	return nil
 }
 
+// partitionBy writes the PARTITION BY clause of the table to the builder.
+func (s *state) partitionBy(b *sqlx.Builder, p *Partition) error {
+	b.P("PARTITION BY")
+	if err := s.partitionKey(b, &p.Key); err != nil {
+		return err
+	}
+	if p.Sub != nil {
+		b.P("SUBPARTITION BY")
+		if err := s.partitionKey(b, p.Sub); err != nil {
+			return err
+		}
+	}
+	if len(p.Defs) > 0 {
+		// Separate the definitions from the wrapped partitioning key.
+		b.WriteByte(' ')
+		s.partitionDefs(b, p, p.Defs)
+	}
+	return nil
+}
+
+// partitionKey writes the partitioning type and key to the builder.
+func (s *state) partitionKey(b *sqlx.Builder, k *PartitionKey) error {
+	t := strings.ToUpper(k.T)
+	switch {
+	case t == "":
+		return errors.New("missing partitioning type")
+	case k.Expr == "" && len(k.Columns) == 0 && t != PartitionTypeKey:
+		return fmt.Errorf("missing expression or columns for %s partitioning", t)
+	case k.Expr != "" && len(k.Columns) > 0:
+		return fmt.Errorf("multiple definitions for %s partitioning, use either expression or columns", t)
+	}
+	if k.Linear {
+		b.P("LINEAR")
+	}
+	b.P(t)
+	if len(k.Columns) > 0 && t != PartitionTypeKey {
+		b.P("COLUMNS")
+	}
+	b.Wrap(func(b *sqlx.Builder) {
+		if k.Expr != "" {
+			b.WriteString(k.Expr)
+			return
+		}
+		b.MapComma(k.Columns, func(i int, b *sqlx.Builder) {
+			b.Ident(k.Columns[i].Name)
+		})
+	})
+	return nil
+}
+
+// partitionDefs writes the given partition definitions to the builder.
+func (s *state) partitionDefs(b *sqlx.Builder, p *Partition, defs []*PartitionDef) *sqlx.Builder {
+	return b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(defs, func(i int, b *sqlx.Builder) {
+			d := defs[i]
+			b.P("PARTITION").Ident(d.Name)
+			switch strings.ToUpper(p.Key.T) {
+			case PartitionTypeRange:
+				b.P("VALUES LESS THAN")
+				// MAXVALUE is wrapped with parentheses only for RANGE COLUMNS.
+				if strings.EqualFold(d.Values, "MAXVALUE") && len(p.Key.Columns) == 0 {
+					b.P("MAXVALUE")
+				} else {
+					b.P("(" + d.Values + ")")
+				}
+			case PartitionTypeList:
+				b.P("VALUES IN", "("+d.Values+")")
+			}
+			if d.Comment != "" {
+				b.P("COMMENT", quote(d.Comment))
+			}
+			if len(d.Subs) > 0 {
+				b.Wrap(func(b *sqlx.Builder) {
+					b.MapComma(d.Subs, func(i int, b *sqlx.Builder) {
+						b.P("SUBPARTITION").Ident(d.Subs[i])
+					})
+				})
+			}
+		})
+	})
+}
+
+// inPlacePartition reports if the partition definitions can be altered in place. i.e.
+// the common partitions keep their order, and new RANGE partitions are added last.
+func inPlacePartition(from, to *Partition) bool {
+	var (
+		last  = -1
+		added bool
+	)
+	for _, d := range to.Defs {
+		i := -1
+		for j := range from.Defs {
+			if from.Defs[j].Name == d.Name {
+				i = j
+			}
+		}
+		switch {
+		case i == -1:
+			added = true
+		case i < last, added && strings.EqualFold(to.Key.T, PartitionTypeRange):
+			return false
+		default:
+			last = i
+		}
+	}
+	return true
+}
+
+// partitionDef returns the partition definition with the given name.
+func partitionDef(p *Partition, name string) (*PartitionDef, bool) {
+	for _, d := range p.Defs {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// isPartitionChange reports if the change is a change of the table partitioning.
+func isPartitionChange(c schema.Change) (ok bool) {
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		_, ok = c.A.(*Partition)
+	case *schema.DropAttr:
+		_, ok = c.A.(*Partition)
+	case *schema.ModifyAttr:
+		_, ok = c.To.(*Partition)
+	}
+	return ok
+}
+
+// triggers builds and appends the migration changes of the given trigger changes.
+func (s *state) triggers(changes []schema.Change) error {
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddTrigger:
+			if err := s.addTrigger(c); err != nil {
+				return err
+			}
+		case *schema.DropTrigger:
+			if err := s.dropTrigger(c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addTrigger builds and appends the migrate.Change
+// for creating a trigger on a table.
+func (s *state) addTrigger(add *schema.AddTrigger) error {
+	cmd, err := s.triggerDef(add.T)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q trigger", add.T.Name),
+		Reverse: s.Build("DROP TRIGGER").Trigger(add.T).String(),
+	})
+	return nil
+}
+
+// dropTrigger builds and appends the migrate.Change
+// for dropping a trigger from a table.
+func (s *state) dropTrigger(drop *schema.DropTrigger) error {
+	create, err := s.triggerDef(drop.T)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.Build("DROP TRIGGER").Trigger(drop.T).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q trigger", drop.T.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// triggerDef returns the CREATE TRIGGER statement of the given trigger.
+func (s *state) triggerDef(t *schema.Trigger) (string, error) {
+	switch {
+	case len(t.Events) != 1:
+		return "", fmt.Errorf("mysql: trigger %q must have exactly one event, got %d", t.Name, len(t.Events))
+	case len(t.Events[0].Columns) > 0:
+		return "", fmt.Errorf("mysql: trigger %q: UPDATE OF columns is not supported", t.Name)
+	case t.Func != nil:
+		return "", fmt.Errorf("mysql: trigger %q: executing functions is not supported", t.Name)
+	case t.For != "" && !strings.EqualFold(t.For, schema.TriggerForRow):
+		return "", fmt.Errorf("mysql: trigger %q: only FOR EACH ROW triggers are supported", t.Name)
+	}
+	return s.Build("CREATE TRIGGER").
+		Trigger(t).
+		P(strings.ToUpper(t.ActionTime), strings.ToUpper(t.Events[0].Name), "ON").
+		Table(t.Table).
+		P("FOR EACH ROW", strings.TrimSpace(t.Body)).
+		String(), nil
+}
+
 // alterTable modifies the given table by executing on it a list of
 // changes in one SQL statement.
 func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
@@ -349,15 +992,35 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 				if err := s.column(b, t, change.C); err != nil {
 					return err
 				}
+				// Columns that are not added at the end of
+				// the table are positioned explicitly.
+				if i := columnIndex(t, change.C); i != -1 && i < len(t.Columns)-1 {
+					columnPosition(b, t, i)
+				}
 				reverse = append(reverse, &schema.DropColumn{C: change.C})
 			case *schema.ModifyColumn:
 				if err := checkChangeGenerated(change.From, change.To); err != nil {
 					return err
 				}
-				b.P("MODIFY COLUMN")
+				// Renamed columns that were also modified are
+				// changed using one CHANGE COLUMN specification.
+				if change.From.Name != change.To.Name {
+					b.P("CHANGE COLUMN").Ident(change.From.Name)
+				} else {
+					b.P("MODIFY COLUMN")
+				}
 				if err := s.column(b, t, change.To); err != nil {
 					return err
 				}
+				if change.Change.Is(schema.ChangePosition) {
+					i := columnIndex(t, change.To)
+					if i == -1 {
+						return fmt.Errorf("moved column %q was not found in table", change.To.Name)
+					}
+					columnPosition(b, t, i)
+					// The previous position of the column is unknown.
+					reversible = false
+				}
 				reverse = append(reverse, &schema.ModifyColumn{
 					From:   change.To,
 					To:     change.From,
@@ -444,9 +1107,12 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 		if err != nil {
 			return "", err
 		}
+		if s.online() {
+			s.algorithm(t, changes).clause(b)
+		}
 		return b.String(), nil
 	}
-	cmd, err := build(changes)
+	cmd, err := build(positionChanges(t, mergeRenames(changes)))
 	if err != nil {
 		return fmt.Errorf("alter table %q: %v", t.Name, err)
 	}
@@ -458,6 +1124,9 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 		},
 		Comment: fmt.Sprintf("modify %q table", t.Name),
 	}
+	if err := s.mayCopy(t, change, s.algorithm(t, changes)); err != nil {
+		return err
+	}
 	if reversible {
 		// Changes should be reverted in
 		// a reversed order they were created.
@@ -479,6 +1148,256 @@ func (s *state) renameTable(c *schema.RenameTable) {
 	})
 }
 
+// List of online DDL algorithms, ordered from the least to the most blocking one.
+const (
+	algInstant = iota
+	algInplace
+	algCopy
+)
+
+// ddlAlgorithm describes the least blocking algorithm and
+// lock that can be used for executing an ALTER TABLE command.
+type ddlAlgorithm struct {
+	alg    int
+	shared bool   // Concurrent DML is not permitted (LOCK=SHARED).
+	reason string // The reason a table copy is required.
+}
+
+// clause writes the ALGORITHM and LOCK clauses of the algorithm.
+func (a ddlAlgorithm) clause(b *sqlx.Builder) {
+	a.options(b.Comma())
+}
+
+// options writes the ALGORITHM and LOCK options of the algorithm.
+func (a ddlAlgorithm) options(b *sqlx.Builder) {
+	switch a.alg {
+	case algInstant:
+		// Only the default lock is permitted with INSTANT.
+		b.P("ALGORITHM=INSTANT")
+	case algInplace:
+		lock := "NONE"
+		if a.shared {
+			lock = "SHARED"
+		}
+		b.P("ALGORITHM=INPLACE").Comma().P("LOCK=" + lock)
+	case algCopy:
+		b.P("ALGORITHM=COPY")
+	}
+}
+
+// online reports if online DDL clauses should be planned.
+func (s *state) online() bool {
+	return s.OnlineDDL != migrate.OnlineDDLNone && s.SupportsOnlineDDL()
+}
+
+// mayCopy marks the change as requiring a table copy, or fails the
+// planning in case online schema changes are required.
+func (s *state) mayCopy(t *schema.Table, c *migrate.Change, a ddlAlgorithm) error {
+	switch {
+	case s.OnlineDDL == migrate.OnlineDDLNone || s.TiDB():
+	case s.OnlineDDL == migrate.OnlineDDLRequire && !s.SupportsOnlineDDL():
+		return fmt.Errorf("online schema changes are not supported by MySQL version %q", string(s.V))
+	case a.alg != algCopy:
+	case s.OnlineDDL == migrate.OnlineDDLRequire:
+		return fmt.Errorf("alter table %q: %s requires a table copy", t.Name, a.reason)
+	default:
+		c.Comment += fmt.Sprintf(" (requires a table copy for %s)", a.reason)
+	}
+	return nil
+}
+
+// algorithm returns the least blocking algorithm for applying the changes
+// on the table, according to the MySQL online DDL documentation. Changes
+// that are not known to be supported online, are executed by copying the
+// table.
+func (s *state) algorithm(t *schema.Table, changes []schema.Change) ddlAlgorithm {
+	var a ddlAlgorithm
+	set := func(alg int, shared bool, reason string) {
+		if alg > a.alg {
+			a.alg, a.reason = alg, reason
+		}
+		a.shared = a.shared || shared
+	}
+	instant := func(ok bool) int {
+		if ok && s.SupportsInstantDDL() {
+			return algInstant
+		}
+		return algInplace
+	}
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddColumn:
+			var x schema.GeneratedExpr
+			switch i := columnIndex(t, c.C); {
+			case sqlx.Has(c.C.Attrs, &x) && storedOrVirtual(x.Type) == stored:
+				set(algCopy, false, fmt.Sprintf("adding stored generated column %q", c.C.Name))
+			case sqlx.Has(c.C.Attrs, &AutoIncrement{}):
+				set(algInplace, true, "")
+			default:
+				last := i == -1 || i == len(t.Columns)-1
+				set(instant((last || s.SupportsInstantAnyPosition()) && !hasIndexType(t, IndexTypeFullText)), false, "")
+			}
+		case *schema.DropColumn:
+			set(instant(s.SupportsInstantAnyPosition()), false, "")
+		case *schema.RenameColumn:
+			set(instant(s.SupportsInstantRenameColumn()), false, "")
+		case *schema.ModifyColumn:
+			k := c.Change
+			if k.Is(schema.ChangeType | schema.ChangeCharset | schema.ChangeCollate | schema.ChangeGenerated | schema.ChangeAttr) {
+				set(algCopy, false, fmt.Sprintf("modifying column %q", c.To.Name))
+			}
+			if k.Is(schema.ChangeNull | schema.ChangeComment) {
+				set(algInplace, false, "")
+			}
+			if k.Is(schema.ChangePosition) {
+				set(instant(s.SupportsInstantAnyPosition()), false, "")
+			}
+			if k.Is(schema.ChangeDefault) {
+				set(instant(true), false, "")
+			}
+		case *schema.AddIndex:
+			var it IndexType
+			sqlx.Has(c.I.Attrs, &it)
+			set(algInplace, strings.EqualFold(it.T, IndexTypeFullText) || strings.EqualFold(it.T, IndexTypeSpatial), "")
+		case *schema.DropIndex, *schema.DropForeignKey:
+			set(algInplace, false, "")
+		case *schema.RenameIndex, *schema.DropCheck:
+			set(instant(true), false, "")
+		case *schema.AddForeignKey:
+			set(algCopy, false, fmt.Sprintf("adding foreign key %q", c.F.Symbol))
+		case *schema.AddCheck:
+			set(algCopy, false, fmt.Sprintf("adding check constraint %q", c.C.Name))
+		case *schema.ModifyCheck:
+			set(algCopy, false, fmt.Sprintf("modifying check constraint %q", c.To.Name))
+		case *schema.AddAttr, *schema.ModifyAttr:
+			var attr schema.Attr
+			if add, ok := c.(*schema.AddAttr); ok {
+				attr = add.A
+			} else {
+				attr = c.(*schema.ModifyAttr).To
+			}
+			switch attr.(type) {
+			case *AutoIncrement, *schema.Comment, *schema.Charset, *schema.Collation:
+				set(algInplace, false, "")
+			default:
+				set(algCopy, false, fmt.Sprintf("changing table attribute %T", attr))
+			}
+		default:
+			set(algCopy, false, fmt.Sprintf("change %T", c))
+		}
+	}
+	return a
+}
+
+// hasIndexType reports if the table has an index of the given type.
+func hasIndexType(t *schema.Table, typ string) bool {
+	for _, idx := range t.Indexes {
+		var it IndexType
+		if sqlx.Has(idx.Attrs, &it) && strings.EqualFold(it.T, typ) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnIndex returns the position of the column in the table, or -1 if it was not found.
+func columnIndex(t *schema.Table, c *schema.Column) int {
+	for i := range t.Columns {
+		if t.Columns[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// columnPosition writes the position of the i-th column of the table. i.e. FIRST or AFTER.
+func columnPosition(b *sqlx.Builder, t *schema.Table, i int) {
+	if i == 0 {
+		b.P("FIRST")
+	} else {
+		b.P("AFTER").Ident(t.Columns[i-1].Name)
+	}
+}
+
+// mergeRenames removes the column renames that have a matching column
+// modification, as both are expressed using one CHANGE COLUMN clause.
+func mergeRenames(changes []schema.Change) []schema.Change {
+	modified := make(map[string]bool)
+	for _, c := range changes {
+		if m, ok := c.(*schema.ModifyColumn); ok && m.From.Name != m.To.Name {
+			modified[m.From.Name] = true
+		}
+	}
+	if len(modified) == 0 {
+		return changes
+	}
+	merged := make([]schema.Change, 0, len(changes))
+	for _, c := range changes {
+		if r, ok := c.(*schema.RenameColumn); ok && modified[r.From.Name] {
+			continue
+		}
+		merged = append(merged, c)
+	}
+	return merged
+}
+
+// positionChanges orders the column changes that set a position (i.e. ADD COLUMN
+// and MODIFY COLUMN ... AFTER) by the order of the columns in the desired table,
+// in case columns were moved. MySQL applies the ALTER TABLE specifications in order,
+// and therefore, each column is placed after a column that was already positioned.
+func positionChanges(t *schema.Table, changes []schema.Change) []schema.Change {
+	positioned := func(c schema.Change) bool {
+		switch c := c.(type) {
+		case *schema.AddColumn:
+			return true
+		case *schema.ModifyColumn:
+			return c.Change.Is(schema.ChangePosition)
+		}
+		return false
+	}
+	moved := false
+	for _, c := range changes {
+		if m, ok := c.(*schema.ModifyColumn); ok && m.Change.Is(schema.ChangePosition) {
+			moved = true
+			break
+		}
+	}
+	if !moved {
+		return changes
+	}
+	var (
+		at           = -1
+		cols, others []schema.Change
+	)
+	for _, c := range changes {
+		switch c.(type) {
+		case *schema.AddColumn, *schema.ModifyColumn, *schema.RenameColumn, *schema.DropColumn:
+			if positioned(c) {
+				if at == -1 {
+					at = len(others)
+				}
+				cols = append(cols, c)
+				continue
+			}
+			// Positioned columns are placed after the
+			// other column changes, like renames.
+			at = len(others) + 1
+		}
+		others = append(others, c)
+	}
+	index := func(c schema.Change) int {
+		if a, ok := c.(*schema.AddColumn); ok {
+			return columnIndex(t, a.C)
+		}
+		return columnIndex(t, c.(*schema.ModifyColumn).To)
+	}
+	sort.SliceStable(cols, func(i, j int) bool { return index(cols[i]) < index(cols[j]) })
+	ordered := make([]schema.Change, 0, len(changes))
+	ordered = append(ordered, others[:at]...)
+	ordered = append(ordered, cols...)
+	return append(ordered, others[at:]...)
+}
+
 func (s *state) column(b *sqlx.Builder, t *schema.Table, c *schema.Column) error {
 	typ, err := FormatType(c.Type.Type)
 	if err != nil {
@@ -779,6 +1698,132 @@ func checkChangeGenerated(from, to *schema.Column) error {
 	return nil
 }
 
+// addRole builds and executes the query for creating a user account or a role.
+func (s *state) addRole(add *schema.AddRole) {
+	kind := roleKind(add.R)
+	s.append(&migrate.Change{
+		Cmd:     fmt.Sprintf("CREATE %s %s", kind, account(add.R)),
+		Source:  add,
+		Comment: fmt.Sprintf("create %s %q", strings.ToLower(kind), add.R.Name),
+		Reverse: fmt.Sprintf("DROP %s %s", kind, account(add.R)),
+	})
+}
+
+// dropRole builds and executes the query for dropping a user account or a role.
+func (s *state) dropRole(drop *schema.DropRole) {
+	kind := roleKind(drop.R)
+	b := s.Build("DROP", kind)
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(account(drop.R)).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %s %q", strings.ToLower(kind), drop.R.Name),
+		Reverse: fmt.Sprintf("CREATE %s %s", kind, account(drop.R)),
+	})
+}
+
+// modifyRole builds and executes the query for locking or unlocking a user account.
+func (s *state) modifyRole(modify *schema.ModifyRole) {
+	lock := func(r *schema.Role) string {
+		b := s.Build("ALTER USER").P(account(r), "ACCOUNT")
+		if r.Login {
+			return b.P("UNLOCK").String()
+		}
+		return b.P("LOCK").String()
+	}
+	s.append(&migrate.Change{
+		Cmd:     lock(modify.To),
+		Source:  modify,
+		Comment: fmt.Sprintf("modify user %q", modify.To.Name),
+		Reverse: lock(modify.From),
+	})
+}
+
+// grant builds and executes the query for granting privileges to an account.
+func (s *state) grant(g *schema.Grant) error {
+	cmd, err := s.privilege("GRANT", "TO", g.P)
+	if err != nil {
+		return err
+	}
+	reverse, err := s.privilege("REVOKE", "FROM", g.P)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  g,
+		Comment: fmt.Sprintf("grant privileges to %q", g.P.Grantee.Name),
+		Reverse: reverse,
+	})
+	return nil
+}
+
+// revoke builds and executes the query for revoking privileges from an account.
+func (s *state) revoke(r *schema.Revoke) error {
+	cmd, err := s.privilege("REVOKE", "FROM", r.P)
+	if err != nil {
+		return err
+	}
+	reverse, err := s.privilege("GRANT", "TO", r.P)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  r,
+		Comment: fmt.Sprintf("revoke privileges from %q", r.P.Grantee.Name),
+		Reverse: reverse,
+	})
+	return nil
+}
+
+// privilege returns the GRANT or REVOKE statement for the given privilege.
+func (s *state) privilege(action, prep string, p *schema.Privilege) (string, error) {
+	if p.Grantee == nil || len(p.Privs) == 0 {
+		return "", fmt.Errorf("mysql: missing grantee or privileges for %s statement", action)
+	}
+	privs := p.Privs
+	// Unlike GRANT, the grant option is revoked explicitly.
+	if p.Grantable && action == "REVOKE" {
+		privs = append(privs[:len(privs):len(privs)], "GRANT OPTION")
+	}
+	b := s.Build(action).P(strings.Join(privs, ", "), "ON")
+	switch {
+	case p.Schema == nil:
+		b.P("*.*")
+	case p.Table != nil:
+		b.Table(p.Table)
+	case p.View != nil:
+		b.View(p.View)
+	default:
+		b.P(s.Build().Ident(p.Schema.Name).String() + ".*")
+	}
+	b.P(prep, account(p.Grantee))
+	if p.Grantable && action == "GRANT" {
+		b.P("WITH GRANT OPTION")
+	}
+	return b.String(), nil
+}
+
+// roleKind returns the kind of the given role in CREATE and DROP statements.
+func roleKind(r *schema.Role) string {
+	if r.Login {
+		return "USER"
+	}
+	return "ROLE"
+}
+
+// account returns the account name of the role. e.g. user@host returns 'user'@'host'.
+func account(r *schema.Role) string {
+	user, host := r.Name, "%"
+	if i := strings.LastIndexByte(r.Name, '@'); i != -1 {
+		user, host = r.Name[:i], r.Name[i+1:]
+	}
+	return fmt.Sprintf("'%s'@'%s'", user, host)
+}
+
 func quote(s string) string {
 	if sqlx.IsQuoted(s, '"', '\'') {
 		return s
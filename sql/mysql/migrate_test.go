@@ -193,6 +193,166 @@ func TestPlanChanges(t *testing.T) {
 			// Table "users" has no columns; drop the table instead.
 			wantErr: true,
 		},
+		// Accounts are created before the tables that privileges are granted on, and dropped last.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("test")
+				users := schema.NewTable("users").SetSchema(s).AddColumns(schema.NewIntColumn("id", "int"))
+				app, readers := schema.NewUser("app@%"), schema.NewRole("readers")
+				return []schema.Change{
+					&schema.DropRole{R: schema.NewUser("old@localhost"), Extra: []schema.Clause{&schema.IfExists{}}},
+					&schema.Grant{P: &schema.Privilege{Grantee: app, Schema: s, Table: users, Privs: []string{"SELECT", "INSERT"}, Grantable: true}},
+					&schema.Grant{P: &schema.Privilege{Grantee: readers, Schema: s, Privs: []string{"SELECT"}}},
+					&schema.AddTable{T: users},
+					&schema.AddRole{R: app},
+					&schema.AddRole{R: readers},
+					&schema.ModifyRole{From: schema.NewRole("admin@%"), To: schema.NewUser("admin@%")},
+					&schema.Revoke{P: &schema.Privilege{Grantee: schema.NewUser("admin@%"), Privs: []string{"PROCESS"}, Grantable: true}},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "CREATE USER 'app'@'%'",
+						Reverse: "DROP USER 'app'@'%'",
+					},
+					{
+						Cmd:     "CREATE ROLE 'readers'@'%'",
+						Reverse: "DROP ROLE 'readers'@'%'",
+					},
+					{
+						Cmd:     "ALTER USER 'admin'@'%' ACCOUNT UNLOCK",
+						Reverse: "ALTER USER 'admin'@'%' ACCOUNT LOCK",
+					},
+					{
+						Cmd:     "REVOKE PROCESS, GRANT OPTION ON *.* FROM 'admin'@'%'",
+						Reverse: "GRANT PROCESS ON *.* TO 'admin'@'%' WITH GRANT OPTION",
+					},
+					{
+						Cmd:     "CREATE TABLE `test`.`users` (`id` int NOT NULL)",
+						Reverse: "DROP TABLE `test`.`users`",
+					},
+					{
+						Cmd:     "GRANT SELECT, INSERT ON `test`.`users` TO 'app'@'%' WITH GRANT OPTION",
+						Reverse: "REVOKE SELECT, INSERT, GRANT OPTION ON `test`.`users` FROM 'app'@'%'",
+					},
+					{
+						Cmd:     "GRANT SELECT ON `test`.* TO 'readers'@'%'",
+						Reverse: "REVOKE SELECT ON `test`.* FROM 'readers'@'%'",
+					},
+					{
+						Cmd:     "DROP USER IF EXISTS 'old'@'localhost'",
+						Reverse: "CREATE USER 'old'@'localhost'",
+					},
+				},
+			},
+		},
+		// Changes of the same table are merged into one statement, unless their order matters.
+		{
+			changes: func() []schema.Change {
+				users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("workplace_id", "int"))
+				workplaces := schema.NewTable("workplaces").AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("owner_id", "int"))
+				users.AddForeignKeys(schema.NewForeignKey("workplace").AddColumns(users.Columns[1]).SetRefTable(workplaces).AddRefColumns(workplaces.Columns[0]))
+				workplaces.AddForeignKeys(schema.NewForeignKey("owner").AddColumns(workplaces.Columns[1]).SetRefTable(users).AddRefColumns(users.Columns[0]))
+				owners := schema.NewTable("owners").AddColumns(schema.NewIntColumn("id", "int"))
+				pets := schema.NewTable("pets").AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("owner_id", "int"))
+				pets.AddForeignKeys(schema.NewForeignKey("owner").AddColumns(pets.Columns[1]).SetRefTable(owners).AddRefColumns(owners.Columns[0]))
+				return []schema.Change{
+					&schema.AddTable{T: users},
+					&schema.AddTable{T: workplaces},
+					// Foreign keys are detached from their tables, as there is a
+					// circular reference between "users" and "workplaces".
+					&schema.ModifyTable{
+						T: pets,
+						Changes: []schema.Change{
+							&schema.AddColumn{C: pets.Columns[1]},
+							&schema.AddForeignKey{F: pets.ForeignKeys[0]},
+						},
+					},
+					&schema.ModifyTable{
+						T: pets,
+						Changes: []schema.Change{
+							&schema.AddIndex{I: schema.NewIndex("pets_owner_id").AddColumns(pets.Columns[1])},
+						},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "CREATE TABLE `users` (`id` int NOT NULL, `workplace_id` int NOT NULL)",
+						Reverse: "DROP TABLE `users`",
+					},
+					{
+						Cmd:     "CREATE TABLE `workplaces` (`id` int NOT NULL, `owner_id` int NOT NULL)",
+						Reverse: "DROP TABLE `workplaces`",
+					},
+					{
+						Cmd:     "ALTER TABLE `pets` ADD COLUMN `owner_id` int NOT NULL, ADD INDEX `pets_owner_id` (`owner_id`), ADD CONSTRAINT `owner` FOREIGN KEY (`owner_id`) REFERENCES `owners` (`id`)",
+						Reverse: "ALTER TABLE `pets` DROP FOREIGN KEY `owner`, DROP INDEX `pets_owner_id`, DROP COLUMN `owner_id`",
+					},
+					{
+						Cmd:     "ALTER TABLE `users` ADD CONSTRAINT `workplace` FOREIGN KEY (`workplace_id`) REFERENCES `workplaces` (`id`)",
+						Reverse: "ALTER TABLE `users` DROP FOREIGN KEY `workplace`",
+					},
+					{
+						Cmd:     "ALTER TABLE `workplaces` ADD CONSTRAINT `owner` FOREIGN KEY (`owner_id`) REFERENCES `users` (`id`)",
+						Reverse: "ALTER TABLE `workplaces` DROP FOREIGN KEY `owner`",
+					},
+				},
+			},
+		},
+		// Partitions are added, reorganized and dropped in place.
+		{
+			changes: func() []schema.Change {
+				logs := schema.NewTable("logs").SetSchema(schema.New("test")).AddColumns(schema.NewIntColumn("year", "int"))
+				from := &Partition{
+					Key: PartitionKey{T: PartitionTypeRange, Expr: "`year`"},
+					Defs: []*PartitionDef{
+						{Name: "p0", Values: "2000"},
+						{Name: "p1", Values: "2010"},
+						{Name: "p2", Values: "MAXVALUE"},
+					},
+				}
+				to := &Partition{
+					Key: PartitionKey{T: PartitionTypeRange, Expr: "`year`"},
+					Defs: []*PartitionDef{
+						{Name: "p1", Values: "2020", Comment: "recent"},
+						{Name: "p3", Values: "MAXVALUE"},
+					},
+				}
+				return []schema.Change{
+					&schema.AddTable{T: schema.NewTable("events").SetSchema(schema.New("test")).AddColumns(schema.NewIntColumn("id", "int")).AddAttrs(&Partition{
+						Key:  PartitionKey{T: PartitionTypeHash, Linear: true, Expr: "`id`"},
+						Defs: []*PartitionDef{{Name: "p0"}, {Name: "p1"}},
+					})},
+					&schema.ModifyTable{T: logs, Changes: []schema.Change{&schema.ModifyAttr{From: from, To: to}}},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "CREATE TABLE `test`.`events` (`id` int NOT NULL) PARTITION BY LINEAR HASH (`id`) (PARTITION `p0`, PARTITION `p1`)",
+						Reverse: "DROP TABLE `test`.`events`",
+					},
+					{
+						Cmd:     "ALTER TABLE `test`.`logs` DROP PARTITION `p0`, `p2`",
+						Reverse: "ALTER TABLE `test`.`logs` ADD PARTITION (PARTITION `p0` VALUES LESS THAN (2000), PARTITION `p2` VALUES LESS THAN MAXVALUE)",
+					},
+					{
+						Cmd:     "ALTER TABLE `test`.`logs` REORGANIZE PARTITION `p1` INTO (PARTITION `p1` VALUES LESS THAN (2020) COMMENT \"recent\")",
+						Reverse: "ALTER TABLE `test`.`logs` REORGANIZE PARTITION `p1` INTO (PARTITION `p1` VALUES LESS THAN (2010))",
+					},
+					{
+						Cmd:     "ALTER TABLE `test`.`logs` ADD PARTITION (PARTITION `p3` VALUES LESS THAN MAXVALUE)",
+						Reverse: "ALTER TABLE `test`.`logs` DROP PARTITION `p3`",
+					},
+				},
+			},
+		},
 		{
 			changes: []schema.Change{
 				&schema.AddSchema{S: schema.New("test").SetCharset("utf8mb4"), Extra: []schema.Clause{&schema.IfNotExists{}}},
@@ -568,6 +728,74 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		// Columns that are not added at the end of the table are positioned explicitly.
+		{
+			changes: []schema.Change{
+				func() schema.Change {
+					users := schema.NewTable("users").
+						AddColumns(
+							schema.NewIntColumn("c0", "int"),
+							schema.NewIntColumn("c1", "int"),
+							schema.NewIntColumn("c2", "int"),
+							schema.NewIntColumn("c3", "int"),
+							schema.NewIntColumn("c4", "int"),
+						)
+					return &schema.ModifyTable{
+						T: users,
+						Changes: []schema.Change{
+							&schema.AddColumn{C: users.Columns[0]},
+							&schema.AddColumn{C: users.Columns[2]},
+							&schema.AddColumn{C: users.Columns[4]},
+						},
+					}
+				}(),
+			},
+			wantPlan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "ALTER TABLE `users` ADD COLUMN `c0` int NOT NULL FIRST, ADD COLUMN `c2` int NOT NULL AFTER `c1`, ADD COLUMN `c4` int NOT NULL",
+						Reverse: "ALTER TABLE `users` DROP COLUMN `c4`, DROP COLUMN `c2`, DROP COLUMN `c0`",
+					},
+				},
+			},
+		},
+		// Moved columns are positioned in the order of the desired table.
+		{
+			changes: []schema.Change{
+				func() schema.Change {
+					from := schema.NewTable("users").
+						AddColumns(
+							schema.NewIntColumn("a", "int"),
+							schema.NewIntColumn("b", "int"),
+							schema.NewIntColumn("c", "int"),
+						)
+					to := schema.NewTable("users").
+						AddColumns(
+							schema.NewIntColumn("c", "int"),
+							schema.NewIntColumn("a", "int"),
+							schema.NewIntColumn("n", "int"),
+							schema.NewIntColumn("b", "int"),
+						)
+					return &schema.ModifyTable{
+						T: to,
+						Changes: []schema.Change{
+							&schema.ModifyColumn{From: from.Columns[2], To: to.Columns[0], Change: schema.ChangePosition},
+							&schema.AddColumn{C: to.Columns[2]},
+							&schema.AddIndex{I: schema.NewIndex("n").AddColumns(to.Columns[2])},
+						},
+					}
+				}(),
+			},
+			wantPlan: &migrate.Plan{
+				Reversible: false,
+				Changes: []*migrate.Change{
+					{
+						Cmd: "ALTER TABLE `users` MODIFY COLUMN `c` int NOT NULL FIRST, ADD COLUMN `n` int NOT NULL AFTER `a`, ADD INDEX `n` (`n`)",
+					},
+				},
+			},
+		},
 		{
 			changes: []schema.Change{
 				func() schema.Change {
@@ -859,6 +1087,36 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		// Renamed and modified columns are changed in one clause.
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: schema.NewTable("t1").
+						SetSchema(schema.New("s1")).
+						AddColumns(schema.NewIntColumn("b", "bigint")),
+					Changes: []schema.Change{
+						&schema.RenameColumn{
+							From: schema.NewIntColumn("a", "int"),
+							To:   schema.NewIntColumn("b", "bigint"),
+						},
+						&schema.ModifyColumn{
+							From:   schema.NewIntColumn("a", "int"),
+							To:     schema.NewIntColumn("b", "bigint"),
+							Change: schema.ChangeType,
+						},
+					},
+				},
+			},
+			wantPlan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "ALTER TABLE `s1`.`t1` CHANGE COLUMN `a` `b` bigint NOT NULL",
+						Reverse: "ALTER TABLE `s1`.`t1` CHANGE COLUMN `b` `a` int NOT NULL",
+					},
+				},
+			},
+		},
 		{
 			changes: []schema.Change{
 				&schema.ModifyTable{
@@ -922,6 +1180,135 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		// Views are dropped before and created after tables.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("s")
+				from := schema.NewView("v1", "SELECT a FROM t").SetSchema(s)
+				to := schema.NewView("v1", "SELECT a, b FROM t").SetSchema(s).SetCheckOption(schema.ViewCheckOptionCascaded)
+				return []schema.Change{
+					&schema.ModifyView{From: from, To: to},
+					&schema.AddTable{T: schema.NewTable("t").SetSchema(s).AddColumns(schema.NewIntColumn("a", "int"), schema.NewIntColumn("b", "int"))},
+					&schema.AddView{V: schema.NewView("v2", "SELECT a FROM t").SetSchema(s)},
+					&schema.DropView{V: schema.NewView("v3", "SELECT 1").SetSchema(s)},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "DROP VIEW `s`.`v3`",
+						Reverse: "CREATE VIEW `s`.`v3` AS SELECT 1",
+					},
+					{
+						Cmd:     "CREATE TABLE `s`.`t` (`a` int NOT NULL, `b` int NOT NULL)",
+						Reverse: "DROP TABLE `s`.`t`",
+					},
+					{
+						Cmd:     "CREATE OR REPLACE VIEW `s`.`v1` AS SELECT a, b FROM t WITH CASCADED CHECK OPTION",
+						Reverse: "CREATE OR REPLACE VIEW `s`.`v1` AS SELECT a FROM t",
+					},
+					{
+						Cmd:     "CREATE VIEW `s`.`v2` AS SELECT a FROM t",
+						Reverse: "DROP VIEW `s`.`v2`",
+					},
+				},
+			},
+		},
+		// Functions and procedures are created before and dropped after tables.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("s")
+				from := schema.NewFunc("f1").SetSchema(s).SetReturnType(&schema.IntegerType{T: "int"}).SetBody("RETURN 1")
+				to := schema.NewFunc("f1").SetSchema(s).SetReturnType(&schema.IntegerType{T: "int"}).SetBody("RETURN 2").AddAttrs(&Deterministic{V: true})
+				return []schema.Change{
+					&schema.AddTable{T: schema.NewTable("t").SetSchema(s).AddColumns(schema.NewIntColumn("a", "int"))},
+					&schema.DropProc{P: schema.NewProc("p1").SetSchema(s).SetBody("BEGIN SELECT 1; END")},
+					&schema.ModifyFunc{From: from, To: to},
+					&schema.AddProc{P: schema.NewProc("p2").SetSchema(s).AddArgs(
+						schema.NewFuncArg("a", &schema.IntegerType{T: "int"}).SetMode(schema.FuncArgModeIn),
+						schema.NewFuncArg("b", &schema.IntegerType{T: "int"}).SetMode(schema.FuncArgModeOut),
+					).SetComment("c").SetBody("BEGIN SET b = a; END")},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "DROP FUNCTION `s`.`f1`",
+						Reverse: "CREATE FUNCTION `s`.`f1` () RETURNS int RETURN 1",
+					},
+					{
+						Cmd:     "CREATE FUNCTION `s`.`f1` () RETURNS int DETERMINISTIC RETURN 2",
+						Reverse: "DROP FUNCTION `s`.`f1`",
+					},
+					{
+						Cmd:     "CREATE PROCEDURE `s`.`p2` (IN `a` int, OUT `b` int) COMMENT \"c\" BEGIN SET b = a; END",
+						Reverse: "DROP PROCEDURE `s`.`p2`",
+					},
+					{
+						Cmd:     "CREATE TABLE `s`.`t` (`a` int NOT NULL)",
+						Reverse: "DROP TABLE `s`.`t`",
+					},
+					{
+						Cmd:     "DROP PROCEDURE `s`.`p1`",
+						Reverse: "CREATE PROCEDURE `s`.`p1` () BEGIN SELECT 1; END",
+					},
+				},
+			},
+		},
+		// Triggers are created with their tables, and are replaced by dropping and creating them again.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("s")
+				users := schema.NewTable("users").SetSchema(s).AddColumns(schema.NewIntColumn("id", "int"))
+				users.AddTriggers(
+					schema.NewTrigger("users_bi").
+						SetActionTime(schema.TriggerTimeBefore).
+						AddEvents(schema.TriggerEvent{Name: schema.TriggerEventInsert}).
+						SetFor(schema.TriggerForRow).
+						SetBody("SET NEW.id = NEW.id + 1"),
+				)
+				pets := schema.NewTable("pets").SetSchema(s).AddColumns(schema.NewIntColumn("id", "int"))
+				from := schema.NewTrigger("pets_au").SetTable(pets).SetActionTime(schema.TriggerTimeAfter).AddEvents(schema.TriggerEvent{Name: schema.TriggerEventUpdate}).SetBody("SET @n = 1")
+				to := schema.NewTrigger("pets_au").SetTable(pets).SetActionTime(schema.TriggerTimeAfter).AddEvents(schema.TriggerEvent{Name: schema.TriggerEventUpdate}).SetBody("SET @n = 2")
+				return []schema.Change{
+					&schema.AddTable{T: users},
+					&schema.ModifyTable{
+						T: pets,
+						Changes: []schema.Change{
+							&schema.ModifyTrigger{From: from, To: to},
+							&schema.AddColumn{C: schema.NewIntColumn("age", "int")},
+						},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "CREATE TABLE `s`.`users` (`id` int NOT NULL)",
+						Reverse: "DROP TABLE `s`.`users`",
+					},
+					{
+						Cmd:     "CREATE TRIGGER `s`.`users_bi` BEFORE INSERT ON `s`.`users` FOR EACH ROW SET NEW.id = NEW.id + 1",
+						Reverse: "DROP TRIGGER `s`.`users_bi`",
+					},
+					{
+						Cmd:     "DROP TRIGGER `s`.`pets_au`",
+						Reverse: "CREATE TRIGGER `s`.`pets_au` AFTER UPDATE ON `s`.`pets` FOR EACH ROW SET @n = 1",
+					},
+					{
+						Cmd:     "ALTER TABLE `s`.`pets` ADD COLUMN `age` int NOT NULL",
+						Reverse: "ALTER TABLE `s`.`pets` DROP COLUMN `age`",
+					},
+					{
+						Cmd:     "CREATE TRIGGER `s`.`pets_au` AFTER UPDATE ON `s`.`pets` FOR EACH ROW SET @n = 2",
+						Reverse: "DROP TRIGGER `s`.`pets_au`",
+					},
+				},
+			},
+		},
 		// Empty qualifier in multi-schema mode should fail.
 		{
 			changes: []schema.Change{
@@ -959,6 +1346,200 @@ func TestPlanChanges(t *testing.T) {
 	}
 }
 
+func TestPlanChanges_OnlineDDL(t *testing.T) {
+	users := func() *schema.Table {
+		return schema.NewTable("users").
+			AddColumns(schema.NewIntColumn("a", "int"), schema.NewIntColumn("b", "int"), schema.NewIntColumn("c", "int"))
+	}
+	allowCopy := func(o *migrate.PlanOptions) { o.OnlineDDL = migrate.OnlineDDLAllowCopy }
+	tests := []struct {
+		version string
+		changes func(*schema.Table) []schema.Change
+		options []migrate.PlanOption
+		want    *migrate.Change
+		wantErr string
+	}{
+		// Disabled by default.
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{&schema.AddColumn{C: t.Columns[2]}}
+			},
+			want: &migrate.Change{
+				Cmd:     "ALTER TABLE `users` ADD COLUMN `c` int NOT NULL",
+				Reverse: "ALTER TABLE `users` DROP COLUMN `c`",
+				Comment: `modify "users" table`,
+			},
+		},
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{&schema.AddColumn{C: t.Columns[1]}}
+			},
+			options: []migrate.PlanOption{allowCopy},
+			want: &migrate.Change{
+				Cmd:     "ALTER TABLE `users` ADD COLUMN `b` int NOT NULL AFTER `a`, ALGORITHM=INSTANT",
+				Reverse: "ALTER TABLE `users` DROP COLUMN `b`, ALGORITHM=INSTANT",
+				Comment: `modify "users" table`,
+			},
+		},
+		// Columns can be added instantly only at the end of the table.
+		{
+			version: "8.0.16",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{&schema.AddColumn{C: t.Columns[1]}}
+			},
+			options: []migrate.PlanOption{allowCopy},
+			want: &migrate.Change{
+				Cmd:     "ALTER TABLE `users` ADD COLUMN `b` int NOT NULL AFTER `a`, ALGORITHM=INPLACE, LOCK=NONE",
+				Reverse: "ALTER TABLE `users` DROP COLUMN `b`, ALGORITHM=INPLACE, LOCK=NONE",
+				Comment: `modify "users" table`,
+			},
+		},
+		{
+			version: "8.0.16",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{&schema.AddColumn{C: t.Columns[2]}}
+			},
+			options: []migrate.PlanOption{allowCopy},
+			want: &migrate.Change{
+				Cmd:     "ALTER TABLE `users` ADD COLUMN `c` int NOT NULL, ALGORITHM=INSTANT",
+				Reverse: "ALTER TABLE `users` DROP COLUMN `c`, ALGORITHM=INPLACE, LOCK=NONE",
+				Comment: `modify "users" table`,
+			},
+		},
+		// The most blocking algorithm is used for the statement.
+		{
+			version: "5.7",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.AddColumn{C: t.Columns[2]},
+					&schema.AddIndex{I: schema.NewIndex("c").AddColumns(t.Columns[2]).AddAttrs(&IndexType{T: IndexTypeFullText})},
+				}
+			},
+			options: []migrate.PlanOption{allowCopy},
+			want: &migrate.Change{
+				Cmd:     "ALTER TABLE `users` ADD COLUMN `c` int NOT NULL, ADD FULLTEXT INDEX `c` (`c`), ALGORITHM=INPLACE, LOCK=SHARED",
+				Reverse: "ALTER TABLE `users` DROP INDEX `c`, DROP COLUMN `c`, ALGORITHM=INPLACE, LOCK=NONE",
+				Comment: `modify "users" table`,
+			},
+		},
+		// Changes that require a table copy are marked.
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.ModifyColumn{From: schema.NewIntColumn("a", "smallint"), To: t.Columns[0], Change: schema.ChangeType},
+				}
+			},
+			options: []migrate.PlanOption{allowCopy},
+			want: &migrate.Change{
+				Cmd:     "ALTER TABLE `users` MODIFY COLUMN `a` int NOT NULL, ALGORITHM=COPY",
+				Reverse: "ALTER TABLE `users` MODIFY COLUMN `a` smallint NOT NULL, ALGORITHM=COPY",
+				Comment: `modify "users" table (requires a table copy for modifying column "a")`,
+			},
+		},
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.ModifyColumn{From: schema.NewIntColumn("a", "smallint"), To: t.Columns[0], Change: schema.ChangeType},
+				}
+			},
+			options: []migrate.PlanOption{
+				func(o *migrate.PlanOptions) { o.OnlineDDL = migrate.OnlineDDLRequire },
+			},
+			wantErr: `alter table "users": modifying column "a" requires a table copy`,
+		},
+		// Repartitioning requires a table copy, and the algorithm options precede the partitioning clauses.
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.AddAttr{A: &Partition{Key: PartitionKey{T: PartitionTypeHash, Expr: "`a`"}, Defs: []*PartitionDef{{Name: "p0"}}}},
+				}
+			},
+			options: []migrate.PlanOption{allowCopy},
+			want: &migrate.Change{
+				Cmd:     "ALTER TABLE `users` ALGORITHM=COPY PARTITION BY HASH (`a`) (PARTITION `p0`)",
+				Reverse: "ALTER TABLE `users` ALGORITHM=COPY REMOVE PARTITIONING",
+				Comment: `modify "users" table partitioning (requires a table copy for repartitioning the table)`,
+			},
+		},
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{
+					&schema.DropAttr{A: &Partition{Key: PartitionKey{T: PartitionTypeHash, Expr: "`a`"}, Defs: []*PartitionDef{{Name: "p0"}}}},
+				}
+			},
+			options: []migrate.PlanOption{
+				func(o *migrate.PlanOptions) { o.OnlineDDL = migrate.OnlineDDLRequire },
+			},
+			wantErr: `alter table "users": repartitioning the table requires a table copy`,
+		},
+		// Adding RANGE and LIST partitions permits concurrent DML.
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				from := &Partition{Key: PartitionKey{T: PartitionTypeRange, Expr: "`a`"}, Defs: []*PartitionDef{{Name: "p0", Values: "10"}}}
+				to := &Partition{Key: PartitionKey{T: PartitionTypeRange, Expr: "`a`"}, Defs: []*PartitionDef{{Name: "p0", Values: "10"}, {Name: "p1", Values: "MAXVALUE"}}}
+				return []schema.Change{&schema.ModifyAttr{From: from, To: to}}
+			},
+			options: []migrate.PlanOption{
+				func(o *migrate.PlanOptions) { o.OnlineDDL = migrate.OnlineDDLRequire },
+			},
+			want: &migrate.Change{
+				Cmd:     "ALTER TABLE `users` ALGORITHM=INPLACE, LOCK=NONE, ADD PARTITION (PARTITION `p1` VALUES LESS THAN MAXVALUE)",
+				Reverse: "ALTER TABLE `users` ALGORITHM=INPLACE, LOCK=NONE, DROP PARTITION `p1`",
+				Comment: `modify "users" table partitioning`,
+			},
+		},
+		// Adding HASH and KEY partitions permits only concurrent reads.
+		{
+			version: "8.0.30",
+			changes: func(t *schema.Table) []schema.Change {
+				from := &Partition{Key: PartitionKey{T: PartitionTypeHash, Expr: "`a`"}, Defs: []*PartitionDef{{Name: "p0"}}}
+				to := &Partition{Key: PartitionKey{T: PartitionTypeHash, Expr: "`a`"}, Defs: []*PartitionDef{{Name: "p0"}, {Name: "p1"}}}
+				return []schema.Change{&schema.ModifyAttr{From: from, To: to}}
+			},
+			options: []migrate.PlanOption{allowCopy},
+			want: &migrate.Change{
+				Cmd:     "ALTER TABLE `users` ALGORITHM=INPLACE, LOCK=SHARED, ADD PARTITION (PARTITION `p1`)",
+				Reverse: "ALTER TABLE `users` ALGORITHM=INPLACE, LOCK=SHARED, COALESCE PARTITION 1",
+				Comment: `modify "users" table partitioning`,
+			},
+		},
+		{
+			version: "5.5.8",
+			changes: func(t *schema.Table) []schema.Change {
+				return []schema.Change{&schema.AddColumn{C: t.Columns[2]}}
+			},
+			options: []migrate.PlanOption{
+				func(o *migrate.PlanOptions) { o.OnlineDDL = migrate.OnlineDDLRequire },
+			},
+			wantErr: `online schema changes are not supported by MySQL version "5.5.8"`,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			db, _, err := newMigrate(tt.version)
+			require.NoError(t, err)
+			u := users()
+			plan, err := db.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: u, Changes: tt.changes(u)}}, tt.options...)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, plan.Changes, 1)
+			require.Equal(t, tt.want.Cmd, plan.Changes[0].Cmd)
+			require.Equal(t, tt.want.Reverse, plan.Changes[0].Reverse)
+			require.Equal(t, tt.want.Comment, plan.Changes[0].Comment)
+		})
+	}
+}
+
 func newMigrate(version string) (migrate.PlanApplier, *mock, error) {
 	db, m, err := sqlmock.New()
 	if err != nil {
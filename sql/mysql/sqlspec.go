@@ -22,6 +22,9 @@ import (
 
 type doc struct {
 	Tables  []*sqlspec.Table  `spec:"table"`
+	Views   []*sqlspec.View   `spec:"view"`
+	Funcs   []*sqlspec.Func   `spec:"function"`
+	Procs   []*sqlspec.Proc   `spec:"procedure"`
 	Schemas []*sqlspec.Schema `spec:"schema"`
 }
 
@@ -37,6 +40,15 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 		if err != nil {
 			return fmt.Errorf("mysql: failed converting to *schema.Realm: %w", err)
 		}
+		if err := specutil.ScanViews(v, d.Views, convertView); err != nil {
+			return fmt.Errorf("mysql: failed converting views: %w", err)
+		}
+		if err := specutil.ScanFuncs(v, d.Funcs, d.Procs, convertFunc, convertProc); err != nil {
+			return fmt.Errorf("mysql: failed converting functions: %w", err)
+		}
+		if err := specutil.ScanTriggers(v, d.Tables, convertTrigger); err != nil {
+			return fmt.Errorf("mysql: failed converting triggers: %w", err)
+		}
 		for _, schemaSpec := range d.Schemas {
 			schm, ok := v.Schema(schemaSpec.Name)
 			if !ok {
@@ -54,6 +66,15 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 		if err := specutil.Scan(&r, d.Schemas, d.Tables, convertTable); err != nil {
 			return err
 		}
+		if err := specutil.ScanViews(&r, d.Views, convertView); err != nil {
+			return err
+		}
+		if err := specutil.ScanFuncs(&r, d.Funcs, d.Procs, convertFunc, convertProc); err != nil {
+			return err
+		}
+		if err := specutil.ScanTriggers(&r, d.Tables, convertTrigger); err != nil {
+			return err
+		}
 		if err := convertCharset(d.Schemas[0], &r.Schemas[0].Attrs); err != nil {
 			return err
 		}
@@ -67,7 +88,7 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 
 // MarshalSpec marshals v into an Atlas DDL document using a schemahcl.Marshaler.
 func MarshalSpec(v any, marshaler schemahcl.Marshaler) ([]byte, error) {
-	return specutil.Marshal(v, marshaler, schemaSpec)
+	return specutil.Marshal(v, marshaler, schemaSpec, viewSpec, funcSpec, procSpec)
 }
 
 var (
@@ -75,8 +96,16 @@ var (
 		schemahcl.WithTypes(TypeRegistry.Specs()),
 		schemahcl.WithScopedEnums("table.index.type", IndexTypeBTree, IndexTypeHash, IndexTypeFullText, IndexTypeSpatial),
 		schemahcl.WithScopedEnums("table.column.as.type", stored, persistent, virtual),
+		schemahcl.WithScopedEnums("table.partition.type", PartitionTypeRange, PartitionTypeList, PartitionTypeHash, PartitionTypeKey),
+		schemahcl.WithScopedEnums("table.partition.subpartition.type", PartitionTypeHash, PartitionTypeKey),
 		schemahcl.WithScopedEnums("table.foreign_key.on_update", specutil.ReferenceVars...),
 		schemahcl.WithScopedEnums("table.foreign_key.on_delete", specutil.ReferenceVars...),
+		schemahcl.WithScopedEnums("view.check_option", schema.ViewCheckOptionLocal, schema.ViewCheckOptionCascaded),
+		schemahcl.WithScopedEnums("function.arg.mode", schema.FuncArgModeIn),
+		schemahcl.WithScopedEnums("procedure.arg.mode", schema.FuncArgModeIn, schema.FuncArgModeOut, schema.FuncArgModeInOut),
+		schemahcl.WithScopedEnums("table.trigger.timing", schema.TriggerTimeBefore, schema.TriggerTimeAfter),
+		schemahcl.WithScopedEnums("table.trigger.events", schema.TriggerEventInsert, schema.TriggerEventUpdate, schema.TriggerEventDelete),
+		schemahcl.WithScopedEnums("table.trigger.foreach", schema.TriggerForRow),
 	)
 	// MarshalHCL marshals v into an Atlas HCL DDL document.
 	MarshalHCL = schemahcl.MarshalerFunc(func(v any) ([]byte, error) {
@@ -110,9 +139,122 @@ func convertTable(spec *sqlspec.Table, parent *schema.Schema) (*schema.Table, er
 		}
 		t.AddAttrs(&AutoIncrement{V: v})
 	}
+	if err := convertPartition(spec.Extra, t); err != nil {
+		return nil, err
+	}
 	return t, err
 }
 
+// convertPartition converts and appends the partition block into the table attributes if exists.
+func convertPartition(spec schemahcl.Resource, table *schema.Table) error {
+	r, ok := spec.Resource("partition")
+	if !ok {
+		return nil
+	}
+	p := &Partition{}
+	if err := convertPartitionKey(r, table, &p.Key); err != nil {
+		return fmt.Errorf("parsing %s.partition: %w", table.Name, err)
+	}
+	if sub, ok := r.Resource("subpartition"); ok {
+		p.Sub = &PartitionKey{}
+		if err := convertPartitionKey(sub, table, p.Sub); err != nil {
+			return fmt.Errorf("parsing %s.partition.subpartition: %w", table.Name, err)
+		}
+	}
+	var defs struct {
+		Parts []*struct {
+			Name    string   `spec:",name"`
+			Values  string   `spec:"values"`
+			Comment string   `spec:"comment"`
+			Subs    []string `spec:"subpartitions"`
+		} `spec:"part"`
+	}
+	if err := r.As(&defs); err != nil {
+		return fmt.Errorf("parsing %s.partition: %w", table.Name, err)
+	}
+	for _, d := range defs.Parts {
+		p.Defs = append(p.Defs, &PartitionDef{Name: d.Name, Values: d.Values, Comment: d.Comment, Subs: d.Subs})
+	}
+	table.AddAttrs(p)
+	return nil
+}
+
+// convertPartitionKey converts the partitioning type and key of the given resource.
+func convertPartitionKey(r *schemahcl.Resource, table *schema.Table, k *PartitionKey) error {
+	var spec struct {
+		Type    string           `spec:"type"`
+		Linear  bool             `spec:"linear"`
+		Expr    string           `spec:"expr"`
+		Columns []*schemahcl.Ref `spec:"columns"`
+	}
+	if err := r.As(&spec); err != nil {
+		return err
+	}
+	switch {
+	case spec.Type == "":
+		return errors.New("missing attribute type")
+	case spec.Expr != "" && len(spec.Columns) > 0:
+		return errors.New(`multiple definitions, use "columns" or "expr"`)
+	}
+	k.T, k.Linear, k.Expr = spec.Type, spec.Linear, spec.Expr
+	for _, ref := range spec.Columns {
+		c, err := specutil.ColumnByRef(table, ref)
+		if err != nil {
+			return err
+		}
+		k.Columns = append(k.Columns, c)
+	}
+	return nil
+}
+
+// convertView converts a sqlspec.View to a schema.View.
+func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error) {
+	return specutil.View(spec, parent, convertColumnType)
+}
+
+// convertTrigger converts a sqlspec.Trigger to a schema.Trigger.
+func convertTrigger(spec *sqlspec.Trigger, parent *schema.Table) (*schema.Trigger, error) {
+	return specutil.Trigger(spec, parent, schema.TriggerForRow)
+}
+
+// convertFunc converts a sqlspec.Func to a schema.Func.
+func convertFunc(spec *sqlspec.Func, parent *schema.Schema) (*schema.Func, error) {
+	f, err := specutil.Func(spec, parent, convertColumnType)
+	if err != nil {
+		return nil, err
+	}
+	if err := convertDeterministic(spec, &f.Attrs); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// convertProc converts a sqlspec.Proc to a schema.Proc.
+func convertProc(spec *sqlspec.Proc, parent *schema.Schema) (*schema.Proc, error) {
+	p, err := specutil.Proc(spec, parent, convertColumnType)
+	if err != nil {
+		return nil, err
+	}
+	if err := convertDeterministic(spec, &p.Attrs); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// convertDeterministic converts the "deterministic" attribute of a routine spec.
+func convertDeterministic(spec specutil.Attrer, attrs *[]schema.Attr) error {
+	a, ok := spec.Attr("deterministic")
+	if !ok {
+		return nil
+	}
+	b, err := a.Bool()
+	if err != nil {
+		return err
+	}
+	*attrs = append(*attrs, &Deterministic{V: b})
+	return nil
+}
+
 // convertIndex converts a sqlspec.Index into a schema.Index.
 func convertIndex(spec *sqlspec.Index, parent *schema.Table) (*schema.Index, error) {
 	idx, err := specutil.Index(spec, parent, convertPart)
@@ -229,9 +371,58 @@ func tableSpec(t *schema.Table) (*sqlspec.Table, error) {
 	if c, ok := hasCollate(t.Attrs, t.Schema.Attrs); ok {
 		ts.Extra.Attrs = append(ts.Extra.Attrs, schemahcl.StringAttr("collate", c))
 	}
+	if p := (Partition{}); sqlx.Has(t.Attrs, &p) {
+		ts.Extra.Children = append(ts.Extra.Children, fromPartition(&p))
+	}
 	return ts, nil
 }
 
+// fromPartition returns the resource spec for representing the table partitioning.
+func fromPartition(p *Partition) *schemahcl.Resource {
+	spec := fromPartitionKey("partition", &p.Key)
+	if p.Sub != nil {
+		spec.Children = append(spec.Children, fromPartitionKey("subpartition", p.Sub))
+	}
+	for _, d := range p.Defs {
+		part := &schemahcl.Resource{Type: "part", Name: d.Name}
+		if d.Values != "" {
+			part.Attrs = append(part.Attrs, schemahcl.StringAttr("values", d.Values))
+		}
+		if d.Comment != "" {
+			part.Attrs = append(part.Attrs, schemahcl.StringAttr("comment", d.Comment))
+		}
+		if len(d.Subs) > 0 {
+			part.Attrs = append(part.Attrs, schemahcl.StringsAttr("subpartitions", d.Subs...))
+		}
+		spec.Children = append(spec.Children, part)
+	}
+	return spec
+}
+
+// fromPartitionKey returns the resource spec for representing the partitioning type and key.
+func fromPartitionKey(typ string, k *PartitionKey) *schemahcl.Resource {
+	spec := &schemahcl.Resource{
+		Type: typ,
+		Attrs: []*schemahcl.Attr{
+			specutil.VarAttr("type", strings.ToUpper(k.T)),
+		},
+	}
+	if k.Linear {
+		spec.Attrs = append(spec.Attrs, schemahcl.BoolAttr("linear", true))
+	}
+	if k.Expr != "" {
+		spec.Attrs = append(spec.Attrs, schemahcl.StringAttr("expr", k.Expr))
+	}
+	if len(k.Columns) > 0 {
+		refs := make([]*schemahcl.Ref, 0, len(k.Columns))
+		for _, c := range k.Columns {
+			refs = append(refs, specutil.ColumnRef(c.Name))
+		}
+		spec.Attrs = append(spec.Attrs, schemahcl.RefsAttr("columns", refs...))
+	}
+	return spec
+}
+
 func indexSpec(idx *schema.Index) (*sqlspec.Index, error) {
 	spec, err := specutil.FromIndex(idx, partAttr)
 	if err != nil {
@@ -251,6 +442,35 @@ func partAttr(_ *schema.Index, part *schema.IndexPart, spec *sqlspec.IndexPart)
 	return nil
 }
 
+// funcSpec converts from a concrete MySQL schema.Func into a sqlspec.Func.
+func funcSpec(f *schema.Func) (*sqlspec.Func, error) {
+	spec, err := specutil.FromFunc(f, columnTypeSpec)
+	if err != nil {
+		return nil, err
+	}
+	if deterministic(f.Attrs) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("deterministic", true))
+	}
+	return spec, nil
+}
+
+// procSpec converts from a concrete MySQL schema.Proc into a sqlspec.Proc.
+func procSpec(p *schema.Proc) (*sqlspec.Proc, error) {
+	spec, err := specutil.FromProc(p, columnTypeSpec)
+	if err != nil {
+		return nil, err
+	}
+	if deterministic(p.Attrs) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("deterministic", true))
+	}
+	return spec, nil
+}
+
+// viewSpec converts from a concrete MySQL schema.View into a sqlspec.View.
+func viewSpec(v *schema.View) (*sqlspec.View, error) {
+	return specutil.FromView(v, columnTypeSpec)
+}
+
 // columnSpec converts from a concrete MySQL schema.Column into a sqlspec.Column.
 func columnSpec(c *schema.Column, t *schema.Table) (*sqlspec.Column, error) {
 	spec, err := specutil.FromColumn(c, columnTypeSpec)
@@ -541,6 +541,114 @@ schema "test" {
 	require.EqualValues(t, expected, string(buf))
 }
 
+func TestUnmarshalSpec_Partition(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `
+schema "test" {}
+table "logs" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+	column "year" {
+		type = int
+	}
+	partition {
+		type = RANGE
+		columns = [column.year]
+		subpartition {
+			type = KEY
+			linear = true
+			columns = [column.id]
+		}
+		part "p0" {
+			values = "2000"
+			comment = "old"
+			subpartitions = ["s0", "s1"]
+		}
+		part "p1" {
+			values = "MAXVALUE"
+			subpartitions = ["s2", "s3"]
+		}
+	}
+}
+`
+	)
+	err := EvalHCLBytes([]byte(f), &s, nil)
+	require.NoError(t, err)
+	id, year := schema.NewIntColumn("id", "int"), schema.NewIntColumn("year", "int")
+	exp := schema.New("test").
+		AddTables(
+			schema.NewTable("logs").
+				AddColumns(id, year).
+				AddAttrs(&Partition{
+					Key: PartitionKey{T: PartitionTypeRange, Columns: []*schema.Column{year}},
+					Sub: &PartitionKey{T: PartitionTypeKey, Linear: true, Columns: []*schema.Column{id}},
+					Defs: []*PartitionDef{
+						{Name: "p0", Values: "2000", Comment: "old", Subs: []string{"s0", "s1"}},
+						{Name: "p1", Values: "MAXVALUE", Subs: []string{"s2", "s3"}},
+					},
+				}),
+		)
+	require.EqualValues(t, exp, &s)
+
+	err = EvalHCLBytes([]byte(`
+schema "test" {}
+table "logs" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+	partition {
+		type = HASH
+		expr = "id"
+		columns = [column.id]
+	}
+}
+`), &s, nil)
+	require.EqualError(t, err, `parsing logs.partition: multiple definitions, use "columns" or "expr"`)
+}
+
+func TestMarshalSpec_Partition(t *testing.T) {
+	s := schema.New("test").
+		AddTables(
+			schema.NewTable("logs").
+				AddColumns(schema.NewIntColumn("id", TypeInt)).
+				AddAttrs(&Partition{
+					Key: PartitionKey{T: "list", Expr: "`id` % 3"},
+					Defs: []*PartitionDef{
+						{Name: "p0", Values: "0, 1"},
+						{Name: "p1", Values: "2", Comment: "rest"},
+					},
+				}),
+		)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	const expected = `table "logs" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+  partition {
+    type = LIST
+    expr = "` + "`id`" + ` % 3"
+    part "p0" {
+      values = "0, 1"
+    }
+    part "p1" {
+      values  = "2"
+      comment = "rest"
+    }
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+}
+
 func TestUnmarshalSpec_IndexParts(t *testing.T) {
 	var (
 		s schema.Schema
@@ -1404,3 +1512,43 @@ schema "s2" {
 `,
 		string(got))
 }
+
+func TestMarshalSpec_Trigger(t *testing.T) {
+	s := schema.New("test")
+	users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+	users.AddTriggers(
+		schema.NewTrigger("users_insert").
+			SetActionTime(schema.TriggerTimeBefore).
+			AddEvents(schema.TriggerEvent{Name: schema.TriggerEventInsert}).
+			SetFor(schema.TriggerForRow).
+			SetBody("SET NEW.id = NEW.id + 1"),
+	)
+	s.AddTables(users)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	require.Equal(t, `table "users" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+  trigger "users_insert" {
+    timing  = BEFORE
+    events  = [INSERT]
+    foreach = ROW
+    as      = "SET NEW.id = NEW.id + 1"
+  }
+}
+schema "test" {
+}
+`, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Tables[0].Triggers, 1)
+	tg := got.Tables[0].Triggers[0]
+	require.Equal(t, "users_insert", tg.Name)
+	require.Equal(t, schema.TriggerTimeBefore, tg.ActionTime)
+	require.Equal(t, []schema.TriggerEvent{{Name: schema.TriggerEventInsert}}, tg.Events)
+	require.Equal(t, schema.TriggerForRow, tg.For)
+	require.Equal(t, "SET NEW.id = NEW.id + 1", tg.Body)
+}
@@ -326,7 +326,8 @@ SELECT
 	t4.typtype,
 	t4.typelem,
 	(CASE WHEN t4.typcategory = 'A' AND t4.typelem <> 0 THEN (SELECT t.typtype FROM pg_catalog.pg_type t WHERE t.oid = t4.typelem) END) AS elemtyp,
-	t4.oid
+	t4.oid,
+	a.attislocal AS is_local
 FROM
 	"information_schema"."columns" AS t1
 	JOIN pg_catalog.pg_namespace AS t2 ON t2.nspname = t1.table_schema
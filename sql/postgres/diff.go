@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -40,11 +41,165 @@ func (d *diff) TableAttrDiff(from, to *schema.Table) ([]schema.Change, error) {
 	if err := d.partitionChanged(from, to); err != nil {
 		return nil, err
 	}
+	if change := partitionOfChange(from, to); change != nil {
+		changes = append(changes, change)
+	}
+	if change := inheritsChange(from, to); change != nil {
+		changes = append(changes, change)
+	}
+	changes = append(changes, storageChanges(from, to)...)
+	if change := rowSecurityChange(from, to); change != nil {
+		changes = append(changes, change)
+	}
+	changes = append(changes, policiesDiff(from, to)...)
 	return append(changes, sqlx.CheckDiff(from, to, func(c1, c2 *schema.Check) bool {
 		return sqlx.Has(c1.Attrs, &NoInherit{}) == sqlx.Has(c2.Attrs, &NoInherit{})
 	})...), nil
 }
 
+// rowSecurityChange returns the change for migrating the row-level
+// security attributes of the table from one state to the other.
+func rowSecurityChange(from, to *schema.Table) schema.Change {
+	var fromR, toR RowSecurity
+	fromHas, toHas := sqlx.Has(from.Attrs, &fromR), sqlx.Has(to.Attrs, &toR)
+	switch {
+	case fromR == toR:
+		return nil
+	case !fromHas:
+		return &schema.AddAttr{A: &toR}
+	case !toHas:
+		return &schema.DropAttr{A: &fromR}
+	default:
+		return &schema.ModifyAttr{From: &fromR, To: &toR}
+	}
+}
+
+// policiesDiff returns the changes for migrating the policies of the table from one state to the other.
+func policiesDiff(from, to *schema.Table) []schema.Change {
+	var changes []schema.Change
+	for _, p1 := range policies(from.Attrs) {
+		switch p2, ok := policy(to.Attrs, p1.Name); {
+		case !ok:
+			changes = append(changes, &schema.DropAttr{A: p1})
+		case policyChanged(p1, p2):
+			changes = append(changes, &schema.ModifyAttr{From: p1, To: p2})
+		}
+	}
+	for _, p2 := range policies(to.Attrs) {
+		if _, ok := policy(from.Attrs, p2.Name); !ok {
+			changes = append(changes, &schema.AddAttr{A: p2})
+		}
+	}
+	return changes
+}
+
+// policyChanged reports if the policy definition was changed.
+func policyChanged(from, to *Policy) bool {
+	switch {
+	case policyAs(from) != policyAs(to), policyCmd(from) != policyCmd(to):
+		return true
+	case policyExpr(from.Using) != policyExpr(to.Using), policyExpr(from.Check) != policyExpr(to.Check):
+		return true
+	case len(from.Roles) != len(to.Roles):
+		return true
+	}
+	for i := range from.Roles {
+		if from.Roles[i] != to.Roles[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// policies returns the policies of the table.
+func policies(attrs []schema.Attr) []*Policy {
+	var ps []*Policy
+	for _, a := range attrs {
+		if p, ok := a.(*Policy); ok {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// policy returns the policy with the given name from the table attributes.
+func policy(attrs []schema.Attr, name string) (*Policy, bool) {
+	for _, a := range attrs {
+		if p, ok := a.(*Policy); ok && p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// policyAs returns the kind of the policy, with PERMISSIVE as the default.
+func policyAs(p *Policy) string {
+	if p.As == "" {
+		return PolicyAsPermissive
+	}
+	return strings.ToUpper(p.As)
+}
+
+// policyCmd returns the command of the policy, with ALL as the default.
+func policyCmd(p *Policy) string {
+	if p.Command == "" {
+		return PolicyCmdAll
+	}
+	return strings.ToUpper(p.Command)
+}
+
+// policyExpr returns the normalized form of the given policy expression.
+func policyExpr(x string) string {
+	if x == "" {
+		return ""
+	}
+	return sqlx.MayWrap(x)
+}
+
+// Normalize implements the sqlx.Normalizer interface.
+func (d *diff) Normalize(from, to *schema.Table) error {
+	// Columns and checks that are inherited from the parent tables are
+	// managed by them, and therefore, excluded from the child table diff.
+	if inheritsOf(from) != nil || inheritsOf(to) != nil {
+		dropInherited(from, to)
+		dropInherited(to, from)
+	}
+	return nil
+}
+
+// dropInherited drops the inherited columns and checks from the
+// table, unless they are defined locally by the other table.
+func dropInherited(t, other *schema.Table) {
+	columns := make([]*schema.Column, 0, len(t.Columns))
+	for _, c := range t.Columns {
+		if c2, ok := other.Column(c.Name); sqlx.Has(c.Attrs, &Inherited{}) && (!ok || sqlx.Has(c2.Attrs, &Inherited{})) {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	t.Columns = columns
+	attrs := make([]schema.Attr, 0, len(t.Attrs))
+	for _, a := range t.Attrs {
+		if c, ok := a.(*schema.Check); ok && sqlx.Has(c.Attrs, &Inherited{}) {
+			if c2, ok := checkByName(other.Attrs, c.Name); !ok || sqlx.Has(c2.Attrs, &Inherited{}) {
+				continue
+			}
+		}
+		attrs = append(attrs, a)
+	}
+	t.Attrs = attrs
+}
+
+// checkByName returns the check constraint with the given name from the attributes.
+func checkByName(attrs []schema.Attr, name string) (*schema.Check, bool) {
+	for _, a := range attrs {
+		if c, ok := a.(*schema.Check); ok && c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
 // ColumnChange returns the schema changes (if any) for migrating one column to the other.
 func (d *diff) ColumnChange(_ *schema.Table, from, to *schema.Column) (schema.ChangeKind, error) {
 	change := sqlx.CommentChange(from.Attrs, to.Attrs)
@@ -76,6 +231,398 @@ func (d *diff) ColumnChange(_ *schema.Table, from, to *schema.Column) (schema.Ch
 	return change, nil
 }
 
+// FuncChanged reports if the function definition was changed.
+func (d *diff) FuncChanged(from, to *schema.Func) (bool, error) {
+	if volatility(from.Attrs) != volatility(to.Attrs) {
+		return true, nil
+	}
+	return sqlx.FuncChanged(from, to, d.funcTypeChanged)
+}
+
+// ProcChanged reports if the procedure definition was changed.
+func (d *diff) ProcChanged(from, to *schema.Proc) (bool, error) {
+	return sqlx.ProcChanged(from, to, d.funcTypeChanged)
+}
+
+// RoleChanged reports if the role attributes were changed.
+func (d *diff) RoleChanged(from, to *schema.Role) (bool, error) {
+	var o1, o2 RoleOptions
+	sqlx.Has(from.Attrs, &o1)
+	sqlx.Has(to.Attrs, &o2)
+	return from.Login != to.Login || o1 != o2, nil
+}
+
+// funcTypeChanged reports if the type of function argument (or its return type) was changed.
+func (d *diff) funcTypeChanged(from, to schema.Type) (bool, error) {
+	return d.typeChanged(
+		&schema.Column{Type: &schema.ColumnType{Type: from}},
+		&schema.Column{Type: &schema.ColumnType{Type: to}},
+	)
+}
+
+// ObjectDiff returns a changeset for migrating the extensions, enum types
+// and the standalone sequences of the schema from one state to the other.
+func (d *diff) ObjectDiff(from, to *schema.Schema) ([]schema.Change, error) {
+	// Extensions are created before the rest of the
+	// objects, as they may depend on them, and dropped
+	// after them.
+	changes, drops := extensionsDiff(from, to)
+	// Types are created in their dependency order (enums, domains
+	// and composite types), and dropped in the reverse order.
+	enums, enumDrops := enumsDiff(from, to)
+	domains, domainDrops, err := d.domainsDiff(from, to)
+	if err != nil {
+		return nil, err
+	}
+	composites, compositeDrops, err := d.compositesDiff(from, to)
+	if err != nil {
+		return nil, err
+	}
+	changes = concat(changes, enums, domains, composites)
+	drops = concat(compositeDrops, domainDrops, enumDrops, drops)
+	// Drop or modify sequences.
+	for _, o1 := range from.Objects {
+		s1, ok := o1.(*Sequence)
+		if !ok {
+			continue
+		}
+		s2, ok := sequence(to, s1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: s1})
+			continue
+		}
+		changed, err := sequenceChanged(s1, s2)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyObject{From: s1, To: s2})
+		}
+	}
+	// Add sequences.
+	for _, o2 := range to.Objects {
+		s2, ok := o2.(*Sequence)
+		if !ok {
+			continue
+		}
+		if _, ok := sequence(from, s2.Name); !ok {
+			changes = append(changes, &schema.AddObject{O: s2})
+		}
+	}
+	return append(changes, drops...), nil
+}
+
+// extensionsDiff returns the changes for migrating the extensions of schema "from" to
+// the ones of schema "to". The extension removals are returned separately in "drops".
+func extensionsDiff(from, to *schema.Schema) (changes, drops []schema.Change) {
+	for _, o1 := range from.Objects {
+		e1, ok := o1.(*Extension)
+		if !ok {
+			continue
+		}
+		switch e2, ok := extension(to, e1.Name); {
+		case !ok:
+			drops = append(drops, &schema.DropObject{O: e1})
+		// Extensions without a version are not updated.
+		case e2.Version != "" && e1.Version != e2.Version:
+			changes = append(changes, &schema.ModifyObject{From: e1, To: e2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		e2, ok := o2.(*Extension)
+		if !ok {
+			continue
+		}
+		if _, ok := extension(from, e2.Name); !ok {
+			changes = append(changes, &schema.AddObject{O: e2})
+		}
+	}
+	return changes, drops
+}
+
+// enumsDiff returns the changes for migrating the enum types of schema "from"
+// to the ones of schema "to". Enum types that are not declared as schema objects
+// in the desired state, but used by its columns, are not dropped. Similarly, enum
+// types that are used by the columns of the current state are not created, as the
+// current state may have been inspected without its objects.
+func enumsDiff(from, to *schema.Schema) (changes, drops []schema.Change) {
+	fromUsed, toUsed := usedEnums(from), usedEnums(to)
+	// Drop or modify enums.
+	for _, o1 := range from.Objects {
+		e1, ok := o1.(*schema.EnumType)
+		if !ok {
+			continue
+		}
+		e2, ok := enumObject(to, e1.T)
+		if !ok {
+			e2, ok = toUsed[e1.T]
+		}
+		switch {
+		case !ok:
+			drops = append(drops, &schema.DropObject{O: e1})
+		case !sqlx.ValuesEqual(e1.Values, e2.Values):
+			changes = append(changes, &schema.ModifyObject{From: e1, To: e2})
+		}
+	}
+	// Add enums.
+	for _, o2 := range to.Objects {
+		e2, ok := o2.(*schema.EnumType)
+		if !ok {
+			continue
+		}
+		_, declared := enumObject(from, e2.T)
+		if _, used := fromUsed[e2.T]; !declared && !used {
+			changes = append(changes, &schema.AddObject{O: e2})
+		}
+	}
+	return changes, drops
+}
+
+// usedEnums returns the enum types of the given schema that are used by its table columns.
+func usedEnums(s *schema.Schema) map[string]*schema.EnumType {
+	used := make(map[string]*schema.EnumType)
+	for _, t := range s.Tables {
+		for _, c := range t.Columns {
+			if e, ok := hasEnumType(c); ok && (e.Schema == nil || e.Schema.Name == s.Name) {
+				used[e.T] = e
+			}
+		}
+	}
+	return used
+}
+
+// domainsDiff returns the changes for migrating the domain types of schema "from" to
+// the ones of schema "to". The domain removals are returned separately in "drops".
+func (d *diff) domainsDiff(from, to *schema.Schema) (changes, drops []schema.Change, err error) {
+	for _, o1 := range from.Objects {
+		d1, ok := o1.(*Domain)
+		if !ok {
+			continue
+		}
+		d2, ok := domain(to, d1.T)
+		if !ok {
+			drops = append(drops, &schema.DropObject{O: d1})
+			continue
+		}
+		changed, err := d.domainChanged(d1, d2)
+		if err != nil {
+			return nil, nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyObject{From: d1, To: d2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		d2, ok := o2.(*Domain)
+		if !ok {
+			continue
+		}
+		if _, ok := domain(from, d2.T); !ok {
+			changes = append(changes, &schema.AddObject{O: d2})
+		}
+	}
+	return changes, drops, nil
+}
+
+// domainChanged reports if the domain definition was changed.
+func (d *diff) domainChanged(from, to *Domain) (bool, error) {
+	if from.Null != to.Null {
+		return true, nil
+	}
+	if drop, add := domainChecksDiff(from, to); len(drop) > 0 || len(add) > 0 {
+		return true, nil
+	}
+	// Domains are compared as columns that use their underlying types.
+	c1 := &schema.Column{Name: from.T, Type: &schema.ColumnType{Type: from.Type}, Default: from.Default}
+	c2 := &schema.Column{Name: to.T, Type: &schema.ColumnType{Type: to.Type}, Default: to.Default}
+	if changed, err := d.typeChanged(c1, c2); err != nil || changed {
+		return changed, err
+	}
+	return d.defaultChanged(c1, c2)
+}
+
+// domainChecksDiff returns the check constraints that were dropped from the domain,
+// and the ones that were added to it. Similar to table checks, constraints are
+// matched by their names, or by their expressions in case they are unnamed.
+func domainChecksDiff(from, to *Domain) (drop, add []*schema.Check) {
+	similar := func(checks []*schema.Check, c *schema.Check) bool {
+		for _, c2 := range checks {
+			if c2.Name != "" && c2.Name == c.Name || c2.Expr == c.Expr {
+				return true
+			}
+		}
+		return false
+	}
+	for _, c1 := range from.Checks {
+		if !similar(to.Checks, c1) {
+			drop = append(drop, c1)
+		}
+	}
+	for _, c2 := range to.Checks {
+		if !similar(from.Checks, c2) {
+			add = append(add, c2)
+		}
+	}
+	return drop, add
+}
+
+// compositesDiff returns the changes for migrating the composite types of schema "from"
+// to the ones of schema "to". The type removals are returned separately in "drops".
+func (d *diff) compositesDiff(from, to *schema.Schema) (changes, drops []schema.Change, err error) {
+	for _, o1 := range from.Objects {
+		c1, ok := o1.(*CompositeType)
+		if !ok {
+			continue
+		}
+		c2, ok := composite(to, c1.T)
+		if !ok {
+			drops = append(drops, &schema.DropObject{O: c1})
+			continue
+		}
+		add, drop, modify, err := d.fieldsDiff(c1, c2)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(add) > 0 || len(drop) > 0 || len(modify) > 0 {
+			changes = append(changes, &schema.ModifyObject{From: c1, To: c2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		c2, ok := o2.(*CompositeType)
+		if !ok {
+			continue
+		}
+		if _, ok := composite(from, c2.T); !ok {
+			changes = append(changes, &schema.AddObject{O: c2})
+		}
+	}
+	return changes, drops, nil
+}
+
+// fieldsDiff returns the fields that were added to the composite type, the ones
+// that were dropped from it, and the ones that their types were changed.
+func (d *diff) fieldsDiff(from, to *CompositeType) (add, drop, modify []*schema.Column, err error) {
+	field := func(t *CompositeType, name string) (*schema.Column, bool) {
+		for _, f := range t.Fields {
+			if f.Name == name {
+				return f, true
+			}
+		}
+		return nil, false
+	}
+	for _, f1 := range from.Fields {
+		f2, ok := field(to, f1.Name)
+		if !ok {
+			drop = append(drop, f1)
+			continue
+		}
+		changed, err := d.typeChanged(f1, f2)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if changed {
+			modify = append(modify, f2)
+		}
+	}
+	for _, f2 := range to.Fields {
+		if _, ok := field(from, f2.Name); !ok {
+			add = append(add, f2)
+		}
+	}
+	return add, drop, modify, nil
+}
+
+// sequenceChanged reports if the sequence options were changed.
+func sequenceChanged(from, to *Sequence) (bool, error) {
+	s1, err := sequenceDefaults(from)
+	if err != nil {
+		return false, err
+	}
+	s2, err := sequenceDefaults(to)
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case s1.Type.(*schema.IntegerType).T != s2.Type.(*schema.IntegerType).T,
+		s1.Start != s2.Start, s1.Increment != s2.Increment,
+		s1.Min != s2.Min, s1.Max != s2.Max,
+		s1.Cache != s2.Cache, s1.Cycle != s2.Cycle:
+		return true, nil
+	}
+	return sequenceOwner(from) != sequenceOwner(to), nil
+}
+
+// sequenceDefaults returns a copy of the sequence with its
+// unset options (zero values) replaced by the database defaults.
+// https://postgresql.org/docs/current/sql-createsequence.html
+func sequenceDefaults(s *Sequence) (*Sequence, error) {
+	c := *s
+	if c.Type == nil {
+		c.Type = &schema.IntegerType{T: TypeBigInt}
+	}
+	t, ok := c.Type.(*schema.IntegerType)
+	if !ok {
+		return nil, fmt.Errorf("postgres: unexpected sequence type %T for %q", c.Type, c.Name)
+	}
+	var minV, maxV int64
+	switch t.T {
+	case TypeSmallInt, TypeInt2:
+		minV, maxV = math.MinInt16, math.MaxInt16
+		c.Type = &schema.IntegerType{T: TypeSmallInt}
+	case TypeInteger, TypeInt4, TypeInt:
+		minV, maxV = math.MinInt32, math.MaxInt32
+		c.Type = &schema.IntegerType{T: TypeInteger}
+	case TypeBigInt, TypeInt8:
+		minV, maxV = math.MinInt64, math.MaxInt64
+		c.Type = &schema.IntegerType{T: TypeBigInt}
+	default:
+		return nil, fmt.Errorf("postgres: unexpected sequence type %q for %q", t.T, c.Name)
+	}
+	if c.Increment == 0 {
+		c.Increment = 1
+	}
+	// Ascending sequences start from 1 by default, and descending from -1.
+	if c.Min == 0 {
+		c.Min = 1
+		if c.Increment < 0 {
+			c.Min = minV
+		}
+	}
+	if c.Max == 0 {
+		c.Max = maxV
+		if c.Increment < 0 {
+			c.Max = -1
+		}
+	}
+	if c.Start == 0 {
+		c.Start = c.Min
+		if c.Increment < 0 {
+			c.Start = c.Max
+		}
+	}
+	if c.Cache == 0 {
+		c.Cache = 1
+	}
+	return &c, nil
+}
+
+// sequenceOwner returns the qualified owner column of the sequence, if exists.
+func sequenceOwner(s *Sequence) string {
+	if s.Owner.T == nil || s.Owner.C == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s.%s", s.Owner.T.Name, s.Owner.C.Name)
+}
+
+// volatility returns the volatility category of the function.
+// Functions are VOLATILE by default.
+func volatility(attrs []schema.Attr) string {
+	if v := (Volatility{}); sqlx.Has(attrs, &v) && v.V != "" {
+		return strings.ToUpper(v.V)
+	}
+	return VolatilityVolatile
+}
+
 // defaultChanged reports if the default value of a column was changed.
 func (d *diff) defaultChanged(from, to *schema.Column) (bool, error) {
 	d1, ok1 := sqlx.DefaultValue(from)
@@ -136,6 +683,135 @@ func (*diff) partitionChanged(from, to *schema.Table) error {
 	return nil
 }
 
+// inheritsChange returns the change for migrating the parent tables of the table.
+func inheritsChange(from, to *schema.Table) schema.Change {
+	switch p1, p2 := inheritsOf(from), inheritsOf(to); {
+	case (p1 == nil || len(p1.Parents) == 0) && (p2 == nil || len(p2.Parents) == 0):
+		return nil
+	case p1 == nil || len(p1.Parents) == 0:
+		return &schema.AddAttr{A: p2}
+	case p2 == nil || len(p2.Parents) == 0:
+		return &schema.DropAttr{A: p1}
+	case len(parentsDiff(p1, p2)) > 0 || len(parentsDiff(p2, p1)) > 0:
+		return &schema.ModifyAttr{From: p1, To: p2}
+	}
+	return nil
+}
+
+// parentsDiff returns the parent tables of p1 that do not exist in p2.
+func parentsDiff(p1, p2 *Inherits) []*schema.Table {
+	var diff []*schema.Table
+	for _, t1 := range p1.Parents {
+		found := false
+		for _, t2 := range p2.Parents {
+			if t1.Name == t2.Name && (t1.Schema == nil || t2.Schema == nil || t1.Schema.Name == t2.Schema.Name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff = append(diff, t1)
+		}
+	}
+	return diff
+}
+
+// storageChanges returns the changes for migrating the persistence,
+// the tablespace and the storage parameters of the table.
+func storageChanges(from, to *schema.Table) []schema.Change {
+	var changes []schema.Change
+	switch fromU, toU := sqlx.Has(from.Attrs, &Unlogged{}), sqlx.Has(to.Attrs, &Unlogged{}); {
+	case !fromU && toU:
+		changes = append(changes, &schema.AddAttr{A: &Unlogged{}})
+	case fromU && !toU:
+		changes = append(changes, &schema.DropAttr{A: &Unlogged{}})
+	}
+	var fromT, toT Tablespace
+	switch fromHas, toHas := sqlx.Has(from.Attrs, &fromT), sqlx.Has(to.Attrs, &toT); {
+	case !fromHas && toHas:
+		changes = append(changes, &schema.AddAttr{A: &toT})
+	case fromHas && !toHas:
+		changes = append(changes, &schema.DropAttr{A: &fromT})
+	case fromHas && toHas && fromT.Name != toT.Name:
+		changes = append(changes, &schema.ModifyAttr{From: &fromT, To: &toT})
+	}
+	var fromP, toP TableStorageParams
+	switch fromHas, toHas := sqlx.Has(from.Attrs, &fromP), sqlx.Has(to.Attrs, &toP); {
+	case len(fromP.Params) == 0 && len(toP.Params) == 0:
+	case !fromHas:
+		changes = append(changes, &schema.AddAttr{A: &toP})
+	case !toHas:
+		changes = append(changes, &schema.DropAttr{A: &fromP})
+	case storageParamsChanged(&fromP, &toP):
+		changes = append(changes, &schema.ModifyAttr{From: &fromP, To: &toP})
+	}
+	return changes
+}
+
+// storageParamsChanged reports if the table storage parameters were changed.
+func storageParamsChanged(from, to *TableStorageParams) bool {
+	if len(from.Params) != len(to.Params) {
+		return true
+	}
+	for k, v := range from.Params {
+		if v2, ok := to.Params[k]; !ok || !strings.EqualFold(v, v2) {
+			return true
+		}
+	}
+	return false
+}
+
+// partitionOfChange returns the change for attaching or detaching the table
+// from its partitioned (parent) table.
+func partitionOfChange(from, to *schema.Table) schema.Change {
+	switch p1, p2 := partitionOf(from), partitionOf(to); {
+	case p1 == nil && p2 == nil:
+		return nil
+	case p1 == nil:
+		return &schema.AddAttr{A: p2}
+	case p2 == nil:
+		return &schema.DropAttr{A: p1}
+	case partitionOfChanged(p1, p2):
+		return &schema.ModifyAttr{From: p1, To: p2}
+	}
+	return nil
+}
+
+// partitionOfChanged reports if the parent or the bound of a partition was changed.
+func partitionOfChanged(p1, p2 *PartitionOf) bool {
+	switch {
+	case p1.Default != p2.Default, strings.TrimSpace(p1.Bound) != strings.TrimSpace(p2.Bound):
+		return true
+	case p1.Parent == nil || p2.Parent == nil:
+		return p1.Parent != p2.Parent
+	case p1.Parent.Name != p2.Parent.Name:
+		return true
+	case p1.Parent.Schema != nil && p2.Parent.Schema != nil:
+		return p1.Parent.Schema.Name != p2.Parent.Schema.Name
+	}
+	return false
+}
+
+// inheritsOf returns the Inherits attribute of the table, if exists.
+func inheritsOf(t *schema.Table) *Inherits {
+	for _, a := range t.Attrs {
+		if p, ok := a.(*Inherits); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// partitionOf returns the PartitionOf attribute of the table, if exists.
+func partitionOf(t *schema.Table) *PartitionOf {
+	for _, a := range t.Attrs {
+		if p, ok := a.(*PartitionOf); ok {
+			return p
+		}
+	}
+	return nil
+}
+
 // IsGeneratedIndexName reports if the index name was generated by the database.
 func (d *diff) IsGeneratedIndexName(t *schema.Table, idx *schema.Index) bool {
 	names := make([]string, len(idx.Parts))
@@ -176,6 +852,9 @@ func (*diff) IndexAttrChanged(from, to []schema.Attr) bool {
 	if indexIncludeChanged(from, to) {
 		return true
 	}
+	if isExclusion(from) != isExclusion(to) || deferrableChanged(from, to) {
+		return true
+	}
 	s1, ok1 := indexStorageParams(from)
 	s2, ok2 := indexStorageParams(to)
 	return ok1 != ok2 || ok1 && *s1 != *s2
@@ -191,6 +870,10 @@ func (*diff) IndexPartAttrChanged(fromI, toI *schema.Index, i int) bool {
 	if p1.NullsFirst != p2.NullsFirst || p1.NullsLast != p2.NullsLast {
 		return true
 	}
+	var fromX, toX ExcludeOp
+	if sqlx.Has(from.Attrs, &fromX) != sqlx.Has(to.Attrs, &toX) || fromX.Op != toX.Op {
+		return true
+	}
 	var fromOp, toOp IndexOpClass
 	switch fromHas, toHas := sqlx.Has(from.Attrs, &fromOp), sqlx.Has(to.Attrs, &toOp); {
 	case fromHas && toHas:
@@ -208,6 +891,17 @@ func (*diff) IndexPartAttrChanged(fromI, toI *schema.Index, i int) bool {
 	}
 }
 
+// ForeignKeyAttrChanged reports if the foreign key attributes were changed.
+func (*diff) ForeignKeyAttrChanged(from, to []schema.Attr) bool {
+	return deferrableChanged(from, to)
+}
+
+// deferrableChanged reports if the constraint deferrability was changed.
+func deferrableChanged(from, to []schema.Attr) bool {
+	var d1, d2 Deferrable
+	return sqlx.Has(from, &d1) != sqlx.Has(to, &d2) || d1.InitiallyDeferred != d2.InitiallyDeferred
+}
+
 // ReferenceChanged reports if the foreign key referential action was changed.
 func (*diff) ReferenceChanged(from, to schema.ReferenceOption) bool {
 	// According to PostgreSQL, the NO ACTION rule is set
@@ -248,6 +942,12 @@ func (d *diff) typeChanged(from, to *schema.Column) (bool, error) {
 		// Column type was changed if the underlying enum type was changed or values are not equal.
 		changed = !sqlx.ValuesEqual(fromT.Values, toT.Values) || fromT.T != toT.T ||
 			(toT.Schema != nil && fromT.Schema != nil && fromT.Schema.Name != toT.Schema.Name)
+	case *Domain:
+		toT := toT.(*Domain)
+		changed = fromT.T != toT.T || (toT.Schema != nil && fromT.Schema != nil && fromT.Schema.Name != toT.Schema.Name)
+	case *CompositeType:
+		toT := toT.(*CompositeType)
+		changed = fromT.T != toT.T || (toT.Schema != nil && fromT.Schema != nil && fromT.Schema.Name != toT.Schema.Name)
 	case *CurrencyType:
 		toT := toT.(*CurrencyType)
 		changed = fromT.T != toT.T
@@ -5,6 +5,7 @@
 package postgres
 
 import (
+	"math"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -462,6 +463,137 @@ func TestDiff_TableDiff(t *testing.T) {
 				},
 			}
 		}(),
+		func() testcase {
+			var (
+				from = schema.NewTable("t1").
+					SetSchema(schema.New("public")).
+					AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("c", "int"))
+				to = schema.NewTable("t1").
+					SetSchema(schema.New("public")).
+					AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("c", "int"))
+			)
+			from.AddForeignKeys(schema.NewForeignKey("c").AddColumns(from.Columns[1]).SetRefTable(from).AddRefColumns(from.Columns[0]))
+			from.AddIndexes(schema.NewUniqueIndex("u").AddColumns(from.Columns[1]).AddAttrs(&ConType{T: "u"}))
+			to.AddForeignKeys(schema.NewForeignKey("c").AddColumns(to.Columns[1]).SetRefTable(to).AddRefColumns(to.Columns[0]).AddAttrs(&Deferrable{InitiallyDeferred: true}))
+			to.AddIndexes(schema.NewUniqueIndex("u").AddColumns(to.Columns[1]).AddAttrs(&ConType{T: "u"}, &Deferrable{}))
+			return testcase{
+				name: "deferrable constraints",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyIndex{From: from.Indexes[0], To: to.Indexes[0], Change: schema.ChangeAttr},
+					&schema.ModifyForeignKey{From: from.ForeignKeys[0], To: to.ForeignKeys[0], Change: schema.ChangeAttr},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				from = schema.NewTable("t1").
+					SetSchema(schema.New("public")).
+					AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("c", "int"))
+				to = schema.NewTable("t1").
+					SetSchema(schema.New("public")).
+					AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("c", "int"))
+			)
+			from.AddForeignKeys(schema.NewForeignKey("c").AddColumns(from.Columns[1]).SetRefTable(from).AddRefColumns(from.Columns[0]).AddAttrs(&NotValid{}))
+			from.AddChecks(schema.NewCheck().SetName("positive").SetExpr("(c > 0)").AddAttrs(&NotValid{}))
+			to.AddForeignKeys(schema.NewForeignKey("c").AddColumns(to.Columns[1]).SetRefTable(to).AddRefColumns(to.Columns[0]))
+			to.AddChecks(schema.NewCheck().SetName("positive").SetExpr("(c > 0)"))
+			return testcase{
+				name: "constraints pending validation",
+				from: from,
+				to:   to,
+			}
+		}(),
+		func() testcase {
+			var (
+				s      = schema.New("public")
+				parent = schema.NewTable("logs").SetSchema(s)
+				from   = schema.NewTable("logs_2020").SetSchema(s).AddAttrs(&PartitionOf{Parent: parent, Bound: "FROM (1) TO (10)"})
+				to     = schema.NewTable("logs_2020").SetSchema(s).AddAttrs(&PartitionOf{Parent: parent, Bound: "FROM (1) TO (20)"})
+			)
+			return testcase{
+				name: "partition bound",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyAttr{From: from.Attrs[0], To: to.Attrs[0]},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				s    = schema.New("public")
+				from = schema.NewTable("logs_2020").SetSchema(s)
+				to   = schema.NewTable("logs_2020").SetSchema(s).AddAttrs(&PartitionOf{Parent: schema.NewTable("logs").SetSchema(s), Default: true})
+			)
+			return testcase{
+				name: "attach partition",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.AddAttr{A: to.Attrs[0]},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				s    = schema.New("public")
+				from = schema.NewTable("logs_2020").SetSchema(s).AddAttrs(&PartitionOf{Parent: schema.NewTable("logs").SetSchema(s), Default: true})
+				to   = schema.NewTable("logs_2020").SetSchema(s).AddAttrs(&PartitionOf{Parent: schema.NewTable("logs").SetSchema(s), Default: true})
+			)
+			return testcase{
+				name: "same partition",
+				from: from,
+				to:   to,
+			}
+		}(),
+		func() testcase {
+			var (
+				from = schema.NewTable("users").SetSchema(schema.New("public")).
+					AddAttrs(&Unlogged{}, &Tablespace{Name: "slow"}, &TableStorageParams{Params: map[string]string{"fillfactor": "70"}})
+				to = schema.NewTable("users").SetSchema(schema.New("public")).
+					AddAttrs(&Tablespace{Name: "fast"}, &TableStorageParams{Params: map[string]string{"fillfactor": "80", "autovacuum_enabled": "false"}})
+			)
+			return testcase{
+				name: "storage",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.DropAttr{A: &Unlogged{}},
+					&schema.ModifyAttr{From: from.Attrs[1], To: to.Attrs[0]},
+					&schema.ModifyAttr{From: from.Attrs[2], To: to.Attrs[1]},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				s      = schema.New("public")
+				cities = schema.NewTable("cities").SetSchema(s).
+					AddColumns(schema.NewStringColumn("name", "text"), schema.NewIntColumn("population", "integer"))
+				regions = schema.NewTable("regions").SetSchema(s).AddColumns(schema.NewStringColumn("region", "text"))
+				// Inspected tables contain the inherited columns and checks.
+				from = schema.NewTable("capitals").SetSchema(s).
+					AddColumns(
+						schema.NewStringColumn("name", "text").AddAttrs(&Inherited{}),
+						schema.NewIntColumn("population", "integer").AddAttrs(&Inherited{}),
+						schema.NewStringColumn("state", "text"),
+					).
+					AddChecks(schema.NewCheck().SetName("population_check").SetExpr("(population > 0)").AddAttrs(&Inherited{})).
+					AddAttrs(&Inherits{Parents: []*schema.Table{cities}})
+				to = schema.NewTable("capitals").SetSchema(s).
+					AddColumns(schema.NewStringColumn("state", "text")).
+					AddAttrs(&Inherits{Parents: []*schema.Table{cities, regions}})
+			)
+			return testcase{
+				name: "inherits",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyAttr{From: from.Attrs[1], To: to.Attrs[0]},
+				},
+			}
+		}(),
 	}
 	for _, tt := range tests {
 		db, m, err := sqlmock.New()
@@ -511,6 +643,416 @@ func TestDiff_SchemaDiff(t *testing.T) {
 	}, changes)
 }
 
+func TestDiff_ViewsDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := schema.New("public").AddViews(
+		schema.NewView("same", " SELECT id\n   FROM users;"),
+		schema.NewView("changed", "SELECT id FROM users"),
+		schema.NewView("checked", "SELECT id FROM users").SetCheckOption(schema.ViewCheckOptionNone),
+		schema.NewView("dropped", "SELECT id FROM users"),
+	)
+	to := schema.New("public").AddViews(
+		schema.NewView("same", "SELECT id FROM users"),
+		schema.NewView("changed", "SELECT id, name FROM users"),
+		schema.NewView("checked", "SELECT id FROM users"),
+		schema.NewView("added", "SELECT id FROM users"),
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyView{From: from.Views[1], To: to.Views[1]},
+		&schema.DropView{V: from.Views[3]},
+		&schema.AddView{V: to.Views[3]},
+	}, changes)
+
+	to.Views[2].SetCheckOption(schema.ViewCheckOptionLocal)
+	to.Views[0].SetComment("comment")
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 5)
+	require.Equal(t, &schema.ModifyView{From: from.Views[0], To: to.Views[0]}, changes[0])
+	require.Equal(t, &schema.ModifyView{From: from.Views[2], To: to.Views[2]}, changes[2])
+}
+
+func TestDiff_MaterializedViewsDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mat := func(name string, unique bool) *schema.View {
+		v := schema.NewMaterializedView(name, "SELECT id FROM users").AddColumns(schema.NewIntColumn("id", "int"))
+		return v.AddIndexes(schema.NewIndex(name + "_id").SetUnique(unique).AddColumns(v.Columns[0]))
+	}
+	from := schema.New("public").AddViews(
+		mat("same", true),
+		mat("index", true),
+		schema.NewView("kind", "SELECT id FROM users"),
+	)
+	to := schema.New("public").AddViews(
+		mat("same", true),
+		mat("index", false),
+		schema.NewMaterializedView("kind", "SELECT id FROM users"),
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyView{From: from.Views[1], To: to.Views[1]},
+		&schema.ModifyView{From: from.Views[2], To: to.Views[2]},
+	}, changes)
+}
+
+func TestDiff_RenamesDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	users := func(name, column, index string) *schema.Table {
+		t := schema.NewTable(name).AddColumns(
+			schema.NewIntColumn("id", "int"),
+			schema.NewStringColumn(column, "text"),
+		)
+		return t.AddIndexes(schema.NewIndex(index).AddColumns(t.Columns[1]))
+	}
+	from := schema.New("public").AddTables(users("users", "name", "users_name"))
+	to := schema.New("public").AddTables(users("people", "name", "users_name"))
+
+	// Without hints or AskRename, objects are dropped and added.
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.DropTable{T: from.Tables[0]},
+		&schema.AddTable{T: to.Tables[0]},
+	}, changes)
+
+	// Renames detected by definitions are confirmed using AskRename.
+	accept := func(c schema.Change) (bool, error) { return true, nil }
+	changes, err = schema.SchemaDiff(drv, from, to, schema.DiffAskRename(accept))
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.RenameTable{From: from.Tables[0], To: to.Tables[0]},
+	}, changes)
+
+	var asked []schema.Change
+	t1, t2 := users("users", "name", "users_name"), users("users", "full_name", "users_full_name")
+	changes, err = schema.TableDiff(drv, t1, t2, schema.DiffAskRename(func(c schema.Change) (bool, error) {
+		asked = append(asked, c)
+		_, ok := c.(*schema.RenameColumn)
+		return ok, nil
+	}))
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.RenameColumn{From: t1.Columns[1], To: t2.Columns[1]},
+		&schema.DropIndex{I: t1.Indexes[0]},
+		&schema.AddIndex{I: t2.Indexes[0]},
+	}, changes)
+	require.Len(t, asked, 2)
+	require.IsType(t, &schema.RenameColumn{}, asked[0])
+	require.IsType(t, &schema.RenameIndex{}, asked[1])
+
+	// Renames declared explicitly do not require confirmation,
+	// and renamed columns may be modified in the same change.
+	to = schema.New("public").AddTables(users("people", "full_name", "people_full_name"))
+	to.Tables[0].AddAttrs(&schema.Renamed{From: "users"})
+	to.Tables[0].Columns[1].SetNull(true).AddAttrs(&schema.Renamed{From: "name"})
+	to.Tables[0].Indexes[0].AddAttrs(&schema.Renamed{From: "users_name"})
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, &schema.RenameTable{From: from.Tables[0], To: to.Tables[0]}, changes[0])
+	modify, ok := changes[1].(*schema.ModifyTable)
+	require.True(t, ok)
+	require.Equal(t, to.Tables[0], modify.T)
+	require.Len(t, modify.Changes, 3)
+	require.Equal(t, &schema.RenameColumn{From: from.Tables[0].Columns[1], To: to.Tables[0].Columns[1]}, modify.Changes[0])
+	require.True(t, modify.Changes[1].(*schema.ModifyColumn).Change.Is(schema.ChangeNull))
+	require.Equal(t, &schema.RenameIndex{From: from.Tables[0].Indexes[0], To: to.Tables[0].Indexes[0]}, modify.Changes[2])
+}
+
+func TestDiff_PolicyDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := schema.NewRealm(
+		schema.New("public").AddTables(
+			schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "text")),
+			schema.NewTable("pets").AddColumns(schema.NewIntColumn("id", "int")),
+		),
+		schema.New("other"),
+	)
+	to := schema.NewRealm(
+		schema.New("public").AddTables(
+			schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int")),
+		),
+	)
+	changes, err := drv.RealmDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	changes, err = schema.RealmDiff(drv, from, to, schema.DiffSkipChanges(&schema.DropSchema{}, &schema.DropTable{}))
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyTable{T: to.Schemas[0].Tables[0], Changes: []schema.Change{&schema.DropColumn{C: from.Schemas[0].Tables[0].Columns[1]}}},
+	}, changes)
+	changes, err = schema.RealmDiff(drv, from, to, schema.DiffSkipChanges(&schema.DropSchema{}, &schema.DropTable{}, &schema.DropColumn{}))
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	_, err = schema.RealmDiff(drv, from, to, schema.DiffDenyChanges(&schema.DropColumn{}))
+	require.EqualError(t, err, `sql/schema: change drop column "name" of table "users" is denied by the diff policy`)
+	var denied *schema.DeniedChangeError
+	require.ErrorAs(t, err, &denied)
+	// Skipped changes are not checked by the deny policy.
+	changes, err = schema.RealmDiff(drv, from, to, schema.DiffSkipChanges(&schema.DropColumn{}), schema.DiffDenyChanges(&schema.DropColumn{}))
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+}
+
+func TestDiff_ExtensionsDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := schema.New("public").AddObjects(
+		&Extension{Name: "citext", Version: "1.6"},
+		&Extension{Name: "postgis", Version: "3.1.0"},
+		&Extension{Name: "uuid-ossp", Version: "1.1"},
+		&Sequence{Name: "seq"},
+	)
+	to := schema.New("public").AddObjects(
+		// Version was not set.
+		&Extension{Name: "citext"},
+		&Extension{Name: "postgis", Version: "3.2.0"},
+		&Extension{Name: "pgcrypto"},
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[1]},
+		&schema.AddObject{O: to.Objects[2]},
+		&schema.DropObject{O: from.Objects[3]},
+		// Extensions are dropped after the rest of the objects.
+		&schema.DropObject{O: from.Objects[2]},
+	}, changes)
+}
+
+func TestDiff_PoliciesDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := schema.NewTable("users").
+		SetSchema(schema.New("public")).
+		AddAttrs(
+			&RowSecurity{Enabled: true},
+			&Policy{Name: "same", As: PolicyAsPermissive, Command: PolicyCmdAll, Using: "(id > 0)"},
+			&Policy{Name: "changed", Command: PolicyCmdSelect, Roles: []string{"app"}},
+			&Policy{Name: "dropped", Using: "true"},
+		)
+	to := schema.NewTable("users").
+		SetSchema(schema.New("public")).
+		AddAttrs(
+			&RowSecurity{Enabled: true, Forced: true},
+			// Defaults and wrapping parentheses are ignored.
+			&Policy{Name: "same", Using: "id > 0"},
+			&Policy{Name: "changed", Command: PolicyCmdSelect, Roles: []string{"app", "admin"}},
+			&Policy{Name: "added", As: PolicyAsRestrictive, Check: "true"},
+		)
+	changes, err := drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyAttr{From: from.Attrs[0], To: to.Attrs[0]},
+		&schema.ModifyAttr{From: from.Attrs[2], To: to.Attrs[2]},
+		&schema.DropAttr{A: from.Attrs[3]},
+		&schema.AddAttr{A: to.Attrs[3]},
+	}, changes)
+
+	// Row-level security was disabled.
+	changes, err = drv.TableDiff(from, schema.NewTable("users").SetSchema(schema.New("public")))
+	require.NoError(t, err)
+	require.Equal(t, &schema.DropAttr{A: from.Attrs[0]}, changes[0])
+}
+
+func TestDiff_RolesDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var (
+		from = schema.NewRealm(
+			schema.New("public").AddTables(schema.NewTable("users"), schema.NewTable("logs")),
+		)
+		to = schema.NewRealm(
+			schema.New("public").AddTables(schema.NewTable("users")),
+		)
+		fromApp, toApp         = schema.NewUser("app"), schema.NewUser("app")
+		fromAdmin, toAdmin     = schema.NewRole("admin"), schema.NewRole("admin").AddAttrs(&RoleOptions{CreateDB: true})
+		fromReaders, toWriters = schema.NewRole("readers"), schema.NewRole("writers")
+	)
+	from.AddRoles(fromApp, fromAdmin, fromReaders).AddPrivileges(
+		&schema.Privilege{Grantee: fromApp, Schema: from.Schemas[0], Privs: []string{"USAGE", "CREATE"}},
+		&schema.Privilege{Grantee: fromApp, Schema: from.Schemas[0], Table: from.Schemas[0].Tables[0], Privs: []string{"SELECT"}},
+		// Privileges on dropped tables are not revoked.
+		&schema.Privilege{Grantee: fromApp, Schema: from.Schemas[0], Table: from.Schemas[0].Tables[1], Privs: []string{"SELECT"}},
+		&schema.Privilege{Grantee: fromReaders, Schema: from.Schemas[0], Privs: []string{"USAGE"}},
+	)
+	to.AddRoles(toApp, toAdmin, toWriters).AddPrivileges(
+		&schema.Privilege{Grantee: toApp, Schema: to.Schemas[0], Privs: []string{"USAGE"}},
+		&schema.Privilege{Grantee: toApp, Schema: to.Schemas[0], Table: to.Schemas[0].Tables[0], Privs: []string{"SELECT", "INSERT"}},
+		&schema.Privilege{Grantee: toWriters, Schema: to.Schemas[0], Table: to.Schemas[0].Tables[0], Privs: []string{"INSERT"}, Grantable: true},
+	)
+	changes, err := drv.RealmDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.DropTable{T: from.Schemas[0].Tables[1]},
+		&schema.ModifyRole{From: fromAdmin, To: toAdmin},
+		&schema.DropRole{R: fromReaders},
+		&schema.AddRole{R: toWriters},
+		&schema.Revoke{P: &schema.Privilege{Grantee: fromApp, Schema: from.Schemas[0], Privs: []string{"CREATE"}}},
+		&schema.Revoke{P: from.Privileges[3]},
+		&schema.Grant{P: &schema.Privilege{Grantee: toApp, Schema: to.Schemas[0], Table: to.Schemas[0].Tables[0], Privs: []string{"INSERT"}}},
+		&schema.Grant{P: to.Privileges[2]},
+	}, changes)
+}
+
+func TestDiff_EnumsDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := schema.New("public").AddObjects(
+		&schema.EnumType{T: "same", Values: []string{"a", "b"}},
+		&schema.EnumType{T: "changed", Values: []string{"a", "b"}},
+		&schema.EnumType{T: "used", Values: []string{"a"}},
+		&schema.EnumType{T: "dropped", Values: []string{"a"}},
+	)
+	used := &schema.EnumType{T: "used", Values: []string{"a", "b"}}
+	to := schema.New("public").
+		AddObjects(
+			&schema.EnumType{T: "same", Values: []string{"a", "b"}},
+			&schema.EnumType{T: "changed", Values: []string{"b", "a"}},
+			&schema.EnumType{T: "added", Values: []string{"a"}},
+		).
+		// Enums that are used by columns, but not declared as objects, are not dropped.
+		AddTables(schema.NewTable("t").AddColumns(schema.NewColumn("c").SetType(used)))
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 5)
+	require.Equal(t, &schema.AddTable{T: to.Tables[0]}, changes[0])
+	require.Equal(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[1]},
+		&schema.ModifyObject{From: from.Objects[2], To: used},
+		&schema.AddObject{O: to.Objects[2]},
+		&schema.DropObject{O: from.Objects[3]},
+	}, changes[1:])
+}
+
+func TestDiff_EnumsDiffTablesOnly(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	// The current state was inspected without its objects,
+	// but the enum type is known from the column that uses it.
+	from := schema.New("public").
+		AddTables(schema.NewTable("t").AddColumns(
+			schema.NewEnumColumn("c", schema.EnumName("status"), schema.EnumValues("a", "b")),
+		))
+	status := &schema.EnumType{T: "status", Values: []string{"a", "b"}}
+	to := schema.New("public").
+		AddObjects(status).
+		AddTables(schema.NewTable("t").AddColumns(schema.NewColumn("c").SetType(status)))
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestDiff_TypesDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := schema.New("public").AddObjects(
+		&Domain{T: "same", Type: &schema.IntegerType{T: TypeInteger}, Checks: []*schema.Check{{Name: "same_check", Expr: "(VALUE > 0)"}}},
+		&Domain{T: "nullable", Type: &schema.IntegerType{T: TypeInteger}},
+		&Domain{T: "checked", Type: &schema.IntegerType{T: TypeInteger}},
+		&Domain{T: "dropped", Type: &schema.IntegerType{T: TypeInteger}},
+		&CompositeType{T: "same", Fields: []*schema.Column{schema.NewStringColumn("a", TypeText)}},
+		&CompositeType{T: "changed", Fields: []*schema.Column{schema.NewStringColumn("a", TypeText)}},
+		&CompositeType{T: "dropped", Fields: []*schema.Column{schema.NewStringColumn("a", TypeText)}},
+		&schema.EnumType{T: "dropped", Values: []string{"a"}},
+	)
+	to := schema.New("public").AddObjects(
+		&Domain{T: "same", Type: &schema.IntegerType{T: TypeInt4}, Checks: []*schema.Check{{Name: "same_check", Expr: "VALUE > 0"}}},
+		&Domain{T: "nullable", Type: &schema.IntegerType{T: TypeInteger}, Null: true},
+		&Domain{T: "checked", Type: &schema.IntegerType{T: TypeInteger}, Checks: []*schema.Check{{Expr: "VALUE > 0"}}},
+		&Domain{T: "added", Type: &schema.IntegerType{T: TypeInteger}},
+		&CompositeType{T: "same", Fields: []*schema.Column{schema.NewStringColumn("a", TypeText)}},
+		&CompositeType{T: "changed", Fields: []*schema.Column{schema.NewIntColumn("a", TypeInteger)}},
+		&CompositeType{T: "added", Fields: []*schema.Column{schema.NewStringColumn("a", TypeText)}},
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[1]},
+		&schema.ModifyObject{From: from.Objects[2], To: to.Objects[2]},
+		&schema.AddObject{O: to.Objects[3]},
+		&schema.ModifyObject{From: from.Objects[5], To: to.Objects[5]},
+		&schema.AddObject{O: to.Objects[6]},
+		// Types are dropped in the reverse order of their dependencies.
+		&schema.DropObject{O: from.Objects[6]},
+		&schema.DropObject{O: from.Objects[3]},
+		&schema.DropObject{O: from.Objects[7]},
+	}, changes)
+}
+
+func TestDiff_SequencesDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := schema.New("public").AddObjects(
+		&Sequence{Name: "same", Type: &schema.IntegerType{T: TypeBigInt}, Start: 1, Increment: 1, Min: 1, Max: math.MaxInt64, Cache: 1},
+		&Sequence{Name: "desc", Type: &schema.IntegerType{T: TypeInteger}, Start: -1, Increment: -1, Min: math.MinInt32, Max: -1, Cache: 1},
+		&Sequence{Name: "changed", Type: &schema.IntegerType{T: TypeBigInt}, Start: 1, Increment: 1, Min: 1, Max: math.MaxInt64, Cache: 1},
+		&Sequence{Name: "dropped"},
+	)
+	to := schema.New("public").AddObjects(
+		&Sequence{Name: "same"},
+		&Sequence{Name: "desc", Type: &schema.IntegerType{T: TypeInt4}, Increment: -1},
+		&Sequence{Name: "changed", Cache: 10},
+		&Sequence{Name: "added"},
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[2], To: to.Objects[2]},
+		&schema.DropObject{O: from.Objects[3]},
+		&schema.AddObject{O: to.Objects[3]},
+	}, changes)
+
+	users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+	to.Objects[0].(*Sequence).Owner.T, to.Objects[0].(*Sequence).Owner.C = users, users.Columns[0]
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	require.Equal(t, &schema.ModifyObject{From: from.Objects[0], To: to.Objects[0]}, changes[0])
+}
+
 func TestDefaultDiff(t *testing.T) {
 	changes, err := DefaultDiff.SchemaDiff(
 		schema.New("public").
@@ -138,6 +138,21 @@ func (d *Driver) NormalizeSchema(ctx context.Context, s *schema.Schema) (*schema
 	return d.dev().NormalizeSchema(ctx, s)
 }
 
+// RealmDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Driver) RealmDiffWithOptions(from, to *schema.Realm, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return schema.RealmDiff(d.Differ, from, to, opts...)
+}
+
+// SchemaDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Driver) SchemaDiffWithOptions(from, to *schema.Schema, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return schema.SchemaDiff(d.Differ, from, to, opts...)
+}
+
+// TableDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Driver) TableDiffWithOptions(from, to *schema.Table, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return schema.TableDiff(d.Differ, from, to, opts...)
+}
+
 // Lock implements the schema.Locker interface.
 func (d *Driver) Lock(ctx context.Context, name string, timeout time.Duration) (schema.UnlockFunc, error) {
 	conn, err := sqlx.SingleConn(ctx, d.ExecQuerier)
@@ -172,15 +187,24 @@ func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 	// Postgres will only then be considered bound to a schema if the `search_path` was given.
 	// In all other cases, the connection is considered bound to the realm.
 	if d.schema != "" {
-		s, err := d.InspectSchema(ctx, d.schema, nil)
+		s, err := d.InspectSchema(ctx, d.schema, &schema.InspectOptions{Mode: schema.InspectAll})
 		if err != nil {
 			return nil, err
 		}
 		if len(s.Tables) > 0 {
 			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found table %q in connected schema", s.Tables[0].Name)}
 		}
+		if len(s.Views) > 0 {
+			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found view %q in connected schema", s.Views[0].Name)}
+		}
+		if len(s.Funcs) > 0 {
+			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found function %q in connected schema", s.Funcs[0].Name)}
+		}
+		if len(s.Procs) > 0 {
+			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found procedure %q in connected schema", s.Procs[0].Name)}
+		}
 		return func(ctx context.Context) error {
-			current, err := d.InspectSchema(ctx, s.Name, nil)
+			current, err := d.InspectSchema(ctx, s.Name, &schema.InspectOptions{Mode: schema.InspectAll})
 			if err != nil {
 				return err
 			}
@@ -192,12 +216,12 @@ func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 		}, nil
 	}
 	// Not bound to a schema.
-	realm, err := d.InspectRealm(ctx, nil)
+	realm, err := d.InspectRealm(ctx, &schema.InspectRealmOption{Mode: schema.InspectAll})
 	if err != nil {
 		return nil, err
 	}
 	restore := func(ctx context.Context) error {
-		current, err := d.InspectRealm(ctx, nil)
+		current, err := d.InspectRealm(ctx, &schema.InspectRealmOption{Mode: schema.InspectAll})
 		if err != nil {
 			return err
 		}
@@ -215,6 +239,15 @@ func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 		if len(s.Tables) > 0 {
 			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found table %q in schema %q", s.Tables[0].Name, s.Name)}
 		}
+		if len(s.Views) > 0 {
+			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found view %q in schema %q", s.Views[0].Name, s.Name)}
+		}
+		if len(s.Funcs) > 0 {
+			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found function %q in schema %q", s.Funcs[0].Name, s.Name)}
+		}
+		if len(s.Procs) > 0 {
+			return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found procedure %q in schema %q", s.Procs[0].Name, s.Name)}
+		}
 		return restore, nil
 	}
 	return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found schema %q", realm.Schemas[0].Name)}
@@ -223,21 +256,33 @@ func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 // CheckClean implements migrate.CleanChecker.
 func (d *Driver) CheckClean(ctx context.Context, revT *migrate.TableIdent) error {
 	if d.schema != "" {
-		switch s, err := d.InspectSchema(ctx, d.schema, nil); {
+		switch s, err := d.InspectSchema(ctx, d.schema, &schema.InspectOptions{Mode: schema.InspectAll}); {
 		case err != nil:
 			return err
+		case len(s.Views) > 0:
+			return &migrate.NotCleanError{Reason: fmt.Sprintf("found view %q in schema %q", s.Views[0].Name, s.Name)}
+		case len(s.Funcs) > 0:
+			return &migrate.NotCleanError{Reason: fmt.Sprintf("found function %q in schema %q", s.Funcs[0].Name, s.Name)}
+		case len(s.Procs) > 0:
+			return &migrate.NotCleanError{Reason: fmt.Sprintf("found procedure %q in schema %q", s.Procs[0].Name, s.Name)}
 		case len(s.Tables) == 0, (revT != nil && revT.Schema == "" || s.Name == revT.Schema) && len(s.Tables) == 1 && s.Tables[0].Name == revT.Name:
 			return nil
 		default:
 			return &migrate.NotCleanError{Reason: fmt.Sprintf("found table %q in schema %q", s.Tables[0].Name, s.Name)}
 		}
 	}
-	r, err := d.InspectRealm(ctx, nil)
+	r, err := d.InspectRealm(ctx, &schema.InspectRealmOption{Mode: schema.InspectAll})
 	if err != nil {
 		return err
 	}
 	for _, s := range r.Schemas {
 		switch {
+		case len(s.Views) > 0:
+			return &migrate.NotCleanError{Reason: fmt.Sprintf("found view %q in schema %q", s.Views[0].Name, s.Name)}
+		case len(s.Funcs) > 0:
+			return &migrate.NotCleanError{Reason: fmt.Sprintf("found function %q in schema %q", s.Funcs[0].Name, s.Name)}
+		case len(s.Procs) > 0:
+			return &migrate.NotCleanError{Reason: fmt.Sprintf("found procedure %q in schema %q", s.Procs[0].Name, s.Name)}
 		case len(s.Tables) == 0 && s.Name == "public":
 		case len(s.Tables) == 0 || s.Name != revT.Schema:
 			return &migrate.NotCleanError{Reason: fmt.Sprintf("found schema %q", s.Name)}
@@ -290,6 +335,29 @@ func (c *conn) supportsIndexInclude() bool {
 	return c.version >= 11_00_00
 }
 
+// supportsProcedures reports if the server supports stored procedures.
+func (c *conn) supportsProcedures() bool {
+	return c.version >= 11_00_00
+}
+
+// supportsEnumValuesTx reports if the server supports
+// adding enum values inside a transaction block.
+func (c *conn) supportsEnumValuesTx() bool {
+	return c.version >= 12_00_00
+}
+
+// supportsSequences reports if the server supports inspecting
+// standalone sequences using the pg_sequences view.
+func (c *conn) supportsSequences() bool {
+	return c.version >= 10_00_00 && !c.crdb
+}
+
+// supportsPolicies reports if the server supports inspecting
+// row-level security policies, including restrictive ones.
+func (c *conn) supportsPolicies() bool {
+	return c.version >= 10_00_00 && !c.crdb
+}
+
 type parser struct{}
 
 // ParseURL implements the sqlclient.URLParser interface.
@@ -403,3 +471,25 @@ const (
 	PartitionTypeList  = "LIST"
 	PartitionTypeHash  = "HASH"
 )
+
+// List of function volatility categories.
+const (
+	VolatilityImmutable = "IMMUTABLE"
+	VolatilityStable    = "STABLE"
+	VolatilityVolatile  = "VOLATILE"
+)
+
+// List of policy kinds.
+const (
+	PolicyAsPermissive  = "PERMISSIVE"
+	PolicyAsRestrictive = "RESTRICTIVE"
+)
+
+// List of policy commands.
+const (
+	PolicyCmdAll    = "ALL"
+	PolicyCmdSelect = "SELECT"
+	PolicyCmdInsert = "INSERT"
+	PolicyCmdUpdate = "UPDATE"
+	PolicyCmdDelete = "DELETE"
+)
@@ -160,3 +160,39 @@ func (m *mockInspector) InspectSchema(context.Context, string, *schema.InspectOp
 func (m *mockInspector) InspectRealm(context.Context, *schema.InspectRealmOption) (*schema.Realm, error) {
 	return m.realm, nil
 }
+
+func TestDriver_NormalizeRealm(t *testing.T) {
+	var (
+		seq = &Sequence{Name: "s", Start: 1, Increment: 1}
+		dom = &Domain{T: "posint", Type: &schema.IntegerType{T: "integer"}}
+		s   = schema.New("public").
+			AddObjects(seq, dom).
+			AddTables(
+				schema.NewTable("t").AddColumns(schema.NewColumn("c").SetType(dom)),
+			)
+		app = &mockApplier{}
+		drv = &Driver{Inspector: &mockInspector{realm: schema.NewRealm()}, PlanApplier: app}
+	)
+	seq.Schema, dom.Schema = s, s
+	_, err := drv.NormalizeRealm(context.Background(), schema.NewRealm(s))
+	require.NoError(t, err)
+	require.Len(t, app.changes, 2, "expect 2 calls (create and drop)")
+	changes := app.changes[0]
+	require.Len(t, changes, 4)
+	require.IsType(t, &schema.AddSchema{}, changes[0])
+	require.Equal(t, &schema.AddObject{O: seq}, changes[1])
+	require.Equal(t, &schema.AddObject{O: dom}, changes[2])
+	require.Equal(t, &schema.AddTable{T: s.Tables[0]}, changes[3])
+	// Source realm is restored after normalization.
+	require.Equal(t, "public", s.Name)
+}
+
+type mockApplier struct {
+	migrate.PlanApplier
+	changes [][]schema.Change
+}
+
+func (m *mockApplier) ApplyChanges(_ context.Context, changes []schema.Change, _ ...migrate.PlanOption) error {
+	m.changes = append(m.changes, changes)
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -35,13 +36,46 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 	}
 	r := schema.NewRealm(schemas...).SetCollation(i.collate)
 	r.Attrs = append(r.Attrs, &CType{V: i.ctype})
-	if len(schemas) == 0 || !sqlx.ModeInspectRealm(opts).Is(schema.InspectTables) {
+	if len(schemas) == 0 {
 		return sqlx.ExcludeRealm(r, opts.Exclude)
 	}
-	if err := i.inspectTables(ctx, r, nil); err != nil {
-		return nil, err
+	mode := sqlx.ModeInspectRealm(opts)
+	if mode.Is(schema.InspectTables) {
+		if err := i.inspectTables(ctx, r, nil); err != nil {
+			return nil, err
+		}
+		sqlx.LinkSchemaTables(schemas)
+	}
+	if mode.Is(schema.InspectViews) {
+		if err := i.inspectViews(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectFuncs) && !i.crdb {
+		if err := i.inspectFuncs(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTables) && mode.Is(schema.InspectTriggers) && !i.crdb {
+		if err := i.inspectTriggers(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTables) && i.supportsPolicies() {
+		if err := i.inspectPolicies(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectObjects) && !i.crdb {
+		if err := i.inspectObjects(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectRoles) && !i.crdb {
+		if err := i.inspectRoles(ctx, r); err != nil {
+			return nil, err
+		}
 	}
-	sqlx.LinkSchemaTables(schemas)
 	return sqlx.ExcludeRealm(r, opts.Exclude)
 }
 
@@ -63,12 +97,39 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 	}
 	r := schema.NewRealm(schemas...).SetCollation(i.collate)
 	r.Attrs = append(r.Attrs, &CType{V: i.ctype})
-	if sqlx.ModeInspectSchema(opts).Is(schema.InspectTables) {
+	mode := sqlx.ModeInspectSchema(opts)
+	if mode.Is(schema.InspectTables) {
 		if err := i.inspectTables(ctx, r, opts); err != nil {
 			return nil, err
 		}
 		sqlx.LinkSchemaTables(schemas)
 	}
+	// Views and functions are skipped in case the inspection was limited to a set of tables.
+	if mode.Is(schema.InspectViews) && len(opts.Tables) == 0 {
+		if err := i.inspectViews(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectFuncs) && len(opts.Tables) == 0 && !i.crdb {
+		if err := i.inspectFuncs(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTables) && mode.Is(schema.InspectTriggers) && !i.crdb {
+		if err := i.inspectTriggers(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTables) && i.supportsPolicies() {
+		if err := i.inspectPolicies(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectObjects) && len(opts.Tables) == 0 && !i.crdb {
+		if err := i.inspectObjects(ctx, r); err != nil {
+			return nil, err
+		}
+	}
 	return sqlx.ExcludeSchema(r.Schemas[0], opts.Exclude)
 }
 
@@ -99,6 +160,83 @@ func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *sche
 	return nil
 }
 
+func (i *inspect) inspectViews(ctx context.Context, r *schema.Realm) error {
+	for _, s := range r.Schemas {
+		if err := i.views(ctx, s); err != nil {
+			return err
+		}
+		if len(s.Views) == 0 {
+			continue
+		}
+		if err := i.viewColumns(ctx, s); err != nil {
+			return err
+		}
+		if err := i.viewIndexes(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *inspect) inspectFuncs(ctx context.Context, r *schema.Realm) error {
+	for _, s := range r.Schemas {
+		if err := i.funcs(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *inspect) inspectTriggers(ctx context.Context, r *schema.Realm) error {
+	for _, s := range r.Schemas {
+		if len(s.Tables) == 0 {
+			continue
+		}
+		if err := i.triggers(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *inspect) inspectPolicies(ctx context.Context, r *schema.Realm) error {
+	for _, s := range r.Schemas {
+		if len(s.Tables) == 0 {
+			continue
+		}
+		if err := i.policies(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *inspect) inspectObjects(ctx context.Context, r *schema.Realm) error {
+	for _, s := range r.Schemas {
+		if err := i.extensions(ctx, s); err != nil {
+			return err
+		}
+		if err := i.enums(ctx, s); err != nil {
+			return err
+		}
+		if err := i.domains(ctx, s); err != nil {
+			return err
+		}
+		if err := i.composites(ctx, s); err != nil {
+			return err
+		}
+		if !i.supportsSequences() {
+			continue
+		}
+		if err := i.sequences(ctx, s); err != nil {
+			return err
+		}
+	}
+	linkEnums(r)
+	linkTypes(r)
+	return nil
+}
+
 // table returns the table from the database, or a NotExistError if the table was not found.
 func (i *inspect) tables(ctx context.Context, realm *schema.Realm, opts *schema.InspectOptions) error {
 	var (
@@ -120,8 +258,8 @@ func (i *inspect) tables(ctx context.Context, realm *schema.Realm, opts *schema.
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var tSchema, name, comment, partattrs, partstart, partexprs sql.NullString
-		if err := rows.Scan(&tSchema, &name, &comment, &partattrs, &partstart, &partexprs); err != nil {
+		var tSchema, name, comment, partattrs, partstart, partexprs, parentSchema, parent, bound, persistence, tablespace, params, inherits sql.NullString
+		if err := rows.Scan(&tSchema, &name, &comment, &partattrs, &partstart, &partexprs, &parentSchema, &parent, &bound, &persistence, &tablespace, &params, &inherits); err != nil {
 			return fmt.Errorf("scan table information: %w", err)
 		}
 		if !sqlx.ValidString(tSchema) || !sqlx.ValidString(name) {
@@ -143,8 +281,70 @@ func (i *inspect) tables(ctx context.Context, realm *schema.Realm, opts *schema.
 				exprs: partexprs.String,
 			})
 		}
+		if sqlx.ValidString(parent) {
+			p := &PartitionOf{
+				parent: [2]string{parentSchema.String, parent.String},
+			}
+			switch b := strings.TrimSpace(bound.String); {
+			case strings.EqualFold(b, "DEFAULT"):
+				p.Default = true
+			default:
+				p.Bound = strings.TrimSpace(strings.TrimPrefix(b, "FOR VALUES"))
+			}
+			t.AddAttrs(p)
+		}
+		if persistence.String == "u" {
+			t.AddAttrs(&Unlogged{})
+		}
+		if sqlx.ValidString(tablespace) {
+			t.AddAttrs(&Tablespace{Name: tablespace.String})
+		}
+		if sqlx.ValidString(params) {
+			p, err := newTableStorage(params.String)
+			if err != nil {
+				return err
+			}
+			t.AddAttrs(p)
+		}
+		if sqlx.ValidString(inherits) {
+			p := &Inherits{}
+			if err := json.Unmarshal([]byte(inherits.String), &p.parents); err != nil {
+				return fmt.Errorf("postgres: parsing inherited tables of %q: %w", name.String, err)
+			}
+			t.AddAttrs(p)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	linkParents(realm)
+	return nil
+}
+
+// linkParents links the partition and the inheriting tables to their parents.
+// Parent tables that were not inspected are referenced by their schema-qualified
+// names.
+func linkParents(r *schema.Realm) {
+	parent := func(name [2]string) *schema.Table {
+		if s, ok := r.Schema(name[0]); ok {
+			if t, ok := s.Table(name[1]); ok {
+				return t
+			}
+		}
+		return schema.NewTable(name[1]).SetSchema(schema.New(name[0]))
+	}
+	for _, s := range r.Schemas {
+		for _, t := range s.Tables {
+			if p := partitionOf(t); p != nil && p.Parent == nil {
+				p.Parent = parent(p.parent)
+			}
+			if p := inheritsOf(t); p != nil && len(p.Parents) == 0 {
+				for _, n := range p.parents {
+					p.Parents = append(p.Parents, parent(n))
+				}
+			}
+		}
 	}
-	return rows.Close()
 }
 
 // columns queries and appends the columns of the given table.
@@ -177,10 +377,11 @@ func (i *inspect) addColumn(s *schema.Schema, rows *sql.Rows) (err error) {
 	var (
 		typid, typelem, maxlen, precision, timeprecision, scale, seqstart, seqinc, seqlast                                                  sql.NullInt64
 		table, name, typ, fmtype, nullable, defaults, identity, genidentity, genexpr, charset, collate, comment, typtype, elemtyp, interval sql.NullString
+		local                                                                                                                               sql.NullBool
 	)
 	if err = rows.Scan(
 		&table, &name, &typ, &fmtype, &nullable, &defaults, &maxlen, &precision, &timeprecision, &scale, &interval, &charset,
-		&collate, &identity, &seqstart, &seqinc, &seqlast, &genidentity, &genexpr, &comment, &typtype, &typelem, &elemtyp, &typid,
+		&collate, &identity, &seqstart, &seqinc, &seqlast, &genidentity, &genexpr, &comment, &typtype, &typelem, &elemtyp, &typid, &local,
 	); err != nil {
 		return err
 	}
@@ -235,6 +436,10 @@ func (i *inspect) addColumn(s *schema.Schema, rows *sql.Rows) (err error) {
 	if sqlx.ValidString(collate) {
 		c.SetCollation(collate.String)
 	}
+	// Columns of partitions are not marked, as they are always inherited from their parent.
+	if local.Valid && !local.Bool && inheritsOf(t) != nil {
+		c.Attrs = append(c.Attrs, &Inherited{})
+	}
 	t.Columns = append(t.Columns, c)
 	return nil
 }
@@ -321,20 +526,32 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 	names := make(map[string]*schema.Index)
 	for rows.Next() {
 		var (
-			uniq, primary, included                                           bool
-			table, name, typ                                                  string
-			desc, nullsfirst, nullslast, opcdefault                           sql.NullBool
-			column, contype, pred, expr, comment, options, opcname, opcparams sql.NullString
+			uniq, primary, included                                                   bool
+			table, name, typ                                                          string
+			desc, nullsfirst, nullslast, opcdefault, deferrable, deferred             sql.NullBool
+			column, contype, pred, expr, comment, options, opcname, opcparams, exclOp sql.NullString
 		)
 		if err := rows.Scan(
 			&table, &name, &typ, &column, &included, &primary, &uniq, &contype, &pred, &expr, &desc,
 			&nullsfirst, &nullslast, &comment, &options, &opcname, &opcdefault, &opcparams,
+			&deferrable, &deferred, &exclOp,
 		); err != nil {
 			return fmt.Errorf("postgres: scanning indexes for schema %q: %w", s.Name, err)
 		}
+		// Indexes are either defined on tables or on materialized views.
+		var (
+			v        *schema.View
+			columnOf func(string) (*schema.Column, bool)
+		)
 		t, ok := s.Table(table)
-		if !ok {
-			return fmt.Errorf("table %q was not found in schema", table)
+		switch {
+		case ok:
+			columnOf = t.Column
+		default:
+			if v, ok = s.View(table); !ok || !v.Materialized() {
+				return fmt.Errorf("table %q was not found in schema", table)
+			}
+			t, columnOf = nil, v.Column
 		}
 		idx, ok := names[name]
 		if !ok {
@@ -342,6 +559,7 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 				Name:   name,
 				Unique: uniq,
 				Table:  t,
+				View:   v,
 				Attrs: []schema.Attr{
 					&IndexType{T: typ},
 				},
@@ -355,6 +573,9 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 			if sqlx.ValidString(pred) {
 				idx.Attrs = append(idx.Attrs, &IndexPredicate{P: pred.String})
 			}
+			if deferrable.Bool {
+				idx.Attrs = append(idx.Attrs, &Deferrable{InitiallyDeferred: deferred.Bool})
+			}
 			if sqlx.ValidString(options) {
 				p, err := newIndexStorage(options.String)
 				if err != nil {
@@ -363,9 +584,12 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 				idx.Attrs = append(idx.Attrs, p)
 			}
 			names[name] = idx
-			if primary {
+			switch {
+			case v != nil:
+				v.Indexes = append(v.Indexes, idx)
+			case primary:
 				t.PrimaryKey = idx
-			} else {
+			default:
 				t.Indexes = append(t.Indexes, idx)
 			}
 		}
@@ -378,7 +602,7 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 		}
 		switch {
 		case included:
-			c, ok := t.Column(column.String)
+			c, ok := columnOf(column.String)
 			if !ok {
 				return fmt.Errorf("postgres: INCLUDE column %q was not found for index %q", column.String, idx.Name)
 			}
@@ -387,88 +611,863 @@ func (i *inspect) addIndexes(s *schema.Schema, rows *sql.Rows) error {
 			include.Columns = append(include.Columns, c)
 			schema.ReplaceOrAppend(&idx.Attrs, &include)
 		case sqlx.ValidString(column):
-			part.C, ok = t.Column(column.String)
+			part.C, ok = columnOf(column.String)
 			if !ok {
 				return fmt.Errorf("postgres: column %q was not found for index %q", column.String, idx.Name)
 			}
-			part.C.Indexes = append(part.C.Indexes, idx)
-			idx.Parts = append(idx.Parts, part)
-		case sqlx.ValidString(expr):
-			part.X = &schema.RawExpr{
-				X: expr.String,
+			part.C.Indexes = append(part.C.Indexes, idx)
+			idx.Parts = append(idx.Parts, part)
+		case sqlx.ValidString(expr):
+			part.X = &schema.RawExpr{
+				X: expr.String,
+			}
+			idx.Parts = append(idx.Parts, part)
+		default:
+			return fmt.Errorf("postgres: invalid part for index %q", idx.Name)
+		}
+		if sqlx.ValidString(exclOp) {
+			part.Attrs = append(part.Attrs, &ExcludeOp{Op: exclOp.String})
+		}
+		if err := mayAppendOps(part, opcname.String, opcparams.String, opcdefault.Bool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mayAppendOps appends an operator_class attribute to the part in case it is not the default.
+func mayAppendOps(part *schema.IndexPart, name string, params string, defaults bool) error {
+	if name == "" || defaults && params == "" {
+		return nil
+	}
+	op := &IndexOpClass{Name: name, Default: defaults}
+	if err := op.parseParams(params); err != nil {
+		return err
+	}
+	part.Attrs = append(part.Attrs, op)
+	return nil
+}
+
+// partitions builds the partition each table in the schema.
+func (i *inspect) partitions(s *schema.Schema) error {
+	for _, t := range s.Tables {
+		var d Partition
+		if !sqlx.Has(t.Attrs, &d) {
+			continue
+		}
+		switch s := strings.ToLower(d.start); s {
+		case "r":
+			d.T = PartitionTypeRange
+		case "l":
+			d.T = PartitionTypeList
+		case "h":
+			d.T = PartitionTypeHash
+		default:
+			return fmt.Errorf("postgres: unexpected partition strategy %q", s)
+		}
+		idxs := strings.Split(strings.TrimSpace(d.attrs), " ")
+		if len(idxs) == 0 {
+			return fmt.Errorf("postgres: no columns/expressions were found in partition key for column %q", t.Name)
+		}
+		for i := range idxs {
+			switch idx, err := strconv.Atoi(idxs[i]); {
+			case err != nil:
+				return fmt.Errorf("postgres: faild parsing partition key index %q", idxs[i])
+			// An expression.
+			case idx == 0:
+				j := sqlx.ExprLastIndex(d.exprs)
+				if j == -1 {
+					return fmt.Errorf("postgres: no expression found in partition key: %q", d.exprs)
+				}
+				d.Parts = append(d.Parts, &PartitionPart{
+					X: &schema.RawExpr{X: d.exprs[:j+1]},
+				})
+				d.exprs = strings.TrimPrefix(d.exprs[j+1:], ", ")
+			// A column at index idx-1.
+			default:
+				if idx > len(t.Columns) {
+					return fmt.Errorf("postgres: unexpected column index %d", idx)
+				}
+				d.Parts = append(d.Parts, &PartitionPart{
+					C: t.Columns[idx-1],
+				})
+			}
+		}
+		schema.ReplaceOrAppend(&t.Attrs, &d)
+	}
+	return nil
+}
+
+// views queries and appends the views of the given schema.
+func (i *inspect) views(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, viewsQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q views: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			materialized               sql.NullBool
+			name, def, option, comment sql.NullString
+		)
+		if err := rows.Scan(&name, &def, &option, &comment, &materialized); err != nil {
+			return fmt.Errorf("postgres: scanning view: %w", err)
+		}
+		v := schema.NewView(name.String, strings.TrimSpace(def.String))
+		if materialized.Bool {
+			v.AddAttrs(&schema.Materialized{})
+		}
+		s.AddViews(v)
+		if option.String != "" && option.String != schema.ViewCheckOptionNone {
+			v.SetCheckOption(option.String)
+		}
+		if sqlx.ValidString(comment) {
+			v.SetComment(comment.String)
+		}
+	}
+	return rows.Close()
+}
+
+// viewColumns queries and appends the columns of the schema views.
+func (i *inspect) viewColumns(ctx context.Context, s *schema.Schema) error {
+	args := []any{s.Name}
+	for _, v := range s.Views {
+		args = append(args, v.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(viewColumnsQuery, nArgs(1, len(s.Views))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q view columns: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var view, name, typ, nullable string
+		if err := rows.Scan(&view, &name, &typ, &nullable); err != nil {
+			return fmt.Errorf("postgres: scanning view column: %w", err)
+		}
+		v, ok := s.View(view)
+		if !ok {
+			return fmt.Errorf("postgres: view %q was not found in schema", view)
+		}
+		t, err := ParseType(typ)
+		if err != nil {
+			t = &schema.UnsupportedType{T: typ}
+		}
+		v.AddColumns(&schema.Column{
+			Name: name,
+			Type: &schema.ColumnType{Type: t, Raw: typ, Null: nullable == "YES"},
+		})
+	}
+	return rows.Close()
+}
+
+// viewIndexes queries and appends the indexes of the schema materialized views.
+func (i *inspect) viewIndexes(ctx context.Context, s *schema.Schema) error {
+	args := []any{s.Name}
+	for _, v := range s.Views {
+		if v.Materialized() {
+			args = append(args, v.Name)
+		}
+	}
+	// Materialized view indexes are not inspected on CockroachDB.
+	if len(args) == 1 || i.conn.crdb {
+		return nil
+	}
+	query := indexesQuery
+	if !i.conn.supportsIndexInclude() {
+		query = indexesQueryNoInclude
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(query, nArgs(1, len(args)-1)), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q materialized view indexes: %w", s.Name, err)
+	}
+	defer rows.Close()
+	if err := i.addIndexes(s, rows); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+// funcs queries and appends the functions and procedures of the given schema.
+func (i *inspect) funcs(ctx context.Context, s *schema.Schema) error {
+	query := funcsQuery
+	if !i.supportsProcedures() {
+		query = funcsQueryPG10
+	}
+	rows, err := i.QueryContext(ctx, query, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q functions: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			name, kind, args, lang, body, volatile     string
+			ret, argNames, argModes, argTypes, comment sql.NullString
+		)
+		if err := rows.Scan(&name, &kind, &args, &ret, &lang, &body, &volatile, &argNames, &argModes, &argTypes, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning function: %w", err)
+		}
+		fargs, err := funcArgs(args, argNames.String, argModes.String, argTypes.String)
+		if err != nil {
+			return fmt.Errorf("postgres: parsing arguments of function %q: %w", name, err)
+		}
+		switch kind {
+		case "p":
+			p := schema.NewProc(name).AddArgs(fargs...).SetLang(lang).SetBody(body)
+			if sqlx.ValidString(comment) {
+				p.SetComment(comment.String)
+			}
+			s.AddProcs(p)
+		default:
+			f := schema.NewFunc(name).AddArgs(fargs...).SetLang(lang).SetBody(body)
+			if sqlx.ValidString(ret) {
+				f.SetReturnType(funcType(ret.String))
+			}
+			switch volatile {
+			case "i":
+				f.AddAttrs(&Volatility{V: VolatilityImmutable})
+			case "s":
+				f.AddAttrs(&Volatility{V: VolatilityStable})
+			}
+			if sqlx.ValidString(comment) {
+				f.SetComment(comment.String)
+			}
+			s.AddFuncs(f)
+		}
+	}
+	return rows.Close()
+}
+
+// extensions queries and appends the extensions that were installed in the schema.
+func (i *inspect) extensions(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, extensionsQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q extensions: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e := &Extension{Schema: s}
+		if err := rows.Scan(&e.Name, &e.Version); err != nil {
+			return fmt.Errorf("postgres: scanning extension: %w", err)
+		}
+		s.AddObjects(e)
+	}
+	return rows.Close()
+}
+
+// enums queries and appends the enum types of the schema.
+func (i *inspect) enums(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, enumsQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q enums: %w", s.Name, err)
+	}
+	defer rows.Close()
+	var last *schema.EnumType
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return fmt.Errorf("postgres: scanning enum value: %w", err)
+		}
+		// Values are ordered by their enum types.
+		if last == nil || last.T != name {
+			last = &schema.EnumType{T: name, Schema: s}
+			s.AddObjects(last)
+		}
+		last.Values = append(last.Values, value)
+	}
+	return rows.Close()
+}
+
+// linkEnums links the enum columns in the realm to the enum
+// objects they use, so that columns share their enum types.
+func linkEnums(r *schema.Realm) {
+	for _, s := range r.Schemas {
+		for _, t := range s.Tables {
+			for _, c := range t.Columns {
+				e1, ok := hasEnumType(c)
+				if !ok {
+					continue
+				}
+				es := s
+				if e1.Schema != nil && e1.Schema.Name != s.Name {
+					if es, ok = r.Schema(e1.Schema.Name); !ok {
+						continue
+					}
+				}
+				e2, ok := enumObject(es, e1.T)
+				if !ok {
+					continue
+				}
+				switch t := c.Type.Type.(type) {
+				case *ArrayType:
+					t.Type = e2
+				default:
+					c.Type.Type = e2
+				}
+			}
+		}
+	}
+}
+
+// domains queries and appends the domain types of the schema.
+func (i *inspect) domains(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, domainsQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q domains: %w", s.Name, err)
+	}
+	defer rows.Close()
+	var last *Domain
+	for rows.Next() {
+		var (
+			notnull                    bool
+			name, typ                  string
+			defaults, conname, conexpr sql.NullString
+		)
+		if err := rows.Scan(&name, &typ, &notnull, &defaults, &conname, &conexpr); err != nil {
+			return fmt.Errorf("postgres: scanning domain: %w", err)
+		}
+		// Constraints are ordered by their domain types.
+		if last == nil || last.T != name {
+			t, err := ParseType(typ)
+			if err != nil {
+				return fmt.Errorf("postgres: parsing type %q of domain %q: %w", typ, name, err)
+			}
+			last = &Domain{T: name, Schema: s, Type: t, Null: !notnull}
+			if defaults.Valid {
+				c := &schema.Column{Type: &schema.ColumnType{Type: t}}
+				defaultExpr(c, defaults.String)
+				last.Default = c.Default
+			}
+			s.AddObjects(last)
+		}
+		if sqlx.ValidString(conname) {
+			last.Checks = append(last.Checks, &schema.Check{Name: conname.String, Expr: conexpr.String})
+		}
+	}
+	return rows.Close()
+}
+
+// composites queries and appends the composite types of the schema.
+func (i *inspect) composites(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, compositesQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q composite types: %w", s.Name, err)
+	}
+	defer rows.Close()
+	var last *CompositeType
+	for rows.Next() {
+		var name, field, typ string
+		if err := rows.Scan(&name, &field, &typ); err != nil {
+			return fmt.Errorf("postgres: scanning composite type: %w", err)
+		}
+		// Fields are ordered by their composite types.
+		if last == nil || last.T != name {
+			last = &CompositeType{T: name, Schema: s}
+			s.AddObjects(last)
+		}
+		t, err := ParseType(typ)
+		if err != nil {
+			return fmt.Errorf("postgres: parsing type %q of composite type field %q.%q: %w", typ, name, field, err)
+		}
+		last.Fields = append(last.Fields, &schema.Column{
+			Name: field,
+			Type: &schema.ColumnType{Raw: typ, Type: t, Null: true},
+		})
+	}
+	return rows.Close()
+}
+
+// linkTypes links the user-defined types that are used by the columns in the realm,
+// the composite type fields and the domains to the type objects they reference.
+func linkTypes(r *schema.Realm) {
+	link := func(s *schema.Schema, t schema.Type) schema.Type {
+		switch t := t.(type) {
+		case *UserDefinedType:
+			if o, ok := typeObject(r, s, t.T); ok {
+				return o
+			}
+		case *ArrayType:
+			if u, ok := t.Type.(*UserDefinedType); ok {
+				if o, ok := typeObject(r, s, u.T); ok {
+					t.Type = o
+				}
+			}
+		}
+		return t
+	}
+	for _, s := range r.Schemas {
+		for _, t := range s.Tables {
+			for _, c := range t.Columns {
+				c.Type.Type = link(s, c.Type.Type)
+			}
+		}
+		for _, o := range s.Objects {
+			switch o := o.(type) {
+			case *Domain:
+				o.Type = link(s, o.Type)
+			case *CompositeType:
+				for _, f := range o.Fields {
+					f.Type.Type = link(s, f.Type.Type)
+				}
+			}
+		}
+	}
+}
+
+// typeObject returns the type object (enum, domain or composite type) that is
+// referenced by the given, possibly schema-qualified, name from the realm.
+func typeObject(r *schema.Realm, s *schema.Schema, name string) (schema.Type, bool) {
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		ns, ok := r.Schema(strings.Trim(name[:i], `"`))
+		if !ok {
+			return nil, false
+		}
+		s, name = ns, name[i+1:]
+	}
+	name = strings.Trim(name, `"`)
+	for _, o := range s.Objects {
+		switch o := o.(type) {
+		case *schema.EnumType:
+			if o.T == name {
+				return o, true
+			}
+		case *Domain:
+			if o.T == name {
+				return o, true
+			}
+		case *CompositeType:
+			if o.T == name {
+				return o, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// domain returns the domain type with the given name from the schema objects.
+func domain(s *schema.Schema, name string) (*Domain, bool) {
+	for _, o := range s.Objects {
+		if d, ok := o.(*Domain); ok && d.T == name {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// composite returns the composite type with the given name from the schema objects.
+func composite(s *schema.Schema, name string) (*CompositeType, bool) {
+	for _, o := range s.Objects {
+		if c, ok := o.(*CompositeType); ok && c.T == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// extension returns the extension with the given name from the schema objects.
+func extension(s *schema.Schema, name string) (*Extension, bool) {
+	for _, o := range s.Objects {
+		if e, ok := o.(*Extension); ok && e.Name == name {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// enumObject returns the enum type with the given name from the schema objects.
+func enumObject(s *schema.Schema, name string) (*schema.EnumType, bool) {
+	for _, o := range s.Objects {
+		if e, ok := o.(*schema.EnumType); ok && e.T == name {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// sequences queries and appends the standalone sequences of the schema. Sequences
+// that were created implicitly for serial columns are skipped, as they are part of
+// the column definitions.
+func (i *inspect) sequences(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, sequencesQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q sequences: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			name, typ                     string
+			start, inc, minV, maxV, cache int64
+			cycle                         bool
+			ownerT, ownerC                sql.NullString
+		)
+		if err := rows.Scan(&name, &typ, &start, &inc, &minV, &maxV, &cache, &cycle, &ownerT, &ownerC); err != nil {
+			return fmt.Errorf("postgres: scanning sequence: %w", err)
+		}
+		seq := &Sequence{
+			Name:      name,
+			Schema:    s,
+			Type:      &schema.IntegerType{T: typ},
+			Start:     start,
+			Increment: inc,
+			Min:       minV,
+			Max:       maxV,
+			Cache:     cache,
+			Cycle:     cycle,
+		}
+		if t, ok := s.Table(ownerT.String); ok && sqlx.ValidString(ownerC) {
+			c, ok := t.Column(ownerC.String)
+			if !ok {
+				return fmt.Errorf("postgres: owner column %q.%q was not found for sequence %q", t.Name, ownerC.String, name)
+			}
+			// Sequences that follow the naming convention of serial columns
+			// and are used by them, are implicitly created by the database.
+			if st, ok := c.Type.Type.(*SerialType); ok && st.sequence(t, c) == name && name == fmt.Sprintf("%s_%s_seq", t.Name, c.Name) {
+				continue
+			}
+			seq.Owner.T, seq.Owner.C = t, c
+		}
+		s.AddObjects(seq)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	// Columns that were detected as serials, but their default
+	// values use a standalone sequence, are integer columns.
+	for _, t := range s.Tables {
+		for _, c := range t.Columns {
+			st, ok := c.Type.Type.(*SerialType)
+			if !ok {
+				continue
+			}
+			if seq, ok := sequence(s, st.sequence(t, c)); ok {
+				c.Type.Type = st.IntegerType()
+				c.Type.Raw = st.IntegerType().T
+				c.Default = &schema.RawExpr{X: fmt.Sprintf("nextval('%s'::regclass)", seq.Name)}
+			}
+		}
+	}
+	return nil
+}
+
+// sequence returns the standalone sequence with the given name from the schema objects.
+func sequence(s *schema.Schema, name string) (*Sequence, bool) {
+	for _, o := range s.Objects {
+		if seq, ok := o.(*Sequence); ok && seq.Name == name {
+			return seq, true
+		}
+	}
+	return nil, false
+}
+
+// List of the pg_trigger.tgtype bits.
+const (
+	triggerTypeRow      = 1 << 0
+	triggerTypeBefore   = 1 << 1
+	triggerTypeInsert   = 1 << 2
+	triggerTypeDelete   = 1 << 3
+	triggerTypeUpdate   = 1 << 4
+	triggerTypeTruncate = 1 << 5
+	triggerTypeInstead  = 1 << 6
+)
+
+// triggers queries and appends the triggers of the schema tables.
+func (i *inspect) triggers(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, triggersQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q triggers: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			typ                               int
+			name, table, fname, fschema, cols string
+		)
+		if err := rows.Scan(&name, &table, &typ, &fname, &fschema, &cols); err != nil {
+			return fmt.Errorf("postgres: scanning trigger: %w", err)
+		}
+		t, ok := s.Table(table)
+		// Skip triggers of tables that were not inspected.
+		if !ok {
+			continue
+		}
+		var columns []string
+		if err := json.Unmarshal([]byte(cols), &columns); err != nil {
+			return fmt.Errorf("postgres: parsing columns of trigger %q: %w", name, err)
+		}
+		tg := schema.NewTrigger(name).SetFor(schema.TriggerForStmt)
+		if typ&triggerTypeRow != 0 {
+			tg.SetFor(schema.TriggerForRow)
+		}
+		switch {
+		case typ&triggerTypeBefore != 0:
+			tg.SetActionTime(schema.TriggerTimeBefore)
+		case typ&triggerTypeInstead != 0:
+			tg.SetActionTime(schema.TriggerTimeInstead)
+		default:
+			tg.SetActionTime(schema.TriggerTimeAfter)
+		}
+		for _, e := range []struct {
+			bit  int
+			name string
+		}{
+			{triggerTypeInsert, schema.TriggerEventInsert},
+			{triggerTypeUpdate, schema.TriggerEventUpdate},
+			{triggerTypeDelete, schema.TriggerEventDelete},
+			{triggerTypeTruncate, schema.TriggerEventTruncate},
+		} {
+			if typ&e.bit == 0 {
+				continue
+			}
+			event := schema.TriggerEvent{Name: e.name}
+			if e.name == schema.TriggerEventUpdate {
+				for _, n := range columns {
+					c, ok := t.Column(n)
+					if !ok {
+						return fmt.Errorf("postgres: column %q was not found for trigger %q", n, name)
+					}
+					event.Columns = append(event.Columns, c)
+				}
+			}
+			tg.AddEvents(event)
+		}
+		tg.SetFunc(triggerFunc(s, fschema, fname))
+		t.AddTriggers(tg)
+	}
+	return rows.Close()
+}
+
+// inspectRoles queries and appends the roles of the database, and the privileges
+// that were granted to them on the schemas of the realm and their tables and views.
+func (i *inspect) inspectRoles(ctx context.Context, r *schema.Realm) error {
+	rows, err := i.QueryContext(ctx, rolesQuery)
+	if err != nil {
+		return fmt.Errorf("postgres: querying roles: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			login bool
+			opts  RoleOptions
+			inh   bool
+			ro    = &schema.Role{}
+		)
+		if err := rows.Scan(&ro.Name, &opts.Superuser, &inh, &opts.CreateRole, &opts.CreateDB, &login, &opts.Replication, &opts.BypassRLS); err != nil {
+			return fmt.Errorf("postgres: scanning role: %w", err)
+		}
+		ro.Login, opts.NoInherit = login, !inh
+		if opts != (RoleOptions{}) {
+			ro.AddAttrs(&opts)
+		}
+		r.AddRoles(ro)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if len(r.Roles) == 0 || len(r.Schemas) == 0 {
+		return nil
+	}
+	return i.privileges(ctx, r)
+}
+
+// privileges queries and appends the privileges of the realm roles.
+func (i *inspect) privileges(ctx context.Context, r *schema.Realm) error {
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(privilegesQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying privileges: %w", err)
+	}
+	defer rows.Close()
+	var last *schema.Privilege
+	for rows.Next() {
+		var (
+			grantable        bool
+			role, ns, priv   string
+			objName, objKind sql.NullString
+		)
+		if err := rows.Scan(&role, &ns, &objName, &objKind, &priv, &grantable); err != nil {
+			return fmt.Errorf("postgres: scanning privilege: %w", err)
+		}
+		ro, ok := r.Role(role)
+		if !ok {
+			continue
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			continue
+		}
+		p := &schema.Privilege{Grantee: ro, Schema: s, Grantable: grantable}
+		switch objKind.String {
+		case "v":
+			if p.View, ok = s.View(objName.String); !ok {
+				continue
+			}
+		case "r", "p":
+			if p.Table, ok = s.Table(objName.String); !ok {
+				continue
 			}
-			idx.Parts = append(idx.Parts, part)
-		default:
-			return fmt.Errorf("postgres: invalid part for index %q", idx.Name)
 		}
-		if err := mayAppendOps(part, opcname.String, opcparams.String, opcdefault.Bool); err != nil {
-			return err
+		// Rows are ordered by their grantee, object and grant option.
+		if last == nil || last.Grantee != p.Grantee || last.Schema != p.Schema || last.Table != p.Table || last.View != p.View || last.Grantable != p.Grantable {
+			last = p
+			r.AddPrivileges(last)
 		}
+		last.Privs = append(last.Privs, priv)
 	}
-	return nil
+	return rows.Close()
 }
 
-// mayAppendOps appends an operator_class attribute to the part in case it is not the default.
-func mayAppendOps(part *schema.IndexPart, name string, params string, defaults bool) error {
-	if name == "" || defaults && params == "" {
-		return nil
+// policies queries and appends the row-level security
+// attributes and the policies of the schema tables.
+func (i *inspect) policies(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, policiesQuery, s.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q policies: %w", s.Name, err)
 	}
-	op := &IndexOpClass{Name: name, Default: defaults}
-	if err := op.parseParams(params); err != nil {
-		return err
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			enabled, forced         bool
+			table, roles            string
+			name, cmd, using, check sql.NullString
+			permissive              sql.NullBool
+		)
+		if err := rows.Scan(&table, &enabled, &forced, &name, &cmd, &permissive, &roles, &using, &check); err != nil {
+			return fmt.Errorf("postgres: scanning policy: %w", err)
+		}
+		t, ok := s.Table(table)
+		// Skip policies of tables that were not inspected.
+		if !ok {
+			continue
+		}
+		if (enabled || forced) && !sqlx.Has(t.Attrs, &RowSecurity{}) {
+			t.AddAttrs(&RowSecurity{Enabled: enabled, Forced: forced})
+		}
+		// Row-level security is enabled, but no policies were defined.
+		if !name.Valid {
+			continue
+		}
+		p := &Policy{Name: name.String, Command: cmd.String, As: PolicyAsPermissive, Using: using.String, Check: check.String}
+		if !permissive.Bool {
+			p.As = PolicyAsRestrictive
+		}
+		if err := json.Unmarshal([]byte(roles), &p.Roles); err != nil {
+			return fmt.Errorf("postgres: parsing roles of policy %q: %w", p.Name, err)
+		}
+		// Policies without roles apply to all roles.
+		if len(p.Roles) == 1 && p.Roles[0] == "public" {
+			p.Roles = nil
+		}
+		t.AddAttrs(p)
 	}
-	part.Attrs = append(part.Attrs, op)
-	return nil
+	return rows.Close()
 }
 
-// partitions builds the partition each table in the schema.
-func (i *inspect) partitions(s *schema.Schema) error {
-	for _, t := range s.Tables {
-		var d Partition
-		if !sqlx.Has(t.Attrs, &d) {
+// triggerFunc returns the function executed by a trigger. If the function
+// was not inspected (e.g. it resides in another schema), a reference to it is returned.
+func triggerFunc(s *schema.Schema, fschema, fname string) *schema.Func {
+	fs := s
+	if s.Name != fschema {
+		fs = nil
+		if s.Realm != nil {
+			fs, _ = s.Realm.Schema(fschema)
+		}
+		if fs == nil {
+			fs = schema.New(fschema)
+		}
+	}
+	if f, ok := fs.Func(fname); ok {
+		return f
+	}
+	return schema.NewFunc(fname).SetSchema(fs)
+}
+
+// funcArgs builds the arguments of a function from its catalog information. The names,
+// modes and types are JSON arrays, and the defaults are extracted from the arguments
+// definition as returned by pg_get_function_arguments.
+func funcArgs(def, names, modes, types string) ([]*schema.FuncArg, error) {
+	var ns, ms, ts []string
+	for _, a := range []struct {
+		s string
+		v *[]string
+	}{{names, &ns}, {modes, &ms}, {types, &ts}} {
+		if a.s == "" {
 			continue
 		}
-		switch s := strings.ToLower(d.start); s {
-		case "r":
-			d.T = PartitionTypeRange
-		case "l":
-			d.T = PartitionTypeList
-		case "h":
-			d.T = PartitionTypeHash
-		default:
-			return fmt.Errorf("postgres: unexpected partition strategy %q", s)
+		if err := json.Unmarshal([]byte(a.s), a.v); err != nil {
+			return nil, err
 		}
-		idxs := strings.Split(strings.TrimSpace(d.attrs), " ")
-		if len(idxs) == 0 {
-			return fmt.Errorf("postgres: no columns/expressions were found in partition key for column %q", t.Name)
+	}
+	var (
+		args []*schema.FuncArg
+		defs = splitArgs(def)
+	)
+	for idx, t := range ts {
+		a := &schema.FuncArg{Type: funcType(t)}
+		if idx < len(ns) {
+			a.Name = ns[idx]
 		}
-		for i := range idxs {
-			switch idx, err := strconv.Atoi(idxs[i]); {
-			case err != nil:
-				return fmt.Errorf("postgres: faild parsing partition key index %q", idxs[i])
-			// An expression.
-			case idx == 0:
-				j := sqlx.ExprLastIndex(d.exprs)
-				if j == -1 {
-					return fmt.Errorf("postgres: no expression found in partition key: %q", d.exprs)
-				}
-				d.Parts = append(d.Parts, &PartitionPart{
-					X: &schema.RawExpr{X: d.exprs[:j+1]},
-				})
-				d.exprs = strings.TrimPrefix(d.exprs[j+1:], ", ")
-			// A column at index idx-1.
-			default:
-				if idx > len(t.Columns) {
-					return fmt.Errorf("postgres: unexpected column index %d", idx)
-				}
-				d.Parts = append(d.Parts, &PartitionPart{
-					C: t.Columns[idx-1],
-				})
+		if idx < len(ms) {
+			switch ms[idx] {
+			case "o":
+				a.Mode = schema.FuncArgModeOut
+			case "b":
+				a.Mode = schema.FuncArgModeInOut
+			case "v":
+				a.Mode = schema.FuncArgModeVariadic
+			// Arguments of RETURNS TABLE functions
+			// are part of their return type.
+			case "t":
+				continue
 			}
 		}
-		schema.ReplaceOrAppend(&t.Attrs, &d)
+		if i := len(args); i < len(defs) {
+			if _, x, ok := strings.Cut(defs[i], " DEFAULT "); ok {
+				a.Default = &schema.RawExpr{X: strings.TrimSpace(x)}
+			}
+		}
+		args = append(args, a)
 	}
-	return nil
+	return args, nil
+}
+
+// splitArgs splits the function arguments definition by top-level commas.
+func splitArgs(def string) []string {
+	var (
+		parts []string
+		depth int
+		quote bool
+		start int
+	)
+	for i := 0; i < len(def); i++ {
+		switch c := def[i]; {
+		case c == '\'' || c == '"':
+			quote = !quote
+		case quote:
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(def[start:i]))
+			start = i + 1
+		}
+	}
+	if s := strings.TrimSpace(def[start:]); s != "" {
+		parts = append(parts, s)
+	}
+	return parts
+}
+
+// funcType returns the schema.Type of a function argument or its return type.
+// Types that cannot be parsed (e.g. SETOF integer) are returned as user-defined.
+func funcType(t string) schema.Type {
+	typ, err := ParseType(t)
+	if err != nil {
+		return &UserDefinedType{T: t}
+	}
+	return typ
 }
 
 // fks queries and appends the foreign keys of the given table.
@@ -478,7 +1477,19 @@ func (i *inspect) fks(ctx context.Context, s *schema.Schema) error {
 		return fmt.Errorf("postgres: querying schema %q foreign keys: %w", s.Name, err)
 	}
 	defer rows.Close()
-	if err := sqlx.SchemaFKs(s, rows); err != nil {
+	var (
+		deferrable, deferred string
+		validated            sql.NullBool
+	)
+	err = sqlx.ScanFKs(s, rows, []any{&deferrable, &deferred, &validated}, func(fk *schema.ForeignKey) {
+		if deferrable == "YES" && !sqlx.Has(fk.Attrs, &Deferrable{}) {
+			fk.Attrs = append(fk.Attrs, &Deferrable{InitiallyDeferred: deferred == "YES"})
+		}
+		if validated.Valid && !validated.Bool && !sqlx.Has(fk.Attrs, &NotValid{}) {
+			fk.Attrs = append(fk.Attrs, &NotValid{})
+		}
+	})
+	if err != nil {
 		return fmt.Errorf("postgres: %w", err)
 	}
 	return rows.Err()
@@ -499,13 +1510,15 @@ func (i *inspect) checks(ctx context.Context, s *schema.Schema) error {
 
 // addChecks scans the rows and adds the checks to the table.
 func (i *inspect) addChecks(s *schema.Schema, rows *sql.Rows) error {
-	names := make(map[string]*schema.Check)
+	// Inherited checks share their names with the checks of the parent tables.
+	names := make(map[[2]string]*schema.Check)
 	for rows.Next() {
 		var (
 			noInherit                            bool
+			local, validated                     sql.NullBool
 			table, name, column, clause, indexes string
 		)
-		if err := rows.Scan(&table, &name, &clause, &column, &indexes, &noInherit); err != nil {
+		if err := rows.Scan(&table, &name, &clause, &column, &indexes, &noInherit, &local, &validated); err != nil {
 			return fmt.Errorf("postgres: scanning check: %w", err)
 		}
 		t, ok := s.Table(table)
@@ -515,13 +1528,19 @@ func (i *inspect) addChecks(s *schema.Schema, rows *sql.Rows) error {
 		if _, ok := t.Column(column); !ok {
 			return fmt.Errorf("postgres: column %q was not found for check %q", column, name)
 		}
-		check, ok := names[name]
+		check, ok := names[[2]string{table, name}]
 		if !ok {
 			check = &schema.Check{Name: name, Expr: clause, Attrs: []schema.Attr{&CheckColumns{}}}
 			if noInherit {
 				check.Attrs = append(check.Attrs, &NoInherit{})
 			}
-			names[name] = check
+			if local.Valid && !local.Bool && inheritsOf(t) != nil {
+				check.Attrs = append(check.Attrs, &Inherited{})
+			}
+			if validated.Valid && !validated.Bool {
+				check.Attrs = append(check.Attrs, &NotValid{})
+			}
+			names[[2]string{table, name}] = check
 			t.Attrs = append(t.Attrs, check)
 		}
 		c := check.Attrs[0].(*CheckColumns)
@@ -742,10 +1761,98 @@ type (
 		T string // c, f, p, u, t, x.
 	}
 
-	// Sequence defines (the supported) sequence options.
+	// Extension describes a PostgreSQL extension that is installed in the
+	// database. The extension objects are created in its target schema.
+	// https://postgresql.org/docs/current/sql-createextension.html
+	Extension struct {
+		schema.Object
+		Name    string
+		Schema  *schema.Schema // Target schema.
+		Version string         // Optional version.
+	}
+
+	// RowSecurity describes the row-level security attributes of a table.
+	// https://postgresql.org/docs/current/ddl-rowsecurity.html
+	RowSecurity struct {
+		schema.Attr
+		Enabled bool // ENABLE ROW LEVEL SECURITY.
+		Forced  bool // FORCE ROW LEVEL SECURITY.
+	}
+
+	// Policy describes a row-level security policy of a table.
+	// https://postgresql.org/docs/current/sql-createpolicy.html
+	Policy struct {
+		schema.Attr
+		Name string
+		// As is either PERMISSIVE (the default) or RESTRICTIVE.
+		As string
+		// Command the policy applies to. e.g. ALL (the default), SELECT or UPDATE.
+		Command string
+		// Roles the policy applies to. Empty means PUBLIC.
+		Roles []string
+		// Using and Check hold the USING and the WITH CHECK expressions.
+		Using, Check string
+	}
+
+	// RoleOptions describes the options of a PostgreSQL role. The LOGIN
+	// option is represented by the Login field of the schema.Role.
+	// https://postgresql.org/docs/current/sql-createrole.html
+	RoleOptions struct {
+		schema.Attr
+		Superuser   bool
+		CreateDB    bool
+		CreateRole  bool
+		Replication bool
+		BypassRLS   bool
+		NoInherit   bool
+	}
+
+	// Domain defines a domain type. A domain is a schema object that is based
+	// on an underlying type, and may be used as a column type. The constraints
+	// of the domain (e.g. NOT NULL or CHECK) apply to all columns that use it.
+	// https://postgresql.org/docs/current/sql-createdomain.html
+	Domain struct {
+		schema.Type // Underlying type (e.g. integer).
+		schema.Object
+		T       string // Type name.
+		Schema  *schema.Schema
+		Null    bool
+		Default schema.Expr
+		Checks  []*schema.Check
+	}
+
+	// CompositeType defines a composite type. A composite type is a schema
+	// object that describes the structure of a row, and may be used as a column type.
+	// https://postgresql.org/docs/current/rowtypes.html
+	CompositeType struct {
+		schema.Type
+		schema.Object
+		T      string // Type name.
+		Schema *schema.Schema
+		Fields []*schema.Column
+	}
+
+	// Sequence defines (the supported) sequence options. Sequences are used either
+	// as the options of identity columns, or as standalone schema objects that are
+	// created with CREATE SEQUENCE. In the latter, all fields may be set.
 	// https://postgresql.org/docs/current/sql-createsequence.html
 	Sequence struct {
+		schema.Object
+		Name   string
+		Schema *schema.Schema
+		// Type of the sequence. e.g. smallint, integer or bigint.
+		Type             schema.Type
 		Start, Increment int64
+		// Min and Max values of the sequence. Zero values stand
+		// for the defaults of the sequence type and direction.
+		Min, Max int64
+		Cache    int64
+		Cycle    bool
+		// Owner is the table column that the sequence is owned by (OWNED BY).
+		Owner struct {
+			T *schema.Table
+			C *schema.Column
+		}
 		// Last sequence value written to disk.
 		// https://postgresql.org/docs/current/view-pg-sequences.html.
 		Last int64
@@ -810,6 +1917,21 @@ type (
 		Params  []struct{ N, V string } // Optional parameters.
 	}
 
+	// Deferrable describes the DEFERRABLE clause of a constraint. It can be
+	// set on foreign keys, primary keys, unique and exclusion constraints.
+	// https://postgresql.org/docs/current/sql-set-constraints.html
+	Deferrable struct {
+		schema.Attr
+		InitiallyDeferred bool
+	}
+
+	// ExcludeOp describes the operator of an exclusion constraint element.
+	// https://postgresql.org/docs/current/sql-createtable.html#SQL-CREATETABLE-EXCLUDE
+	ExcludeOp struct {
+		schema.Attr
+		Op string // e.g. =, &&.
+	}
+
 	// Concurrently describes the CONCURRENTLY clause to instruct Postgres to
 	// build or drop the index concurrently without blocking the current table.
 	// https://www.postgresql.org/docs/current/sql-createindex.html#SQL-CREATEINDEX-CONCURRENTLY
@@ -823,6 +1945,13 @@ type (
 		schema.Attr
 	}
 
+	// NotValid attribute describes a foreign key or a CHECK constraint that was
+	// added with the NOT VALID flag, and its existing rows were not validated yet.
+	// https://postgresql.org/docs/current/sql-altertable.html#SQL-ALTERTABLE-NOTES
+	NotValid struct {
+		schema.Attr
+	}
+
 	// CheckColumns attribute hold the column named used by the CHECK constraints.
 	// This attribute is added on inspection for internal usage and has no meaning
 	// on migration.
@@ -845,6 +1974,62 @@ type (
 		start, attrs, exprs string
 	}
 
+	// PartitionOf describes a table that is a partition of a
+	// partitioned (parent) table. e.g. CREATE TABLE ... PARTITION OF.
+	PartitionOf struct {
+		schema.Attr
+		Parent *schema.Table
+		// Bound holds the partition bound specification, without
+		// the FOR VALUES prefix. e.g. "FROM (1) TO (10)", "IN (1, 2)",
+		// or "WITH (MODULUS 4, REMAINDER 0)". It is empty for default
+		// partitions.
+		Bound   string
+		Default bool
+
+		// Internal info returned from pg_inherits.
+		parent [2]string
+	}
+
+	// Inherits describes the parent tables of a table that uses the classic
+	// table inheritance. e.g. CREATE TABLE ... INHERITS. Partitions are
+	// described by the PartitionOf attribute.
+	Inherits struct {
+		schema.Attr
+		Parents []*schema.Table
+
+		// Internal info returned from pg_inherits.
+		parents [][2]string
+	}
+
+	// Inherited marks the columns and the check constraints that are inherited
+	// from the parent tables, and are not defined locally in the child table.
+	Inherited struct {
+		schema.Attr
+	}
+
+	// Unlogged describes an unlogged table. Data written to unlogged
+	// tables is not written to the write-ahead log.
+	Unlogged struct {
+		schema.Attr
+	}
+
+	// Tablespace describes the tablespace in which the table is stored.
+	// Tables that are stored in the database default tablespace do not
+	// hold this attribute.
+	Tablespace struct {
+		schema.Attr
+		Name string
+	}
+
+	// TableStorageParams describes table storage parameters added with the WITH clause.
+	// https://postgresql.org/docs/current/sql-createtable.html#SQL-CREATETABLE-STORAGE-PARAMETERS
+	TableStorageParams struct {
+		schema.Attr
+		// Params holds the storage parameters by their names. e.g. fillfactor,
+		// autovacuum_enabled or toast.autovacuum_enabled.
+		Params map[string]string
+	}
+
 	// An PartitionPart represents an index part that
 	// can be either an expression or a column.
 	PartitionPart struct {
@@ -852,11 +2037,30 @@ type (
 		C     *schema.Column
 		Attrs []schema.Attr
 	}
+
+	// Volatility describes the volatility category of a function.
+	// https://www.postgresql.org/docs/current/xfunc-volatility.html
+	Volatility struct {
+		schema.Attr
+		V string // IMMUTABLE, STABLE or VOLATILE.
+	}
 )
 
 // IsUnique reports if the type is unique constraint.
 func (c ConType) IsUnique() bool { return strings.ToLower(c.T) == "u" }
 
+// ObjectName returns the name of the domain. It implements the sqlx.ObjectNamer interface.
+func (d *Domain) ObjectName() string { return d.T }
+
+// ObjectName returns the name of the composite type. It implements the sqlx.ObjectNamer interface.
+func (c *CompositeType) ObjectName() string { return c.T }
+
+// ObjectName returns the name of the sequence. It implements the sqlx.ObjectNamer interface.
+func (s *Sequence) ObjectName() string { return s.Name }
+
+// ObjectName returns the name of the extension. It implements the sqlx.ObjectNamer interface.
+func (e *Extension) ObjectName() string { return e.Name }
+
 // IntegerType returns the underlying integer type this serial type represents.
 func (s *SerialType) IntegerType() *schema.IntegerType {
 	t := &schema.IntegerType{T: TypeInteger}
@@ -1022,6 +2226,19 @@ func newIndexStorage(opts string) (*IndexStorageParams, error) {
 	return params, nil
 }
 
+// newTableStorage parses and returns the table storage parameters.
+func newTableStorage(opts string) (*TableStorageParams, error) {
+	params := &TableStorageParams{Params: make(map[string]string)}
+	for _, p := range strings.Split(strings.Trim(opts, "{}"), ",") {
+		kv := strings.SplitN(strings.Trim(p, `"`), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("postgres: invalid table storage parameter: %s", p)
+		}
+		params.Params[kv[0]] = kv[1]
+	}
+	return params, nil
+}
+
 // reEnumType extracts the enum type and an option schema qualifier.
 var reEnumType = regexp.MustCompile(`^(?:(".+"|\w+)\.)?(".+"|\w+)$`)
 
@@ -1064,15 +2281,26 @@ SELECT
 	pg_catalog.obj_description(t3.oid, 'pg_class') AS comment,
 	t4.partattrs AS partition_attrs,
 	t4.partstrat AS partition_strategy,
-	pg_get_expr(t4.partexprs, t4.partrelid) AS partition_exprs
+	pg_get_expr(t4.partexprs, t4.partrelid) AS partition_exprs,
+	t7.nspname AS partition_parent_schema,
+	t6.relname AS partition_parent,
+	pg_get_expr(t3.relpartbound, t3.oid) AS partition_bound,
+	t3.relpersistence AS persistence,
+	t8.spcname AS tablespace,
+	(SELECT array_cat(t3.reloptions, array_agg('toast.' || o)) FROM pg_catalog.pg_class AS t9, unnest(t9.reloptions) AS o WHERE t9.oid = t3.reltoastrelid) AS storage_params,
+	(SELECT json_agg(json_build_array(t12.nspname, t11.relname) ORDER BY t10.inhseqno) FROM pg_catalog.pg_inherits AS t10 JOIN pg_catalog.pg_class AS t11 ON t11.oid = t10.inhparent JOIN pg_catalog.pg_namespace AS t12 ON t12.oid = t11.relnamespace WHERE t10.inhrelid = t3.oid AND NOT COALESCE(t3.relispartition, false)) AS inherits
 FROM
 	INFORMATION_SCHEMA.TABLES AS t1
 	JOIN pg_catalog.pg_namespace AS t2 ON t2.nspname = t1.table_schema
 	JOIN pg_catalog.pg_class AS t3 ON t3.relnamespace = t2.oid AND t3.relname = t1.table_name
 	LEFT JOIN pg_catalog.pg_partitioned_table AS t4 ON t4.partrelid = t3.oid
+	LEFT JOIN pg_catalog.pg_inherits AS t5 ON t5.inhrelid = t3.oid AND COALESCE(t3.relispartition, false)
+	LEFT JOIN pg_catalog.pg_class AS t6 ON t6.oid = t5.inhparent
+	LEFT JOIN pg_catalog.pg_namespace AS t7 ON t7.oid = t6.relnamespace
+	LEFT JOIN pg_catalog.pg_tablespace AS t8 ON t8.oid = t3.reltablespace
 WHERE
 	t1.table_type = 'BASE TABLE'
-	AND NOT COALESCE(t3.relispartition, false)
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_class'::regclass AND d.objid = t3.oid AND d.deptype = 'e')
 	AND t1.table_schema IN (%s)
 ORDER BY
 	t1.table_schema, t1.table_name
@@ -1084,15 +2312,26 @@ SELECT
 	pg_catalog.obj_description(t3.oid, 'pg_class') AS comment,
 	t4.partattrs AS partition_attrs,
 	t4.partstrat AS partition_strategy,
-	pg_get_expr(t4.partexprs, t4.partrelid) AS partition_exprs
+	pg_get_expr(t4.partexprs, t4.partrelid) AS partition_exprs,
+	t7.nspname AS partition_parent_schema,
+	t6.relname AS partition_parent,
+	pg_get_expr(t3.relpartbound, t3.oid) AS partition_bound,
+	t3.relpersistence AS persistence,
+	t8.spcname AS tablespace,
+	(SELECT array_cat(t3.reloptions, array_agg('toast.' || o)) FROM pg_catalog.pg_class AS t9, unnest(t9.reloptions) AS o WHERE t9.oid = t3.reltoastrelid) AS storage_params,
+	(SELECT json_agg(json_build_array(t12.nspname, t11.relname) ORDER BY t10.inhseqno) FROM pg_catalog.pg_inherits AS t10 JOIN pg_catalog.pg_class AS t11 ON t11.oid = t10.inhparent JOIN pg_catalog.pg_namespace AS t12 ON t12.oid = t11.relnamespace WHERE t10.inhrelid = t3.oid AND NOT COALESCE(t3.relispartition, false)) AS inherits
 FROM
 	INFORMATION_SCHEMA.TABLES AS t1
 	JOIN pg_catalog.pg_namespace AS t2 ON t2.nspname = t1.table_schema
 	JOIN pg_catalog.pg_class AS t3 ON t3.relnamespace = t2.oid AND t3.relname = t1.table_name
 	LEFT JOIN pg_catalog.pg_partitioned_table AS t4 ON t4.partrelid = t3.oid
+	LEFT JOIN pg_catalog.pg_inherits AS t5 ON t5.inhrelid = t3.oid AND COALESCE(t3.relispartition, false)
+	LEFT JOIN pg_catalog.pg_class AS t6 ON t6.oid = t5.inhparent
+	LEFT JOIN pg_catalog.pg_namespace AS t7 ON t7.oid = t6.relnamespace
+	LEFT JOIN pg_catalog.pg_tablespace AS t8 ON t8.oid = t3.reltablespace
 WHERE
 	t1.table_type = 'BASE TABLE'
-	AND NOT COALESCE(t3.relispartition, false)
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_class'::regclass AND d.objid = t3.oid AND d.deptype = 'e')
 	AND t1.table_schema IN (%s)
 	AND t1.table_name IN (%s)
 ORDER BY
@@ -1124,7 +2363,8 @@ SELECT
 	t4.typtype,
 	t4.typelem,
 	(CASE WHEN t4.typcategory = 'A' AND t4.typelem <> 0 THEN (SELECT t.typtype FROM pg_catalog.pg_type t WHERE t.oid = t4.typelem) END) AS elemtyp,
-	t4.oid
+	t4.oid,
+	a.attislocal AS is_local
 FROM
 	"information_schema"."columns" AS t1
 	JOIN pg_catalog.pg_namespace AS t2 ON t2.nspname = t1.table_schema
@@ -1136,6 +2376,293 @@ WHERE
 ORDER BY
 	t1.table_name, t1.ordinal_position
 `
+	// Query to list schema views and materialized views.
+	viewsQuery = `
+SELECT
+	t1.table_name,
+	pg_catalog.pg_get_viewdef(t3.oid) AS view_definition,
+	t1.check_option,
+	pg_catalog.obj_description(t3.oid, 'pg_class') AS comment,
+	false AS materialized
+FROM
+	INFORMATION_SCHEMA.VIEWS AS t1
+	JOIN pg_catalog.pg_namespace AS t2 ON t2.nspname = t1.table_schema
+	JOIN pg_catalog.pg_class AS t3 ON t3.relnamespace = t2.oid AND t3.relname = t1.table_name
+WHERE
+	t1.table_schema = $1
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_class'::regclass AND d.objid = t3.oid AND d.deptype = 'e')
+UNION ALL
+SELECT
+	t1.matviewname AS table_name,
+	pg_catalog.pg_get_viewdef(t3.oid) AS view_definition,
+	NULL AS check_option,
+	pg_catalog.obj_description(t3.oid, 'pg_class') AS comment,
+	true AS materialized
+FROM
+	pg_catalog.pg_matviews AS t1
+	JOIN pg_catalog.pg_namespace AS t2 ON t2.nspname = t1.schemaname
+	JOIN pg_catalog.pg_class AS t3 ON t3.relnamespace = t2.oid AND t3.relname = t1.matviewname
+WHERE
+	t1.schemaname = $1
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_class'::regclass AND d.objid = t3.oid AND d.deptype = 'e')
+ORDER BY
+	table_name
+`
+
+	// Query to list view columns. The columns are queried from pg_attribute,
+	// as materialized views are not listed in the information_schema.
+	viewColumnsQuery = `
+SELECT
+	t1.relname AS table_name,
+	a.attname AS column_name,
+	pg_catalog.format_type(a.atttypid, a.atttypmod) AS format_type,
+	(CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END) AS is_nullable
+FROM
+	pg_catalog.pg_class AS t1
+	JOIN pg_catalog.pg_namespace AS t2 ON t2.oid = t1.relnamespace
+	JOIN pg_catalog.pg_attribute AS a ON a.attrelid = t1.oid AND a.attnum > 0 AND NOT a.attisdropped
+WHERE
+	t2.nspname = $1 AND t1.relname IN (%s)
+ORDER BY
+	t1.relname, a.attnum
+`
+
+	// Query to list schema functions and procedures. Functions
+	// that were created by extensions are ignored.
+	funcsQueryTmpl = `
+SELECT
+	p.proname,
+	%[1]s AS kind,
+	pg_catalog.pg_get_function_arguments(p.oid) AS args,
+	pg_catalog.pg_get_function_result(p.oid) AS result,
+	l.lanname,
+	p.prosrc,
+	p.provolatile,
+	array_to_json(p.proargnames) AS arg_names,
+	array_to_json(p.proargmodes) AS arg_modes,
+	(SELECT json_agg(pg_catalog.format_type(a.t, NULL) ORDER BY a.o) FROM unnest(COALESCE(p.proallargtypes, p.proargtypes::oid[])) WITH ORDINALITY AS a(t, o)) AS arg_types,
+	pg_catalog.obj_description(p.oid, 'pg_proc') AS comment
+FROM
+	pg_catalog.pg_proc AS p
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = p.pronamespace
+	JOIN pg_catalog.pg_language AS l ON l.oid = p.prolang
+WHERE
+	n.nspname = $1
+	AND %[1]s IN ('f', 'p')
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e')
+ORDER BY
+	p.proname
+`
+
+	// Query to list the extensions that were installed in a schema.
+	extensionsQuery = `
+SELECT
+	e.extname,
+	e.extversion
+FROM
+	pg_catalog.pg_extension AS e
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = e.extnamespace
+WHERE
+	n.nspname = $1
+ORDER BY
+	e.extname
+`
+
+	// Query to list the enum types of a schema and their values.
+	// Enum types that were created by extensions are ignored.
+	enumsQuery = `
+SELECT
+	t.typname AS enum_name,
+	e.enumlabel AS enum_value
+FROM
+	pg_catalog.pg_type AS t
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = t.typnamespace
+	JOIN pg_catalog.pg_enum AS e ON e.enumtypid = t.oid
+WHERE
+	n.nspname = $1
+	AND t.typtype = 'e'
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e')
+ORDER BY
+	t.typname, e.enumsortorder
+`
+
+	// Query to list the domain types of a schema and their check constraints.
+	// Domain types that were created by extensions are ignored.
+	domainsQuery = `
+SELECT
+	t.typname AS domain_name,
+	pg_catalog.format_type(t.typbasetype, t.typtypmod) AS base_type,
+	t.typnotnull,
+	t.typdefault,
+	c.conname,
+	pg_catalog.pg_get_expr(c.conbin, 0) AS expression
+FROM
+	pg_catalog.pg_type AS t
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = t.typnamespace
+	LEFT JOIN pg_catalog.pg_constraint AS c ON c.contypid = t.oid AND c.contype = 'c'
+WHERE
+	n.nspname = $1
+	AND t.typtype = 'd'
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e')
+ORDER BY
+	t.typname, c.conname
+`
+
+	// Query to list the composite types of a schema and their fields. The row
+	// types of tables and views and the types created by extensions are ignored.
+	compositesQuery = `
+SELECT
+	t.typname AS type_name,
+	a.attname AS field_name,
+	pg_catalog.format_type(a.atttypid, a.atttypmod) AS field_type
+FROM
+	pg_catalog.pg_type AS t
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = t.typnamespace
+	JOIN pg_catalog.pg_class AS c ON c.oid = t.typrelid AND c.relkind = 'c'
+	JOIN pg_catalog.pg_attribute AS a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+WHERE
+	n.nspname = $1
+	AND t.typtype = 'c'
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend AS d WHERE d.classid = 'pg_catalog.pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e')
+ORDER BY
+	t.typname, a.attnum
+`
+
+	// Query to list the roles that were created by users.
+	// Built-in roles (e.g. pg_monitor) are ignored.
+	rolesQuery = `
+SELECT
+	r.rolname,
+	r.rolsuper,
+	r.rolinherit,
+	r.rolcreaterole,
+	r.rolcreatedb,
+	r.rolcanlogin,
+	r.rolreplication,
+	r.rolbypassrls
+FROM
+	pg_catalog.pg_roles AS r
+WHERE
+	r.oid >= 16384
+ORDER BY
+	r.rolname
+`
+
+	// Query to list the privileges that were granted to user-created roles on the given
+	// schemas and their tables and views. The implicit privileges of owners are ignored.
+	privilegesQuery = `
+SELECT
+	r.rolname,
+	n.nspname,
+	NULL::name AS object_name,
+	NULL::"char" AS object_kind,
+	a.privilege_type,
+	a.is_grantable
+FROM
+	pg_catalog.pg_namespace AS n
+	CROSS JOIN LATERAL pg_catalog.aclexplode(n.nspacl) AS a
+	JOIN pg_catalog.pg_roles AS r ON r.oid = a.grantee
+WHERE
+	n.nspname IN (%[1]s)
+	AND a.grantee <> n.nspowner
+	AND r.oid >= 16384
+UNION ALL
+SELECT
+	r.rolname,
+	n.nspname,
+	c.relname AS object_name,
+	c.relkind AS object_kind,
+	a.privilege_type,
+	a.is_grantable
+FROM
+	pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	CROSS JOIN LATERAL pg_catalog.aclexplode(c.relacl) AS a
+	JOIN pg_catalog.pg_roles AS r ON r.oid = a.grantee
+WHERE
+	n.nspname IN (%[1]s)
+	AND c.relkind IN ('r', 'p', 'v')
+	AND a.grantee <> c.relowner
+	AND r.oid >= 16384
+ORDER BY
+	1, 2, 3 NULLS FIRST, 6, 5
+`
+
+	// Query to list the sequences of a schema. Sequences that were created
+	// implicitly for identity columns are ignored.
+	sequencesQuery = `
+SELECT
+	s.sequencename,
+	s.data_type,
+	s.start_value,
+	s.increment_by,
+	s.min_value,
+	s.max_value,
+	s.cache_size,
+	s.cycle,
+	t.relname AS owner_table,
+	a.attname AS owner_column
+FROM
+	pg_catalog.pg_sequences AS s
+	JOIN pg_catalog.pg_namespace AS n ON n.nspname = s.schemaname
+	JOIN pg_catalog.pg_class AS c ON c.relnamespace = n.oid AND c.relname = s.sequencename
+	LEFT JOIN pg_catalog.pg_depend AS d ON d.classid = 'pg_catalog.pg_class'::regclass AND d.objid = c.oid AND d.refclassid = 'pg_catalog.pg_class'::regclass AND d.deptype IN ('a', 'i')
+	LEFT JOIN pg_catalog.pg_class AS t ON t.oid = d.refobjid
+	LEFT JOIN pg_catalog.pg_attribute AS a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+WHERE
+	s.schemaname = $1
+	AND (d.deptype IS NULL OR d.deptype <> 'i')
+ORDER BY
+	s.sequencename
+`
+
+	// Query to list the triggers of schema tables. Internal triggers
+	// (e.g. the ones that implement foreign keys) are ignored.
+	triggersQuery = `
+SELECT
+	t.tgname,
+	c.relname,
+	t.tgtype,
+	p.proname,
+	pn.nspname,
+	COALESCE((SELECT json_agg(a.attname ORDER BY k.o) FROM unnest(t.tgattr::int2[]) WITH ORDINALITY AS k(n, o) JOIN pg_catalog.pg_attribute AS a ON a.attrelid = t.tgrelid AND a.attnum = k.n), '[]') AS columns
+FROM
+	pg_catalog.pg_trigger AS t
+	JOIN pg_catalog.pg_class AS c ON c.oid = t.tgrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_catalog.pg_proc AS p ON p.oid = t.tgfoid
+	JOIN pg_catalog.pg_namespace AS pn ON pn.oid = p.pronamespace
+WHERE
+	n.nspname = $1
+	AND NOT t.tgisinternal
+ORDER BY
+	c.relname, t.tgname
+`
+
+	// Query to list the row-level security attributes and the policies of the schema tables.
+	policiesQuery = `
+SELECT
+	c.relname,
+	c.relrowsecurity,
+	c.relforcerowsecurity,
+	p.polname,
+	CASE p.polcmd WHEN 'r' THEN 'SELECT' WHEN 'a' THEN 'INSERT' WHEN 'w' THEN 'UPDATE' WHEN 'd' THEN 'DELETE' ELSE 'ALL' END AS command,
+	p.polpermissive,
+	COALESCE((SELECT json_agg(CASE WHEN r.oid = 0 THEN 'public' ELSE pg_catalog.pg_get_userbyid(r.oid) END ORDER BY r.o) FROM unnest(p.polroles) WITH ORDINALITY AS r(oid, o)), '[]') AS roles,
+	pg_catalog.pg_get_expr(p.polqual, p.polrelid) AS using_expr,
+	pg_catalog.pg_get_expr(p.polwithcheck, p.polrelid) AS check_expr
+FROM
+	pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	LEFT JOIN pg_catalog.pg_policy AS p ON p.polrelid = c.oid
+WHERE
+	n.nspname = $1
+	AND c.relkind IN ('r', 'p')
+	AND (c.relrowsecurity OR c.relforcerowsecurity OR p.oid IS NOT NULL)
+ORDER BY
+	c.relname, p.polname
+`
+
 	fksQuery = `
 SELECT
     t1.constraint_name,
@@ -1146,7 +2673,15 @@ SELECT
     t3.column_name AS referenced_column_name,
     t3.table_schema AS referenced_schema_name,
     t4.update_rule,
-    t4.delete_rule
+    t4.delete_rule,
+    t1.is_deferrable,
+    t1.initially_deferred,
+    (
+        SELECT c.convalidated FROM pg_constraint c
+        JOIN pg_class r ON r.oid = c.conrelid
+        JOIN pg_namespace n ON n.oid = r.relnamespace
+        WHERE c.conname = t1.constraint_name AND r.relname = t1.table_name AND n.nspname = t1.table_schema
+    ) AS validated
 FROM
     information_schema.table_constraints t1
     JOIN information_schema.key_column_usage t2
@@ -1175,7 +2710,9 @@ SELECT
 	pg_get_expr(t1.conbin, t1.conrelid) as expression,
 	t2.attname as column_name,
 	t1.conkey as column_indexes,
-	t1.connoinherit as no_inherit
+	t1.connoinherit as no_inherit,
+	t1.conislocal as is_local,
+	t1.convalidated as validated
 FROM
 	pg_constraint t1
 	JOIN pg_attribute t2
@@ -1195,6 +2732,8 @@ ORDER BY
 )
 
 var (
+	funcsQuery            = fmt.Sprintf(funcsQueryTmpl, "p.prokind")
+	funcsQueryPG10        = fmt.Sprintf(funcsQueryTmpl, "(CASE WHEN p.proisagg THEN 'a' WHEN p.proiswindow THEN 'w' ELSE 'f' END)")
 	indexesQuery          = fmt.Sprintf(indexesQueryTmpl, "(a.attname <> '' AND idx.indnatts > idx.indnkeyatts AND idx.ord > idx.indnkeyatts)", "%s")
 	indexesQueryNoInclude = fmt.Sprintf(indexesQueryTmpl, "false", "%s")
 	indexesQueryTmpl      = `
@@ -1216,7 +2755,10 @@ SELECT
 	i.reloptions AS options,
 	op.opcname AS opclass_name,
 	op.opcdefault AS opclass_default,
-	a2.attoptions AS opclass_params
+	a2.attoptions AS opclass_params,
+	c.condeferrable AS deferrable,
+	c.condeferred AS initially_deferred,
+	(SELECT o.oprname FROM pg_operator o WHERE o.oid = c.conexclop[idx.ord]) AS exclusion_op
 FROM
 	(
 		select
@@ -1237,6 +2779,7 @@ WHERE
 	n.nspname = $1
 	AND t.relname IN (%s)
 	AND COALESCE(c.contype, '') <> 'f'
+	AND NOT COALESCE(i.relispartition, false)
 ORDER BY
 	table_name, index_name, idx.ord
 `
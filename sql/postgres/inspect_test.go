@@ -7,9 +7,11 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"math"
 	"testing"
 
 	"ariga.io/atlas/sql/internal/sqltest"
+	"ariga.io/atlas/sql/internal/sqlx"
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
 
@@ -41,8 +43,8 @@ func TestDriver_InspectTable(t *testing.T) {
 				m.ExpectQuery(queryColumns).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
- table_name  |  column_name |          data_type          |  formatted          | is_nullable |         column_default                 | character_maximum_length | numeric_precision | datetime_precision | numeric_scale |    interval_type    | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp |  oid
--------------+--------------+-----------------------------+---------------------|-------------+----------------------------------------+--------------------------+-------------------+--------------------+---------------+---------------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------
+ table_name  |  column_name |          data_type          |  formatted          | is_nullable |         column_default                 | character_maximum_length | numeric_precision | datetime_precision | numeric_scale |    interval_type    | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp |  oid | is_local
+-------------+--------------+-----------------------------+---------------------|-------------+----------------------------------------+--------------------------+-------------------+--------------------+---------------+---------------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------+---------
  users       |  id          | bigint                      | int8                | NO          |                                        |                          |                64 |                    |             0 |                     |                    |                | YES         |      100       |          1         |          1       |    BY DEFAULT       |                       |         | b       |         |         |    20
  users       |  rank        | integer                     | int4                | YES         |                                        |                          |                32 |                    |             0 |                     |                    |                | NO          |                |                    |                  |                     |                       | rank    | b       |         |         |    23
  users       |  c1          | smallint                    | int2                | NO          |           1000                         |                          |                16 |                    |             0 |                     |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |    21
@@ -150,8 +152,8 @@ func TestDriver_InspectTable(t *testing.T) {
 				m.ExpectQuery(queryColumns).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-table_name | column_name |      data_type      | formatted |  is_nullable |         column_default          | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp |  oid
------------+-------------+---------------------+-----------+--------------+---------------------------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------
+table_name | column_name |      data_type      | formatted |  is_nullable |         column_default          | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp |  oid | is_local
+-----------+-------------+---------------------+-----------+--------------+---------------------------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------+---------
 users      | id          | bigint              | int8      |  NO          |                                 |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |    20
 users      | c1          | smallint            | int2      |  NO          |                                 |                          |                16 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |    21
 users      | parent_id   | bigint              | int8      |  YES         |                                 |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |    22
@@ -160,23 +162,24 @@ users      | ts          | tsvector            | tsvector  |  NO          |
 				m.ExpectQuery(queryIndexes).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-   table_name   |    index_name   | index_type  | column_name | included | primary | unique | constraint_type | predicate             |   expression              | desc | nulls_first | nulls_last | comment   |                 options               |   opclass_name    | opclass_default | opclass_params 
-----------------+-----------------+-------------+-------------+----------+---------+--------+-----------------+-----------------------+---------------------------+------+-------------+------------+-----------+---------------------------------------+-------------------+-----------------+----------------
-users           | idx             | hash        |             | f        | f       | f      |                 |                       | "left"((c11)::text, 100)  | t    | t           | f          | boring    |                                       |     int4_ops      |        t        |
-users           | idx1            | btree       |             | f        | f       | f      |                 | (id <> NULL::integer) | "left"((c11)::text, 100)  | t    | t           | f          |           |                                       |     int4_ops      |        t        |
-users           | t1_c1_key       | btree       | c1          | f        | f       | t      | u               |                       | c1                        | t    | t           | f          |           |                                       |     int4_ops      |        t        |
-users           | t1_pkey         | btree       | id          | f        | t       | t      | p               |                       | id                        | t    | f           | f          |           |                                       |     int4_ops      |        t        |
-users           | idx4            | btree       | c1          | f        | f       | t      |                 |                       | c1                        | f    | f           | f          |           |                                       |     int4_ops      |        t        |
-users           | idx4            | btree       | id          | f        | f       | t      |                 |                       | id                        | f    | f           | t          |           |                                       |     int4_ops      |        t        |
-users           | idx5            | btree       | c1          | f        | f       | t      |                 |                       | c1                        | f    | f           | f          |           |                                       |     int4_ops      |        t        |
-users           | idx5            | btree       |             | f        | f       | t      |                 |                       | coalesce(parent_id, 0)    | f    | f           | f          |           |                                       |     int4_ops      |        t        |
-users           | idx6            | brin        | c1          | f        | f       | t      |                 |                       |                           | f    | f           | f          |           | {autosummarize=true,pages_per_range=2}|     int4_ops      |        t        |
-users           | idx2            | btree       |             | f        | f       | f      |                 |                       | ((c * 2))                 | f    | f           | t          |           |                                       |     int4_ops      |        t        |
-users           | idx2            | btree       | c1          | f        | f       | f      |                 |                       | c                         | f    | f           | t          |           |                                       |     int4_ops      |        t        |
-users           | idx2            | btree       | id          | f        | f       | f      |                 |                       | d                         | f    | f           | t          |           |                                       |     int4_ops      |        t        |
-users           | idx2            | btree       | c1          | t        | f       | f      |                 |                       | c                         |      |             |            |           |                                       |     int4_ops      |        t        |
-users           | idx2            | btree       | parent_id   | t        | f       | f      |                 |                       | d                         |      |             |            |           |                                       |     int4_ops      |        t        |
-users           | tsx             | gist        | ts          | f        | f       | f      |                 |                       | ts                        |      |             |            |           |                                       |     tsvector_ops  |        f        | {siglen=1}
+   table_name   |    index_name   | index_type  | column_name | included | primary | unique | constraint_type | predicate             |   expression              | desc | nulls_first | nulls_last | comment   |                 options               |   opclass_name    | opclass_default | opclass_params | deferrable | initially_deferred | exclusion_op
+----------------+-----------------+-------------+-------------+----------+---------+--------+-----------------+-----------------------+---------------------------+------+-------------+------------+-----------+---------------------------------------+-------------------+-----------------+-----------------+------------+--------------------+-------------
+users           | idx             | hash        |             | f        | f       | f      |                 |                       | "left"((c11)::text, 100)  | t    | t           | f          | boring    |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx1            | btree       |             | f        | f       | f      |                 | (id <> NULL::integer) | "left"((c11)::text, 100)  | t    | t           | f          |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | t1_c1_key       | btree       | c1          | f        | f       | t      | u               |                       | c1                        | t    | t           | f          |           |                                       |     int4_ops      |        t        | | t          | t                  |
+users           | t1_pkey         | btree       | id          | f        | t       | t      | p               |                       | id                        | t    | f           | f          |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx4            | btree       | c1          | f        | f       | t      |                 |                       | c1                        | f    | f           | f          |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx4            | btree       | id          | f        | f       | t      |                 |                       | id                        | f    | f           | t          |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx5            | btree       | c1          | f        | f       | t      |                 |                       | c1                        | f    | f           | f          |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx5            | btree       |             | f        | f       | t      |                 |                       | coalesce(parent_id, 0)    | f    | f           | f          |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx6            | brin        | c1          | f        | f       | t      |                 |                       |                           | f    | f           | f          |           | {autosummarize=true,pages_per_range=2}|     int4_ops      |        t        | | f          | f                  |
+users           | idx2            | btree       |             | f        | f       | f      |                 |                       | ((c * 2))                 | f    | f           | t          |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx2            | btree       | c1          | f        | f       | f      |                 |                       | c                         | f    | f           | t          |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx2            | btree       | id          | f        | f       | f      |                 |                       | d                         | f    | f           | t          |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx2            | btree       | c1          | t        | f       | f      |                 |                       | c                         |      |             |            |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | idx2            | btree       | parent_id   | t        | f       | f      |                 |                       | d                         |      |             |            |           |                                       |     int4_ops      |        t        | | f          | f                  |
+users           | tsx             | gist        | ts          | f        | f       | f      |                 |                       | ts                        |      |             |            |           |                                       |     tsvector_ops  |        f        | {siglen=1} | f          | f                  |
+users           | excl            | gist        | ts          | f        | f       | f      | x               | (c1 > 0)              | ts                        |      |             |            |           |                                       |     tsvector_ops  |        t        |                | f          | f                  | =
 `))
 				m.noFKs()
 				m.noChecks()
@@ -193,12 +196,13 @@ users           | tsx             | gist        | ts          | f        | f
 				indexes := []*schema.Index{
 					{Name: "idx", Table: t, Attrs: []schema.Attr{&IndexType{T: "hash"}, &schema.Comment{Text: "boring"}}, Parts: []*schema.IndexPart{{SeqNo: 1, X: &schema.RawExpr{X: `"left"((c11)::text, 100)`}, Desc: true, Attrs: []schema.Attr{&IndexColumnProperty{NullsFirst: true}}}}},
 					{Name: "idx1", Table: t, Attrs: []schema.Attr{&IndexType{T: "btree"}, &IndexPredicate{P: `(id <> NULL::integer)`}}, Parts: []*schema.IndexPart{{SeqNo: 1, X: &schema.RawExpr{X: `"left"((c11)::text, 100)`}, Desc: true, Attrs: []schema.Attr{&IndexColumnProperty{NullsFirst: true}}}}},
-					{Name: "t1_c1_key", Unique: true, Table: t, Attrs: []schema.Attr{&IndexType{T: "btree"}, &ConType{T: "u"}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[1], Desc: true, Attrs: []schema.Attr{&IndexColumnProperty{NullsFirst: true}}}}},
+					{Name: "t1_c1_key", Unique: true, Table: t, Attrs: []schema.Attr{&IndexType{T: "btree"}, &ConType{T: "u"}, &Deferrable{InitiallyDeferred: true}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[1], Desc: true, Attrs: []schema.Attr{&IndexColumnProperty{NullsFirst: true}}}}},
 					{Name: "idx4", Unique: true, Table: t, Attrs: []schema.Attr{&IndexType{T: "btree"}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[1]}, {SeqNo: 2, C: columns[0], Attrs: []schema.Attr{&IndexColumnProperty{NullsLast: true}}}}},
 					{Name: "idx5", Unique: true, Table: t, Attrs: []schema.Attr{&IndexType{T: "btree"}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[1]}, {SeqNo: 2, X: &schema.RawExpr{X: `coalesce(parent_id, 0)`}}}},
 					{Name: "idx6", Unique: true, Table: t, Attrs: []schema.Attr{&IndexType{T: "brin"}, &IndexStorageParams{AutoSummarize: true, PagesPerRange: 2}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[1]}}},
 					{Name: "idx2", Unique: false, Table: t, Attrs: []schema.Attr{&IndexType{T: "btree"}, &IndexInclude{Columns: columns[1:3]}}, Parts: []*schema.IndexPart{{SeqNo: 1, X: &schema.RawExpr{X: `((c * 2))`}, Attrs: []schema.Attr{&IndexColumnProperty{NullsLast: true}}}, {SeqNo: 2, C: columns[1], Attrs: []schema.Attr{&IndexColumnProperty{NullsLast: true}}}, {SeqNo: 3, C: columns[0], Attrs: []schema.Attr{&IndexColumnProperty{NullsLast: true}}}}},
 					{Name: "tsx", Unique: false, Table: t, Attrs: []schema.Attr{&IndexType{T: "gist"}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[3], Attrs: []schema.Attr{&IndexOpClass{Name: "tsvector_ops", Params: []struct{ N, V string }{{N: "siglen", V: "1"}}}}}}},
+					{Name: "excl", Unique: false, Table: t, Attrs: []schema.Attr{&IndexType{T: "gist"}, &ConType{T: "x"}, &IndexPredicate{P: "(c1 > 0)"}}, Parts: []*schema.IndexPart{{SeqNo: 1, C: columns[3], Attrs: []schema.Attr{&ExcludeOp{Op: "="}}}}},
 				}
 				pk := &schema.Index{
 					Name:   "t1_pkey",
@@ -222,8 +226,8 @@ users           | tsx             | gist        | ts          | f        | f
 				m.ExpectQuery(queryColumns).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-table_name | column_name |      data_type      | formatted | is_nullable |         column_default          | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp |  oid
------------+-------------+---------------------+-----------+-------------+---------------------------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------
+table_name | column_name |      data_type      | formatted | is_nullable |         column_default          | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp |  oid | is_local
+-----------+-------------+---------------------+-----------+-------------+---------------------------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-------+---------
 users      | id          | integer             | int       | NO          |                                 |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |    20
 users      | oid         | integer             | int       | NO          |                                 |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |    21
 users      | uid         | integer             | int       | NO          |                                 |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |    21
@@ -232,13 +236,13 @@ users      | uid         | integer             | int       | NO          |
 				m.ExpectQuery(queryFKs).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-constraint_name | table_name | column_name | table_schema | referenced_table_name | referenced_column_name | referenced_schema_name | update_rule | delete_rule
------------------+------------+-------------+--------------+-----------------------+------------------------+------------------------+-------------+-------------
-multi_column    | users      | id          | public       | t1                    | gid                    | public                 | NO ACTION   | CASCADE
-multi_column    | users      | id          | public       | t1                    | xid                    | public                 | NO ACTION   | CASCADE
-multi_column    | users      | oid         | public       | t1                    | gid                    | public                 | NO ACTION   | CASCADE
-multi_column    | users      | oid         | public       | t1                    | xid                    | public                 | NO ACTION   | CASCADE
-self_reference  | users      | uid         | public       | users                 | id                     | public                 | NO ACTION   | CASCADE
+constraint_name | table_name | column_name | table_schema | referenced_table_name | referenced_column_name | referenced_schema_name | update_rule | delete_rule | is_deferrable | initially_deferred | validated
+-----------------+------------+-------------+--------------+-----------------------+------------------------+------------------------+-------------+-------------+---------------+--------------------+-----------
+multi_column    | users      | id          | public       | t1                    | gid                    | public                 | NO ACTION   | CASCADE     | NO            | NO                 | t
+multi_column    | users      | id          | public       | t1                    | xid                    | public                 | NO ACTION   | CASCADE     | NO            | NO                 | t
+multi_column    | users      | oid         | public       | t1                    | gid                    | public                 | NO ACTION   | CASCADE     | NO            | NO                 | t
+multi_column    | users      | oid         | public       | t1                    | xid                    | public                 | NO ACTION   | CASCADE     | NO            | NO                 | t
+self_reference  | users      | uid         | public       | users                 | id                     | public                 | NO ACTION   | CASCADE     | YES           | YES                | f
 `))
 				m.noChecks()
 			},
@@ -248,7 +252,7 @@ self_reference  | users      | uid         | public       | users
 				require.Equal("public", t.Schema.Name)
 				fks := []*schema.ForeignKey{
 					{Symbol: "multi_column", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: &schema.Table{Name: "t1", Schema: t.Schema}, RefColumns: []*schema.Column{{Name: "gid"}, {Name: "xid"}}},
-					{Symbol: "self_reference", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: t},
+					{Symbol: "self_reference", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: t, Attrs: []schema.Attr{&Deferrable{InitiallyDeferred: true}, &NotValid{}}},
 				}
 				columns := []*schema.Column{
 					{Name: "id", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}, ForeignKeys: fks[0:1]},
@@ -269,8 +273,8 @@ self_reference  | users      | uid         | public       | users
 				m.ExpectQuery(queryColumns).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
------------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----
+table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+-----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----+---------
 users      | c1         | integer   | int4      | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
 users      | c2         | integer   | int4      | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
 users      | c3         | integer   | int4      | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
@@ -280,18 +284,17 @@ users      | c3         | integer   | int4      | NO          |                |
 				m.ExpectQuery(queryChecks).
 					WithArgs("public", "users").
 					WillReturnRows(sqltest.Rows(`
-table_name   | constraint_name    |       expression        | column_name | column_indexes | no_inherit
--------------+--------------------+-------------------------+-------------+----------------+----------------
+table_name   | constraint_name    |       expression        | column_name | column_indexes | no_inherit | is_local | validated
+-------------+--------------------+-------------------------+-------------+----------------+----------------+----------+-----------
 users        | boring             | (c1 > 1)                | c1          | {1}            | t
 users        | users_c2_check     | (c2 > 0)                | c2          | {2}            | f
-users        | users_c2_check1    | (c2 > 0)                | c2          | {2}            | f
+users        | users_c2_check1    | (c2 > 0)                | c2          | {2}            | f          | t        | f
 users        | users_check        | ((c2 + c1) > 2)         | c2          | {2,1}          | f
 users        | users_check        | ((c2 + c1) > 2)         | c1          | {2,1}          | f
 users        | users_check1       | (((c2 + c1) + c3) > 10) | c2          | {2,1,3}        | f
 users        | users_check1       | (((c2 + c1) + c3) > 10) | c1          | {2,1,3}        | f
 users        | users_check1       | (((c2 + c1) + c3) > 10) | c3          | {2,1,3}        | f
 `))
-				m.noChecks()
 			},
 			expect: func(require *require.Assertions, t *schema.Table, err error) {
 				require.NoError(err)
@@ -305,12 +308,39 @@ users        | users_check1       | (((c2 + c1) + c3) > 10) | c3          | {2,1
 				require.EqualValues([]schema.Attr{
 					&schema.Check{Name: "boring", Expr: "(c1 > 1)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c1"}}, &NoInherit{}}},
 					&schema.Check{Name: "users_c2_check", Expr: "(c2 > 0)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2"}}}},
-					&schema.Check{Name: "users_c2_check1", Expr: "(c2 > 0)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2"}}}},
+					&schema.Check{Name: "users_c2_check1", Expr: "(c2 > 0)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2"}}, &NotValid{}}},
 					&schema.Check{Name: "users_check", Expr: "((c2 + c1) > 2)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2", "c1"}}}},
 					&schema.Check{Name: "users_check1", Expr: "(((c2 + c1) + c3) > 10)", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"c2", "c1", "c3"}}}},
 				}, t.Attrs)
 			},
 		},
+		{
+			name: "storage",
+			before: func(m mock) {
+				m.ExpectQuery(queryTables).
+					WithArgs("public").
+					WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "table_comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"}).
+						AddRow("public", "users", nil, nil, nil, nil, nil, nil, nil, "u", "fast", "{fillfactor=70,autovacuum_enabled=false,toast.autovacuum_enabled=off}", nil))
+				m.ExpectQuery(queryColumns).
+					WithArgs("public", "users").
+					WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+-----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----+---------
+users      | c1         | integer   | int4      | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.EqualValues([]schema.Attr{
+					&Unlogged{},
+					&Tablespace{Name: "fast"},
+					&TableStorageParams{Params: map[string]string{"fillfactor": "70", "autovacuum_enabled": "false", "toast.autovacuum_enabled": "off"}},
+				}, t.Attrs)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -329,6 +359,7 @@ users        | users_check1       | (((c2 + c1) + c3) > 10) | c3          | {2,1
  public
 `))
 			tt.before(mk)
+			mk.noPolicies("public")
 			s, err := drv.InspectSchema(context.Background(), "public", nil)
 			require.NoError(t, err)
 			tt.expect(require.New(t), s.Tables[0], err)
@@ -352,30 +383,35 @@ public
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
 		WithArgs("public").
 		WillReturnRows(sqltest.Rows(`
- table_schema | table_name  | comment | partition_attrs | partition_strategy |                  partition_exprs                   
---------------+-------------+---------+-----------------+--------------------+----------------------------------------------------
+ table_schema | table_name  | comment | partition_attrs | partition_strategy |                  partition_exprs | partition_parent_schema | partition_parent | partition_bound | persistence | tablespace | storage_params | inherits
+--------------+-------------+---------+-----------------+--------------------+----------------------------------------------------+-------------------------+------------------+----------------+-------------+------------+---------------+---------
  public       | logs1       |         |                 |                    | 
- public       | logs2       |         | 1               | r                  | 
+ public       | logs2       |         | 1               | r                  |                                                    |                         |                  |
+ public       | logs2_2020  |         |                 |                    |                                                    | public                  | logs2            | FOR VALUES FROM (1) TO (10)
+ public       | logs2_rest  |         |                 |                    |                                                    | public                  | logs2            | DEFAULT
  public       | logs3       |         | 2 0 0           | l                  | (a + b), (a + (b * 2))
 
 `))
-	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2, $3, $4"))).
-		WithArgs("public", "logs1", "logs2", "logs3").
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2, $3, $4, $5, $6"))).
+		WithArgs("public", "logs1", "logs2", "logs2_2020", "logs2_rest", "logs3").
 		WillReturnRows(sqltest.Rows(`
-table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid
------------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----
+table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+-----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----+---------
 logs1      | c1         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
 logs2      | c2         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
 logs2      | c3         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+logs2_2020 | c2         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+logs2_rest | c2         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
 logs3      | c4         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
 logs3      | c5         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
 `))
-	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "$2, $3, $4"))).
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "$2, $3, $4, $5, $6"))).
 		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression"}))
-	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2, $3, $4"))).
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2, $3, $4, $5, $6"))).
 		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "referenced_table_name", "referenced_column_name", "referenced_table_schema", "update_rule", "delete_rule"}))
-	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2, $3, $4"))).
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2, $3, $4, $5, $6"))).
 		WillReturnRows(sqlmock.NewRows([]string{"table_name", "constraint_name", "expression", "column_name", "column_indexes"}))
+	mk.noPolicies("public")
 	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{})
 	require.NoError(t, err)
 
@@ -392,6 +428,18 @@ logs3      | c5         | integer   | integer   | NO          |                |
 		{C: &schema.Column{Name: "c2", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}}}},
 	}, key.Parts)
 
+	for _, tt := range []struct {
+		name string
+		want *PartitionOf
+	}{
+		{name: "logs2_2020", want: &PartitionOf{Parent: t2, Bound: "FROM (1) TO (10)", parent: [2]string{"public", "logs2"}}},
+		{name: "logs2_rest", want: &PartitionOf{Parent: t2, Default: true, parent: [2]string{"public", "logs2"}}},
+	} {
+		p, ok := s.Table(tt.name)
+		require.True(t, ok)
+		require.Equal(t, []schema.Attr{tt.want}, p.Attrs)
+	}
+
 	t3, ok := s.Table("logs3")
 	require.True(t, ok)
 	require.Len(t, t3.Attrs, 1)
@@ -404,6 +452,73 @@ logs3      | c5         | integer   | integer   | NO          |                |
 	}, key.Parts)
 }
 
+func TestDriver_InspectInheritedTable(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+   schema_name
+--------------------
+public
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "table_comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"}).
+			AddRow("public", "capitals", nil, nil, nil, nil, nil, nil, nil, "p", nil, nil, `[["public", "cities"]]`).
+			AddRow("public", "cities", nil, nil, nil, nil, nil, nil, nil, "p", nil, nil, nil))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2, $3"))).
+		WithArgs("public", "capitals", "cities").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+-----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----+---------
+capitals   | name       | text      | text      | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  25 | f
+capitals   | population | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23 | t
+capitals   | state      | text      | text      | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  25 | t
+cities     | name       | text      | text      | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  25 | t
+cities     | population | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23 | t
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "$2, $3"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2, $3"))).
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "referenced_table_name", "referenced_column_name", "referenced_table_schema", "update_rule", "delete_rule"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2, $3"))).
+		WillReturnRows(sqltest.Rows(`
+table_name   | constraint_name    |       expression        | column_name | column_indexes | no_inherit | is_local | validated
+-------------+--------------------+-------------------------+-------------+----------------+------------+----------+-----------
+capitals     | population_check   | (population > 0)        | population  | {2}            | f          | f
+cities       | population_check   | (population > 0)        | population  | {2}            | f          | t
+`))
+	mk.noPolicies("public")
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{})
+	require.NoError(t, err)
+
+	cities, ok := s.Table("cities")
+	require.True(t, ok)
+	require.Nil(t, inheritsOf(cities))
+	for _, c := range cities.Columns {
+		require.False(t, sqlx.Has(c.Attrs, &Inherited{}))
+	}
+	capitals, ok := s.Table("capitals")
+	require.True(t, ok)
+	p := inheritsOf(capitals)
+	require.NotNil(t, p)
+	require.Equal(t, []*schema.Table{cities}, p.Parents)
+	require.True(t, sqlx.Has(capitals.Columns[0].Attrs, &Inherited{}))
+	// Columns that are also defined locally are not marked as inherited.
+	require.False(t, sqlx.Has(capitals.Columns[1].Attrs, &Inherited{}))
+	require.False(t, sqlx.Has(capitals.Columns[2].Attrs, &Inherited{}))
+	c1, ok := checkByName(cities.Attrs, "population_check")
+	require.True(t, ok)
+	c2, ok := checkByName(capitals.Attrs, "population_check")
+	require.True(t, ok)
+	require.False(t, sqlx.Has(c1.Attrs, &Inherited{}))
+	require.True(t, sqlx.Has(c2.Attrs, &Inherited{}))
+}
+
 func TestDriver_InspectCRDBSchema(t *testing.T) {
 	db, m, err := sqlmock.New()
 	require.NoError(t, err)
@@ -430,8 +545,8 @@ public
 	mk.ExpectQuery(queryCrdbColumns).
 		WithArgs("public", "users").
 		WillReturnRows(sqltest.Rows(`
-table_name  | column_name | data_type | formatted | is_nullable |              column_default               | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  |  identity_generation  | generation_expression | comment | typtype | typelem | elemtyp | oid 
-------------+-------------+-----------+-----------+-------------+-------------------------------------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------|-------------+----------------+--------------------+------------------+-----------------------+-----------------------+---------+---------+---------+---------+-----
+table_name  | column_name | data_type | formatted | is_nullable |              column_default               | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  |  identity_generation  | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+------------+-------------+-----------+-----------+-------------+-------------------------------------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------|-------------+----------------+--------------------+------------------+-----------------------+-----------------------+---------+---------+---------+---------+-----+---------
 users       | a           | bigint    | bigint    | NO          |                                           |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |                  |                       |                       |         | b       |         |         | 20 
 users       | b           | bigint    | bigint    | NO          |                                           |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |                  |                       |                       |         | b       |         |         | 20 
 users       | c           | bigint    | bigint    | NO          |                                           |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |                  |                       |                       |         | b       |         |         | 20 
@@ -492,7 +607,7 @@ test
 `))
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
 		WithArgs("test").
-		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs"}))
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"}))
 	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{})
 	require.NoError(t, err)
 	require.EqualValues(t, func() *schema.Schema {
@@ -517,6 +632,259 @@ test
 	}(), s)
 }
 
+func TestDriver_InspectViews(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name
+--------------------
+ public
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"}))
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ table_name |        view_definition        | check_option |   comment   | materialized
+------------+-------------------------------+--------------+-------------+--------------
+ active     |  SELECT users.id FROM users;  | NONE         |             | f
+ checked    |  SELECT users.id FROM users;  | LOCAL        | checked ids | f
+ stats      |  SELECT users.id FROM users;  |              |             | t
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewColumnsQuery, "$2, $3, $4"))).
+		WithArgs("public", "active", "checked", "stats").
+		WillReturnRows(sqltest.Rows(`
+ table_name | column_name | format_type | is_nullable
+------------+-------------+-------------+-------------
+ active     | id          | integer     | YES
+ checked    | id          | integer     | YES
+ stats      | id          | integer     | YES
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "$2"))).
+		WithArgs("public", "stats").
+		WillReturnRows(sqltest.Rows(`
+   table_name   |    index_name   | index_type  | column_name | included | primary | unique | constraint_type | predicate | expression | desc | nulls_first | nulls_last | comment | options | opclass_name | opclass_default | opclass_params | deferrable | initially_deferred | exclusion_op
+----------------+-----------------+-------------+-------------+----------+---------+--------+-----------------+-----------+------------+------+-------------+------------+---------+---------+--------------+-----------------+----------------+------------+--------------------+-------------
+stats           | stats_id        | btree       | id          | f        | f       | t      |                 |           |            | f    | f           | f          |         |         | int4_ops     | t               |                | f          | f                  |
+`))
+	mk.noFuncs("public")
+	mk.noObjects("public")
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{Mode: schema.InspectAll})
+	require.NoError(t, err)
+	require.Empty(t, s.Tables)
+	require.Len(t, s.Views, 3)
+	active, checked, stats := s.Views[0], s.Views[1], s.Views[2]
+	require.Equal(t, "active", active.Name)
+	require.Equal(t, s, active.Schema)
+	require.Equal(t, "SELECT users.id FROM users;", active.Def)
+	require.Empty(t, active.Attrs)
+	require.Equal(t, []*schema.Column{
+		{Name: "id", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}, Null: true}},
+	}, active.Columns)
+	require.Equal(t, "checked", checked.Name)
+	require.Equal(t, []schema.Attr{&schema.ViewCheckOption{V: schema.ViewCheckOptionLocal}, &schema.Comment{Text: "checked ids"}}, checked.Attrs)
+	require.False(t, active.Materialized())
+	require.True(t, stats.Materialized())
+	require.Len(t, stats.Indexes, 1)
+	idx := stats.Indexes[0]
+	require.Equal(t, "stats_id", idx.Name)
+	require.True(t, idx.Unique)
+	require.Nil(t, idx.Table)
+	require.Equal(t, stats, idx.View)
+	require.Len(t, idx.Parts, 1)
+	require.Equal(t, stats.Columns[0], idx.Parts[0].C)
+}
+
+func TestDriver_InspectFuncs(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name
+--------------------
+ public
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"}))
+	mk.noViews("public")
+	m.ExpectQuery(sqltest.Escape(funcsQuery)).
+		WithArgs("public").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"proname", "kind", "args", "result", "lanname", "prosrc", "provolatile", "arg_names", "arg_modes", "arg_types", "comment"}).
+				AddRow("add", "f", "a integer, b integer DEFAULT 1", "integer", "sql", "SELECT a + b", "i", `["a","b"]`, nil, `["integer","integer"]`, "adds numbers").
+				AddRow("archive", "p", "IN id bigint, INOUT status text", nil, "plpgsql", "BEGIN status := 'done'; END", "v", `["id","status"]`, `["i","b"]`, `["bigint","text"]`, nil).
+				AddRow("ids", "f", "", "TABLE(id integer)", "sql", "SELECT 1", "s", `["id"]`, `["t"]`, `["integer"]`, nil),
+		)
+	mk.noObjects("public")
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{Mode: schema.InspectAll})
+	require.NoError(t, err)
+	require.Len(t, s.Funcs, 2)
+	require.Len(t, s.Procs, 1)
+	add, ids, archive := s.Funcs[0], s.Funcs[1], s.Procs[0]
+	require.Equal(t, s, add.Schema)
+	require.Equal(t, "sql", add.Lang)
+	require.Equal(t, "SELECT a + b", add.Body)
+	require.Equal(t, &schema.IntegerType{T: "integer"}, add.Ret)
+	require.Equal(t, []*schema.FuncArg{
+		{Name: "a", Type: &schema.IntegerType{T: "integer"}},
+		{Name: "b", Type: &schema.IntegerType{T: "integer"}, Default: &schema.RawExpr{X: "1"}},
+	}, add.Args)
+	require.Equal(t, []schema.Attr{&Volatility{V: VolatilityImmutable}, &schema.Comment{Text: "adds numbers"}}, add.Attrs)
+	require.Empty(t, ids.Args)
+	require.Equal(t, &UserDefinedType{T: "TABLE(id integer)"}, ids.Ret)
+	require.Equal(t, []schema.Attr{&Volatility{V: VolatilityStable}}, ids.Attrs)
+	require.Equal(t, "archive", archive.Name)
+	require.Equal(t, "plpgsql", archive.Lang)
+	require.Equal(t, []*schema.FuncArg{
+		{Name: "id", Type: &schema.IntegerType{T: "bigint"}},
+		{Name: "status", Type: &schema.StringType{T: "text"}, Mode: schema.FuncArgModeInOut},
+	}, archive.Args)
+	require.Empty(t, archive.Attrs)
+}
+
+func TestDriver_InspectPolicies(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name
+--------------------
+ public
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ table_schema | table_name  | comment | partition_attrs | partition_strategy | partition_exprs | partition_parent_schema | partition_parent | partition_bound | persistence | tablespace | storage_params | inherits
+--------------+-------------+---------+-----------------+--------------------+----------------+-------------------------+------------------+----------------+-------------+------------+---------------+---------
+ public       | accounts    |         |                 |                    |
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2"))).
+		WithArgs("public", "accounts").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+-----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----+---------
+accounts   | id         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+accounts   | name       | text      | text      | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  25
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "referenced_table_name", "referenced_column_name", "referenced_table_schema", "update_rule", "delete_rule"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "constraint_name", "expression", "column_name", "column_indexes"}))
+	m.ExpectQuery(sqltest.Escape(policiesQuery)).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ relname  | relrowsecurity | relforcerowsecurity | polname   | command | polpermissive | roles             | using_expr                                  | check_expr
+----------+----------------+---------------------+-----------+---------+---------------+-------------------+---------------------------------------------+--------------------
+ accounts | t              | t                   | admins    | ALL     | t             | ["admin","audit"] | true                                        |
+ accounts | t              | t                   | isolation | ALL     | f             | ["public"]        | (id = (current_setting('app.id'))::integer) |
+ accounts | t              | t                   | writes    | INSERT  | t             | ["app"]           |                                             | (name <> ''::text)
+ logs     | t              | f                   |           |         |               | []                |                                             |
+`))
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{Mode: schema.InspectTables})
+	require.NoError(t, err)
+	accounts, ok := s.Table("accounts")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{
+		&RowSecurity{Enabled: true, Forced: true},
+		&Policy{Name: "admins", As: PolicyAsPermissive, Command: PolicyCmdAll, Roles: []string{"admin", "audit"}, Using: "true"},
+		&Policy{Name: "isolation", As: PolicyAsRestrictive, Command: PolicyCmdAll, Using: "(id = (current_setting('app.id'))::integer)"},
+		&Policy{Name: "writes", As: PolicyAsPermissive, Command: PolicyCmdInsert, Roles: []string{"app"}, Check: "(name <> ''::text)"},
+	}, accounts.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectTriggers(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name
+--------------------
+ public
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ table_schema | table_name  | comment | partition_attrs | partition_strategy | partition_exprs | partition_parent_schema | partition_parent | partition_bound | persistence | tablespace | storage_params | inherits
+--------------+-------------+---------+-----------------+--------------------+----------------+-------------------------+------------------+----------------+-------------+------------+---------------+---------
+ public       | users       |         |                 |                    |
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2"))).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+-----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----+---------
+users      | id         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+users      | name       | text      | text      | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  25
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "referenced_table_name", "referenced_column_name", "referenced_table_schema", "update_rule", "delete_rule"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "constraint_name", "expression", "column_name", "column_indexes"}))
+	mk.noViews("public")
+	m.ExpectQuery(sqltest.Escape(funcsQuery)).
+		WithArgs("public").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"proname", "kind", "args", "result", "lanname", "prosrc", "provolatile", "arg_names", "arg_modes", "arg_types", "comment"}).
+				AddRow("audit", "f", "", "trigger", "plpgsql", "BEGIN RETURN NEW; END", "v", nil, nil, nil, nil),
+		)
+	m.ExpectQuery(sqltest.Escape(triggersQuery)).
+		WithArgs("public").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"tgname", "relname", "tgtype", "proname", "nspname", "columns"}).
+				AddRow("users_audit", "users", 1|4|16, "audit", "public", `["name"]`).
+				AddRow("users_truncate", "users", 2|32, "notify", "util", `[]`),
+		)
+	mk.noPolicies("public")
+	mk.noObjects("public")
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{Mode: schema.InspectAll})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Len(t, users.Triggers, 2)
+	audit, truncate := users.Triggers[0], users.Triggers[1]
+	require.Equal(t, users, audit.Table)
+	require.Equal(t, schema.TriggerTimeAfter, audit.ActionTime)
+	require.Equal(t, schema.TriggerForRow, audit.For)
+	require.Equal(t, []schema.TriggerEvent{
+		{Name: schema.TriggerEventInsert},
+		{Name: schema.TriggerEventUpdate, Columns: []*schema.Column{users.Columns[1]}},
+	}, audit.Events)
+	require.Equal(t, s.Funcs[0], audit.Func)
+	require.Equal(t, schema.TriggerTimeBefore, truncate.ActionTime)
+	require.Equal(t, schema.TriggerForStmt, truncate.For)
+	require.Equal(t, []schema.TriggerEvent{{Name: schema.TriggerEventTruncate}}, truncate.Events)
+	require.Equal(t, "notify", truncate.Func.Name)
+	require.Equal(t, "util", truncate.Func.Schema.Name)
+}
+
 func TestDriver_Realm(t *testing.T) {
 	db, m, err := sqlmock.New()
 	require.NoError(t, err)
@@ -533,7 +901,7 @@ public
 `))
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1, $2"))).
 		WithArgs("test", "public").
-		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs"}))
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"}))
 	realm, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{})
 	require.NoError(t, err)
 	require.EqualValues(t, func() *schema.Realm {
@@ -571,7 +939,7 @@ public
 `))
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1, $2"))).
 		WithArgs("test", "public").
-		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs"}))
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"}))
 	realm, err = drv.InspectRealm(context.Background(), &schema.InspectRealmOption{Schemas: []string{"test", "public"}})
 	require.NoError(t, err)
 	require.EqualValues(t, func() *schema.Realm {
@@ -608,7 +976,7 @@ public
 `))
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
 		WithArgs("test").
-		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs"}))
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"}))
 	realm, err = drv.InspectRealm(context.Background(), &schema.InspectRealmOption{Schemas: []string{"test"}})
 	require.NoError(t, err)
 	require.EqualValues(t, func() *schema.Realm {
@@ -674,6 +1042,59 @@ public
 	}(), realm)
 }
 
+func TestDriver_InspectRoles(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name
+--------------------
+ public
+`))
+	m.ExpectQuery(sqltest.Escape(rolesQuery)).
+		WillReturnRows(sqltest.Rows(`
+ rolname | rolsuper | rolinherit | rolcreaterole | rolcreatedb | rolcanlogin | rolreplication | rolbypassrls
+---------+----------+------------+---------------+-------------+-------------+----------------+--------------
+ admin   | t        | t          | t             | t           | t           | f              | f
+ app     | f        | t          | f             | f           | t           | f              | f
+ readers | f        | f          | f             | f           | f           | f              | f
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(privilegesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ rolname | nspname | object_name | object_kind | privilege_type | is_grantable
+---------+---------+-------------+-------------+----------------+--------------
+ app     | public  |             |             | CREATE         | f
+ app     | public  |             |             | USAGE          | f
+ app     | public  |             |             | USAGE          | t
+ readers | public  |             |             | USAGE          | f
+ readers | public  | users       | r           | SELECT         | f
+`))
+	realm, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{
+		Schemas: []string{"public"},
+		Mode:    schema.InspectSchemas | schema.InspectRoles,
+	})
+	require.NoError(t, err)
+	public := realm.Schemas[0]
+	require.Len(t, realm.Roles, 3)
+	admin, app, readers := realm.Roles[0], realm.Roles[1], realm.Roles[2]
+	require.Equal(t, &schema.Role{Name: "admin", Realm: realm, Login: true, Attrs: []schema.Attr{&RoleOptions{Superuser: true, CreateDB: true, CreateRole: true}}}, admin)
+	require.Equal(t, &schema.Role{Name: "app", Realm: realm, Login: true}, app)
+	require.Equal(t, &schema.Role{Name: "readers", Realm: realm, Attrs: []schema.Attr{&RoleOptions{NoInherit: true}}}, readers)
+	// Privileges on tables that were not inspected are ignored.
+	require.Equal(t, []*schema.Privilege{
+		{Grantee: app, Schema: public, Privs: []string{"CREATE", "USAGE"}},
+		{Grantee: app, Schema: public, Privs: []string{"USAGE"}, Grantable: true},
+		{Grantee: readers, Schema: public, Privs: []string{"USAGE"}},
+	}, realm.Privileges)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
 func TestIndexOpClass_UnmarshalText(t *testing.T) {
 	var op IndexOpClass
 	require.NoError(t, op.UnmarshalText([]byte("int4_ops")))
@@ -703,15 +1124,374 @@ func (m mock) version(version string) {
 }
 
 func (m mock) tableExists(schema, table string, exists bool) {
-	rows := sqlmock.NewRows([]string{"table_schema", "table_name", "table_comment", "partition_attrs", "partition_strategy", "partition_exprs"})
+	rows := sqlmock.NewRows([]string{"table_schema", "table_name", "table_comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"})
 	if exists {
-		rows.AddRow(schema, table, nil, nil, nil, nil)
+		rows.AddRow(schema, table, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	}
 	m.ExpectQuery(queryTables).
 		WithArgs(schema).
 		WillReturnRows(rows)
 }
 
+func TestDriver_InspectSequences(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name
+--------------------
+ public
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ table_schema | table_name  | comment | partition_attrs | partition_strategy | partition_exprs | partition_parent_schema | partition_parent | partition_bound | persistence | tablespace | storage_params | inherits
+--------------+-------------+---------+-----------------+--------------------+----------------+-------------------------+------------------+----------------+-------------+------------+---------------+---------
+ public       | users       |         |                 |                    |
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2"))).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type | formatted | is_nullable | column_default                      | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+-----------+------------+-----------+-----------+-------------+-------------------------------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----+---------
+users      | id         | integer   | integer   | NO          | nextval('users_id_seq'::regclass)   |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  23
+users      | oid        | bigint    | bigint    | NO          | nextval('orders_oid_seq'::regclass) |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |         |  20
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "referenced_table_name", "referenced_column_name", "referenced_table_schema", "update_rule", "delete_rule"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "constraint_name", "expression", "column_name", "column_indexes"}))
+	mk.noViews("public")
+	mk.noFuncs("public")
+	mk.noTriggers("public")
+	mk.noPolicies("public")
+	mk.noExtensions("public")
+	mk.noEnums("public")
+	mk.noTypes("public")
+	m.ExpectQuery(sqltest.Escape(sequencesQuery)).
+		WithArgs("public").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"sequencename", "data_type", "start_value", "increment_by", "min_value", "max_value", "cache_size", "cycle", "owner_table", "owner_column"}).
+				AddRow("counter", "smallint", 10, -2, -100, 10, 5, true, nil, nil).
+				AddRow("orders_oid_seq", "bigint", 1, 1, 1, math.MaxInt64, 1, false, "users", "oid").
+				AddRow("users_id_seq", "integer", 1, 1, 1, math.MaxInt32, 1, false, "users", "id"),
+		)
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{Mode: schema.InspectAll})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Len(t, s.Objects, 2)
+	counter, orders := s.Objects[0].(*Sequence), s.Objects[1].(*Sequence)
+	require.Equal(t, "counter", counter.Name)
+	require.Equal(t, s, counter.Schema)
+	require.Equal(t, &schema.IntegerType{T: "smallint"}, counter.Type)
+	require.EqualValues(t, 10, counter.Start)
+	require.EqualValues(t, -2, counter.Increment)
+	require.EqualValues(t, -100, counter.Min)
+	require.EqualValues(t, 10, counter.Max)
+	require.EqualValues(t, 5, counter.Cache)
+	require.True(t, counter.Cycle)
+	require.Nil(t, counter.Owner.T)
+	require.Equal(t, "orders_oid_seq", orders.Name)
+	require.Equal(t, users, orders.Owner.T)
+	require.Equal(t, users.Columns[1], orders.Owner.C)
+	// Serial columns that use their implicit sequences are kept as is.
+	require.Equal(t, &SerialType{T: TypeSerial, SequenceName: "users_id_seq"}, users.Columns[0].Type.Type)
+	// Columns that use standalone sequences are integer columns.
+	require.Equal(t, &schema.IntegerType{T: TypeBigInt}, users.Columns[1].Type.Type)
+	require.Equal(t, &schema.RawExpr{X: "nextval('orders_oid_seq'::regclass)"}, users.Columns[1].Default)
+}
+
+func TestDriver_InspectExtensions(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name
+--------------------
+ public
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "comment", "partition_attrs", "partition_strategy", "partition_exprs", "partition_parent_schema", "partition_parent", "partition_bound", "persistence", "tablespace", "storage_params", "inherits"}))
+	mk.noViews("public")
+	mk.noFuncs("public")
+	m.ExpectQuery(sqltest.Escape(extensionsQuery)).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ extname   | extversion
+-----------+------------
+ citext    | 1.6
+ uuid-ossp | 1.1
+`))
+	mk.noEnums("public")
+	mk.noTypes("public")
+	mk.noSequences("public")
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{Mode: schema.InspectAll})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Object{
+		&Extension{Name: "citext", Schema: s, Version: "1.6"},
+		&Extension{Name: "uuid-ossp", Schema: s, Version: "1.1"},
+	}, s.Objects)
+}
+
+func TestDriver_InspectEnums(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name
+--------------------
+ public
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ table_schema | table_name  | comment | partition_attrs | partition_strategy | partition_exprs | partition_parent_schema | partition_parent | partition_bound | persistence | tablespace | storage_params | inherits
+--------------+-------------+---------+-----------------+--------------------+----------------+-------------------------+------------------+----------------+-------------+------------+---------------+---------
+ public       | users       |         |                 |                    |
+ public       | pets        |         |                 |                    |
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2, $3"))).
+		WithArgs("public", "users", "pets").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type    | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+-----------+------------+--------------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----+---------
+users      | status     | USER-DEFINED | status    | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | e       |         |         | 100
+users      | history    | ARRAY        | status[]  | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       | 100     | e       | 101
+pets       | status     | USER-DEFINED | status    | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | e       |         |         | 100
+`))
+	m.ExpectQuery(sqltest.Escape(`SELECT enumtypid, enumlabel FROM pg_enum WHERE enumtypid IN ($1)`)).
+		WithArgs(100).
+		WillReturnRows(sqltest.Rows(`
+ enumtypid | enumlabel
+-----------+-----------
+     100   | active
+     100   | inactive
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "$2, $3"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2, $3"))).
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "referenced_table_name", "referenced_column_name", "referenced_table_schema", "update_rule", "delete_rule"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2, $3"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "constraint_name", "expression", "column_name", "column_indexes"}))
+	mk.noViews("public")
+	mk.noFuncs("public")
+	mk.noTriggers("public")
+	mk.noPolicies("public")
+	mk.noExtensions("public")
+	m.ExpectQuery(sqltest.Escape(enumsQuery)).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ enum_name | enum_value
+-----------+------------
+ mood      | sad
+ mood      | happy
+ status    | active
+ status    | inactive
+`))
+	mk.noTypes("public")
+	mk.noSequences("public")
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{Mode: schema.InspectAll})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Object{
+		&schema.EnumType{T: "mood", Schema: s, Values: []string{"sad", "happy"}},
+		&schema.EnumType{T: "status", Schema: s, Values: []string{"active", "inactive"}},
+	}, s.Objects)
+	// Columns share the enum types of the schema.
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	pets, ok := s.Table("pets")
+	require.True(t, ok)
+	require.Same(t, s.Objects[1], users.Columns[0].Type.Type)
+	require.Same(t, s.Objects[1], users.Columns[1].Type.Type.(*ArrayType).Type)
+	require.Same(t, s.Objects[1], pets.Columns[0].Type.Type)
+}
+
+func TestDriver_InspectTypes(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name
+--------------------
+ public
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ table_schema | table_name  | comment | partition_attrs | partition_strategy | partition_exprs | partition_parent_schema | partition_parent | partition_bound | persistence | tablespace | storage_params | inherits
+--------------+-------------+---------+-----------------+--------------------+----------------+-------------------------+------------------+----------------+-------------+------------+---------------+---------
+ public       | users       |         |                 |                    |
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2"))).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+table_name |column_name | data_type    | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem | elemtyp | oid | is_local
+-----------+------------+--------------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+---------+-----+---------
+users      | age        | integer      | posint    | NO          |                |                          | 32                |                    | 0             |               |                    |                | NO          |                |                    |                  |                     |                       |         | d       |         |         | 100
+users      | address    | USER-DEFINED | address   | YES         |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | c       |         |         | 101
+users      | ages       | ARRAY        | posint[]  | YES         |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       | 100     | d       | 102
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "referenced_table_name", "referenced_column_name", "referenced_table_schema", "update_rule", "delete_rule"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "constraint_name", "expression", "column_name", "column_indexes"}))
+	mk.noViews("public")
+	mk.noFuncs("public")
+	mk.noTriggers("public")
+	mk.noPolicies("public")
+	mk.noExtensions("public")
+	m.ExpectQuery(sqltest.Escape(enumsQuery)).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ enum_name | enum_value
+-----------+------------
+ mood      | sad
+ mood      | happy
+`))
+	m.ExpectQuery(sqltest.Escape(domainsQuery)).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ domain_name | base_type             | typnotnull | typdefault | conname        | expression
+-------------+-----------------------+------------+------------+----------------+------------------
+ feeling     | mood                  | f          |            |                |
+ posint      | integer               | t          | 1          | posint_check   | (VALUE > 0)
+ posint      | integer               | t          | 1          | posint_max     | (VALUE < 1000)
+ zip         | character varying(10) | f          |            |                |
+`))
+	m.ExpectQuery(sqltest.Escape(compositesQuery)).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ type_name | field_name | field_type
+-----------+------------+-------------
+ address   | street     | text
+ address   | zip        | zip
+ address   | feeling    | mood
+`))
+	mk.noSequences("public")
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{Mode: schema.InspectAll})
+	require.NoError(t, err)
+	mood := &schema.EnumType{T: "mood", Schema: s, Values: []string{"sad", "happy"}}
+	zip := &Domain{T: "zip", Schema: s, Type: &schema.StringType{T: "character varying", Size: 10}, Null: true}
+	posint := &Domain{
+		T:       "posint",
+		Schema:  s,
+		Type:    &schema.IntegerType{T: "integer"},
+		Default: &schema.Literal{V: "1"},
+		Checks: []*schema.Check{
+			{Name: "posint_check", Expr: "(VALUE > 0)"},
+			{Name: "posint_max", Expr: "(VALUE < 1000)"},
+		},
+	}
+	address := &CompositeType{
+		T:      "address",
+		Schema: s,
+		Fields: []*schema.Column{
+			{Name: "street", Type: &schema.ColumnType{Raw: "text", Type: &schema.StringType{T: "text"}, Null: true}},
+			{Name: "zip", Type: &schema.ColumnType{Raw: "zip", Type: zip, Null: true}},
+			{Name: "feeling", Type: &schema.ColumnType{Raw: "mood", Type: mood, Null: true}},
+		},
+	}
+	require.Equal(t, []schema.Object{
+		mood,
+		&Domain{T: "feeling", Schema: s, Type: mood, Null: true},
+		posint,
+		zip,
+		address,
+	}, s.Objects)
+	// Columns and fields share the type objects of the schema.
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Same(t, s.Objects[2], users.Columns[0].Type.Type)
+	require.Same(t, s.Objects[4], users.Columns[1].Type.Type)
+	require.Same(t, s.Objects[2], users.Columns[2].Type.Type.(*ArrayType).Type)
+	require.Same(t, s.Objects[3], s.Objects[4].(*CompositeType).Fields[1].Type.Type)
+	require.Same(t, s.Objects[0], s.Objects[1].(*Domain).Type)
+}
+
+func (m mock) noViews(schema string) {
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "view_definition", "check_option", "comment", "materialized"}))
+}
+
+func (m mock) noFuncs(schema string) {
+	m.ExpectQuery(sqltest.Escape(funcsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"proname", "kind", "args", "result", "lanname", "prosrc", "provolatile", "arg_names", "arg_modes", "arg_types", "comment"}))
+}
+
+func (m mock) noTriggers(schema string) {
+	m.ExpectQuery(sqltest.Escape(triggersQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"tgname", "relname", "tgtype", "proname", "nspname", "columns"}))
+}
+
+func (m mock) noPolicies(schema string) {
+	m.ExpectQuery(sqltest.Escape(policiesQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"relname", "relrowsecurity", "relforcerowsecurity", "polname", "command", "polpermissive", "roles", "using_expr", "check_expr"}))
+}
+
+func (m mock) noObjects(schema string) {
+	m.noExtensions(schema)
+	m.noEnums(schema)
+	m.noTypes(schema)
+	m.noSequences(schema)
+}
+
+func (m mock) noExtensions(schema string) {
+	m.ExpectQuery(sqltest.Escape(extensionsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"extname", "extversion"}))
+}
+
+func (m mock) noEnums(schema string) {
+	m.ExpectQuery(sqltest.Escape(enumsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"enum_name", "enum_value"}))
+}
+
+func (m mock) noTypes(schema string) {
+	m.ExpectQuery(sqltest.Escape(domainsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"domain_name", "base_type", "typnotnull", "typdefault", "conname", "expression"}))
+	m.ExpectQuery(sqltest.Escape(compositesQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"type_name", "field_name", "field_type"}))
+}
+
+func (m mock) noSequences(schema string) {
+	m.ExpectQuery(sqltest.Escape(sequencesQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"sequencename", "data_type", "start_value", "increment_by", "min_value", "max_value", "cache_size", "cycle", "owner_table", "owner_column"}))
+}
+
 func (m mock) noIndexes() {
 	m.ExpectQuery(queryIndexes).
 		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression", "options"}))
@@ -726,3 +1506,16 @@ func (m mock) noChecks() {
 	m.ExpectQuery(queryChecks).
 		WillReturnRows(sqlmock.NewRows([]string{"table_name", "constraint_name", "expression", "column_name", "column_indexes"}))
 }
+
+func TestObjectName(t *testing.T) {
+	for n, o := range map[string]schema.Object{
+		"d":   &Domain{T: "d"},
+		"c":   &CompositeType{T: "c"},
+		"seq": &Sequence{Name: "seq"},
+		"ext": &Extension{Name: "ext"},
+	} {
+		named, ok := o.(sqlx.ObjectNamer)
+		require.True(t, ok)
+		require.Equal(t, n, named.ObjectName())
+	}
+}
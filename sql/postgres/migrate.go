@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -74,12 +75,51 @@ func (s *state) plan(ctx context.Context, changes []schema.Change) error {
 		}
 	}
 	planned := s.topLevel(changes)
+	rbefore, planned, rafter := sqlx.DetachRoles(planned)
+	objs, planned, dropObjs := sqlx.DetachObjects(planned)
+	before, planned, after := sqlx.DetachViews(planned)
+	fbefore, planned, fafter := sqlx.DetachFuncs(planned)
 	planned, err := sqlx.DetachCycles(planned)
 	if err != nil {
 		return err
 	}
-	for _, c := range planned {
+	planned = sqlx.MergeTableChanges(sortChildTables(planned))
+	for _, c := range concat(rbefore, objs, before, fbefore, planned, fafter, after, dropObjs, rafter) {
 		switch c := c.(type) {
+		case *schema.AddRole:
+			s.addRole(c)
+		case *schema.DropRole:
+			s.dropRole(c)
+		case *schema.ModifyRole:
+			s.modifyRole(c)
+		case *schema.Grant:
+			err = s.grant(c)
+		case *schema.Revoke:
+			err = s.revoke(c)
+		case *schema.AddObject:
+			err = s.addObject(c)
+		case *schema.DropObject:
+			err = s.dropObject(c)
+		case *schema.ModifyObject:
+			err = s.modifyObject(c)
+		case *schema.AddView:
+			err = s.addView(c)
+		case *schema.DropView:
+			s.dropView(c)
+		case *schema.ModifyView:
+			err = s.modifyView(c)
+		case *schema.AddFunc:
+			err = s.addFunc(c)
+		case *schema.DropFunc:
+			err = s.dropFunc(c)
+		case *schema.ModifyFunc:
+			err = s.modifyFunc(c)
+		case *schema.AddProc:
+			err = s.addProc(c)
+		case *schema.DropProc:
+			err = s.dropProc(c)
+		case *schema.ModifyProc:
+			err = s.modifyProc(c)
 		case *schema.AddTable:
 			err = s.addTable(ctx, c)
 		case *schema.DropTable:
@@ -95,9 +135,67 @@ func (s *state) plan(ctx context.Context, changes []schema.Change) error {
 			return err
 		}
 	}
+	for _, c := range objs {
+		s.sequenceOwned(c)
+	}
 	return nil
 }
 
+// sortChildTables sorts the given changes such that partitions and inheriting
+// tables are created after their parent tables and dropped before them.
+func sortChildTables(changes []schema.Change) []schema.Change {
+	var (
+		before, rest, after []schema.Change
+		depth               func(*schema.Table, int) int
+	)
+	depth = func(t *schema.Table, n int) (d int) {
+		// Avoid infinite recursion in case of invalid (cyclic) definitions.
+		if n > len(changes) {
+			return n
+		}
+		for _, p := range parentTables(t) {
+			if pd := depth(p, n+1) + 1; pd > d {
+				d = pd
+			}
+		}
+		return d
+	}
+	for _, c := range changes {
+		switch c := c.(type) {
+		case *schema.AddTable:
+			if len(parentTables(c.T)) > 0 {
+				after = append(after, c)
+				continue
+			}
+		case *schema.DropTable:
+			if len(parentTables(c.T)) > 0 {
+				before = append(before, c)
+				continue
+			}
+		}
+		rest = append(rest, c)
+	}
+	sort.SliceStable(before, func(i, j int) bool {
+		return depth(before[i].(*schema.DropTable).T, 0) > depth(before[j].(*schema.DropTable).T, 0)
+	})
+	sort.SliceStable(after, func(i, j int) bool {
+		return depth(after[i].(*schema.AddTable).T, 0) < depth(after[j].(*schema.AddTable).T, 0)
+	})
+	return concat(before, rest, after)
+}
+
+// parentTables returns the parent tables of a partition or an inheriting table.
+func parentTables(t *schema.Table) []*schema.Table {
+	var parents []*schema.Table
+	if p := partitionOf(t); p != nil && p.Parent != nil {
+		parents = append(parents, p.Parent)
+	}
+	if p := inheritsOf(t); p != nil {
+		parents = append(parents, p.Parents...)
+	}
+	return parents
+}
+
 // topLevel executes first the changes for creating or dropping schemas (top-level schema elements).
 func (s *state) topLevel(changes []schema.Change) []schema.Change {
 	planned := make([]schema.Change, 0, len(changes))
@@ -141,35 +239,74 @@ func (s *state) addTable(ctx context.Context, add *schema.AddTable) error {
 	}
 	var (
 		errs []string
-		b    = s.Build("CREATE TABLE")
+		b    = s.Build("CREATE")
 	)
+	if sqlx.Has(add.T.Attrs, &Unlogged{}) {
+		b.P("UNLOGGED")
+	}
+	b.P("TABLE")
 	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
 		b.P("IF NOT EXISTS")
 	}
 	b.Table(add.T)
-	b.Wrap(func(b *sqlx.Builder) {
-		b.MapComma(add.T.Columns, func(i int, b *sqlx.Builder) {
-			if err := s.column(b, add.T, add.T.Columns[i]); err != nil {
-				errs = append(errs, err.Error())
-			}
-		})
-		if pk := add.T.PrimaryKey; pk != nil {
-			b.Comma().P("PRIMARY KEY")
-			if err := s.indexParts(b, pk); err != nil {
-				errs = append(errs, err.Error())
-			}
+	// Partitions inherit their columns and constraints from their parent table.
+	if p := partitionOf(add.T); p != nil {
+		if p.Parent != nil {
+			b.P("PARTITION OF").Table(p.Parent)
 		}
-		if len(add.T.ForeignKeys) > 0 {
-			b.Comma()
-			s.fks(b, add.T.ForeignKeys...)
+		if err := partitionBound(b, add.T, p); err != nil {
+			errs = append(errs, err.Error())
+		}
+	} else {
+		// Inherited columns and checks are copied from the parent tables.
+		columns := make([]*schema.Column, 0, len(add.T.Columns))
+		for _, c := range add.T.Columns {
+			if !sqlx.Has(c.Attrs, &Inherited{}) {
+				columns = append(columns, c)
+			}
 		}
-		for _, attr := range add.T.Attrs {
-			if c, ok := attr.(*schema.Check); ok {
+		b.Wrap(func(b *sqlx.Builder) {
+			b.MapComma(columns, func(i int, b *sqlx.Builder) {
+				if err := s.column(b, add.T, columns[i]); err != nil {
+					errs = append(errs, err.Error())
+				}
+			})
+			if pk := add.T.PrimaryKey; pk != nil {
+				if len(columns) > 0 {
+					b.Comma()
+				}
+				b.P("PRIMARY KEY")
+				if err := s.indexParts(b, pk); err != nil {
+					errs = append(errs, err.Error())
+				}
+				deferrable(b, pk.Attrs)
+			}
+			for _, idx := range add.T.Indexes {
+				if isTableConstraint(idx) {
+					if err := s.constraint(b.Comma(), idx); err != nil {
+						errs = append(errs, err.Error())
+					}
+				}
+			}
+			if len(add.T.ForeignKeys) > 0 {
 				b.Comma()
-				check(b, c)
+				s.fks(b, add.T.ForeignKeys...)
+			}
+			for _, attr := range add.T.Attrs {
+				if c, ok := attr.(*schema.Check); ok && !sqlx.Has(c.Attrs, &Inherited{}) {
+					b.Comma()
+					check(b, c)
+				}
 			}
+		})
+		if p := inheritsOf(add.T); p != nil && len(p.Parents) > 0 {
+			b.P("INHERITS").Wrap(func(b *sqlx.Builder) {
+				b.MapComma(p.Parents, func(i int, b *sqlx.Builder) {
+					b.Table(p.Parents[i])
+				})
+			})
 		}
-	})
+	}
 	if p := (Partition{}); sqlx.Has(add.T.Attrs, &p) {
 		s, err := formatPartition(p)
 		if err != nil {
@@ -177,34 +314,1456 @@ func (s *state) addTable(ctx context.Context, add *schema.AddTable) error {
 		}
 		b.P(s)
 	}
-	if len(errs) > 0 {
-		return fmt.Errorf("create table %q: %s", add.T.Name, strings.Join(errs, ", "))
+	if p := (TableStorageParams{}); sqlx.Has(add.T.Attrs, &p) && len(p.Params) > 0 {
+		storageParams(b.P("WITH"), p.Params)
+	}
+	if t := (Tablespace{}); sqlx.Has(add.T.Attrs, &t) {
+		b.P("TABLESPACE").Ident(t.Name)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("create table %q: %s", add.T.Name, strings.Join(errs, ", "))
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q table", add.T.Name),
+		Reverse: s.Build("DROP TABLE").Table(add.T).String(),
+	})
+	indexes := make([]*schema.Index, 0, len(add.T.Indexes))
+	for _, idx := range add.T.Indexes {
+		// Constraints are created with the table.
+		if !isTableConstraint(idx) {
+			indexes = append(indexes, idx)
+		}
+	}
+	if err := s.addIndexes(add.T, indexes...); err != nil {
+		return err
+	}
+	s.addComments(add.T)
+	if r := (RowSecurity{}); sqlx.Has(add.T.Attrs, &r) {
+		s.append(s.rowSecurity(add.T, add, nil, &r)...)
+	}
+	s.addPolicies(add.T, policies(add.T.Attrs)...)
+	return s.addTriggers(add.T.Triggers...)
+}
+
+// partitionBound writes the bound of the partition to the builder.
+func partitionBound(b *sqlx.Builder, t *schema.Table, p *PartitionOf) error {
+	switch {
+	case p.Parent == nil:
+		return fmt.Errorf("missing parent table for partition %q", t.Name)
+	case p.Default:
+		b.P("DEFAULT")
+	case p.Bound == "":
+		return fmt.Errorf("missing bound for partition %q", t.Name)
+	default:
+		b.P("FOR VALUES", p.Bound)
+	}
+	return nil
+}
+
+// dropTable builds and executes the query for dropping a table from a schema.
+func (s *state) dropTable(drop *schema.DropTable) {
+	b := s.Build("DROP TABLE")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	b.Table(drop.T)
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q table", drop.T.Name),
+	})
+}
+
+// addView builds and executes the query for creating a view in a schema.
+func (s *state) addView(add *schema.AddView) error {
+	s.append(&migrate.Change{
+		Cmd:     s.viewDef(s.Build("CREATE", viewKind(add.V)), add.V),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q view", add.V.Name),
+		Reverse: s.Build("DROP", viewKind(add.V)).View(add.V).String(),
+	})
+	var c schema.Comment
+	if sqlx.Has(add.V.Attrs, &c) && c.Text != "" {
+		s.append(s.viewComment(add.V, c.Text, ""))
+	}
+	return s.addViewIndexes(add.V)
+}
+
+// dropView builds and executes the query for dropping a view from a schema.
+func (s *state) dropView(drop *schema.DropView) {
+	b := s.Build("DROP", viewKind(drop.V))
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.View(drop.V).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q view", drop.V.Name),
+		Reverse: s.viewDef(s.Build("CREATE", viewKind(drop.V)), drop.V),
+	})
+}
+
+// modifyView builds the statements that bring the view into its modified state.
+func (s *state) modifyView(modify *schema.ModifyView) error {
+	from, to := modify.From, modify.To
+	// CREATE OR REPLACE VIEW is limited to appending new columns to the view, and
+	// is not supported by materialized views. Other changes require recreating it.
+	if !from.Materialized() && !to.Materialized() && viewColumnsAppended(from, to) {
+		s.append(&migrate.Change{
+			Cmd:     s.viewDef(s.Build("CREATE OR REPLACE VIEW"), to),
+			Source:  modify,
+			Comment: fmt.Sprintf("modify %q view", to.Name),
+			Reverse: s.viewDef(s.Build("CREATE OR REPLACE VIEW"), from),
+		})
+	} else {
+		s.append(&migrate.Change{
+			Cmd:     s.Build("DROP", viewKind(from)).View(from).String(),
+			Source:  modify,
+			Comment: fmt.Sprintf("drop %q view before recreating it", from.Name),
+			Reverse: s.viewDef(s.Build("CREATE", viewKind(from)), from),
+		})
+		s.append(&migrate.Change{
+			Cmd:     s.viewDef(s.Build("CREATE", viewKind(to)), to),
+			Source:  modify,
+			Comment: fmt.Sprintf("recreate %q view", to.Name),
+			Reverse: s.Build("DROP", viewKind(to)).View(to).String(),
+		})
+		// Indexes are dropped along with the materialized view.
+		if err := s.addViewIndexes(to); err != nil {
+			return err
+		}
+	}
+	var fromC, toC schema.Comment
+	sqlx.Has(from.Attrs, &fromC)
+	sqlx.Has(to.Attrs, &toC)
+	if fromC.Text != toC.Text {
+		s.append(s.viewComment(to, toC.Text, fromC.Text))
+	}
+	return nil
+}
+
+// viewDef writes the view name, its definition and options to the given builder.
+func (s *state) viewDef(b *sqlx.Builder, v *schema.View) string {
+	b.View(v).P("AS", strings.TrimSuffix(strings.TrimSpace(v.Def), ";"))
+	if c := (schema.ViewCheckOption{}); sqlx.Has(v.Attrs, &c) && c.V != "" && !strings.EqualFold(c.V, schema.ViewCheckOptionNone) {
+		b.P("WITH", strings.ToUpper(c.V), "CHECK OPTION")
+	}
+	return b.String()
+}
+
+func (s *state) viewComment(v *schema.View, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON", viewKind(v)).View(v).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to view: %q", v.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// addViewIndexes builds the statements for creating the indexes of a materialized view.
+func (s *state) addViewIndexes(v *schema.View) error {
+	for _, idx := range v.Indexes {
+		b := s.Build("CREATE")
+		if idx.Unique {
+			b.P("UNIQUE")
+		}
+		b.P("INDEX")
+		if idx.Name != "" {
+			b.Ident(idx.Name)
+		}
+		b.P("ON").View(v)
+		if err := s.index(b, idx); err != nil {
+			return err
+		}
+		r := s.Build("DROP INDEX")
+		if v.Schema != nil {
+			r.WriteString(s.schemaPrefix(v.Schema))
+		}
+		s.append(&migrate.Change{
+			Cmd:     b.String(),
+			Comment: fmt.Sprintf("create index %q to materialized view: %q", idx.Name, v.Name),
+			Reverse: r.Ident(idx.Name).String(),
+		})
+	}
+	return nil
+}
+
+// viewKind returns the object type of the view used in DDL statements.
+func viewKind(v *schema.View) string {
+	if v.Materialized() {
+		return "MATERIALIZED VIEW"
+	}
+	return "VIEW"
+}
+
+// viewColumnsAppended reports if the columns of the view "to" keep the existing
+// columns of view "from" in their positions. Column changes are checked only if
+// both views were inspected or declared with their columns.
+func viewColumnsAppended(from, to *schema.View) bool {
+	if len(from.Columns) == 0 || len(to.Columns) == 0 {
+		return true
+	}
+	if len(from.Columns) > len(to.Columns) {
+		return false
+	}
+	for i, c := range from.Columns {
+		if c.Name != to.Columns[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// addFunc builds and executes the query for creating a function in a schema.
+func (s *state) addFunc(add *schema.AddFunc) error {
+	cmd, err := s.funcDef(s.Build("CREATE FUNCTION"), add.F)
+	if err != nil {
+		return err
+	}
+	drop, err := s.dropFuncCmd(s.Build("DROP FUNCTION"), add.F)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q function", add.F.Name),
+		Reverse: drop,
+	})
+	if c := (schema.Comment{}); sqlx.Has(add.F.Attrs, &c) && c.Text != "" {
+		return s.funcComment(add.F, c.Text, "")
+	}
+	return nil
+}
+
+// dropFunc builds and executes the query for dropping a function from a schema.
+func (s *state) dropFunc(drop *schema.DropFunc) error {
+	b := s.Build("DROP FUNCTION")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	cmd, err := s.dropFuncCmd(b, drop.F)
+	if err != nil {
+		return err
+	}
+	create, err := s.funcDef(s.Build("CREATE FUNCTION"), drop.F)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q function", drop.F.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyFunc builds the statements that bring the function into its modified state.
+func (s *state) modifyFunc(modify *schema.ModifyFunc) error {
+	from, to := modify.From, modify.To
+	fromSig, err := s.funcSignature(from.Args, from.Ret)
+	if err != nil {
+		return err
+	}
+	toSig, err := s.funcSignature(to.Args, to.Ret)
+	if err != nil {
+		return err
+	}
+	// CREATE OR REPLACE FUNCTION cannot change the arguments
+	// or the return type of the function. In this case, the
+	// function is dropped and then created again.
+	if fromSig == toSig {
+		cmd, err := s.funcDef(s.Build("CREATE OR REPLACE FUNCTION"), to)
+		if err != nil {
+			return err
+		}
+		reverse, err := s.funcDef(s.Build("CREATE OR REPLACE FUNCTION"), from)
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     cmd,
+			Source:  modify,
+			Comment: fmt.Sprintf("modify %q function", to.Name),
+			Reverse: reverse,
+		})
+	} else {
+		if err := s.dropFunc(&schema.DropFunc{F: from}); err != nil {
+			return err
+		}
+		if err := s.addFunc(&schema.AddFunc{F: to}); err != nil {
+			return err
+		}
+		return nil
+	}
+	var fromC, toC schema.Comment
+	sqlx.Has(from.Attrs, &fromC)
+	sqlx.Has(to.Attrs, &toC)
+	if fromC.Text != toC.Text {
+		return s.funcComment(to, toC.Text, fromC.Text)
+	}
+	return nil
+}
+
+// addProc builds and executes the query for creating a procedure in a schema.
+func (s *state) addProc(add *schema.AddProc) error {
+	cmd, err := s.procDef(s.Build("CREATE PROCEDURE"), add.P)
+	if err != nil {
+		return err
+	}
+	drop, err := s.dropProcCmd(s.Build("DROP PROCEDURE"), add.P)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q procedure", add.P.Name),
+		Reverse: drop,
+	})
+	if c := (schema.Comment{}); sqlx.Has(add.P.Attrs, &c) && c.Text != "" {
+		return s.procComment(add.P, c.Text, "")
+	}
+	return nil
+}
+
+// dropProc builds and executes the query for dropping a procedure from a schema.
+func (s *state) dropProc(drop *schema.DropProc) error {
+	b := s.Build("DROP PROCEDURE")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	cmd, err := s.dropProcCmd(b, drop.P)
+	if err != nil {
+		return err
+	}
+	create, err := s.procDef(s.Build("CREATE PROCEDURE"), drop.P)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q procedure", drop.P.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyProc builds the statements that bring the procedure into its modified state.
+func (s *state) modifyProc(modify *schema.ModifyProc) error {
+	from, to := modify.From, modify.To
+	fromSig, err := s.funcSignature(from.Args, nil)
+	if err != nil {
+		return err
+	}
+	toSig, err := s.funcSignature(to.Args, nil)
+	if err != nil {
+		return err
+	}
+	// Similar to functions, procedures with changed
+	// arguments are dropped and then created again.
+	if fromSig == toSig {
+		cmd, err := s.procDef(s.Build("CREATE OR REPLACE PROCEDURE"), to)
+		if err != nil {
+			return err
+		}
+		reverse, err := s.procDef(s.Build("CREATE OR REPLACE PROCEDURE"), from)
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     cmd,
+			Source:  modify,
+			Comment: fmt.Sprintf("modify %q procedure", to.Name),
+			Reverse: reverse,
+		})
+	} else {
+		if err := s.dropProc(&schema.DropProc{P: from}); err != nil {
+			return err
+		}
+		if err := s.addProc(&schema.AddProc{P: to}); err != nil {
+			return err
+		}
+		return nil
+	}
+	var fromC, toC schema.Comment
+	sqlx.Has(from.Attrs, &fromC)
+	sqlx.Has(to.Attrs, &toC)
+	if fromC.Text != toC.Text {
+		return s.procComment(to, toC.Text, fromC.Text)
+	}
+	return nil
+}
+
+// funcDef writes the function name, its arguments, return type and body to the given builder.
+func (s *state) funcDef(b *sqlx.Builder, f *schema.Func) (string, error) {
+	if err := s.funcArgs(b.Func(f), f.Args); err != nil {
+		return "", err
+	}
+	if f.Ret != nil {
+		t, err := funcTypeString(f.Ret)
+		if err != nil {
+			return "", err
+		}
+		b.P("RETURNS", t)
+	}
+	if f.Lang != "" {
+		b.P("LANGUAGE", f.Lang)
+	}
+	if v := volatility(f.Attrs); v != VolatilityVolatile {
+		b.P(v)
+	}
+	return b.P("AS", dollarQuote(f.Body)).String(), nil
+}
+
+// procDef writes the procedure name, its arguments and body to the given builder.
+func (s *state) procDef(b *sqlx.Builder, p *schema.Proc) (string, error) {
+	if err := s.funcArgs(b.Proc(p), p.Args); err != nil {
+		return "", err
+	}
+	if p.Lang != "" {
+		b.P("LANGUAGE", p.Lang)
+	}
+	return b.P("AS", dollarQuote(p.Body)).String(), nil
+}
+
+// funcArgs writes the arguments list of a function or a procedure to the given builder.
+func (s *state) funcArgs(b *sqlx.Builder, args []*schema.FuncArg) error {
+	var err error
+	b.Wrap(func(b *sqlx.Builder) {
+		err = b.MapCommaErr(args, func(i int, b *sqlx.Builder) error {
+			a := args[i]
+			if a.Mode != "" && !strings.EqualFold(a.Mode, schema.FuncArgModeIn) {
+				b.P(strings.ToUpper(a.Mode))
+			}
+			t, err := funcTypeString(a.Type)
+			if err != nil {
+				return err
+			}
+			b.Ident(a.Name).P(t)
+			if x, ok := sqlx.DefaultValue(&schema.Column{Default: a.Default}); ok {
+				b.P("DEFAULT", x)
+			}
+			return nil
+		})
+	})
+	return err
+}
+
+// dropFuncCmd writes the function identifier with its argument types to the given builder.
+func (s *state) dropFuncCmd(b *sqlx.Builder, f *schema.Func) (string, error) {
+	if err := s.funcIdentArgs(b.Func(f), f.Args); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// dropProcCmd writes the procedure identifier with its argument types to the given builder.
+func (s *state) dropProcCmd(b *sqlx.Builder, p *schema.Proc) (string, error) {
+	if err := s.funcIdentArgs(b.Proc(p), p.Args); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// funcIdentArgs writes the argument types that identify a function or a procedure.
+// Output arguments are not part of the function identity.
+func (s *state) funcIdentArgs(b *sqlx.Builder, args []*schema.FuncArg) error {
+	ident := make([]*schema.FuncArg, 0, len(args))
+	for _, a := range args {
+		if !strings.EqualFold(a.Mode, schema.FuncArgModeOut) {
+			ident = append(ident, a)
+		}
+	}
+	var err error
+	b.Wrap(func(b *sqlx.Builder) {
+		err = b.MapCommaErr(ident, func(i int, b *sqlx.Builder) error {
+			t, err := funcTypeString(ident[i].Type)
+			if err != nil {
+				return err
+			}
+			b.P(t)
+			return nil
+		})
+	})
+	return err
+}
+
+// funcSignature returns a string representation of the function arguments and return type.
+func (s *state) funcSignature(args []*schema.FuncArg, ret schema.Type) (string, error) {
+	b := s.Build()
+	if err := s.funcArgs(b, args); err != nil {
+		return "", err
+	}
+	if ret != nil {
+		t, err := funcTypeString(ret)
+		if err != nil {
+			return "", err
+		}
+		b.P(t)
+	}
+	return b.String(), nil
+}
+
+func (s *state) funcComment(f *schema.Func, to, from string) error {
+	b := s.Build("COMMENT ON FUNCTION")
+	if err := s.funcIdentArgs(b.Func(f), f.Args); err != nil {
+		return err
+	}
+	b.P("IS")
+	s.append(&migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to function: %q", f.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	})
+	return nil
+}
+
+func (s *state) procComment(p *schema.Proc, to, from string) error {
+	b := s.Build("COMMENT ON PROCEDURE")
+	if err := s.funcIdentArgs(b.Proc(p), p.Args); err != nil {
+		return err
+	}
+	b.P("IS")
+	s.append(&migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to procedure: %q", p.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	})
+	return nil
+}
+
+// funcTypeString returns the string representation of a function argument
+// or return type. User-defined types (e.g. SETOF or TABLE) are kept as-is.
+func funcTypeString(t schema.Type) (string, error) {
+	if u, ok := t.(*UserDefinedType); ok {
+		return u.T, nil
+	}
+	return FormatType(t)
+}
+
+// dollarQuote quotes the given function body using
+// a dollar-quoted tag that does not appear in it.
+func dollarQuote(body string) string {
+	tag := "$$"
+	for i := 1; strings.Contains(body, tag); i++ {
+		tag = fmt.Sprintf("$body%d$", i)
+	}
+	return tag + body + tag
+}
+
+// concat returns a new slice holding the given changes in order.
+func concat(changes ...[]schema.Change) []schema.Change {
+	var all []schema.Change
+	for _, c := range changes {
+		all = append(all, c...)
+	}
+	return all
+}
+
+// addObject builds and executes the query for creating a schema object.
+func (s *state) addObject(add *schema.AddObject) error {
+	switch o := add.O.(type) {
+	case *Extension:
+		s.addExtension(add, o)
+	case *schema.EnumType:
+		s.addEnum(add, o)
+	case *Domain:
+		return s.addDomain(add, o)
+	case *CompositeType:
+		return s.addComposite(add, o)
+	case *Sequence:
+		return s.addSequence(add, o)
+	default:
+		return fmt.Errorf("unsupported object %T", add.O)
+	}
+	return nil
+}
+
+// dropObject builds and executes the query for dropping a schema object.
+func (s *state) dropObject(drop *schema.DropObject) error {
+	switch o := drop.O.(type) {
+	case *Extension:
+		s.dropExtension(drop, o)
+	case *schema.EnumType:
+		s.dropEnum(drop, o)
+	case *Domain:
+		return s.dropDomain(drop, o)
+	case *CompositeType:
+		return s.dropComposite(drop, o)
+	case *Sequence:
+		return s.dropSequence(drop, o)
+	default:
+		return fmt.Errorf("unsupported object %T", drop.O)
+	}
+	return nil
+}
+
+// modifyObject builds the statements that bring the schema object into its modified state.
+func (s *state) modifyObject(modify *schema.ModifyObject) error {
+	switch from := modify.From.(type) {
+	case *Extension:
+		if to, ok := modify.To.(*Extension); ok {
+			s.modifyExtension(modify, from, to)
+			return nil
+		}
+	case *schema.EnumType:
+		if to, ok := modify.To.(*schema.EnumType); ok {
+			return s.modifyEnum(modify, from, to)
+		}
+	case *Domain:
+		if to, ok := modify.To.(*Domain); ok {
+			return s.modifyDomain(modify, from, to)
+		}
+	case *CompositeType:
+		if to, ok := modify.To.(*CompositeType); ok {
+			return s.modifyComposite(modify, from, to)
+		}
+	case *Sequence:
+		if to, ok := modify.To.(*Sequence); ok {
+			return s.modifySequence(modify, from, to)
+		}
+	}
+	return fmt.Errorf("unsupported object modification %T -> %T", modify.From, modify.To)
+}
+
+// addExtension builds and executes the query for installing an extension.
+func (s *state) addExtension(add *schema.AddObject, e *Extension) {
+	b := s.Build("CREATE EXTENSION")
+	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
+		b.P("IF NOT EXISTS")
+	}
+	b.Ident(e.Name)
+	if ns := s.extensionSchema(e); ns != "" {
+		b.P("WITH SCHEMA").Ident(ns)
+	}
+	if e.Version != "" {
+		b.P("VERSION", quote(e.Version))
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create extension %q", e.Name),
+		Reverse: s.Build("DROP EXTENSION").Ident(e.Name).String(),
+	})
+}
+
+// dropExtension builds and executes the query for removing an extension.
+func (s *state) dropExtension(drop *schema.DropObject, e *Extension) {
+	b := s.Build("DROP EXTENSION")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	reverse := s.Build("CREATE EXTENSION").Ident(e.Name)
+	if ns := s.extensionSchema(e); ns != "" {
+		reverse.P("WITH SCHEMA").Ident(ns)
+	}
+	if e.Version != "" {
+		reverse.P("VERSION", quote(e.Version))
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(e.Name).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop extension %q", e.Name),
+		Reverse: reverse.String(),
+	})
+}
+
+// modifyExtension builds the statement for updating the extension version.
+func (s *state) modifyExtension(modify *schema.ModifyObject, from, to *Extension) {
+	cmd := s.Build("ALTER EXTENSION").Ident(to.Name).P("UPDATE")
+	if to.Version != "" {
+		cmd.P("TO", quote(to.Version))
+	}
+	reverse := s.Build("ALTER EXTENSION").Ident(from.Name).P("UPDATE")
+	if from.Version != "" {
+		reverse.P("TO", quote(from.Version))
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd.String(),
+		Source:  modify,
+		Comment: fmt.Sprintf("update extension %q", to.Name),
+		Reverse: reverse.String(),
+	})
+}
+
+// extensionSchema returns the target schema of the extension based on the planner config.
+func (s *state) extensionSchema(e *Extension) string {
+	switch {
+	case s.SchemaQualifier != nil:
+		return *s.SchemaQualifier
+	case e.Schema != nil:
+		return e.Schema.Name
+	}
+	return ""
+}
+
+// addEnum builds and executes the query for creating an enum type.
+func (s *state) addEnum(add *schema.AddObject, e *schema.EnumType) {
+	name := s.enumIdent(e.Schema, e)
+	if _, ok := s.created[name]; ok {
+		return
+	}
+	s.created[name] = e
+	create, drop := s.createDropEnum(e.Schema, e)
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create enum type %q", e.T),
+		Reverse: drop,
+	})
+}
+
+// dropEnum builds and executes the query for dropping an enum type.
+func (s *state) dropEnum(drop *schema.DropObject, e *schema.EnumType) {
+	name := s.enumIdent(e.Schema, e)
+	// Enum was dropped with the last column that used it.
+	if _, ok := s.dropped[name]; ok {
+		return
+	}
+	s.dropped[name] = e
+	create, cmd := s.createDropEnum(e.Schema, e)
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  drop,
+		Comment: fmt.Sprintf("drop enum type %q", e.T),
+		Reverse: create,
+	})
+}
+
+// modifyEnum builds the statements that bring the enum type into its modified state.
+// New values are added in their positions using ALTER TYPE ... ADD VALUE, and a single
+// value that was replaced in its position is renamed using ALTER TYPE ... RENAME VALUE.
+// Otherwise, the enum type is recreated and the columns that use it are converted to
+// the new type.
+func (s *state) modifyEnum(modify *schema.ModifyObject, from, to *schema.EnumType) error {
+	name := s.enumIdent(from.Schema, from)
+	if prev, ok := s.altered[name]; ok {
+		if !sqlx.ValuesEqual(prev.Values, to.Values) {
+			return fmt.Errorf("enum type %s has inconsistent desired state: %q != %q", name, prev.Values, to.Values)
+		}
+		return nil
+	}
+	s.altered[name] = to
+	var (
+		added   = make(map[string]bool)
+		removed = make(map[string]bool)
+	)
+	for _, v := range to.Values {
+		added[v] = true
+	}
+	for _, v := range from.Values {
+		if !added[v] {
+			removed[v] = true
+		}
+		delete(added, v)
+	}
+	switch {
+	case len(removed) == 0 && enumOrderKept(from, to):
+		for i, v := range to.Values {
+			if !added[v] {
+				continue
+			}
+			b := s.Build("ALTER TYPE").P(name, "ADD VALUE", quote(v))
+			switch {
+			case i == 0 && len(from.Values) > 0:
+				b.P("BEFORE", quote(from.Values[0]))
+			case i > 0 && i < len(to.Values)-1:
+				b.P("AFTER", quote(to.Values[i-1]))
+			}
+			s.addEnumValue(&migrate.Change{
+				Cmd:     b.String(),
+				Source:  modify,
+				Comment: fmt.Sprintf("add value to enum type: %q", to.T),
+			})
+		}
+	case enumRenamed(from, to) != -1:
+		i := enumRenamed(from, to)
+		s.append(&migrate.Change{
+			Cmd:     s.Build("ALTER TYPE").P(name, "RENAME VALUE", quote(from.Values[i]), "TO", quote(to.Values[i])).String(),
+			Source:  modify,
+			Comment: fmt.Sprintf("rename a value of enum type %q from %q to %q", to.T, from.Values[i], to.Values[i]),
+			Reverse: s.Build("ALTER TYPE").P(name, "RENAME VALUE", quote(to.Values[i]), "TO", quote(from.Values[i])).String(),
+		})
+	default:
+		s.recreateEnum(modify, from, to)
+	}
+	return nil
+}
+
+// recreateEnum replaces the enum type with a new one, and converts the columns that use
+// it to the new type using their text values. The reverse statements convert the columns
+// back to the original type, that is recreated from the values of the "from" enum.
+//
+// Note, only the columns of the tables known to the realm of the enum schema (i.e. the
+// inspected state) are converted. Columns of other tables that use the enum type fail
+// the DROP TYPE statement, and rows that hold removed values fail the conversion.
+func (s *state) recreateEnum(modify *schema.ModifyObject, from, to *schema.EnumType) {
+	var (
+		name = s.enumIdent(from.Schema, from)
+		old  = &schema.EnumType{T: from.T + "_old", Schema: from.Schema, Values: from.Values}
+	)
+	s.append(&migrate.Change{
+		Cmd:     s.Build("ALTER TYPE").P(name, "RENAME TO").Ident(old.T).String(),
+		Source:  modify,
+		Comment: fmt.Sprintf("rename enum type %q before recreating it", from.T),
+		Reverse: s.Build("ALTER TYPE").P(s.enumIdent(from.Schema, old), "RENAME TO").Ident(from.T).String(),
+	})
+	create, drop := s.createDropEnum(from.Schema, to)
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  modify,
+		Comment: fmt.Sprintf("recreate enum type %q", to.T),
+		Reverse: drop,
+	})
+	schemas := []*schema.Schema{from.Schema}
+	if from.Schema != nil && from.Schema.Realm != nil && len(from.Schema.Realm.Schemas) > 0 {
+		schemas = from.Schema.Realm.Schemas
+	}
+	for _, ns := range schemas {
+		if ns == nil {
+			continue
+		}
+		for _, t := range ns.Tables {
+			var columns []*schema.Column
+			for _, c := range t.Columns {
+				if e, ok := hasEnumType(c); ok && s.enumIdent(t.Schema, e) == name {
+					columns = append(columns, c)
+				}
+			}
+			if len(columns) == 0 {
+				continue
+			}
+			convert := func(typ string) string {
+				b := s.Build("ALTER TABLE").Table(t)
+				b.MapComma(columns, func(i int, b *sqlx.Builder) {
+					c := columns[i]
+					typ, cast := typ, "text"
+					if _, ok := c.Type.Type.(*ArrayType); ok {
+						typ, cast = typ+"[]", cast+"[]"
+					}
+					// Defaults that use the old type are dropped
+					// before the conversion, and set again after it.
+					if c.Default != nil {
+						b.P("ALTER COLUMN").Ident(c.Name).P("DROP DEFAULT").Comma()
+					}
+					b.P("ALTER COLUMN").Ident(c.Name).P("TYPE", typ, "USING", fmt.Sprintf("%s::%s::%s", s.Build().Ident(c.Name), cast, typ))
+					if c.Default != nil {
+						b.Comma().P("ALTER COLUMN").Ident(c.Name).P("SET")
+						s.columnDefault(b, c)
+					}
+				})
+				return b.String()
+			}
+			s.append(&migrate.Change{
+				Cmd:     convert(name),
+				Source:  modify,
+				Comment: fmt.Sprintf("convert the columns of table %q to the recreated enum type %q", t.Name, to.T),
+				Reverse: convert(s.enumIdent(from.Schema, old)),
+			})
+		}
+	}
+	create, drop = s.createDropEnum(from.Schema, old)
+	s.append(&migrate.Change{
+		Cmd:     drop,
+		Source:  modify,
+		Comment: fmt.Sprintf("drop the replaced enum type %q", from.T),
+		Reverse: create,
+	})
+}
+
+// enumOrderKept reports if the values of enum "from" keep their relative order in enum "to".
+func enumOrderKept(from, to *schema.EnumType) bool {
+	i := 0
+	for _, v := range to.Values {
+		if i < len(from.Values) && from.Values[i] == v {
+			i++
+		}
+	}
+	return i == len(from.Values)
+}
+
+// enumRenamed returns the position of the single value of enum "from" that was
+// replaced by another value in enum "to", or -1 if there is no such position.
+func enumRenamed(from, to *schema.EnumType) int {
+	if len(from.Values) != len(to.Values) {
+		return -1
+	}
+	pos := -1
+	for i, v := range from.Values {
+		switch {
+		case v == to.Values[i]:
+		case pos != -1:
+			return -1
+		default:
+			pos = i
+		}
+	}
+	return pos
+}
+
+// addEnumValue appends the given "ALTER TYPE ... ADD VALUE" change. Before PostgreSQL 12,
+// enum values cannot be added inside a transaction block, and the plan is marked as
+// non-transactional.
+func (s *state) addEnumValue(c *migrate.Change) {
+	if !s.supportsEnumValuesTx() {
+		s.Transactional = false
+	}
+	s.append(c)
+}
+
+// addDomain builds and executes the query for creating a domain type.
+func (s *state) addDomain(add *schema.AddObject, d *Domain) error {
+	create, err := s.domainDef(d)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create domain type %q", d.T),
+		Reverse: s.Build("DROP DOMAIN").P(s.typeIdent(d.Schema, d)).String(),
+	})
+	return nil
+}
+
+// dropDomain builds and executes the query for dropping a domain type.
+func (s *state) dropDomain(drop *schema.DropObject, d *Domain) error {
+	create, err := s.domainDef(d)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP DOMAIN")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(s.typeIdent(d.Schema, d)).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop domain type %q", d.T),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyDomain builds the statements that bring the domain type into its modified state.
+// Each ALTER DOMAIN statement accepts a single action, and the underlying type of the
+// domain cannot be changed.
+func (s *state) modifyDomain(modify *schema.ModifyObject, from, to *Domain) error {
+	t1, err := s.typeString(from.Schema, from.Type)
+	if err != nil {
+		return err
+	}
+	t2, err := s.typeString(to.Schema, to.Type)
+	if err != nil {
+		return err
+	}
+	if t1 != t2 {
+		return fmt.Errorf("changing the underlying type of domain %q is not supported", to.T)
+	}
+	var (
+		name   = s.typeIdent(to.Schema, to)
+		alter  = func() *sqlx.Builder { return s.Build("ALTER DOMAIN").P(name) }
+		change = func(cmd, reverse *sqlx.Builder, comment string) {
+			s.append(&migrate.Change{
+				Cmd:     cmd.String(),
+				Source:  modify,
+				Comment: fmt.Sprintf("%s of domain type %q", comment, to.T),
+				Reverse: reverse.String(),
+			})
+		}
+		defaultB = func(d *Domain) *sqlx.Builder {
+			if d.Default == nil {
+				return alter().P("DROP DEFAULT")
+			}
+			b := alter().P("SET")
+			s.columnDefault(b, &schema.Column{Type: &schema.ColumnType{Type: d.Type}, Default: d.Default})
+			return b
+		}
+		nullB = func(d *Domain) *sqlx.Builder {
+			if d.Null {
+				return alter().P("DROP NOT NULL")
+			}
+			return alter().P("SET NOT NULL")
+		}
+	)
+	// The differ already reported that the domain was changed. Hence,
+	// the default values are compared without querying the database.
+	c1 := &schema.Column{Name: from.T, Type: &schema.ColumnType{Type: from.Type}, Default: from.Default}
+	c2 := &schema.Column{Name: to.T, Type: &schema.ColumnType{Type: to.Type}, Default: to.Default}
+	if changed, err := (&diff{}).defaultChanged(c1, c2); err != nil {
+		return err
+	} else if changed {
+		change(defaultB(to), defaultB(from), "change default value")
+	}
+	if from.Null != to.Null {
+		change(nullB(to), nullB(from), "change nullability")
+	}
+	drop, add := domainChecksDiff(from, to)
+	for _, c := range drop {
+		change(alter().P("DROP CONSTRAINT").Ident(c.Name), s.domainCheck(alter().P("ADD"), c), fmt.Sprintf("drop check constraint %q", c.Name))
+	}
+	for _, c := range add {
+		reverse := alter().P("DROP CONSTRAINT").Ident(c.Name)
+		if c.Name == "" {
+			// Unnamed constraints are named by the database as <domain>_check.
+			reverse = alter().P("DROP CONSTRAINT").Ident(to.T + "_check")
+		}
+		change(s.domainCheck(alter().P("ADD"), c), reverse, "add check constraint")
+	}
+	return nil
+}
+
+// domainDef returns the CREATE DOMAIN statement of the given domain.
+func (s *state) domainDef(d *Domain) (string, error) {
+	t, err := s.typeString(d.Schema, d.Type)
+	if err != nil {
+		return "", err
+	}
+	b := s.Build("CREATE DOMAIN").P(s.typeIdent(d.Schema, d), "AS", t)
+	if d.Default != nil {
+		s.columnDefault(b, &schema.Column{Type: &schema.ColumnType{Type: d.Type}, Default: d.Default})
+	}
+	if !d.Null {
+		b.P("NOT NULL")
+	}
+	for _, c := range d.Checks {
+		s.domainCheck(b, c)
+	}
+	return b.String(), nil
+}
+
+// domainCheck writes the check constraint of a domain to the builder.
+func (s *state) domainCheck(b *sqlx.Builder, c *schema.Check) *sqlx.Builder {
+	if c.Name != "" {
+		b.P("CONSTRAINT").Ident(c.Name)
+	}
+	return b.P("CHECK", sqlx.MayWrap(c.Expr))
+}
+
+// addComposite builds and executes the query for creating a composite type.
+func (s *state) addComposite(add *schema.AddObject, c *CompositeType) error {
+	create, err := s.compositeDef(c)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create composite type %q", c.T),
+		Reverse: s.Build("DROP TYPE").P(s.typeIdent(c.Schema, c)).String(),
+	})
+	return nil
+}
+
+// dropComposite builds and executes the query for dropping a composite type.
+func (s *state) dropComposite(drop *schema.DropObject, c *CompositeType) error {
+	create, err := s.compositeDef(c)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP TYPE")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(s.typeIdent(c.Schema, c)).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop composite type %q", c.T),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyComposite builds the statement that adds, drops and alters the fields of a composite type.
+func (s *state) modifyComposite(modify *schema.ModifyObject, from, to *CompositeType) error {
+	add, drop, alter, err := (&diff{}).fieldsDiff(from, to)
+	if err != nil {
+		return err
+	}
+	var (
+		name    = s.typeIdent(to.Schema, to)
+		cmd     = s.Build("ALTER TYPE").P(name)
+		reverse = s.Build("ALTER TYPE").P(name)
+		field   = func(t *CompositeType, name string) *schema.Column {
+			for _, f := range t.Fields {
+				if f.Name == name {
+					return f
+				}
+			}
+			return nil
+		}
+		clauses = func(b *sqlx.Builder, add, drop, alter []*schema.Column, ns *schema.Schema) error {
+			var fs []func(*sqlx.Builder) error
+			for _, f := range add {
+				fs = append(fs, func(b *sqlx.Builder) error {
+					t, err := s.typeString(ns, f.Type.Type)
+					b.P("ADD ATTRIBUTE").Ident(f.Name).P(t)
+					return err
+				})
+			}
+			for _, f := range drop {
+				fs = append(fs, func(b *sqlx.Builder) error {
+					b.P("DROP ATTRIBUTE").Ident(f.Name)
+					return nil
+				})
+			}
+			for _, f := range alter {
+				fs = append(fs, func(b *sqlx.Builder) error {
+					t, err := s.typeString(ns, f.Type.Type)
+					b.P("ALTER ATTRIBUTE").Ident(f.Name).P("TYPE", t)
+					return err
+				})
+			}
+			return b.MapCommaErr(fs, func(i int, b *sqlx.Builder) error {
+				return fs[i](b)
+			})
+		}
+		prev []*schema.Column
+	)
+	for _, f := range alter {
+		prev = append(prev, field(from, f.Name))
+	}
+	if err := clauses(cmd, add, drop, alter, to.Schema); err != nil {
+		return err
+	}
+	if err := clauses(reverse, drop, add, prev, from.Schema); err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd.String(),
+		Source:  modify,
+		Comment: fmt.Sprintf("modify composite type %q", to.T),
+		Reverse: reverse.String(),
+	})
+	return nil
+}
+
+// compositeDef returns the CREATE TYPE statement of the given composite type.
+func (s *state) compositeDef(c *CompositeType) (string, error) {
+	b := s.Build("CREATE TYPE").P(s.typeIdent(c.Schema, c), "AS")
+	var err error
+	b.Wrap(func(b *sqlx.Builder) {
+		err = b.MapCommaErr(c.Fields, func(i int, b *sqlx.Builder) error {
+			t, err := s.typeString(c.Schema, c.Fields[i].Type.Type)
+			b.Ident(c.Fields[i].Name).P(t)
+			return err
+		})
+	})
+	return b.String(), err
+}
+
+// addSequence builds and executes the query for creating a standalone sequence.
+func (s *state) addSequence(add *schema.AddObject, seq *Sequence) error {
+	cmd, err := s.sequenceDef(s.Build("CREATE SEQUENCE"), seq, add.Extra...)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q sequence", seq.Name),
+		Reverse: s.Build("DROP SEQUENCE").Object(seq.Schema, seq.Name).String(),
+	})
+	return nil
+}
+
+// dropSequence builds and executes the query for dropping a standalone sequence.
+func (s *state) dropSequence(drop *schema.DropObject, seq *Sequence) error {
+	b := s.Build("DROP SEQUENCE")
+	// Owned sequences are dropped automatically with their
+	// owner columns, which might be dropped in this plan.
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) || seq.Owner.T != nil {
+		b.P("IF EXISTS")
+	}
+	reverse, err := s.sequenceDef(s.Build("CREATE SEQUENCE"), seq)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Object(seq.Schema, seq.Name).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q sequence", seq.Name),
+		Reverse: reverse,
+	})
+	return nil
+}
+
+// modifySequence builds the statements that bring the standalone sequence into its modified state.
+func (s *state) modifySequence(modify *schema.ModifyObject, from, to *Sequence) error {
+	s1, err := sequenceDefaults(from)
+	if err != nil {
+		return err
+	}
+	s2, err := sequenceDefaults(to)
+	if err != nil {
+		return err
+	}
+	cmd := s.Build("ALTER SEQUENCE").Object(to.Schema, to.Name)
+	n := cmd.Len()
+	// Ownership changes are planned separately.
+	if sequenceOptions(cmd, s1, s2); cmd.Len() == n {
+		return nil
+	}
+	reverse := s.Build("ALTER SEQUENCE").Object(from.Schema, from.Name)
+	sequenceOptions(reverse, s2, s1)
+	s.append(&migrate.Change{
+		Cmd:     cmd.String(),
+		Source:  modify,
+		Comment: fmt.Sprintf("modify %q sequence", to.Name),
+		Reverse: reverse.String(),
+	})
+	return nil
+}
+
+// addRole builds and executes the query for creating a role.
+func (s *state) addRole(add *schema.AddRole) {
+	b := s.Build("CREATE ROLE").Ident(add.R.Name)
+	if opts := roleOptions(add.R); len(opts) > 0 {
+		b.P("WITH").P(opts...)
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create role %q", add.R.Name),
+		Reverse: s.Build("DROP ROLE").Ident(add.R.Name).String(),
+	})
+}
+
+// dropRole builds and executes the query for dropping a role.
+func (s *state) dropRole(drop *schema.DropRole) {
+	b := s.Build("DROP ROLE")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	reverse := s.Build("CREATE ROLE").Ident(drop.R.Name)
+	if opts := roleOptions(drop.R); len(opts) > 0 {
+		reverse.P("WITH").P(opts...)
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(drop.R.Name).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop role %q", drop.R.Name),
+		Reverse: reverse.String(),
+	})
+}
+
+// modifyRole builds and executes the query for altering the role options.
+func (s *state) modifyRole(modify *schema.ModifyRole) {
+	alter := func(r *schema.Role) string {
+		// All options are set explicitly, as options that were
+		// removed from the role should be reset to their defaults.
+		var o RoleOptions
+		sqlx.Has(r.Attrs, &o)
+		return s.Build("ALTER ROLE").Ident(r.Name).P("WITH").P(
+			roleOption(o.Superuser, "SUPERUSER"),
+			roleOption(o.CreateDB, "CREATEDB"),
+			roleOption(o.CreateRole, "CREATEROLE"),
+			roleOption(!o.NoInherit, "INHERIT"),
+			roleOption(r.Login, "LOGIN"),
+			roleOption(o.Replication, "REPLICATION"),
+			roleOption(o.BypassRLS, "BYPASSRLS"),
+		).String()
+	}
+	s.append(&migrate.Change{
+		Cmd:     alter(modify.To),
+		Source:  modify,
+		Comment: fmt.Sprintf("modify role %q", modify.To.Name),
+		Reverse: alter(modify.From),
+	})
+}
+
+// roleOptions returns the non-default options of the role.
+func roleOptions(r *schema.Role) []string {
+	var (
+		o    RoleOptions
+		opts []string
+	)
+	sqlx.Has(r.Attrs, &o)
+	for _, v := range []struct {
+		set bool
+		opt string
+	}{
+		{o.Superuser, "SUPERUSER"},
+		{o.CreateDB, "CREATEDB"},
+		{o.CreateRole, "CREATEROLE"},
+		{o.NoInherit, "NOINHERIT"},
+		{r.Login, "LOGIN"},
+		{o.Replication, "REPLICATION"},
+		{o.BypassRLS, "BYPASSRLS"},
+	} {
+		if v.set {
+			opts = append(opts, v.opt)
+		}
+	}
+	return opts
+}
+
+// roleOption returns the option name, or its negation (e.g. NOLOGIN) if it is not set.
+func roleOption(set bool, opt string) string {
+	if set {
+		return opt
+	}
+	return "NO" + opt
+}
+
+// grant builds and executes the query for granting privileges to a role.
+func (s *state) grant(g *schema.Grant) error {
+	cmd, err := s.privilege("GRANT", "TO", g.P)
+	if err != nil {
+		return err
+	}
+	reverse, err := s.privilege("REVOKE", "FROM", g.P)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  g,
+		Comment: fmt.Sprintf("grant privileges to role %q", g.P.Grantee.Name),
+		Reverse: reverse,
+	})
+	return nil
+}
+
+// revoke builds and executes the query for revoking privileges from a role.
+func (s *state) revoke(r *schema.Revoke) error {
+	cmd, err := s.privilege("REVOKE", "FROM", r.P)
+	if err != nil {
+		return err
+	}
+	reverse, err := s.privilege("GRANT", "TO", r.P)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Source:  r,
+		Comment: fmt.Sprintf("revoke privileges from role %q", r.P.Grantee.Name),
+		Reverse: reverse,
+	})
+	return nil
+}
+
+// privilege returns the GRANT or REVOKE statement for the given privilege.
+func (s *state) privilege(action, prep string, p *schema.Privilege) (string, error) {
+	if p.Grantee == nil || len(p.Privs) == 0 {
+		return "", fmt.Errorf("postgres: missing grantee or privileges for %s statement", action)
+	}
+	b := s.Build(action).P(strings.Join(p.Privs, ", "), "ON")
+	switch {
+	case p.Schema == nil:
+		return "", fmt.Errorf("postgres: database-wide privileges are not supported")
+	case p.Table != nil:
+		b.P("TABLE").Table(p.Table)
+	case p.View != nil:
+		b.P("TABLE").View(p.View)
+	default:
+		b.P("SCHEMA").Ident(p.Schema.Name)
+	}
+	b.P(prep).Ident(p.Grantee.Name)
+	// Revoking a privilege revokes also the option to grant it.
+	if p.Grantable && action == "GRANT" {
+		b.P("WITH GRANT OPTION")
+	}
+	return b.String(), nil
+}
+
+// sequenceOwned sets the ownership of sequences that were added or modified in
+// the plan. It is executed after the tables changes, as the owner column of the
+// sequence might be added in the same plan.
+func (s *state) sequenceOwned(c schema.Change) {
+	var from, to *Sequence
+	switch c := c.(type) {
+	case *schema.AddObject:
+		to, _ = c.O.(*Sequence)
+		if to == nil || to.Owner.T == nil {
+			return
+		}
+		from = &Sequence{Name: to.Name, Schema: to.Schema}
+	case *schema.ModifyObject:
+		from, _ = c.From.(*Sequence)
+		to, _ = c.To.(*Sequence)
+		if from == nil || to == nil || sequenceOwner(from) == sequenceOwner(to) {
+			return
+		}
+	default:
+		return
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.sequenceOwner(to),
+		Source:  c,
+		Comment: fmt.Sprintf("set the ownership of %q sequence", to.Name),
+		Reverse: s.sequenceOwner(from),
+	})
+}
+
+// sequenceOwner returns the statement for setting the ownership of the sequence.
+func (s *state) sequenceOwner(seq *Sequence) string {
+	b := s.Build("ALTER SEQUENCE").Object(seq.Schema, seq.Name).P("OWNED BY")
+	if seq.Owner.T == nil || seq.Owner.C == nil {
+		return b.P("NONE").String()
+	}
+	return b.P(s.Build().Table(seq.Owner.T).String() + "." + s.Build().Ident(seq.Owner.C.Name).String()).String()
+}
+
+// sequenceDef writes the sequence name and its options to the given builder.
+// Options that are equal to the defaults of the sequence type are omitted.
+func (s *state) sequenceDef(b *sqlx.Builder, seq *Sequence, extra ...schema.Clause) (string, error) {
+	if sqlx.Has(extra, &schema.IfNotExists{}) {
+		b.P("IF NOT EXISTS")
+	}
+	b.Object(seq.Schema, seq.Name)
+	to, err := sequenceDefaults(seq)
+	if err != nil {
+		return "", err
+	}
+	base, err := sequenceDefaults(&Sequence{Name: seq.Name, Type: to.Type, Increment: to.Increment})
+	if err != nil {
+		return "", err
+	}
+	if t := to.Type.(*schema.IntegerType).T; t != TypeBigInt {
+		b.P("AS", t)
 	}
-	s.append(&migrate.Change{
-		Cmd:     b.String(),
-		Source:  add,
-		Comment: fmt.Sprintf("create %q table", add.T.Name),
-		Reverse: s.Build("DROP TABLE").Table(add.T).String(),
-	})
-	if err := s.addIndexes(add.T, add.T.Indexes...); err != nil {
-		return err
+	if to.Increment != 1 {
+		b.P("INCREMENT BY", strconv.FormatInt(to.Increment, 10))
 	}
-	s.addComments(add.T)
-	return nil
+	sequenceOptions(b, base, to)
+	return b.String(), nil
 }
 
-// dropTable builds and executes the query for dropping a table from a schema.
-func (s *state) dropTable(drop *schema.DropTable) {
-	b := s.Build("DROP TABLE")
-	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
-		b.P("IF EXISTS")
+// sequenceOptions writes the options of sequence "to" that
+// are different from the ones of sequence "from". Both are
+// expected to be normalized with their defaults.
+func sequenceOptions(b *sqlx.Builder, from, to *Sequence) {
+	if t1, t2 := from.Type.(*schema.IntegerType).T, to.Type.(*schema.IntegerType).T; t1 != t2 {
+		b.P("AS", t2)
+	}
+	if from.Increment != to.Increment {
+		b.P("INCREMENT BY", strconv.FormatInt(to.Increment, 10))
+	}
+	if from.Min != to.Min {
+		b.P("MINVALUE", strconv.FormatInt(to.Min, 10))
+	}
+	if from.Max != to.Max {
+		b.P("MAXVALUE", strconv.FormatInt(to.Max, 10))
+	}
+	if from.Start != to.Start {
+		b.P("START WITH", strconv.FormatInt(to.Start, 10))
+	}
+	if from.Cache != to.Cache {
+		b.P("CACHE", strconv.FormatInt(to.Cache, 10))
+	}
+	switch {
+	case !from.Cycle && to.Cycle:
+		b.P("CYCLE")
+	case from.Cycle && !to.Cycle:
+		b.P("NO CYCLE")
 	}
-	b.Table(drop.T)
-	s.append(&migrate.Change{
-		Cmd:     b.String(),
-		Source:  drop,
-		Comment: fmt.Sprintf("drop %q table", drop.T.Name),
-	})
 }
 
 // modifyTable builds the statements that bring the table into its modified state.
@@ -212,27 +1771,62 @@ func (s *state) modifyTable(ctx context.Context, modify *schema.ModifyTable) err
 	var (
 		alter       []schema.Change
 		addI, dropI []*schema.Index
+		addT, dropT []*schema.Trigger
+		addP, dropP []*Policy
 		changes     []*migrate.Change
+		// Renames are executed before the other alterations,
+		// as they may refer to the columns and indexes by their new names.
+		renames []*migrate.Change
 	)
 	for _, change := range skipAutoChanges(modify.Changes) {
 		switch change := change.(type) {
-		case *schema.AddAttr, *schema.ModifyAttr:
-			from, to, err := commentChange(change)
-			if err != nil {
-				return err
+		case *schema.AddAttr, *schema.ModifyAttr, *schema.DropAttr:
+			switch from, to := attrChange(change); {
+			// Policies are replaced by dropping and creating them again, as
+			// ALTER POLICY cannot change their kind, command or remove clauses.
+			case isPolicy(from) || isPolicy(to):
+				if p, ok := from.(*Policy); ok {
+					dropP = append(dropP, p)
+				}
+				if p, ok := to.(*Policy); ok {
+					addP = append(addP, p)
+				}
+			case isRowSecurity(from) || isRowSecurity(to):
+				changes = append(changes, s.rowSecurity(modify.T, change, from, to)...)
+			// Storage and inheritance changes are combined with the other table alterations.
+			case isStorageAttr(from) || isStorageAttr(to), isInherits(from) || isInherits(to):
+				alter = append(alter, change)
+			case isPartitionOf(from) || isPartitionOf(to):
+				c, err := s.attachPartition(modify.T, change, from, to)
+				if err != nil {
+					return err
+				}
+				changes = append(changes, c...)
+			default:
+				if _, ok := change.(*schema.DropAttr); ok {
+					return fmt.Errorf("unsupported change type: %T", change)
+				}
+				from, to, err := commentChange(change)
+				if err != nil {
+					return err
+				}
+				changes = append(changes, s.tableComment(modify.T, to, from))
 			}
-			changes = append(changes, s.tableComment(modify.T, to, from))
-		case *schema.DropAttr:
-			return fmt.Errorf("unsupported change type: %T", change)
 		case *schema.AddIndex:
 			if c := (schema.Comment{}); sqlx.Has(change.I.Attrs, &c) {
 				changes = append(changes, s.indexComment(modify.T, change.I, c.Text, ""))
 			}
-			addI = append(addI, change.I)
+			// Constraints that cannot be created using CREATE INDEX
+			// are added to the ALTER TABLE statement below.
+			if isTableConstraint(change.I) {
+				alter = append(alter, change)
+			} else {
+				addI = append(addI, change.I)
+			}
 		case *schema.DropIndex:
 			// Unlike DROP INDEX statements that are executed separately,
 			// DROP CONSTRAINT are added to the ALTER TABLE statement below.
-			if isUniqueConstraint(change.I) {
+			if isUniqueConstraint(change.I) || isExclusion(change.I.Attrs) {
 				alter = append(alter, change)
 			} else {
 				dropI = append(dropI, change.I)
@@ -251,16 +1845,39 @@ func (s *state) modifyTable(ctx context.Context, modify *schema.ModifyTable) err
 				}
 			}
 			// Index modification requires rebuilding the index.
-			addI = append(addI, change.To)
-			dropI = append(dropI, change.From)
+			if isUniqueConstraint(change.From) || isExclusion(change.From.Attrs) {
+				alter = append(alter, &schema.DropIndex{I: change.From})
+			} else {
+				dropI = append(dropI, change.From)
+			}
+			if isTableConstraint(change.To) {
+				alter = append(alter, &schema.AddIndex{I: change.To})
+			} else {
+				addI = append(addI, change.To)
+			}
 		case *schema.RenameIndex:
-			changes = append(changes, &migrate.Change{
+			renames = append(renames, &migrate.Change{
 				Source:  change,
 				Comment: fmt.Sprintf("rename an index from %q to %q", change.From.Name, change.To.Name),
 				Cmd:     s.Build("ALTER INDEX").Ident(change.From.Name).P("RENAME TO").Ident(change.To.Name).String(),
 				Reverse: s.Build("ALTER INDEX").Ident(change.To.Name).P("RENAME TO").Ident(change.From.Name).String(),
 			})
+		case *schema.AddTrigger:
+			addT = append(addT, change.T)
+		case *schema.DropTrigger:
+			dropT = append(dropT, change.T)
+		// Triggers are replaced by dropping and creating them again, as
+		// "CREATE OR REPLACE TRIGGER" is supported only since PostgreSQL 14.
+		case *schema.ModifyTrigger:
+			dropT = append(dropT, change.From)
+			addT = append(addT, change.To)
+		// Changing only the deferrability of a foreign key does not
+		// require recreating it and is done using ALTER CONSTRAINT.
 		case *schema.ModifyForeignKey:
+			if change.Change == schema.ChangeAttr {
+				alter = append(alter, change)
+				continue
+			}
 			// Foreign-key modification is translated into 2 steps.
 			// Dropping the current foreign key and creating a new one.
 			alter = append(alter, &schema.DropForeignKey{
@@ -314,7 +1931,7 @@ func (s *state) modifyTable(ctx context.Context, modify *schema.ModifyTable) err
 			// "RENAME COLUMN" cannot be combined with other alterations.
 			b := s.Build("ALTER TABLE").Table(modify.T).P("RENAME COLUMN")
 			r := b.Clone()
-			changes = append(changes, &migrate.Change{
+			renames = append(renames, &migrate.Change{
 				Source:  change,
 				Comment: fmt.Sprintf("rename a column from %q to %q", change.From.Name, change.To.Name),
 				Cmd:     b.Ident(change.From.Name).P("TO").Ident(change.To.Name).String(),
@@ -324,6 +1941,12 @@ func (s *state) modifyTable(ctx context.Context, modify *schema.ModifyTable) err
 			alter = append(alter, change)
 		}
 	}
+	s.append(renames...)
+	if err := s.dropTriggers(dropT...); err != nil {
+		return err
+	}
+	// Policies are dropped before the columns they use, and created after them.
+	s.dropPolicies(modify.T, dropP...)
 	s.dropIndexes(modify.T, dropI...)
 	if len(alter) > 0 {
 		if err := s.alterTable(modify.T, alter); err != nil {
@@ -331,10 +1954,361 @@ func (s *state) modifyTable(ctx context.Context, modify *schema.ModifyTable) err
 		}
 	}
 	s.addIndexes(modify.T, addI...)
+	if err := s.addTriggers(addT...); err != nil {
+		return err
+	}
+	s.addPolicies(modify.T, addP...)
 	s.append(changes...)
 	return nil
 }
 
+// attrChange returns the attributes of the given attribute change.
+func attrChange(c schema.Change) (from, to schema.Attr) {
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		to = c.A
+	case *schema.DropAttr:
+		from = c.A
+	case *schema.ModifyAttr:
+		from, to = c.From, c.To
+	}
+	return from, to
+}
+
+func isPolicy(a schema.Attr) bool {
+	_, ok := a.(*Policy)
+	return ok
+}
+
+func isRowSecurity(a schema.Attr) bool {
+	_, ok := a.(*RowSecurity)
+	return ok
+}
+
+func isStorageAttr(a schema.Attr) bool {
+	switch a.(type) {
+	case *Unlogged, *Tablespace, *TableStorageParams:
+		return true
+	}
+	return false
+}
+
+func isInherits(a schema.Attr) bool {
+	_, ok := a.(*Inherits)
+	return ok
+}
+
+// alterAttr writes the clauses for changing the given table attribute.
+func alterAttr(b *sqlx.Builder, from, to schema.Attr) {
+	if isInherits(from) || isInherits(to) {
+		alterInherits(b, from, to)
+		return
+	}
+	alterStorage(b, from, to)
+}
+
+// alterInherits writes the INHERIT and NO INHERIT clauses
+// for changing the parent tables of a table.
+func alterInherits(b *sqlx.Builder, from, to schema.Attr) {
+	fromP, toP := &Inherits{}, &Inherits{}
+	if p, ok := from.(*Inherits); ok {
+		fromP = p
+	}
+	if p, ok := to.(*Inherits); ok {
+		toP = p
+	}
+	added, dropped := parentsDiff(toP, fromP), parentsDiff(fromP, toP)
+	b.MapComma(dropped, func(i int, b *sqlx.Builder) {
+		b.P("NO INHERIT").Table(dropped[i])
+	})
+	if len(dropped) > 0 && len(added) > 0 {
+		b.Comma()
+	}
+	b.MapComma(added, func(i int, b *sqlx.Builder) {
+		b.P("INHERIT").Table(added[i])
+	})
+}
+
+// alterStorage writes the clauses for changing the persistence,
+// the tablespace or the storage parameters of a table.
+func alterStorage(b *sqlx.Builder, from, to schema.Attr) {
+	switch {
+	case isUnlogged(to):
+		b.P("SET UNLOGGED")
+	case isUnlogged(from):
+		b.P("SET LOGGED")
+	}
+	switch t := to.(type) {
+	case *Tablespace:
+		b.P("SET TABLESPACE").Ident(t.Name)
+	default:
+		if _, ok := from.(*Tablespace); ok {
+			b.P("SET TABLESPACE").Ident("pg_default")
+		}
+	}
+	var fromP, toP map[string]string
+	if p, ok := from.(*TableStorageParams); ok {
+		fromP = p.Params
+	}
+	if p, ok := to.(*TableStorageParams); ok {
+		toP = p.Params
+	}
+	set := make(map[string]string)
+	for k, v := range toP {
+		if v1, ok := fromP[k]; !ok || v1 != v {
+			set[k] = v
+		}
+	}
+	var reset []string
+	for k := range fromP {
+		if _, ok := toP[k]; !ok {
+			reset = append(reset, k)
+		}
+	}
+	sort.Strings(reset)
+	if len(set) > 0 {
+		storageParams(b.P("SET"), set)
+	}
+	if len(reset) > 0 {
+		if len(set) > 0 {
+			b.Comma()
+		}
+		b.P("RESET").Wrap(func(b *sqlx.Builder) {
+			b.MapComma(reset, func(i int, b *sqlx.Builder) {
+				b.WriteString(reset[i])
+			})
+		})
+	}
+}
+
+func isUnlogged(a schema.Attr) bool {
+	_, ok := a.(*Unlogged)
+	return ok
+}
+
+// storageParams writes the given storage parameters to the builder, ordered by their names.
+func storageParams(b *sqlx.Builder, params map[string]string) {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(keys, func(i int, b *sqlx.Builder) {
+			b.WriteString(keys[i] + " = " + params[keys[i]])
+		})
+	})
+}
+
+func isPartitionOf(a schema.Attr) bool {
+	_, ok := a.(*PartitionOf)
+	return ok
+}
+
+// attachPartition returns the changes for detaching the table from
+// its current parent table and attaching it to the desired one.
+func (s *state) attachPartition(t *schema.Table, c schema.Change, from, to schema.Attr) ([]*migrate.Change, error) {
+	var (
+		changes []*migrate.Change
+		attach  = func(p *PartitionOf) (string, error) {
+			if p.Parent == nil {
+				return "", fmt.Errorf("missing parent table for partition %q", t.Name)
+			}
+			b := s.Build("ALTER TABLE").Table(p.Parent).P("ATTACH PARTITION").Table(t)
+			if err := partitionBound(b, t, p); err != nil {
+				return "", err
+			}
+			return b.String(), nil
+		}
+		detach = func(p *PartitionOf) string {
+			return s.Build("ALTER TABLE").Table(p.Parent).P("DETACH PARTITION").Table(t).String()
+		}
+	)
+	if p, ok := from.(*PartitionOf); ok {
+		reverse, err := attach(p)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &migrate.Change{
+			Cmd:     detach(p),
+			Source:  c,
+			Comment: fmt.Sprintf("detach %q partition from %q table", t.Name, p.Parent.Name),
+			Reverse: reverse,
+		})
+	}
+	if p, ok := to.(*PartitionOf); ok {
+		cmd, err := attach(p)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &migrate.Change{
+			Cmd:     cmd,
+			Source:  c,
+			Comment: fmt.Sprintf("attach %q partition to %q table", t.Name, p.Parent.Name),
+			Reverse: detach(p),
+		})
+	}
+	return changes, nil
+}
+
+// rowSecurity returns the changes for migrating the row-level security attributes of the table.
+func (s *state) rowSecurity(t *schema.Table, c schema.Change, from, to schema.Attr) []*migrate.Change {
+	var fromR, toR RowSecurity
+	if r, ok := from.(*RowSecurity); ok {
+		fromR = *r
+	}
+	if r, ok := to.(*RowSecurity); ok {
+		toR = *r
+	}
+	var changes []*migrate.Change
+	for _, o := range []struct {
+		from, to bool
+		action   string
+	}{
+		{fromR.Enabled, toR.Enabled, "ENABLE"},
+		{fromR.Forced, toR.Forced, "FORCE"},
+	} {
+		if o.from == o.to {
+			continue
+		}
+		on, off := o.action, "NO FORCE"
+		if o.action == "ENABLE" {
+			off = "DISABLE"
+		}
+		cmd, reverse := on, off
+		if !o.to {
+			cmd, reverse = off, on
+		}
+		changes = append(changes, &migrate.Change{
+			Cmd:     s.Build("ALTER TABLE").Table(t).P(cmd, "ROW LEVEL SECURITY").String(),
+			Source:  c,
+			Comment: fmt.Sprintf("%s row-level security of %q table", strings.ToLower(cmd), t.Name),
+			Reverse: s.Build("ALTER TABLE").Table(t).P(reverse, "ROW LEVEL SECURITY").String(),
+		})
+	}
+	return changes
+}
+
+// addPolicies builds and appends the changes for creating the given policies.
+func (s *state) addPolicies(t *schema.Table, policies ...*Policy) {
+	for _, p := range policies {
+		s.append(&migrate.Change{
+			Cmd:     s.policyDef(t, p),
+			Source:  &schema.AddAttr{A: p},
+			Comment: fmt.Sprintf("create %q policy on %q table", p.Name, t.Name),
+			Reverse: s.Build("DROP POLICY").Ident(p.Name).P("ON").Table(t).String(),
+		})
+	}
+}
+
+// dropPolicies builds and appends the changes for dropping the given policies.
+func (s *state) dropPolicies(t *schema.Table, policies ...*Policy) {
+	for _, p := range policies {
+		s.append(&migrate.Change{
+			Cmd:     s.Build("DROP POLICY").Ident(p.Name).P("ON").Table(t).String(),
+			Source:  &schema.DropAttr{A: p},
+			Comment: fmt.Sprintf("drop %q policy from %q table", p.Name, t.Name),
+			Reverse: s.policyDef(t, p),
+		})
+	}
+}
+
+// policyDef returns the CREATE POLICY statement of the given policy.
+func (s *state) policyDef(t *schema.Table, p *Policy) string {
+	b := s.Build("CREATE POLICY").Ident(p.Name).P("ON").Table(t)
+	if as := policyAs(p); as != PolicyAsPermissive {
+		b.P("AS", as)
+	}
+	if cmd := policyCmd(p); cmd != PolicyCmdAll {
+		b.P("FOR", cmd)
+	}
+	if len(p.Roles) > 0 {
+		b.P("TO").MapComma(p.Roles, func(i int, b *sqlx.Builder) {
+			switch r := p.Roles[i]; strings.ToUpper(r) {
+			case "PUBLIC", "CURRENT_USER", "CURRENT_ROLE", "SESSION_USER":
+				b.P(strings.ToUpper(r))
+			default:
+				b.Ident(r)
+			}
+		})
+	}
+	if p.Using != "" {
+		b.P("USING", sqlx.MayWrap(p.Using))
+	}
+	if p.Check != "" {
+		b.P("WITH CHECK", sqlx.MayWrap(p.Check))
+	}
+	return b.String()
+}
+
+// addTriggers builds and appends the changes for creating the given triggers.
+func (s *state) addTriggers(triggers ...*schema.Trigger) error {
+	for _, t := range triggers {
+		cmd, err := s.triggerDef(t)
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     cmd,
+			Source:  &schema.AddTrigger{T: t},
+			Comment: fmt.Sprintf("create %q trigger", t.Name),
+			Reverse: s.Build("DROP TRIGGER").Ident(t.Name).P("ON").Table(t.Table).String(),
+		})
+	}
+	return nil
+}
+
+// dropTriggers builds and appends the changes for dropping the given triggers.
+func (s *state) dropTriggers(triggers ...*schema.Trigger) error {
+	for _, t := range triggers {
+		create, err := s.triggerDef(t)
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     s.Build("DROP TRIGGER").Ident(t.Name).P("ON").Table(t.Table).String(),
+			Source:  &schema.DropTrigger{T: t},
+			Comment: fmt.Sprintf("drop %q trigger", t.Name),
+			Reverse: create,
+		})
+	}
+	return nil
+}
+
+// triggerDef returns the CREATE TRIGGER statement of the given trigger.
+func (s *state) triggerDef(t *schema.Trigger) (string, error) {
+	switch {
+	case t.Func == nil:
+		return "", fmt.Errorf("postgres: missing function for trigger %q", t.Name)
+	case len(t.Events) == 0:
+		return "", fmt.Errorf("postgres: missing events for trigger %q", t.Name)
+	}
+	b := s.Build("CREATE TRIGGER").Ident(t.Name).P(strings.ToUpper(t.ActionTime))
+	for i, e := range t.Events {
+		if i > 0 {
+			b.P("OR")
+		}
+		b.P(strings.ToUpper(e.Name))
+		if len(e.Columns) > 0 {
+			b.P("OF").MapComma(e.Columns, func(i int, b *sqlx.Builder) {
+				b.Ident(e.Columns[i].Name)
+			})
+		}
+	}
+	b.P("ON").Table(t.Table)
+	if t.For != "" {
+		b.P("FOR EACH", strings.ToUpper(t.For))
+	}
+	// EXECUTE FUNCTION was added in PostgreSQL 11,
+	// and replaced the EXECUTE PROCEDURE syntax.
+	if s.supportsProcedures() {
+		b.P("EXECUTE FUNCTION")
+	} else {
+		b.P("EXECUTE PROCEDURE")
+	}
+	return b.Func(t.Func).P("()").String(), nil
+}
+
 // alterTable modifies the given table by executing on it a list of changes in one SQL statement.
 func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 	var (
@@ -380,24 +2354,50 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 					}
 				}
 			case *schema.AddIndex:
-				b.P("ADD CONSTRAINT").Ident(change.I.Name).P("UNIQUE")
-				if err := s.indexParts(b, change.I); err != nil {
+				if err := s.constraint(b.P("ADD"), change.I); err != nil {
 					return err
 				}
-				// Skip reversing this operation as it is the inverse of
-				// the operation below and should not be used besides this.
+				reverse = append(reverse, &schema.DropIndex{I: change.I})
 			case *schema.DropIndex:
 				b.P("DROP CONSTRAINT").Ident(change.I.Name)
 				reverse = append(reverse, &schema.AddIndex{I: change.I})
 			case *schema.AddForeignKey:
 				b.P("ADD")
 				s.fks(b, change.F)
+				s.notValid(b, alter, t, change.F.Symbol, change.F.Attrs)
 				reverse = append(reverse, &schema.DropForeignKey{F: change.F})
 			case *schema.DropForeignKey:
 				b.P("DROP CONSTRAINT").Ident(change.F.Symbol)
 				reverse = append(reverse, &schema.AddForeignKey{F: change.F})
+			case *schema.ModifyForeignKey:
+				b.P("ALTER CONSTRAINT").Ident(change.To.Symbol)
+				// Unlike the CREATE form, the initial mode is set
+				// explicitly, as it is not reset by ALTER CONSTRAINT.
+				switch d := (Deferrable{}); {
+				case !sqlx.Has(change.To.Attrs, &d):
+					b.P("NOT DEFERRABLE")
+				case d.InitiallyDeferred:
+					b.P("DEFERRABLE INITIALLY DEFERRED")
+				default:
+					b.P("DEFERRABLE INITIALLY IMMEDIATE")
+				}
+				reverse = append(reverse, &schema.ModifyForeignKey{
+					From:   change.To,
+					To:     change.From,
+					Change: change.Change,
+				})
+			case *schema.AddAttr:
+				alterAttr(b, nil, change.A)
+				reverse = append(reverse, &schema.DropAttr{A: change.A})
+			case *schema.DropAttr:
+				alterAttr(b, change.A, nil)
+				reverse = append(reverse, &schema.AddAttr{A: change.A})
+			case *schema.ModifyAttr:
+				alterAttr(b, change.From, change.To)
+				reverse = append(reverse, &schema.ModifyAttr{From: change.To, To: change.From})
 			case *schema.AddCheck:
 				check(b.P("ADD"), change.C)
+				s.notValid(b, alter, t, change.C.Name, change.C.Attrs)
 				// Reverse operation is supported if
 				// the constraint name is not generated.
 				if reversible = reversible && change.C.Name != ""; reversible {
@@ -417,6 +2417,7 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 					!sqlx.Has(change.From.Attrs, &NoInherit{}) && sqlx.Has(change.To.Attrs, &NoInherit{}):
 					b.P("DROP CONSTRAINT").Ident(change.From.Name).Comma().P("ADD")
 					check(b, change.To)
+					s.notValid(b, alter, t, change.To.Name, change.To.Attrs)
 				default:
 					return errors.New("unknown check constraint change")
 				}
@@ -432,7 +2433,7 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 		}
 		return b.String(), nil
 	}
-	cmd := &alterChange{}
+	cmd := &alterChange{notValid: s.DeferValidation}
 	stmt, err := build(cmd, changes)
 	if err != nil {
 		return fmt.Errorf("alter table %q: %v", t.Name, err)
@@ -462,6 +2463,9 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 type alterChange struct {
 	main          *migrate.Change
 	before, after []*migrate.Change
+	// Add new constraints as NOT VALID, and validate them
+	// after the main command. See PlanOptions.DeferValidation.
+	notValid bool
 }
 
 func (a *alterChange) append(s *state) {
@@ -470,6 +2474,27 @@ func (a *alterChange) append(s *state) {
 	s.append(a.after...)
 }
 
+// notValid writes the NOT VALID clause of a constraint that is added to the given table.
+// The clause is written if the constraint is marked as NOT VALID, or if its validation is
+// deferred to a separate statement that is executed after the main command. In the latter
+// case, the plan is marked as non-transactional, as otherwise the lock that is taken by the
+// main command is held until the validation is done.
+func (s *state) notValid(b *sqlx.Builder, alter *alterChange, t *schema.Table, name string, attrs []schema.Attr) {
+	switch {
+	case sqlx.Has(attrs, &NotValid{}):
+		b.P("NOT VALID")
+	// Unnamed constraints cannot be validated, as
+	// their names are generated by the database.
+	case alter.notValid && name != "":
+		b.P("NOT VALID")
+		s.Transactional = false
+		alter.after = append(alter.after, &migrate.Change{
+			Cmd:     s.Build("ALTER TABLE").Table(t).P("VALIDATE CONSTRAINT").Ident(name).String(),
+			Comment: fmt.Sprintf("validate constraint %q of %q table", name, t.Name),
+		})
+	}
+}
+
 func (s *state) alterColumn(b *sqlx.Builder, alter *alterChange, t *schema.Table, c *schema.ModifyColumn) error {
 	for k := c.Change; !k.Is(schema.NoChange); {
 		b.P("ALTER COLUMN").Ident(c.To.Name)
@@ -599,9 +2624,7 @@ func (s *state) alterType(b *sqlx.Builder, alter *alterChange, t *schema.Table,
 			f   string
 			err error
 		)
-		if e, ok := c.To.Type.Type.(*schema.EnumType); ok {
-			f = s.enumIdent(t.Schema, e)
-		} else if f, err = FormatType(c.To.Type.Type); err != nil {
+		if f, err = s.formatType(t, c.To); err != nil {
 			return err
 		}
 		b.P("TYPE", f)
@@ -688,13 +2711,6 @@ func (s *state) mayAddEnums(ctx context.Context, t *schema.Table, columns ...*sc
 		if e.T == "" {
 			return fmt.Errorf("missing enum name for column %q", c.Name)
 		}
-		if exists, err := s.enumExists(ctx, t.Schema, e); err != nil {
-			return err
-		} else if exists {
-			// Enum exists and was not created
-			// on this migration phase.
-			continue
-		}
 		name := s.enumIdent(t.Schema, e)
 		if prev, ok := s.created[name]; ok {
 			if !sqlx.ValuesEqual(prev.Values, e.Values) {
@@ -702,6 +2718,13 @@ func (s *state) mayAddEnums(ctx context.Context, t *schema.Table, columns ...*sc
 			}
 			continue
 		}
+		if exists, err := s.enumExists(ctx, t.Schema, e); err != nil {
+			return err
+		} else if exists {
+			// Enum exists and was not created
+			// on this migration phase.
+			continue
+		}
 		s.created[name] = e
 		create, drop := s.createDropEnum(t.Schema, e)
 		s.append(&migrate.Change{
@@ -714,6 +2737,14 @@ func (s *state) mayAddEnums(ctx context.Context, t *schema.Table, columns ...*sc
 }
 
 func (s *state) alterEnum(t *schema.Table, from, to *schema.EnumType) error {
+	name := s.enumIdent(t.Schema, from)
+	// Enum was altered by a previous change (e.g. as a schema object).
+	if prev, ok := s.altered[name]; ok {
+		if !sqlx.ValuesEqual(prev.Values, to.Values) {
+			return fmt.Errorf("enum type %s has inconsistent desired state: %q != %q", name, prev.Values, to.Values)
+		}
+		return nil
+	}
 	if len(from.Values) > len(to.Values) {
 		return fmt.Errorf("dropping enum (%q) value is not supported", from.T)
 	}
@@ -722,16 +2753,9 @@ func (s *state) alterEnum(t *schema.Table, from, to *schema.EnumType) error {
 			return fmt.Errorf("replacing or reordering enum (%q) value is not supported: %q != %q", to.T, to.Values, from.Values)
 		}
 	}
-	name := s.enumIdent(t.Schema, from)
-	if prev, ok := s.altered[name]; ok {
-		if !sqlx.ValuesEqual(prev.Values, to.Values) {
-			return fmt.Errorf("enum type %s has inconsistent desired state: %q != %q", name, prev.Values, to.Values)
-		}
-		return nil
-	}
 	s.altered[name] = to
 	for _, v := range to.Values[len(from.Values):] {
-		s.append(&migrate.Change{
+		s.addEnumValue(&migrate.Change{
 			Cmd:     s.Build("ALTER TYPE").P(name, "ADD VALUE", quote(v)).String(),
 			Comment: fmt.Sprintf("add value to enum type: %q", from.T),
 		})
@@ -841,7 +2865,7 @@ func (s *state) column(b *sqlx.Builder, t *schema.Table, c *schema.Column) error
 	s.columnDefault(b, c)
 	for _, attr := range c.Attrs {
 		switch a := attr.(type) {
-		case *schema.Comment:
+		case *schema.Comment, *schema.Renamed:
 		case *schema.Collation:
 			b.P("COLLATE").Ident(a.V)
 		case *Identity, *schema.GeneratedExpr:
@@ -937,12 +2961,15 @@ func (s *state) partAttrs(b *sqlx.Builder, idx *schema.Index, p *schema.IndexPar
 			case !p.Desc && attr.NullsFirst:
 				b.P("NULL FIRST")
 			}
-		// Handled above.
-		case *IndexOpClass, *schema.Collation:
+		// Handled above and below.
+		case *IndexOpClass, *schema.Collation, *ExcludeOp:
 		default:
 			return fmt.Errorf("postgres: unexpected index part attribute: %T", attr)
 		}
 	}
+	if op := (ExcludeOp{}); sqlx.Has(p.Attrs, &op) {
+		b.P("WITH", op.Op)
+	}
 	return nil
 }
 
@@ -980,7 +3007,7 @@ func (s *state) index(b *sqlx.Builder, idx *schema.Index) error {
 	}
 	for _, attr := range idx.Attrs {
 		switch attr.(type) {
-		case *schema.Comment, *ConType, *IndexType, *IndexInclude, *Concurrently, *IndexPredicate, *IndexStorageParams:
+		case *schema.Comment, *schema.Renamed, *ConType, *IndexType, *IndexInclude, *Concurrently, *IndexPredicate, *IndexStorageParams, *Deferrable:
 		default:
 			return fmt.Errorf("postgres: unexpected index attribute: %T", attr)
 		}
@@ -1012,9 +3039,47 @@ func (s *state) fks(b *sqlx.Builder, fks ...*schema.ForeignKey) {
 		if fk.OnDelete != "" {
 			b.P("ON DELETE", string(fk.OnDelete))
 		}
+		deferrable(b, fk.Attrs)
 	})
 }
 
+// constraint writes the definition of a UNIQUE or an EXCLUDE constraint to the builder.
+func (s *state) constraint(b *sqlx.Builder, idx *schema.Index) error {
+	if idx.Name != "" {
+		b.P("CONSTRAINT").Ident(idx.Name)
+	}
+	if !isExclusion(idx.Attrs) {
+		b.P("UNIQUE")
+		if err := s.indexParts(b, idx); err != nil {
+			return err
+		}
+		deferrable(b, idx.Attrs)
+		return nil
+	}
+	b.P("EXCLUDE")
+	if t := (IndexType{}); sqlx.Has(idx.Attrs, &t) && strings.ToUpper(t.T) != IndexTypeBTree {
+		b.P("USING", t.T)
+	}
+	if err := s.indexParts(b, idx); err != nil {
+		return err
+	}
+	if p := (IndexPredicate{}); sqlx.Has(idx.Attrs, &p) {
+		b.P("WHERE", sqlx.MayWrap(p.P))
+	}
+	deferrable(b, idx.Attrs)
+	return nil
+}
+
+// deferrable writes the DEFERRABLE clause of a constraint, if it was set.
+func deferrable(b *sqlx.Builder, attrs []schema.Attr) {
+	if d := (Deferrable{}); sqlx.Has(attrs, &d) {
+		b.P("DEFERRABLE")
+		if d.InitiallyDeferred {
+			b.P("INITIALLY DEFERRED")
+		}
+	}
+}
+
 func (s *state) append(c ...*migrate.Change) {
 	s.Changes = append(s.Changes, c...)
 }
@@ -1098,6 +3163,19 @@ func check(b *sqlx.Builder, c *schema.Check) {
 	}
 }
 
+// isExclusion reports if the index attributes describe an EXCLUDE constraint.
+func isExclusion(attrs []schema.Attr) bool {
+	c := ConType{}
+	return sqlx.Has(attrs, &c) && strings.ToLower(c.T) == "x"
+}
+
+// isTableConstraint reports if the index must be created as a table
+// constraint, because it cannot be expressed by CREATE INDEX. i.e.
+// exclusion constraints and deferrable unique constraints.
+func isTableConstraint(i *schema.Index) bool {
+	return isExclusion(i.Attrs) || isUniqueConstraint(i) && sqlx.Has(i.Attrs, &Deferrable{})
+}
+
 // isUniqueConstraint reports if the index is a valid UNIQUE constraint.
 func isUniqueConstraint(i *schema.Index) bool {
 	if c := (ConType{}); !sqlx.Has(i.Attrs, &c) || !c.IsUnique() || !i.Unique {
@@ -1184,15 +3262,58 @@ func (s *state) schemaPrefix(ns *schema.Schema) string {
 
 // formatType formats the type but takes into account the qualifier.
 func (s *state) formatType(t *schema.Table, c *schema.Column) (string, error) {
-	switch tt := c.Type.Type.(type) {
+	return s.typeString(t.Schema, c.Type.Type)
+}
+
+// typeString formats the given type, and qualifies the type objects
+// it references (e.g. enums or domains) based on the given schema.
+func (s *state) typeString(ns *schema.Schema, t schema.Type) (string, error) {
+	switch tt := t.(type) {
 	case *schema.EnumType:
-		return s.enumIdent(t.Schema, tt), nil
+		return s.enumIdent(ns, tt), nil
+	case *Domain, *CompositeType:
+		return s.typeIdent(ns, tt), nil
 	case *ArrayType:
-		if e, ok := tt.Type.(*schema.EnumType); ok {
-			return s.enumIdent(t.Schema, e) + "[]", nil
+		switch e := tt.Type.(type) {
+		case *schema.EnumType:
+			return s.enumIdent(ns, e) + "[]", nil
+		case *Domain, *CompositeType:
+			return s.typeIdent(ns, e) + "[]", nil
+		}
+	}
+	return FormatType(t)
+}
+
+// typeIdent returns the identifier of a domain or a composite type. Similar to
+// enums, the type is qualified by the planner config, its own schema or the
+// schema of the object that uses it.
+func (s *state) typeIdent(ns *schema.Schema, t schema.Type) string {
+	var (
+		name string
+		q    string
+	)
+	switch t := t.(type) {
+	case *Domain:
+		name = t.T
+		if t.Schema != nil {
+			ns = t.Schema
 		}
+	case *CompositeType:
+		name = t.T
+		if t.Schema != nil {
+			ns = t.Schema
+		}
+	}
+	switch {
+	case s.SchemaQualifier != nil:
+		q = *s.SchemaQualifier
+	case ns != nil:
+		q = ns.Name
+	}
+	if q != "" {
+		return fmt.Sprintf("%q.%q", q, name)
 	}
-	return FormatType(c.Type.Type)
+	return strconv.Quote(name)
 }
 
 func hasEnumType(c *schema.Column) (*schema.EnumType, bool) {
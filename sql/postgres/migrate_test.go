@@ -6,6 +6,7 @@ package postgres
 
 import (
 	"context"
+	"math"
 	"strconv"
 	"testing"
 
@@ -21,10 +22,103 @@ func TestPlanChanges(t *testing.T) {
 	tests := []struct {
 		changes  []schema.Change
 		options  []migrate.PlanOption
+		version  string
 		mock     func(mock)
 		wantPlan *migrate.Plan
 		wantErr  bool
 	}{
+		// Constraints are added as NOT VALID and validated separately.
+		{
+			changes: func() []schema.Change {
+				users := schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("age", "int"))
+				pets := schema.NewTable("pets").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("owner_id", "int"))
+				return []schema.Change{
+					&schema.ModifyTable{
+						T: pets,
+						Changes: []schema.Change{
+							&schema.AddForeignKey{F: schema.NewForeignKey("owner").SetTable(pets).AddColumns(pets.Columns[1]).SetRefTable(users).AddRefColumns(users.Columns[0])},
+							// Unnamed checks cannot be validated separately.
+							&schema.AddCheck{C: schema.NewCheck().SetExpr("id > 0")},
+						},
+					},
+					&schema.ModifyTable{
+						T: users,
+						Changes: []schema.Change{
+							&schema.AddCheck{C: schema.NewCheck().SetName("positive_age").SetExpr("age > 0")},
+							// Constraints that are marked as NOT VALID are not validated.
+							&schema.AddCheck{C: schema.NewCheck().SetName("adult").SetExpr("age > 18").AddAttrs(&NotValid{})},
+						},
+					},
+				}
+			}(),
+			options: []migrate.PlanOption{
+				func(o *migrate.PlanOptions) { o.DeferValidation = true },
+			},
+			// Validations run outside of the transaction that added the constraints.
+			wantPlan: &migrate.Plan{
+				Reversible:    false,
+				Transactional: false,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `ALTER TABLE "public"."users" ADD CONSTRAINT "positive_age" CHECK (age > 0) NOT VALID, ADD CONSTRAINT "adult" CHECK (age > 18) NOT VALID`,
+						Reverse: `ALTER TABLE "public"."users" DROP CONSTRAINT "adult", DROP CONSTRAINT "positive_age"`,
+					},
+					{
+						Cmd: `ALTER TABLE "public"."users" VALIDATE CONSTRAINT "positive_age"`,
+					},
+					{
+						Cmd: `ALTER TABLE "public"."pets" ADD CONSTRAINT "owner" FOREIGN KEY ("owner_id") REFERENCES "public"."users" ("id") NOT VALID, ADD CHECK (id > 0)`,
+					},
+					{
+						Cmd: `ALTER TABLE "public"."pets" VALIDATE CONSTRAINT "owner"`,
+					},
+				},
+			},
+		},
+		// Plans without deferred validations remain transactional.
+		{
+			changes: func() []schema.Change {
+				users := schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("age", "int"))
+				return []schema.Change{
+					&schema.ModifyTable{
+						T: users,
+						Changes: []schema.Change{
+							&schema.AddCheck{C: schema.NewCheck().SetExpr("age > 0")},
+						},
+					},
+				}
+			}(),
+			options: []migrate.PlanOption{
+				func(o *migrate.PlanOptions) { o.DeferValidation = true },
+			},
+			wantPlan: &migrate.Plan{
+				Reversible:    false,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{Cmd: `ALTER TABLE "public"."users" ADD CHECK (age > 0)`},
+				},
+			},
+		},
+		// Consecutive changes of the same table are merged into one statement.
+		{
+			changes: func() []schema.Change {
+				users := schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("id", "int"))
+				return []schema.Change{
+					&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.AddColumn{C: schema.NewIntColumn("age", "int")}}},
+					&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.AddCheck{C: schema.NewCheck().SetName("positive_age").SetExpr("age > 0")}}},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `ALTER TABLE "public"."users" ADD COLUMN "age" integer NOT NULL, ADD CONSTRAINT "positive_age" CHECK (age > 0)`,
+						Reverse: `ALTER TABLE "public"."users" DROP CONSTRAINT "positive_age", DROP COLUMN "age"`,
+					},
+				},
+			},
+		},
 		{
 			changes: []schema.Change{
 				&schema.AddSchema{S: schema.New("test"), Extra: []schema.Clause{&schema.IfNotExists{}}},
@@ -658,6 +752,23 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		// Enum values cannot be added inside a transaction block before PostgreSQL 12.
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{
+					From: &schema.EnumType{T: "state", Schema: schema.New("public"), Values: []string{"on", "off"}},
+					To:   &schema.EnumType{T: "state", Schema: schema.New("public"), Values: []string{"on", "off", "unknown"}},
+				},
+			},
+			version: "110000",
+			wantPlan: &migrate.Plan{
+				Reversible:    false,
+				Transactional: false,
+				Changes: []*migrate.Change{
+					{Cmd: `ALTER TYPE "public"."state" ADD VALUE 'unknown'`},
+				},
+			},
+		},
 		// Modify column type and drop comment.
 		{
 			changes: []schema.Change{
@@ -888,14 +999,14 @@ func TestPlanChanges(t *testing.T) {
 				Reversible:    true,
 				Transactional: true,
 				Changes: []*migrate.Change{
-					{
-						Cmd:     `ALTER TABLE "s1"."t1" ADD COLUMN "c" integer NOT NULL`,
-						Reverse: `ALTER TABLE "s1"."t1" DROP COLUMN "c"`,
-					},
 					{
 						Cmd:     `ALTER TABLE "s1"."t1" RENAME COLUMN "a" TO "b"`,
 						Reverse: `ALTER TABLE "s1"."t1" RENAME COLUMN "b" TO "a"`,
 					},
+					{
+						Cmd:     `ALTER TABLE "s1"."t1" ADD COLUMN "c" integer NOT NULL`,
+						Reverse: `ALTER TABLE "s1"."t1" DROP COLUMN "c"`,
+					},
 				},
 			},
 		},
@@ -922,6 +1033,55 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		// Renames are planned before the alterations that use the new names.
+		{
+			changes: []schema.Change{
+				&schema.RenameTable{
+					From: schema.NewTable("t1").SetSchema(schema.New("s1")),
+					To:   schema.NewTable("t2").SetSchema(schema.New("s1")),
+				},
+				&schema.ModifyTable{
+					T: schema.NewTable("t2").SetSchema(schema.New("s1")),
+					Changes: []schema.Change{
+						&schema.ModifyColumn{
+							From:   schema.NewIntColumn("b", "int"),
+							To:     schema.NewIntColumn("b", "bigint"),
+							Change: schema.ChangeType,
+						},
+						&schema.RenameColumn{
+							From: schema.NewIntColumn("a", "int"),
+							To:   schema.NewIntColumn("b", "int"),
+						},
+						&schema.RenameIndex{
+							From: schema.NewIndex("b"),
+							To:   schema.NewIndex("c"),
+						},
+					},
+				},
+			},
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `ALTER TABLE "s1"."t1" RENAME TO "s1"."t2"`,
+						Reverse: `ALTER TABLE "s1"."t2" RENAME TO "s1"."t1"`,
+					},
+					{
+						Cmd:     `ALTER TABLE "s1"."t2" RENAME COLUMN "a" TO "b"`,
+						Reverse: `ALTER TABLE "s1"."t2" RENAME COLUMN "b" TO "a"`,
+					},
+					{
+						Cmd:     `ALTER INDEX "b" RENAME TO "c"`,
+						Reverse: `ALTER INDEX "c" RENAME TO "b"`,
+					},
+					{
+						Cmd:     `ALTER TABLE "s1"."t2" ALTER COLUMN "b" TYPE bigint`,
+						Reverse: `ALTER TABLE "s1"."t2" ALTER COLUMN "b" TYPE integer`,
+					},
+				},
+			},
+		},
 		// Invalid serial type.
 		{
 			changes: []schema.Change{
@@ -1157,6 +1317,823 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		// Views are dropped before and created after tables.
+		{
+			changes: func() []schema.Change {
+				public := schema.New("public")
+				users := schema.NewTable("users").SetSchema(public).AddColumns(schema.NewIntColumn("id", "int"))
+				active := schema.NewView("active", "SELECT id FROM users").SetSchema(public).SetCheckOption(schema.ViewCheckOptionLocal).SetComment("active users")
+				return []schema.Change{
+					&schema.AddView{V: active},
+					&schema.AddTable{T: users},
+					&schema.DropView{V: schema.NewView("old", "SELECT 1;").SetSchema(public), Extra: []schema.Clause{&schema.IfExists{}}},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `DROP VIEW IF EXISTS "public"."old"`,
+						Reverse: `CREATE VIEW "public"."old" AS SELECT 1`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL)`,
+						Reverse: `DROP TABLE "public"."users"`,
+					},
+					{
+						Cmd:     `CREATE VIEW "public"."active" AS SELECT id FROM users WITH LOCAL CHECK OPTION`,
+						Reverse: `DROP VIEW "public"."active"`,
+					},
+					{
+						Cmd:     `COMMENT ON VIEW "public"."active" IS 'active users'`,
+						Reverse: `COMMENT ON VIEW "public"."active" IS ''`,
+					},
+				},
+			},
+		},
+		{
+			changes: func() []schema.Change {
+				from := schema.NewView("v", "SELECT id FROM users").AddColumns(schema.NewIntColumn("id", "int"))
+				to := schema.NewView("v", "SELECT id, name FROM users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "text"))
+				return []schema.Change{
+					&schema.ModifyView{From: from, To: to},
+					&schema.ModifyView{From: to, To: from},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE OR REPLACE VIEW "v" AS SELECT id, name FROM users`,
+						Reverse: `CREATE OR REPLACE VIEW "v" AS SELECT id FROM users`,
+					},
+					{
+						Cmd:     `DROP VIEW "v"`,
+						Reverse: `CREATE VIEW "v" AS SELECT id, name FROM users`,
+					},
+					{
+						Cmd:     `CREATE VIEW "v" AS SELECT id FROM users`,
+						Reverse: `DROP VIEW "v"`,
+					},
+				},
+			},
+		},
+		// Views that select from columns that are dropped are recreated around the table changes.
+		{
+			changes: func() []schema.Change {
+				name := schema.NewStringColumn("name", "text")
+				users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), name)
+				from := schema.NewView("v", "SELECT id, name FROM users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "text"))
+				to := schema.NewView("v", "SELECT id FROM users").AddColumns(schema.NewIntColumn("id", "int"))
+				return []schema.Change{
+					&schema.ModifyView{From: from, To: to},
+					&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.DropColumn{C: name}}},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `DROP VIEW "v"`,
+						Reverse: `CREATE VIEW "v" AS SELECT id, name FROM users`,
+					},
+					{
+						Cmd:     `ALTER TABLE "users" DROP COLUMN "name"`,
+						Reverse: `ALTER TABLE "users" ADD COLUMN "name" text NOT NULL`,
+					},
+					{
+						Cmd:     `CREATE VIEW "v" AS SELECT id FROM users`,
+						Reverse: `DROP VIEW "v"`,
+					},
+				},
+			},
+		},
+		// Materialized views are recreated along with their indexes.
+		{
+			changes: func() []schema.Change {
+				public := schema.New("public")
+				from := schema.NewMaterializedView("stats", "SELECT id FROM users").SetSchema(public).AddColumns(schema.NewIntColumn("id", "int"))
+				to := schema.NewMaterializedView("stats", "SELECT id, name FROM users").SetSchema(public).AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "text"))
+				to.AddIndexes(schema.NewUniqueIndex("stats_id").AddColumns(to.Columns[0]))
+				added := schema.NewMaterializedView("totals", "SELECT count(*) AS c FROM users").SetSchema(public).AddColumns(schema.NewIntColumn("c", "bigint"))
+				added.AddIndexes(schema.NewIndex("totals_c").AddColumns(added.Columns[0])).SetComment("user totals")
+				return []schema.Change{
+					&schema.AddView{V: added},
+					&schema.ModifyView{From: from, To: to},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE MATERIALIZED VIEW "public"."totals" AS SELECT count(*) AS c FROM users`,
+						Reverse: `DROP MATERIALIZED VIEW "public"."totals"`,
+					},
+					{
+						Cmd:     `COMMENT ON MATERIALIZED VIEW "public"."totals" IS 'user totals'`,
+						Reverse: `COMMENT ON MATERIALIZED VIEW "public"."totals" IS ''`,
+					},
+					{
+						Cmd:     `CREATE INDEX "totals_c" ON "public"."totals" ("c")`,
+						Reverse: `DROP INDEX "public"."totals_c"`,
+					},
+					{
+						Cmd:     `DROP MATERIALIZED VIEW "public"."stats"`,
+						Reverse: `CREATE MATERIALIZED VIEW "public"."stats" AS SELECT id FROM users`,
+					},
+					{
+						Cmd:     `CREATE MATERIALIZED VIEW "public"."stats" AS SELECT id, name FROM users`,
+						Reverse: `DROP MATERIALIZED VIEW "public"."stats"`,
+					},
+					{
+						Cmd:     `CREATE UNIQUE INDEX "stats_id" ON "public"."stats" ("id")`,
+						Reverse: `DROP INDEX "public"."stats_id"`,
+					},
+				},
+			},
+		},
+		// Functions are created before and dropped after tables.
+		{
+			changes: func() []schema.Change {
+				public := schema.New("public")
+				users := schema.NewTable("users").SetSchema(public).AddColumns(schema.NewIntColumn("id", "int"))
+				add := schema.NewFunc("add").
+					SetSchema(public).
+					AddArgs(
+						schema.NewFuncArg("a", &schema.IntegerType{T: "int"}),
+						schema.NewFuncArg("b", &schema.IntegerType{T: "int"}).SetDefault(&schema.Literal{V: "1"}),
+					).
+					SetReturnType(&schema.IntegerType{T: "int"}).
+					SetLang("sql").
+					SetBody("SELECT a + b").
+					SetComment("adds numbers").
+					AddAttrs(&Volatility{V: VolatilityImmutable})
+				return []schema.Change{
+					&schema.DropFunc{F: schema.NewFunc("old").SetSchema(public).SetReturnType(&UserDefinedType{T: "trigger"}).SetLang("plpgsql").SetBody("BEGIN RETURN NEW; END"), Extra: []schema.Clause{&schema.IfExists{}}},
+					&schema.AddTable{T: users},
+					&schema.AddFunc{F: add},
+					&schema.AddProc{P: schema.NewProc("archive").SetSchema(public).AddArgs(schema.NewFuncArg("status", &schema.StringType{T: "text"}).SetMode(schema.FuncArgModeInOut)).SetLang("plpgsql").SetBody("BEGIN status := '$$'; END")},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE FUNCTION "public"."add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE sql IMMUTABLE AS $$SELECT a + b$$`,
+						Reverse: `DROP FUNCTION "public"."add" (integer, integer)`,
+					},
+					{
+						Cmd:     `COMMENT ON FUNCTION "public"."add" (integer, integer) IS 'adds numbers'`,
+						Reverse: `COMMENT ON FUNCTION "public"."add" (integer, integer) IS ''`,
+					},
+					{
+						Cmd:     `CREATE PROCEDURE "public"."archive" (INOUT "status" text) LANGUAGE plpgsql AS $body1$BEGIN status := '$$'; END$body1$`,
+						Reverse: `DROP PROCEDURE "public"."archive" (text)`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL)`,
+						Reverse: `DROP TABLE "public"."users"`,
+					},
+					{
+						Cmd:     `DROP FUNCTION IF EXISTS "public"."old" ()`,
+						Reverse: `CREATE FUNCTION "public"."old" () RETURNS trigger LANGUAGE plpgsql AS $$BEGIN RETURN NEW; END$$`,
+					},
+				},
+			},
+		},
+		{
+			changes: func() []schema.Change {
+				from := schema.NewFunc("f").AddArgs(schema.NewFuncArg("a", &schema.IntegerType{T: "int"})).SetReturnType(&schema.IntegerType{T: "int"}).SetLang("sql").SetBody("SELECT a")
+				body := schema.NewFunc("f").AddArgs(schema.NewFuncArg("a", &schema.IntegerType{T: "int"})).SetReturnType(&schema.IntegerType{T: "int"}).SetLang("sql").SetBody("SELECT a + 1")
+				ret := schema.NewFunc("f").AddArgs(schema.NewFuncArg("a", &schema.IntegerType{T: "int"})).SetReturnType(&schema.IntegerType{T: "bigint"}).SetLang("sql").SetBody("SELECT a")
+				return []schema.Change{
+					&schema.ModifyFunc{From: from, To: body},
+					&schema.ModifyFunc{From: from, To: ret},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE OR REPLACE FUNCTION "f" ("a" integer) RETURNS integer LANGUAGE sql AS $$SELECT a + 1$$`,
+						Reverse: `CREATE OR REPLACE FUNCTION "f" ("a" integer) RETURNS integer LANGUAGE sql AS $$SELECT a$$`,
+					},
+					{
+						Cmd:     `DROP FUNCTION "f" (integer)`,
+						Reverse: `CREATE FUNCTION "f" ("a" integer) RETURNS integer LANGUAGE sql AS $$SELECT a$$`,
+					},
+					{
+						Cmd:     `CREATE FUNCTION "f" ("a" integer) RETURNS bigint LANGUAGE sql AS $$SELECT a$$`,
+						Reverse: `DROP FUNCTION "f" (integer)`,
+					},
+				},
+			},
+		},
+		// Triggers are created after their tables, and replaced by dropping and creating them again.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				audit := schema.NewFunc("audit").SetSchema(s)
+				users := schema.NewTable("users").SetSchema(s).AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "text"))
+				users.AddTriggers(
+					schema.NewTrigger("users_audit").
+						SetActionTime(schema.TriggerTimeAfter).
+						AddEvents(
+							schema.TriggerEvent{Name: schema.TriggerEventInsert},
+							schema.TriggerEvent{Name: schema.TriggerEventUpdate, Columns: users.Columns[1:]},
+						).
+						SetFor(schema.TriggerForRow).
+						SetFunc(audit),
+				)
+				pets := schema.NewTable("pets").SetSchema(s).AddColumns(schema.NewIntColumn("id", "int"))
+				from := schema.NewTrigger("pets_audit").SetTable(pets).SetActionTime(schema.TriggerTimeBefore).AddEvents(schema.TriggerEvent{Name: schema.TriggerEventDelete}).SetFunc(audit)
+				to := schema.NewTrigger("pets_audit").SetTable(pets).SetActionTime(schema.TriggerTimeBefore).AddEvents(schema.TriggerEvent{Name: schema.TriggerEventTruncate}).SetFunc(audit)
+				return []schema.Change{
+					&schema.AddTable{T: users},
+					&schema.ModifyTable{
+						T:       pets,
+						Changes: []schema.Change{&schema.ModifyTrigger{From: from, To: to}},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL, "name" text NOT NULL)`,
+						Reverse: `DROP TABLE "public"."users"`,
+					},
+					{
+						Cmd:     `CREATE TRIGGER "users_audit" AFTER INSERT OR UPDATE OF "name" ON "public"."users" FOR EACH ROW EXECUTE FUNCTION "public"."audit" ()`,
+						Reverse: `DROP TRIGGER "users_audit" ON "public"."users"`,
+					},
+					{
+						Cmd:     `DROP TRIGGER "pets_audit" ON "public"."pets"`,
+						Reverse: `CREATE TRIGGER "pets_audit" BEFORE DELETE ON "public"."pets" EXECUTE FUNCTION "public"."audit" ()`,
+					},
+					{
+						Cmd:     `CREATE TRIGGER "pets_audit" BEFORE TRUNCATE ON "public"."pets" EXECUTE FUNCTION "public"."audit" ()`,
+						Reverse: `DROP TRIGGER "pets_audit" ON "public"."pets"`,
+					},
+				},
+			},
+		},
+		// Sequences are created before the tables, owned after their creation, and dropped at the end.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				users := schema.NewTable("users").SetSchema(s).AddColumns(schema.NewIntColumn("id", "bigint"))
+				users.Columns[0].Default = &schema.RawExpr{X: "nextval('users_seq')"}
+				add := &Sequence{Name: "users_seq", Schema: s, Type: &schema.IntegerType{T: TypeInteger}, Start: 100, Cache: 10}
+				add.Owner.T, add.Owner.C = users, users.Columns[0]
+				from := &Sequence{Name: "counter", Schema: s, Type: &schema.IntegerType{T: TypeBigInt}, Start: 1, Increment: 1, Min: 1, Max: math.MaxInt64, Cache: 1}
+				to := &Sequence{Name: "counter", Schema: s, Increment: 2, Max: 1000, Cycle: true}
+				return []schema.Change{
+					&schema.AddTable{T: users},
+					&schema.DropObject{O: &Sequence{Name: "old", Schema: s, Increment: -1}},
+					&schema.AddObject{O: add},
+					&schema.ModifyObject{From: from, To: to},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE SEQUENCE "public"."users_seq" AS integer START WITH 100 CACHE 10`,
+						Reverse: `DROP SEQUENCE "public"."users_seq"`,
+					},
+					{
+						Cmd:     `ALTER SEQUENCE "public"."counter" INCREMENT BY 2 MAXVALUE 1000 CYCLE`,
+						Reverse: `ALTER SEQUENCE "public"."counter" INCREMENT BY 1 MAXVALUE 9223372036854775807 NO CYCLE`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."users" ("id" bigint NOT NULL DEFAULT nextval('users_seq'))`,
+						Reverse: `DROP TABLE "public"."users"`,
+					},
+					{
+						Cmd:     `DROP SEQUENCE "public"."old"`,
+						Reverse: `CREATE SEQUENCE "public"."old" INCREMENT BY -1`,
+					},
+					{
+						Cmd:     `ALTER SEQUENCE "public"."users_seq" OWNED BY "public"."users"."id"`,
+						Reverse: `ALTER SEQUENCE "public"."users_seq" OWNED BY NONE`,
+					},
+				},
+			},
+		},
+		// Extensions are installed before the tables that depend on them, and removed after them.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				users := schema.NewTable("users").SetSchema(s).AddColumns(schema.NewColumn("email").SetType(&UserDefinedType{T: "citext"}))
+				return []schema.Change{
+					&schema.DropObject{O: &Extension{Name: "uuid-ossp", Schema: s, Version: "1.1"}},
+					&schema.AddTable{T: users},
+					&schema.AddObject{O: &Extension{Name: "citext", Schema: s}, Extra: []schema.Clause{&schema.IfNotExists{}}},
+					&schema.ModifyObject{From: &Extension{Name: "postgis", Schema: s, Version: "3.1.0"}, To: &Extension{Name: "postgis", Schema: s, Version: "3.2.0"}},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE EXTENSION IF NOT EXISTS "citext" WITH SCHEMA "public"`,
+						Reverse: `DROP EXTENSION "citext"`,
+					},
+					{
+						Cmd:     `ALTER EXTENSION "postgis" UPDATE TO '3.2.0'`,
+						Reverse: `ALTER EXTENSION "postgis" UPDATE TO '3.1.0'`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."users" ("email" citext NOT NULL)`,
+						Reverse: `DROP TABLE "public"."users"`,
+					},
+					{
+						Cmd:     `DROP EXTENSION "uuid-ossp"`,
+						Reverse: `CREATE EXTENSION "uuid-ossp" WITH SCHEMA "public" VERSION '1.1'`,
+					},
+				},
+			},
+		},
+		// Domains and composite types are created before the tables that use them.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				zip := &Domain{T: "zip", Schema: s, Type: &schema.StringType{T: TypeVarChar, Size: 10}, Null: true}
+				posint := &Domain{T: "posint", Schema: s, Type: &schema.IntegerType{T: TypeInteger}, Default: &schema.Literal{V: "1"}, Checks: []*schema.Check{{Name: "posint_check", Expr: "VALUE > 0"}}}
+				address := &CompositeType{T: "address", Schema: s, Fields: []*schema.Column{schema.NewStringColumn("street", TypeText), schema.NewColumn("zip").SetType(zip)}}
+				users := schema.NewTable("users").SetSchema(s).AddColumns(
+					schema.NewColumn("age").SetType(posint),
+					schema.NewNullColumn("address").SetType(address),
+				)
+				return []schema.Change{
+					&schema.DropObject{O: &Domain{T: "old", Schema: s, Type: &schema.StringType{T: TypeText}, Null: true}},
+					&schema.AddObject{O: zip},
+					&schema.AddObject{O: posint},
+					&schema.AddObject{O: address},
+					&schema.AddTable{T: users},
+					&schema.ModifyObject{
+						From: &Domain{T: "rating", Schema: s, Type: &schema.IntegerType{T: TypeInteger}, Null: true},
+						To:   &Domain{T: "rating", Schema: s, Type: &schema.IntegerType{T: TypeInteger}, Default: &schema.Literal{V: "0"}, Checks: []*schema.Check{{Name: "rating_check", Expr: "VALUE <= 5"}}},
+					},
+					&schema.ModifyObject{
+						From: &CompositeType{T: "point", Schema: s, Fields: []*schema.Column{schema.NewIntColumn("x", TypeInteger), schema.NewIntColumn("y", TypeInteger), schema.NewIntColumn("z", TypeInteger)}},
+						To:   &CompositeType{T: "point", Schema: s, Fields: []*schema.Column{schema.NewIntColumn("x", TypeBigInt), schema.NewIntColumn("y", TypeInteger), schema.NewIntColumn("w", TypeInteger)}},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE DOMAIN "public"."zip" AS character varying(10)`,
+						Reverse: `DROP DOMAIN "public"."zip"`,
+					},
+					{
+						Cmd:     `CREATE DOMAIN "public"."posint" AS integer DEFAULT 1 NOT NULL CONSTRAINT "posint_check" CHECK (VALUE > 0)`,
+						Reverse: `DROP DOMAIN "public"."posint"`,
+					},
+					{
+						Cmd:     `CREATE TYPE "public"."address" AS ("street" text, "zip" "public"."zip")`,
+						Reverse: `DROP TYPE "public"."address"`,
+					},
+					{
+						Cmd:     `ALTER DOMAIN "public"."rating" SET DEFAULT 0`,
+						Reverse: `ALTER DOMAIN "public"."rating" DROP DEFAULT`,
+					},
+					{
+						Cmd:     `ALTER DOMAIN "public"."rating" SET NOT NULL`,
+						Reverse: `ALTER DOMAIN "public"."rating" DROP NOT NULL`,
+					},
+					{
+						Cmd:     `ALTER DOMAIN "public"."rating" ADD CONSTRAINT "rating_check" CHECK (VALUE <= 5)`,
+						Reverse: `ALTER DOMAIN "public"."rating" DROP CONSTRAINT "rating_check"`,
+					},
+					{
+						Cmd:     `ALTER TYPE "public"."point" ADD ATTRIBUTE "w" integer, DROP ATTRIBUTE "z", ALTER ATTRIBUTE "x" TYPE bigint`,
+						Reverse: `ALTER TYPE "public"."point" ADD ATTRIBUTE "z" integer, DROP ATTRIBUTE "w", ALTER ATTRIBUTE "x" TYPE integer`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."users" ("age" "public"."posint" NOT NULL, "address" "public"."address" NULL)`,
+						Reverse: `DROP TABLE "public"."users"`,
+					},
+					{
+						Cmd:     `DROP DOMAIN "public"."old"`,
+						Reverse: `CREATE DOMAIN "public"."old" AS text`,
+					},
+				},
+			},
+		},
+		// Row-level security and policies.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				accounts := schema.NewTable("accounts").SetSchema(s).
+					AddColumns(schema.NewIntColumn("id", TypeInteger)).
+					AddAttrs(
+						&RowSecurity{Enabled: true},
+						&Policy{Name: "isolation", As: PolicyAsRestrictive, Roles: []string{"app", "public"}, Using: "id = current_setting('app.id')::int"},
+					)
+				users := schema.NewTable("users").SetSchema(s)
+				return []schema.Change{
+					&schema.AddTable{T: accounts},
+					&schema.ModifyTable{
+						T: users,
+						Changes: []schema.Change{
+							&schema.ModifyAttr{From: &RowSecurity{Enabled: true}, To: &RowSecurity{Forced: true}},
+							&schema.DropAttr{A: &Policy{Name: "old", Using: "true"}},
+							&schema.ModifyAttr{
+								From: &Policy{Name: "writes", Command: PolicyCmdInsert, Check: "true"},
+								To:   &Policy{Name: "writes", Command: PolicyCmdUpdate, Using: "true", Check: "(id > 0)"},
+							},
+						},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE TABLE "public"."accounts" ("id" integer NOT NULL)`,
+						Reverse: `DROP TABLE "public"."accounts"`,
+					},
+					{
+						Cmd:     `ALTER TABLE "public"."accounts" ENABLE ROW LEVEL SECURITY`,
+						Reverse: `ALTER TABLE "public"."accounts" DISABLE ROW LEVEL SECURITY`,
+					},
+					{
+						Cmd:     `CREATE POLICY "isolation" ON "public"."accounts" AS RESTRICTIVE TO "app", PUBLIC USING (id = current_setting('app.id')::int)`,
+						Reverse: `DROP POLICY "isolation" ON "public"."accounts"`,
+					},
+					{
+						Cmd:     `DROP POLICY "old" ON "public"."users"`,
+						Reverse: `CREATE POLICY "old" ON "public"."users" USING (true)`,
+					},
+					{
+						Cmd:     `DROP POLICY "writes" ON "public"."users"`,
+						Reverse: `CREATE POLICY "writes" ON "public"."users" FOR INSERT WITH CHECK (true)`,
+					},
+					{
+						Cmd:     `CREATE POLICY "writes" ON "public"."users" FOR UPDATE USING (true) WITH CHECK (id > 0)`,
+						Reverse: `DROP POLICY "writes" ON "public"."users"`,
+					},
+					{
+						Cmd:     `ALTER TABLE "public"."users" DISABLE ROW LEVEL SECURITY`,
+						Reverse: `ALTER TABLE "public"."users" ENABLE ROW LEVEL SECURITY`,
+					},
+					{
+						Cmd:     `ALTER TABLE "public"."users" FORCE ROW LEVEL SECURITY`,
+						Reverse: `ALTER TABLE "public"."users" NO FORCE ROW LEVEL SECURITY`,
+					},
+				},
+			},
+		},
+		// Deferrable and exclusion constraints.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				rooms := schema.NewTable("rooms").SetSchema(s).AddColumns(schema.NewIntColumn("id", TypeInteger))
+				bookings := schema.NewTable("bookings").SetSchema(s).
+					AddColumns(schema.NewIntColumn("id", TypeInteger), schema.NewIntColumn("room", TypeInteger), schema.NewIntColumn("slot", TypeInteger))
+				bookings.SetPrimaryKey(schema.NewPrimaryKey(bookings.Columns[0]).AddAttrs(&Deferrable{})).
+					AddIndexes(
+						schema.NewUniqueIndex("bookings_slot_key").AddColumns(bookings.Columns[2]).AddAttrs(&ConType{T: "u"}, &Deferrable{InitiallyDeferred: true}),
+						schema.NewIndex("no_overlap").
+							AddParts(
+								schema.NewColumnPart(bookings.Columns[1]).AddAttrs(&ExcludeOp{Op: "="}),
+								schema.NewColumnPart(bookings.Columns[2]).AddAttrs(&ExcludeOp{Op: "="}),
+							).
+							AddAttrs(&ConType{T: "x"}, &IndexType{T: IndexTypeGiST}, &IndexPredicate{P: "id > 0"}),
+					).
+					AddForeignKeys(schema.NewForeignKey("room_fk").AddColumns(bookings.Columns[1]).SetRefTable(rooms).AddRefColumns(rooms.Columns[0]).AddAttrs(&Deferrable{InitiallyDeferred: true}))
+				fk := schema.NewForeignKey("user_fk").SetTable(rooms).AddColumns(schema.NewIntColumn("uid", TypeInteger)).SetRefTable(rooms).AddRefColumns(rooms.Columns[0])
+				return []schema.Change{
+					&schema.AddTable{T: bookings},
+					&schema.ModifyTable{
+						T: rooms,
+						Changes: []schema.Change{
+							&schema.ModifyForeignKey{From: fk, To: schema.NewForeignKey("user_fk").SetTable(rooms).AddColumns(fk.Columns...).SetRefTable(rooms).AddRefColumns(rooms.Columns[0]).AddAttrs(&Deferrable{}), Change: schema.ChangeAttr},
+							&schema.AddIndex{I: schema.NewIndex("rooms_excl").AddParts(schema.NewColumnPart(rooms.Columns[0]).AddAttrs(&ExcludeOp{Op: "="})).AddAttrs(&ConType{T: "x"})},
+							&schema.DropIndex{I: schema.NewIndex("old_excl").AddParts(schema.NewColumnPart(rooms.Columns[0]).AddAttrs(&ExcludeOp{Op: "="})).AddAttrs(&ConType{T: "x"}, &IndexType{T: IndexTypeGiST})},
+						},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `ALTER TABLE "public"."rooms" ALTER CONSTRAINT "user_fk" DEFERRABLE INITIALLY IMMEDIATE, ADD CONSTRAINT "rooms_excl" EXCLUDE ("id" WITH =), DROP CONSTRAINT "old_excl"`,
+						Reverse: `ALTER TABLE "public"."rooms" ADD CONSTRAINT "old_excl" EXCLUDE USING GIST ("id" WITH =), DROP CONSTRAINT "rooms_excl", ALTER CONSTRAINT "user_fk" NOT DEFERRABLE`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."bookings" ("id" integer NOT NULL, "room" integer NOT NULL, "slot" integer NOT NULL, PRIMARY KEY ("id") DEFERRABLE, CONSTRAINT "bookings_slot_key" UNIQUE ("slot") DEFERRABLE INITIALLY DEFERRED, CONSTRAINT "no_overlap" EXCLUDE USING GIST ("room" WITH =, "slot" WITH =) WHERE (id > 0), CONSTRAINT "room_fk" FOREIGN KEY ("room") REFERENCES "public"."rooms" ("id") DEFERRABLE INITIALLY DEFERRED)`,
+						Reverse: `DROP TABLE "public"."bookings"`,
+					},
+				},
+			},
+		},
+		// Table persistence, tablespace and storage parameters.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				users := schema.NewTable("users").SetSchema(s).AddColumns(schema.NewIntColumn("id", TypeInteger))
+				return []schema.Change{
+					&schema.AddTable{T: schema.NewTable("logs").SetSchema(s).AddColumns(schema.NewIntColumn("id", TypeInteger)).
+						AddAttrs(&Unlogged{}, &Tablespace{Name: "fast"}, &TableStorageParams{Params: map[string]string{"fillfactor": "70", "autovacuum_enabled": "false"}})},
+					&schema.ModifyTable{
+						T: users,
+						Changes: []schema.Change{
+							&schema.AddAttr{A: &Unlogged{}},
+							&schema.DropAttr{A: &Tablespace{Name: "slow"}},
+							&schema.ModifyAttr{
+								From: &TableStorageParams{Params: map[string]string{"fillfactor": "70", "autovacuum_enabled": "false"}},
+								To:   &TableStorageParams{Params: map[string]string{"fillfactor": "80"}},
+							},
+						},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE UNLOGGED TABLE "public"."logs" ("id" integer NOT NULL) WITH (autovacuum_enabled = false, fillfactor = 70) TABLESPACE "fast"`,
+						Reverse: `DROP TABLE "public"."logs"`,
+					},
+					{
+						Cmd:     `ALTER TABLE "public"."users" SET UNLOGGED, SET TABLESPACE "pg_default", SET (fillfactor = 80), RESET (autovacuum_enabled)`,
+						Reverse: `ALTER TABLE "public"."users" SET (autovacuum_enabled = false, fillfactor = 70), SET TABLESPACE "slow", SET LOGGED`,
+					},
+				},
+			},
+		},
+		// Inheriting tables are created after their parent tables, and their inherited columns are omitted.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				cities := schema.NewTable("cities").SetSchema(s).AddColumns(schema.NewStringColumn("name", "text"))
+				regions := schema.NewTable("regions").SetSchema(s).AddColumns(schema.NewStringColumn("region", "text"))
+				return []schema.Change{
+					&schema.AddTable{
+						T: schema.NewTable("capitals").SetSchema(s).
+							AddColumns(schema.NewStringColumn("name", "text").AddAttrs(&Inherited{}), schema.NewStringColumn("state", "text")).
+							AddAttrs(&Inherits{Parents: []*schema.Table{cities}}),
+					},
+					&schema.AddTable{T: cities},
+					&schema.ModifyTable{
+						T: schema.NewTable("towns").SetSchema(s),
+						Changes: []schema.Change{
+							&schema.ModifyAttr{From: &Inherits{Parents: []*schema.Table{cities}}, To: &Inherits{Parents: []*schema.Table{regions}}},
+						},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE TABLE "public"."cities" ("name" text NOT NULL)`,
+						Reverse: `DROP TABLE "public"."cities"`,
+					},
+					{
+						Cmd:     `ALTER TABLE "public"."towns" NO INHERIT "public"."cities", INHERIT "public"."regions"`,
+						Reverse: `ALTER TABLE "public"."towns" NO INHERIT "public"."regions", INHERIT "public"."cities"`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."capitals" ("state" text NOT NULL) INHERITS ("public"."cities")`,
+						Reverse: `DROP TABLE "public"."capitals"`,
+					},
+				},
+			},
+		},
+		// Partitions are created after their parent tables, and dropped before them.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				logs := schema.NewTable("logs").SetSchema(s).
+					AddColumns(schema.NewIntColumn("id", TypeInteger)).
+					AddAttrs(&Partition{T: PartitionTypeRange, Parts: []*PartitionPart{{C: schema.NewColumn("id")}}})
+				events := schema.NewTable("events").SetSchema(s).AddColumns(schema.NewIntColumn("id", TypeInteger))
+				return []schema.Change{
+					&schema.AddTable{T: schema.NewTable("logs_2020").SetSchema(s).AddColumns(schema.NewIntColumn("id", TypeInteger)).AddAttrs(&PartitionOf{Parent: logs, Bound: "FROM (1) TO (10)"})},
+					&schema.AddTable{T: schema.NewTable("logs_rest").SetSchema(s).AddColumns(schema.NewIntColumn("id", TypeInteger)).AddAttrs(&PartitionOf{Parent: logs, Default: true})},
+					&schema.AddTable{T: logs},
+					&schema.DropTable{T: events},
+					&schema.DropTable{T: schema.NewTable("events_old").SetSchema(s).AddAttrs(&PartitionOf{Parent: events, Default: true})},
+					&schema.ModifyTable{
+						T: schema.NewTable("logs_2021").SetSchema(s),
+						Changes: []schema.Change{
+							&schema.ModifyAttr{From: &PartitionOf{Parent: logs, Bound: "FROM (10) TO (20)"}, To: &PartitionOf{Parent: logs, Bound: "FROM (10) TO (30)"}},
+						},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd: `DROP TABLE "public"."events_old"`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."logs" ("id" integer NOT NULL) PARTITION BY RANGE ("id")`,
+						Reverse: `DROP TABLE "public"."logs"`,
+					},
+					{
+						Cmd: `DROP TABLE "public"."events"`,
+					},
+					{
+						Cmd:     `ALTER TABLE "public"."logs" DETACH PARTITION "public"."logs_2021"`,
+						Reverse: `ALTER TABLE "public"."logs" ATTACH PARTITION "public"."logs_2021" FOR VALUES FROM (10) TO (20)`,
+					},
+					{
+						Cmd:     `ALTER TABLE "public"."logs" ATTACH PARTITION "public"."logs_2021" FOR VALUES FROM (10) TO (30)`,
+						Reverse: `ALTER TABLE "public"."logs" DETACH PARTITION "public"."logs_2021"`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."logs_2020" PARTITION OF "public"."logs" FOR VALUES FROM (1) TO (10)`,
+						Reverse: `DROP TABLE "public"."logs_2020"`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."logs_rest" PARTITION OF "public"."logs" DEFAULT`,
+						Reverse: `DROP TABLE "public"."logs_rest"`,
+					},
+				},
+			},
+		},
+		// Roles are created before the objects that privileges are granted on, and dropped last.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				users := schema.NewTable("users").SetSchema(s).AddColumns(schema.NewIntColumn("id", TypeInteger))
+				app, readers := schema.NewUser("app"), schema.NewRole("readers")
+				return []schema.Change{
+					&schema.DropRole{R: schema.NewRole("old").AddAttrs(&RoleOptions{NoInherit: true})},
+					&schema.Grant{P: &schema.Privilege{Grantee: app, Schema: s, Table: users, Privs: []string{"SELECT", "INSERT"}, Grantable: true}},
+					&schema.AddTable{T: users},
+					&schema.AddRole{R: app},
+					&schema.AddRole{R: readers.AddAttrs(&RoleOptions{CreateDB: true})},
+					&schema.ModifyRole{From: schema.NewRole("admin"), To: schema.NewUser("admin").AddAttrs(&RoleOptions{Superuser: true})},
+					&schema.Revoke{P: &schema.Privilege{Grantee: readers, Schema: s, Privs: []string{"CREATE"}}},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE ROLE "app" WITH LOGIN`,
+						Reverse: `DROP ROLE "app"`,
+					},
+					{
+						Cmd:     `CREATE ROLE "readers" WITH CREATEDB`,
+						Reverse: `DROP ROLE "readers"`,
+					},
+					{
+						Cmd:     `ALTER ROLE "admin" WITH SUPERUSER NOCREATEDB NOCREATEROLE INHERIT LOGIN NOREPLICATION NOBYPASSRLS`,
+						Reverse: `ALTER ROLE "admin" WITH NOSUPERUSER NOCREATEDB NOCREATEROLE INHERIT NOLOGIN NOREPLICATION NOBYPASSRLS`,
+					},
+					{
+						Cmd:     `REVOKE CREATE ON SCHEMA "public" FROM "readers"`,
+						Reverse: `GRANT CREATE ON SCHEMA "public" TO "readers"`,
+					},
+					{
+						Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL)`,
+						Reverse: `DROP TABLE "public"."users"`,
+					},
+					{
+						Cmd:     `GRANT SELECT, INSERT ON TABLE "public"."users" TO "app" WITH GRANT OPTION`,
+						Reverse: `REVOKE SELECT, INSERT ON TABLE "public"."users" FROM "app"`,
+					},
+					{
+						Cmd:     `DROP ROLE "old"`,
+						Reverse: `CREATE ROLE "old" WITH NOINHERIT`,
+					},
+				},
+			},
+		},
+		// Enum types as schema objects.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				return []schema.Change{
+					&schema.AddObject{O: &schema.EnumType{T: "mood", Schema: s, Values: []string{"sad", "happy"}}},
+					&schema.DropObject{O: &schema.EnumType{T: "old", Schema: s, Values: []string{"a"}}},
+					// Values are added in their positions.
+					&schema.ModifyObject{
+						From: &schema.EnumType{T: "state", Schema: s, Values: []string{"b", "d"}},
+						To:   &schema.EnumType{T: "state", Schema: s, Values: []string{"a", "b", "c", "d", "e"}},
+					},
+					// A single value replaced in its position is renamed.
+					&schema.ModifyObject{
+						From: &schema.EnumType{T: "size", Schema: s, Values: []string{"small", "mid", "large"}},
+						To:   &schema.EnumType{T: "size", Schema: s, Values: []string{"small", "medium", "large"}},
+					},
+					// Otherwise, replaced values are not renamed.
+					&schema.ModifyObject{
+						From: &schema.EnumType{T: "level", Schema: s, Values: []string{"low", "mid", "high"}},
+						To:   &schema.EnumType{T: "level", Schema: s, Values: []string{"low", "medium", "high", "max"}},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    false,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `CREATE TYPE "public"."mood" AS ENUM ('sad', 'happy')`,
+						Reverse: `DROP TYPE "public"."mood"`,
+					},
+					{
+						Cmd: `ALTER TYPE "public"."state" ADD VALUE 'a' BEFORE 'b'`,
+					},
+					{
+						Cmd: `ALTER TYPE "public"."state" ADD VALUE 'c' AFTER 'b'`,
+					},
+					{
+						Cmd: `ALTER TYPE "public"."state" ADD VALUE 'e'`,
+					},
+					{
+						Cmd:     `ALTER TYPE "public"."size" RENAME VALUE 'mid' TO 'medium'`,
+						Reverse: `ALTER TYPE "public"."size" RENAME VALUE 'medium' TO 'mid'`,
+					},
+					{
+						Cmd:     `ALTER TYPE "public"."level" RENAME TO "level_old"`,
+						Reverse: `ALTER TYPE "public"."level_old" RENAME TO "level"`,
+					},
+					{
+						Cmd:     `CREATE TYPE "public"."level" AS ENUM ('low', 'medium', 'high', 'max')`,
+						Reverse: `DROP TYPE "public"."level"`,
+					},
+					{
+						Cmd:     `DROP TYPE "public"."level_old"`,
+						Reverse: `CREATE TYPE "public"."level_old" AS ENUM ('low', 'mid', 'high')`,
+					},
+					{
+						Cmd:     `DROP TYPE "public"."old"`,
+						Reverse: `CREATE TYPE "public"."old" AS ENUM ('a')`,
+					},
+				},
+			},
+		},
+		// Enum types are recreated when values are removed, and the columns that use them are converted.
+		{
+			changes: func() []schema.Change {
+				s := schema.New("public")
+				from := &schema.EnumType{T: "status", Schema: s, Values: []string{"active", "inactive", "banned"}}
+				to := &schema.EnumType{T: "status", Schema: s, Values: []string{"active", "inactive"}}
+				users := schema.NewTable("users").AddColumns(
+					schema.NewColumn("status").SetType(from).SetDefault(&schema.Literal{V: "'active'"}),
+					schema.NewColumn("history").SetType(&ArrayType{T: "status[]", Type: from}),
+				)
+				s.AddTables(users).AddObjects(from)
+				return []schema.Change{
+					&schema.ModifyObject{From: from, To: to},
+					&schema.ModifyTable{
+						T: users,
+						Changes: []schema.Change{
+							&schema.ModifyColumn{From: users.Columns[0], To: schema.NewColumn("status").SetType(to).SetDefault(&schema.Literal{V: "'active'"}), Change: schema.ChangeType},
+						},
+					},
+				}
+			}(),
+			wantPlan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     `ALTER TYPE "public"."status" RENAME TO "status_old"`,
+						Reverse: `ALTER TYPE "public"."status_old" RENAME TO "status"`,
+					},
+					{
+						Cmd:     `CREATE TYPE "public"."status" AS ENUM ('active', 'inactive')`,
+						Reverse: `DROP TYPE "public"."status"`,
+					},
+					{
+						Cmd:     `ALTER TABLE "public"."users" ALTER COLUMN "status" DROP DEFAULT, ALTER COLUMN "status" TYPE "public"."status" USING "status"::text::"public"."status", ALTER COLUMN "status" SET DEFAULT 'active', ALTER COLUMN "history" TYPE "public"."status"[] USING "history"::text[]::"public"."status"[]`,
+						Reverse: `ALTER TABLE "public"."users" ALTER COLUMN "status" DROP DEFAULT, ALTER COLUMN "status" TYPE "public"."status_old" USING "status"::text::"public"."status_old", ALTER COLUMN "status" SET DEFAULT 'active', ALTER COLUMN "history" TYPE "public"."status_old"[] USING "history"::text[]::"public"."status_old"[]`,
+					},
+					{
+						Cmd:     `DROP TYPE "public"."status_old"`,
+						Reverse: `CREATE TYPE "public"."status_old" AS ENUM ('active', 'inactive', 'banned')`,
+					},
+				},
+			},
+		},
 		// Empty qualifier in multi-schema mode should fail.
 		{
 			changes: []schema.Change{
@@ -1174,7 +2151,10 @@ func TestPlanChanges(t *testing.T) {
 			db, mk, err := sqlmock.New()
 			require.NoError(t, err)
 			m := mock{mk}
-			m.version("130000")
+			if tt.version == "" {
+				tt.version = "130000"
+			}
+			m.version(tt.version)
 			if tt.mock != nil {
 				tt.mock(m)
 			}
@@ -5,8 +5,10 @@
 package postgres
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -23,9 +25,17 @@ import (
 
 type (
 	doc struct {
-		Tables  []*sqlspec.Table  `spec:"table"`
-		Enums   []*Enum           `spec:"enum"`
-		Schemas []*sqlspec.Schema `spec:"schema"`
+		Tables     []*sqlspec.Table    `spec:"table"`
+		Views      []*sqlspec.View     `spec:"view"`
+		Mats       []*materializedSpec `spec:"materialized"`
+		Funcs      []*sqlspec.Func     `spec:"function"`
+		Procs      []*sqlspec.Proc     `spec:"procedure"`
+		Enums      []*Enum             `spec:"enum"`
+		Sequences  []*sequenceSpec     `spec:"sequence"`
+		Extensions []*extensionSpec    `spec:"extension"`
+		Domains    []*domainSpec       `spec:"domain"`
+		Composites []*compositeSpec    `spec:"composite"`
+		Schemas    []*sqlspec.Schema   `spec:"schema"`
 	}
 	// Enum holds a specification for an enum, that can be referenced as a column type.
 	Enum struct {
@@ -34,10 +44,73 @@ type (
 		Values []string       `spec:"values"`
 		schemahcl.DefaultExtension
 	}
+	// sequenceSpec holds a specification for a standalone sequence. Options
+	// that are not set fall back to the defaults of the sequence type.
+	sequenceSpec struct {
+		Name      string          `spec:",name"`
+		Qualifier string          `spec:",qualifier"`
+		Schema    *schemahcl.Ref  `spec:"schema"`
+		Type      *schemahcl.Type `spec:"type,omitempty"`
+		Start     int64           `spec:"start,omitempty"`
+		Increment int64           `spec:"increment,omitempty"`
+		MinValue  int64           `spec:"min_value,omitempty"`
+		MaxValue  int64           `spec:"max_value,omitempty"`
+		Cache     int64           `spec:"cache,omitempty"`
+		Cycle     bool            `spec:"cycle,omitempty"`
+		OwnedBy   *schemahcl.Ref  `spec:"owned_by,omitempty"`
+		schemahcl.DefaultExtension
+	}
+	// domainSpec holds a specification for a domain type, that can be referenced as a column type.
+	domainSpec struct {
+		Name    string           `spec:",name"`
+		Schema  *schemahcl.Ref   `spec:"schema"`
+		Type    *schemahcl.Type  `spec:"type"`
+		Null    bool             `spec:"null,omitempty"`
+		Default cty.Value        `spec:"default"`
+		Checks  []*sqlspec.Check `spec:"check"`
+		schemahcl.DefaultExtension
+	}
+	// compositeSpec holds a specification for a composite type, that can be referenced as a column type.
+	compositeSpec struct {
+		Name   string         `spec:",name"`
+		Schema *schemahcl.Ref `spec:"schema"`
+		Fields []*fieldSpec   `spec:"field"`
+		schemahcl.DefaultExtension
+	}
+	// fieldSpec holds a specification for a field of a composite type.
+	fieldSpec struct {
+		Name string          `spec:",name"`
+		Type *schemahcl.Type `spec:"type"`
+		schemahcl.DefaultExtension
+	}
+	// materializedSpec holds a specification for a materialized view.
+	// Unlike regular views, materialized views can be indexed.
+	materializedSpec struct {
+		Name      string            `spec:",name"`
+		Qualifier string            `spec:",qualifier"`
+		Schema    *schemahcl.Ref    `spec:"schema"`
+		Columns   []*sqlspec.Column `spec:"column"`
+		As        string            `spec:"as"`
+		Indexes   []*sqlspec.Index  `spec:"index"`
+		schemahcl.DefaultExtension
+	}
+	// extensionSpec holds a specification for an extension installed
+	// in a schema. An empty version stands for the default version.
+	extensionSpec struct {
+		Name    string         `spec:",name"`
+		Schema  *schemahcl.Ref `spec:"schema"`
+		Version string         `spec:"version,omitempty"`
+		schemahcl.DefaultExtension
+	}
 )
 
 func init() {
 	schemahcl.Register("enum", &Enum{})
+	schemahcl.Register("sequence", &sequenceSpec{})
+	schemahcl.Register("extension", &extensionSpec{})
+	schemahcl.Register("domain", &domainSpec{})
+	schemahcl.Register("composite", &compositeSpec{})
+	schemahcl.Register("materialized", &materializedSpec{})
 }
 
 // evalSpec evaluates an Atlas DDL document into v using the input.
@@ -51,11 +124,41 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 		if err := specutil.Scan(v, d.Schemas, d.Tables, convertTable); err != nil {
 			return fmt.Errorf("specutil: failed converting to *schema.Realm: %w", err)
 		}
+		if err := convertForeignKeys(d.Tables, v); err != nil {
+			return fmt.Errorf("specutil: failed converting foreign keys: %w", err)
+		}
+		if err := convertPartitionOf(d.Tables, v); err != nil {
+			return fmt.Errorf("specutil: failed converting partitions: %w", err)
+		}
+		if err := convertInherits(d.Tables, v); err != nil {
+			return fmt.Errorf("specutil: failed converting table inheritance: %w", err)
+		}
+		if err := specutil.ScanViews(v, d.Views, convertView); err != nil {
+			return fmt.Errorf("specutil: failed converting views: %w", err)
+		}
+		if err := convertMaterialized(d.Mats, v); err != nil {
+			return fmt.Errorf("specutil: failed converting materialized views: %w", err)
+		}
+		if err := specutil.ScanFuncs(v, d.Funcs, d.Procs, convertFunc, convertProc); err != nil {
+			return fmt.Errorf("specutil: failed converting functions: %w", err)
+		}
+		if err := specutil.ScanTriggers(v, d.Tables, convertTrigger); err != nil {
+			return fmt.Errorf("specutil: failed converting triggers: %w", err)
+		}
+		if err := convertExtensions(d.Extensions, v); err != nil {
+			return fmt.Errorf("specutil: failed converting extensions: %w", err)
+		}
+		if err := convertSequences(d.Sequences, v); err != nil {
+			return fmt.Errorf("specutil: failed converting sequences: %w", err)
+		}
 		if len(d.Enums) > 0 {
 			if err := convertEnums(d.Tables, d.Enums, v); err != nil {
 				return err
 			}
 		}
+		if err := convertTypes(d.Domains, d.Composites, v); err != nil {
+			return fmt.Errorf("specutil: failed converting types: %w", err)
+		}
 	case *schema.Schema:
 		if len(d.Schemas) != 1 {
 			return fmt.Errorf("specutil: expecting document to contain a single schema, got %d", len(d.Schemas))
@@ -64,9 +167,39 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 		if err := specutil.Scan(r, d.Schemas, d.Tables, convertTable); err != nil {
 			return err
 		}
+		if err := convertForeignKeys(d.Tables, r); err != nil {
+			return err
+		}
+		if err := convertPartitionOf(d.Tables, r); err != nil {
+			return err
+		}
+		if err := convertInherits(d.Tables, r); err != nil {
+			return err
+		}
+		if err := specutil.ScanViews(r, d.Views, convertView); err != nil {
+			return err
+		}
+		if err := convertMaterialized(d.Mats, r); err != nil {
+			return err
+		}
+		if err := specutil.ScanFuncs(r, d.Funcs, d.Procs, convertFunc, convertProc); err != nil {
+			return err
+		}
+		if err := specutil.ScanTriggers(r, d.Tables, convertTrigger); err != nil {
+			return err
+		}
+		if err := convertExtensions(d.Extensions, r); err != nil {
+			return err
+		}
+		if err := convertSequences(d.Sequences, r); err != nil {
+			return err
+		}
 		if err := convertEnums(d.Tables, d.Enums, r); err != nil {
 			return err
 		}
+		if err := convertTypes(d.Domains, d.Composites, r); err != nil {
+			return err
+		}
 		*v = *r.Schemas[0]
 	default:
 		return fmt.Errorf("specutil: failed unmarshaling spec. %T is not supported", v)
@@ -85,8 +218,16 @@ func MarshalSpec(v any, marshaler schemahcl.Marshaler) ([]byte, error) {
 			return nil, fmt.Errorf("specutil: failed converting schema to spec: %w", err)
 		}
 		d.Tables = doc.Tables
+		d.Views = doc.Views
+		d.Mats = doc.Mats
+		d.Funcs = doc.Funcs
+		d.Procs = doc.Procs
 		d.Schemas = doc.Schemas
 		d.Enums = doc.Enums
+		d.Sequences = doc.Sequences
+		d.Extensions = doc.Extensions
+		d.Domains = doc.Domains
+		d.Composites = doc.Composites
 	case *schema.Realm:
 		for _, s := range s.Schemas {
 			doc, err := schemaSpec(s)
@@ -94,15 +235,33 @@ func MarshalSpec(v any, marshaler schemahcl.Marshaler) ([]byte, error) {
 				return nil, fmt.Errorf("specutil: failed converting schema to spec: %w", err)
 			}
 			d.Tables = append(d.Tables, doc.Tables...)
+			d.Views = append(d.Views, doc.Views...)
+			d.Mats = append(d.Mats, doc.Mats...)
+			d.Funcs = append(d.Funcs, doc.Funcs...)
+			d.Procs = append(d.Procs, doc.Procs...)
 			d.Schemas = append(d.Schemas, doc.Schemas...)
 			d.Enums = append(d.Enums, doc.Enums...)
+			d.Sequences = append(d.Sequences, doc.Sequences...)
+			d.Extensions = append(d.Extensions, doc.Extensions...)
+			d.Domains = append(d.Domains, doc.Domains...)
+			d.Composites = append(d.Composites, doc.Composites...)
 		}
 		if err := specutil.QualifyDuplicates(d.Tables); err != nil {
 			return nil, err
 		}
+		if err := specutil.QualifyViewDuplicates(d.Views); err != nil {
+			return nil, err
+		}
+		if err := specutil.QualifyFuncDuplicates(d.Funcs, d.Procs); err != nil {
+			return nil, err
+		}
 		if err := specutil.QualifyReferences(d.Tables, s); err != nil {
 			return nil, err
 		}
+		if err := specutil.QualifyTriggerReferences(d.Tables, d.Funcs, s); err != nil {
+			return nil, err
+		}
+		qualifySequences(d.Sequences, d.Tables)
 	default:
 		return nil, fmt.Errorf("specutil: failed marshaling spec. %T is not supported", v)
 	}
@@ -113,11 +272,22 @@ var (
 	hclState = schemahcl.New(
 		schemahcl.WithTypes(TypeRegistry.Specs()),
 		schemahcl.WithScopedEnums("table.index.type", IndexTypeBTree, IndexTypeBRIN, IndexTypeHash, IndexTypeGIN, IndexTypeGiST, "GiST", IndexTypeSPGiST, "SPGiST"),
+		schemahcl.WithScopedEnums("table.exclude.type", IndexTypeBTree, IndexTypeHash, IndexTypeGIN, IndexTypeGiST, "GiST", IndexTypeSPGiST, "SPGiST"),
 		schemahcl.WithScopedEnums("table.partition.type", PartitionTypeRange, PartitionTypeList, PartitionTypeHash),
+		schemahcl.WithScopedEnums("table.policy.as", PolicyAsPermissive, PolicyAsRestrictive),
+		schemahcl.WithScopedEnums("table.policy.command", PolicyCmdAll, PolicyCmdSelect, PolicyCmdInsert, PolicyCmdUpdate, PolicyCmdDelete),
 		schemahcl.WithScopedEnums("table.column.identity.generated", GeneratedTypeAlways, GeneratedTypeByDefault),
 		schemahcl.WithScopedEnums("table.column.as.type", "STORED"),
 		schemahcl.WithScopedEnums("table.foreign_key.on_update", specutil.ReferenceVars...),
 		schemahcl.WithScopedEnums("table.foreign_key.on_delete", specutil.ReferenceVars...),
+		schemahcl.WithScopedEnums("view.check_option", schema.ViewCheckOptionLocal, schema.ViewCheckOptionCascaded),
+		schemahcl.WithScopedEnums("materialized.index.type", IndexTypeBTree, IndexTypeBRIN, IndexTypeHash, IndexTypeGIN, IndexTypeGiST, "GiST", IndexTypeSPGiST, "SPGiST"),
+		schemahcl.WithScopedEnums("function.volatility", VolatilityImmutable, VolatilityStable, VolatilityVolatile),
+		schemahcl.WithScopedEnums("function.arg.mode", schema.FuncArgModeIn, schema.FuncArgModeOut, schema.FuncArgModeInOut, schema.FuncArgModeVariadic),
+		schemahcl.WithScopedEnums("procedure.arg.mode", schema.FuncArgModeIn, schema.FuncArgModeOut, schema.FuncArgModeInOut, schema.FuncArgModeVariadic),
+		schemahcl.WithScopedEnums("table.trigger.timing", schema.TriggerTimeBefore, schema.TriggerTimeAfter, specutil.Var(schema.TriggerTimeInstead)),
+		schemahcl.WithScopedEnums("table.trigger.events", schema.TriggerEventInsert, schema.TriggerEventUpdate, schema.TriggerEventDelete, schema.TriggerEventTruncate),
+		schemahcl.WithScopedEnums("table.trigger.foreach", schema.TriggerForRow, schema.TriggerForStmt),
 		schemahcl.WithScopedEnums("table.index.on.ops", func() (ops []string) {
 			for _, op := range postgresop.Classes {
 				ops = append(ops, op.Name)
@@ -141,16 +311,369 @@ var (
 // ForeignKeySpecs into ForeignKeys, as the target tables do not necessarily exist in the schema
 // at this point. Instead, the linking is done by the convertSchema function.
 func convertTable(spec *sqlspec.Table, parent *schema.Schema) (*schema.Table, error) {
-	t, err := specutil.Table(spec, parent, convertColumn, specutil.PrimaryKey, convertIndex, specutil.Check)
+	t, err := specutil.Table(spec, parent, convertColumn, convertPK, convertIndex, specutil.Check)
 	if err != nil {
 		return nil, err
 	}
+	if err := convertExclusions(spec.Extra, t); err != nil {
+		return nil, err
+	}
 	if err := convertPartition(spec.Extra, t); err != nil {
 		return nil, err
 	}
+	if err := convertPolicies(spec.Extra, t); err != nil {
+		return nil, err
+	}
+	if err := convertStorage(spec.Extra, t); err != nil {
+		return nil, err
+	}
 	return t, nil
 }
 
+// convertStorage converts and appends the persistence, the tablespace
+// and the storage parameters into the table attributes if exist.
+func convertStorage(spec schemahcl.Resource, table *schema.Table) error {
+	if a, ok := spec.Attr("unlogged"); ok {
+		b, err := a.Bool()
+		if err != nil {
+			return fmt.Errorf("parsing %s.unlogged: %w", table.Name, err)
+		}
+		if b {
+			table.AddAttrs(&Unlogged{})
+		}
+	}
+	if a, ok := spec.Attr("tablespace"); ok {
+		n, err := a.String()
+		if err != nil {
+			return fmt.Errorf("parsing %s.tablespace: %w", table.Name, err)
+		}
+		table.AddAttrs(&Tablespace{Name: n})
+	}
+	r, ok := spec.Resource("storage_params")
+	if !ok {
+		return nil
+	}
+	p := &TableStorageParams{Params: make(map[string]string)}
+	for _, a := range r.Attrs {
+		v, err := storageParamValue(a)
+		if err != nil {
+			return fmt.Errorf("parsing %s.storage_params: %w", table.Name, err)
+		}
+		p.Params[a.K] = v
+	}
+	// Parameters of the TOAST table are prefixed with "toast.".
+	if toast, ok := r.Resource("toast"); ok {
+		for _, a := range toast.Attrs {
+			v, err := storageParamValue(a)
+			if err != nil {
+				return fmt.Errorf("parsing %s.storage_params.toast: %w", table.Name, err)
+			}
+			p.Params["toast."+a.K] = v
+		}
+	}
+	table.AddAttrs(p)
+	return nil
+}
+
+// storageParamValue returns the string representation of a storage parameter value.
+func storageParamValue(a *schemahcl.Attr) (string, error) {
+	switch t := a.V.Type(); t {
+	case cty.String:
+		return a.String()
+	case cty.Bool:
+		b, err := a.Bool()
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(b), nil
+	case cty.Number:
+		return a.V.AsBigFloat().Text('f', -1), nil
+	default:
+		return "", fmt.Errorf("unexpected type %s for storage parameter %q", t.FriendlyName(), a.K)
+	}
+}
+
+// fromStorage returns the attributes and the resource spec for
+// representing the storage options of the table.
+func fromStorage(table *schema.Table) ([]*schemahcl.Attr, *schemahcl.Resource) {
+	var attrs []*schemahcl.Attr
+	if sqlx.Has(table.Attrs, &Unlogged{}) {
+		attrs = append(attrs, schemahcl.BoolAttr("unlogged", true))
+	}
+	if t := (Tablespace{}); sqlx.Has(table.Attrs, &t) {
+		attrs = append(attrs, schemahcl.StringAttr("tablespace", t.Name))
+	}
+	p := TableStorageParams{}
+	if !sqlx.Has(table.Attrs, &p) || len(p.Params) == 0 {
+		return attrs, nil
+	}
+	keys := make([]string, 0, len(p.Params))
+	for k := range p.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var (
+		spec  = &schemahcl.Resource{Type: "storage_params"}
+		toast = &schemahcl.Resource{Type: "toast"}
+	)
+	for _, k := range keys {
+		if n := strings.TrimPrefix(k, "toast."); n != k {
+			toast.Attrs = append(toast.Attrs, storageParamAttr(n, p.Params[k]))
+		} else {
+			spec.Attrs = append(spec.Attrs, storageParamAttr(k, p.Params[k]))
+		}
+	}
+	if len(toast.Attrs) > 0 {
+		spec.Children = append(spec.Children, toast)
+	}
+	return attrs, spec
+}
+
+// storageParamAttr returns the attribute for representing a storage parameter.
+func storageParamAttr(k, v string) *schemahcl.Attr {
+	if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return schemahcl.Int64Attr(k, i)
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return &schemahcl.Attr{K: k, V: cty.NumberFloatVal(f)}
+	}
+	if b, err := strconv.ParseBool(v); err == nil {
+		return schemahcl.BoolAttr(k, b)
+	}
+	return schemahcl.StringAttr(k, v)
+}
+
+// convertPK converts a sqlspec.PrimaryKey into a schema.Index.
+func convertPK(spec *sqlspec.PrimaryKey, t *schema.Table) (*schema.Index, error) {
+	pk, err := specutil.PrimaryKey(spec, t)
+	if err != nil {
+		return nil, err
+	}
+	if err := convertDeferrable(spec, &pk.Attrs); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+// convertExclusions converts and appends the exclude blocks into the table indexes if exist.
+func convertExclusions(spec schemahcl.Resource, table *schema.Table) error {
+	for _, r := range spec.Children {
+		if r.Type != "exclude" {
+			continue
+		}
+		var x sqlspec.Index
+		if err := r.As(&x); err != nil {
+			return fmt.Errorf("parsing %s.exclude.%s: %w", table.Name, r.Name, err)
+		}
+		if len(x.Columns) > 0 {
+			return fmt.Errorf("exclusion constraint %q must define its elements using the on block", r.Name)
+		}
+		idx, err := convertIndex(&x, table)
+		if err != nil {
+			return err
+		}
+		for i, p := range x.Parts {
+			attr, ok := p.Attr("op")
+			if !ok {
+				return fmt.Errorf("missing operator for element %d of exclusion constraint %q", i, r.Name)
+			}
+			op, err := attr.String()
+			if err != nil {
+				return err
+			}
+			idx.Parts[i].Attrs = append(idx.Parts[i].Attrs, &ExcludeOp{Op: op})
+		}
+		table.AddIndexes(idx.AddAttrs(&ConType{T: "x"}))
+	}
+	return nil
+}
+
+// fromExclusions returns the resource specs for representing the exclusion constraints of the table.
+func fromExclusions(table *schema.Table) ([]*schemahcl.Resource, error) {
+	var specs []*schemahcl.Resource
+	for _, idx := range table.Indexes {
+		if !isExclusion(idx.Attrs) {
+			continue
+		}
+		x, err := indexSpec(idx)
+		if err != nil {
+			return nil, err
+		}
+		// Exclusion elements are always printed as blocks, as each element has an operator.
+		x.Parts, x.Columns = make([]*sqlspec.IndexPart, 0, len(idx.Parts)), nil
+		for _, p := range idx.Parts {
+			part := &sqlspec.IndexPart{Desc: p.Desc}
+			switch {
+			case p.C != nil:
+				part.Column = specutil.ColumnRef(p.C.Name)
+			case p.X != nil:
+				switch e := p.X.(type) {
+				case *schema.RawExpr:
+					part.Expr = e.X
+				default:
+					return nil, fmt.Errorf("unexpected expression type %T in exclusion constraint %q", p.X, idx.Name)
+				}
+			}
+			if err := partAttr(idx, p, part); err != nil {
+				return nil, err
+			}
+			if op := (ExcludeOp{}); sqlx.Has(p.Attrs, &op) {
+				part.Extra.Attrs = append(part.Extra.Attrs, schemahcl.StringAttr("op", op.Op))
+			}
+			x.Parts = append(x.Parts, part)
+		}
+		spec := &schemahcl.Resource{}
+		if err := spec.Scan(x); err != nil {
+			return nil, err
+		}
+		spec.Type = "exclude"
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// convertForeignKeys converts the driver-specific attributes of the foreign keys.
+// It should be called after the tables were scanned, as foreign keys are linked
+// only after all tables were converted.
+func convertForeignKeys(tables []*sqlspec.Table, r *schema.Realm) error {
+	for _, ts := range tables {
+		if len(ts.ForeignKeys) == 0 {
+			continue
+		}
+		name, err := specutil.SchemaName(ts.Schema)
+		if err != nil {
+			return fmt.Errorf("extract schema name from table refrence: %w", err)
+		}
+		s, ok := r.Schema(name)
+		if !ok {
+			return fmt.Errorf("schema %q not found in realm for table %q", name, ts.Name)
+		}
+		t, ok := s.Table(ts.Name)
+		if !ok {
+			return fmt.Errorf("table %q not found in schema %q", ts.Name, s.Name)
+		}
+		for _, spec := range ts.ForeignKeys {
+			fk, ok := t.ForeignKey(spec.Symbol)
+			if !ok {
+				return fmt.Errorf("foreign key %q not found in table %q", spec.Symbol, t.Name)
+			}
+			if err := convertDeferrable(spec, &fk.Attrs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// convertDeferrable converts the deferrable attributes of a constraint spec.
+func convertDeferrable(spec specutil.Attrer, attrs *[]schema.Attr) error {
+	var d Deferrable
+	if a, ok := spec.Attr("initially_deferred"); ok {
+		b, err := a.Bool()
+		if err != nil {
+			return err
+		}
+		d.InitiallyDeferred = b
+	}
+	a, ok := spec.Attr("deferrable")
+	if !ok {
+		if d.InitiallyDeferred {
+			return errors.New("initially_deferred requires the constraint to be deferrable")
+		}
+		return nil
+	}
+	switch b, err := a.Bool(); {
+	case err != nil:
+		return err
+	case b:
+		*attrs = append(*attrs, &d)
+	case d.InitiallyDeferred:
+		return errors.New("initially_deferred requires the constraint to be deferrable")
+	}
+	return nil
+}
+
+// fromDeferrable returns the spec attributes for representing the constraint deferrability.
+func fromDeferrable(attrs []schema.Attr) []*schemahcl.Attr {
+	d := Deferrable{}
+	if !sqlx.Has(attrs, &d) {
+		return nil
+	}
+	specs := []*schemahcl.Attr{schemahcl.BoolAttr("deferrable", true)}
+	if d.InitiallyDeferred {
+		specs = append(specs, schemahcl.BoolAttr("initially_deferred", true))
+	}
+	return specs
+}
+
+// convertPolicies converts and appends the row_security and the policy blocks into the table attributes if exist.
+func convertPolicies(spec schemahcl.Resource, table *schema.Table) error {
+	if r, ok := spec.Resource("row_security"); ok {
+		var rs struct {
+			Enabled bool `spec:"enabled"`
+			Forced  bool `spec:"force"`
+		}
+		if err := r.As(&rs); err != nil {
+			return fmt.Errorf("parsing %s.row_security: %w", table.Name, err)
+		}
+		table.AddAttrs(&RowSecurity{Enabled: rs.Enabled, Forced: rs.Forced})
+	}
+	for _, r := range spec.Children {
+		if r.Type != "policy" {
+			continue
+		}
+		var p struct {
+			As      string   `spec:"as"`
+			Command string   `spec:"command"`
+			Roles   []string `spec:"to"`
+			Using   string   `spec:"using"`
+			Check   string   `spec:"check"`
+		}
+		if err := r.As(&p); err != nil {
+			return fmt.Errorf("parsing %s.policy.%s: %w", table.Name, r.Name, err)
+		}
+		table.AddAttrs(&Policy{Name: r.Name, As: p.As, Command: p.Command, Roles: p.Roles, Using: p.Using, Check: p.Check})
+	}
+	return nil
+}
+
+// fromPolicies returns the resource specs for representing the
+// row-level security attributes and the policies of the table.
+func fromPolicies(table *schema.Table) []*schemahcl.Resource {
+	var specs []*schemahcl.Resource
+	if r := (RowSecurity{}); sqlx.Has(table.Attrs, &r) {
+		spec := &schemahcl.Resource{Type: "row_security"}
+		if r.Enabled {
+			spec.Attrs = append(spec.Attrs, schemahcl.BoolAttr("enabled", true))
+		}
+		if r.Forced {
+			spec.Attrs = append(spec.Attrs, schemahcl.BoolAttr("force", true))
+		}
+		specs = append(specs, spec)
+	}
+	for _, p := range policies(table.Attrs) {
+		spec := &schemahcl.Resource{Type: "policy", Name: p.Name}
+		// Avoid printing the defaults.
+		if as := policyAs(p); as != PolicyAsPermissive {
+			spec.Attrs = append(spec.Attrs, specutil.VarAttr("as", as))
+		}
+		if cmd := policyCmd(p); cmd != PolicyCmdAll {
+			spec.Attrs = append(spec.Attrs, specutil.VarAttr("command", cmd))
+		}
+		if len(p.Roles) > 0 {
+			spec.Attrs = append(spec.Attrs, schemahcl.StringsAttr("to", p.Roles...))
+		}
+		if p.Using != "" {
+			spec.Attrs = append(spec.Attrs, schemahcl.StringAttr("using", p.Using))
+		}
+		if p.Check != "" {
+			spec.Attrs = append(spec.Attrs, schemahcl.StringAttr("check", p.Check))
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
 // convertPartition converts and appends the partition block into the table attributes if exists.
 func convertPartition(spec schemahcl.Resource, table *schema.Table) error {
 	r, ok := spec.Resource("partition")
@@ -242,6 +765,183 @@ func fromPartition(p Partition) *schemahcl.Resource {
 	return key
 }
 
+// convertPartitionOf converts the partition_of blocks of the tables into attributes.
+// It should be called after the tables were scanned, as partitions reference their
+// parent tables.
+func convertPartitionOf(tables []*sqlspec.Table, r *schema.Realm) error {
+	for _, ts := range tables {
+		spec, ok := ts.Extra.Resource("partition_of")
+		if !ok {
+			continue
+		}
+		name, err := specutil.SchemaName(ts.Schema)
+		if err != nil {
+			return fmt.Errorf("extract schema name from table refrence: %w", err)
+		}
+		s, ok := r.Schema(name)
+		if !ok {
+			return fmt.Errorf("schema %q not found in realm for table %q", name, ts.Name)
+		}
+		t, ok := s.Table(ts.Name)
+		if !ok {
+			return fmt.Errorf("table %q not found in schema %q", ts.Name, s.Name)
+		}
+		var p struct {
+			Parent  *schemahcl.Ref `spec:"parent"`
+			Bound   string         `spec:"bound"`
+			Default bool           `spec:"default"`
+		}
+		if err := spec.As(&p); err != nil {
+			return fmt.Errorf("parsing %s.partition_of: %w", t.Name, err)
+		}
+		switch {
+		case p.Parent == nil:
+			return fmt.Errorf("missing attribute %s.partition_of.parent", t.Name)
+		case p.Bound == "" && !p.Default:
+			return fmt.Errorf(`missing "bound" or "default" for %s.partition_of`, t.Name)
+		case p.Bound != "" && p.Default:
+			return fmt.Errorf(`multiple definitions for %s.partition_of, use "bound" or "default"`, t.Name)
+		}
+		parent, err := partitionParent(r, s, p.Parent)
+		if err != nil {
+			return fmt.Errorf("%s.partition_of: %w", t.Name, err)
+		}
+		t.AddAttrs(&PartitionOf{Parent: parent, Bound: p.Bound, Default: p.Default})
+	}
+	return nil
+}
+
+// partitionParent returns the table referenced by the given reference. Unqualified
+// references are resolved from the schema of the partition.
+func partitionParent(r *schema.Realm, s *schema.Schema, ref *schemahcl.Ref) (*schema.Table, error) {
+	switch path := strings.Split(ref.V, "."); {
+	case len(path) == 2 && path[0] == "$table":
+		if t, ok := s.Table(path[1]); ok {
+			return t, nil
+		}
+		return nil, fmt.Errorf("table %q was not found in schema %q", path[1], s.Name)
+	case len(path) == 3 && path[0] == "$table":
+		if s, ok := r.Schema(path[1]); ok {
+			if t, ok := s.Table(path[2]); ok {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("table %q was not found in schema %q", path[2], path[1])
+	default:
+		return nil, fmt.Errorf("expected ref format of $table.name, got %q", ref.V)
+	}
+}
+
+// fromPartitionOf returns the resource spec for representing the partition_of block.
+func fromPartitionOf(t *schema.Table, p *PartitionOf) *schemahcl.Resource {
+	spec := &schemahcl.Resource{
+		Type: "partition_of",
+		Attrs: []*schemahcl.Attr{
+			schemahcl.RefAttr("parent", parentRef(t, p.Parent)),
+		},
+	}
+	if p.Default {
+		spec.Attrs = append(spec.Attrs, schemahcl.BoolAttr("default", true))
+	} else {
+		spec.Attrs = append(spec.Attrs, schemahcl.StringAttr("bound", p.Bound))
+	}
+	return spec
+}
+
+// parentRef returns the reference of the parent table. Tables
+// from other schemas are referenced by their qualified name.
+func parentRef(t, parent *schema.Table) *schemahcl.Ref {
+	if s := parent.Schema; s != nil && t.Schema != nil && s.Name != t.Schema.Name {
+		return &schemahcl.Ref{V: "$table." + s.Name + "." + parent.Name}
+	}
+	return &schemahcl.Ref{V: "$table." + parent.Name}
+}
+
+// convertInherits converts the inherits attributes of the tables into attributes.
+// It is called after all tables were scanned, as parents can be defined after
+// their children.
+func convertInherits(tables []*sqlspec.Table, r *schema.Realm) error {
+	for _, ts := range tables {
+		a, ok := ts.Extra.Attr("inherits")
+		if !ok {
+			continue
+		}
+		name, err := specutil.SchemaName(ts.Schema)
+		if err != nil {
+			return fmt.Errorf("extract schema name from table refrence: %w", err)
+		}
+		s, ok := r.Schema(name)
+		if !ok {
+			return fmt.Errorf("schema %q not found in realm for table %q", name, ts.Name)
+		}
+		t, ok := s.Table(ts.Name)
+		if !ok {
+			return fmt.Errorf("table %q not found in schema %q", ts.Name, s.Name)
+		}
+		refs, err := a.Refs()
+		if err != nil {
+			return fmt.Errorf("parsing %s.inherits: %w", t.Name, err)
+		}
+		p := &Inherits{Parents: make([]*schema.Table, 0, len(refs))}
+		for _, ref := range refs {
+			parent, err := partitionParent(r, s, ref)
+			if err != nil {
+				return fmt.Errorf("%s.inherits: %w", t.Name, err)
+			}
+			p.Parents = append(p.Parents, parent)
+		}
+		t.AddAttrs(p)
+	}
+	return nil
+}
+
+// fromInherits returns the attribute for representing the parent tables of the table.
+func fromInherits(t *schema.Table, p *Inherits) *schemahcl.Attr {
+	refs := make([]*schemahcl.Ref, 0, len(p.Parents))
+	for _, parent := range p.Parents {
+		refs = append(refs, parentRef(t, parent))
+	}
+	return schemahcl.RefsAttr("inherits", refs...)
+}
+
+// convertView converts a sqlspec.View to a schema.View.
+func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error) {
+	return specutil.View(spec, parent, convertColumnType)
+}
+
+// convertTrigger converts a sqlspec.Trigger to a schema.Trigger.
+// Triggers without an explicit level are FOR EACH STATEMENT triggers.
+func convertTrigger(spec *sqlspec.Trigger, parent *schema.Table) (*schema.Trigger, error) {
+	return specutil.Trigger(spec, parent, schema.TriggerForStmt)
+}
+
+// convertFunc converts a sqlspec.Func to a schema.Func.
+func convertFunc(spec *sqlspec.Func, parent *schema.Schema) (*schema.Func, error) {
+	if spec.Lang == "" {
+		return nil, fmt.Errorf("postgres: missing language (lang) for function %q", spec.Name)
+	}
+	f, err := specutil.Func(spec, parent, convertColumnType)
+	if err != nil {
+		return nil, err
+	}
+	if a, ok := spec.Attr("volatility"); ok {
+		v, err := a.String()
+		if err != nil {
+			return nil, err
+		}
+		f.AddAttrs(&Volatility{V: v})
+	}
+	return f, nil
+}
+
+// convertProc converts a sqlspec.Proc to a schema.Proc.
+func convertProc(spec *sqlspec.Proc, parent *schema.Schema) (*schema.Proc, error) {
+	if spec.Lang == "" {
+		return nil, fmt.Errorf("postgres: missing language (lang) for procedure %q", spec.Name)
+	}
+	return specutil.Proc(spec, parent, convertColumnType)
+}
+
 // convertColumn converts a sqlspec.Column into a schema.Column.
 func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error) {
 	if err := fixDefaultQuotes(spec); err != nil {
@@ -343,6 +1043,13 @@ func convertIndex(spec *sqlspec.Index, t *schema.Table) (*schema.Index, error) {
 		}
 		idx.Attrs = append(idx.Attrs, &IndexInclude{Columns: include})
 	}
+	if err := convertDeferrable(spec, &idx.Attrs); err != nil {
+		return nil, err
+	}
+	// Deferrable unique indexes can be defined only as constraints.
+	if idx.Unique && sqlx.Has(idx.Attrs, &Deferrable{}) && !sqlx.Has(idx.Attrs, &ConType{}) {
+		idx.Attrs = append(idx.Attrs, &ConType{T: "u"})
+	}
 	return idx, nil
 }
 
@@ -398,17 +1105,27 @@ func convertColumnType(spec *sqlspec.Column) (schema.Type, error) {
 // convertEnums converts possibly referenced column types (like enums) to
 // an actual schema.Type and sets it on the correct schema.Column.
 func convertEnums(tables []*sqlspec.Table, enums []*Enum, r *schema.Realm) error {
-	var (
-		used   = make(map[*Enum]struct{})
-		byName = make(map[string]*Enum)
-	)
+	byName := make(map[string]*schema.EnumType)
 	for _, e := range enums {
-		byName[e.Name] = e
+		schemaE, err := specutil.SchemaName(e.Schema)
+		if err != nil {
+			return fmt.Errorf("extract schema name from enum refrence: %w", err)
+		}
+		es, ok := r.Schema(schemaE)
+		if !ok {
+			return fmt.Errorf("schema %q not found in realm for enum %q", schemaE, e.Name)
+		}
+		// Enum types are schema objects that are shared by the columns that use them.
+		byName[e.Name] = &schema.EnumType{T: e.Name, Schema: es, Values: e.Values}
+		es.AddObjects(byName[e.Name])
 	}
 	for _, t := range tables {
 		for _, c := range t.Columns {
-			var enum *Enum
+			var enum *schema.EnumType
 			switch {
+			// Other types (e.g. domains) are converted by convertTypes.
+			case c.Type.IsRef && !strings.HasPrefix(c.Type.T, "$enum."):
+				continue
 			case c.Type.IsRef:
 				n, err := enumName(c.Type)
 				if err != nil {
@@ -426,15 +1143,6 @@ func convertEnums(tables []*sqlspec.Table, enums []*Enum, r *schema.Realm) error
 				}
 				enum = byName[n]
 			}
-			used[enum] = struct{}{}
-			schemaE, err := specutil.SchemaName(enum.Schema)
-			if err != nil {
-				return fmt.Errorf("extract schema name from enum refrence: %w", err)
-			}
-			es, ok := r.Schema(schemaE)
-			if !ok {
-				return fmt.Errorf("schema %q not found in realm for table %q", schemaE, t.Name)
-			}
 			schemaT, err := specutil.SchemaName(t.Schema)
 			if err != nil {
 				return fmt.Errorf("extract schema name from table refrence: %w", err)
@@ -451,23 +1159,268 @@ func convertEnums(tables []*sqlspec.Table, enums []*Enum, r *schema.Realm) error
 			if !ok {
 				return fmt.Errorf("column %q not found in table %q", c.Name, t.Name)
 			}
-			e := &schema.EnumType{T: enum.Name, Schema: es, Values: enum.Values}
 			switch t := cc.Type.Type.(type) {
 			case *ArrayType:
-				t.Type = e
+				t.Type = enum
 			default:
-				cc.Type.Type = e
+				cc.Type.Type = enum
 			}
 		}
 	}
-	for _, e := range enums {
-		if _, ok := used[e]; !ok {
-			return fmt.Errorf("enum %q declared but not used", e.Name)
+	return nil
+}
+
+// convertSequences converts the sequence specs to standalone
+// sequences, and adds them to the schemas of the realm.
+func convertSequences(specs []*sequenceSpec, r *schema.Realm) error {
+	for _, spec := range specs {
+		s, err := specSchema(r, spec.Schema, "sequence", spec.Name)
+		if err != nil {
+			return err
+		}
+		seq := &Sequence{
+			Name:      spec.Name,
+			Schema:    s,
+			Start:     spec.Start,
+			Increment: spec.Increment,
+			Min:       spec.MinValue,
+			Max:       spec.MaxValue,
+			Cache:     spec.Cache,
+			Cycle:     spec.Cycle,
 		}
+		if spec.Type != nil {
+			t, err := TypeRegistry.Type(spec.Type, nil)
+			if err != nil {
+				return err
+			}
+			if _, ok := t.(*schema.IntegerType); !ok {
+				return fmt.Errorf("postgres: unexpected type %q for sequence %q", spec.Type.T, spec.Name)
+			}
+			seq.Type = t
+		}
+		if spec.OwnedBy != nil {
+			if seq.Owner.T, seq.Owner.C, err = specutil.ColumnByExternalRef(spec.OwnedBy, s); err != nil {
+				return fmt.Errorf("postgres: owner of sequence %q: %w", spec.Name, err)
+			}
+		}
+		s.AddObjects(seq)
 	}
 	return nil
 }
 
+// convertExtensions converts the extension specs to
+// extensions, and adds them to the schemas of the realm.
+func convertExtensions(specs []*extensionSpec, r *schema.Realm) error {
+	for _, spec := range specs {
+		s, err := specSchema(r, spec.Schema, "extension", spec.Name)
+		if err != nil {
+			return err
+		}
+		s.AddObjects(&Extension{Name: spec.Name, Schema: s, Version: spec.Version})
+	}
+	return nil
+}
+
+// convertTypes converts the domain and composite type specs to their schema objects,
+// and resolves the column types, the composite type fields and the domain types that
+// reference them.
+func convertTypes(domains []*domainSpec, composites []*compositeSpec, r *schema.Realm) error {
+	for _, spec := range domains {
+		s, err := specSchema(r, spec.Schema, "domain", spec.Name)
+		if err != nil {
+			return err
+		}
+		c, err := specutil.Column(&sqlspec.Column{Name: spec.Name, Type: spec.Type, Null: spec.Null, Default: spec.Default}, convertColumnType)
+		if err != nil {
+			return err
+		}
+		d := &Domain{T: spec.Name, Schema: s, Type: c.Type.Type, Null: c.Type.Null, Default: c.Default}
+		for _, ck := range spec.Checks {
+			d.Checks = append(d.Checks, &schema.Check{Name: ck.Name, Expr: ck.Expr})
+		}
+		s.AddObjects(d)
+	}
+	for _, spec := range composites {
+		s, err := specSchema(r, spec.Schema, "composite", spec.Name)
+		if err != nil {
+			return err
+		}
+		c := &CompositeType{T: spec.Name, Schema: s}
+		for _, f := range spec.Fields {
+			t, err := TypeRegistry.Type(f.Type, nil)
+			if err != nil {
+				return err
+			}
+			c.Fields = append(c.Fields, &schema.Column{Name: f.Name, Type: &schema.ColumnType{Type: t, Null: true}})
+		}
+		s.AddObjects(c)
+	}
+	resolve := func(s *schema.Schema, t schema.Type) (schema.Type, error) {
+		u, ok := t.(*UserDefinedType)
+		if !ok {
+			return t, nil
+		}
+		for _, p := range []string{"$enum.", "$domain.", "$composite."} {
+			if !strings.HasPrefix(u.T, p) {
+				continue
+			}
+			name := strings.TrimPrefix(u.T, p)
+			if o, ok := typeObject(r, s, name); ok {
+				return o, nil
+			}
+			for _, s := range r.Schemas {
+				if o, ok := typeObject(r, s, name); ok {
+					return o, nil
+				}
+			}
+			return nil, fmt.Errorf("%s %q was not found", strings.Trim(p, "$."), name)
+		}
+		return t, nil
+	}
+	for _, s := range r.Schemas {
+		for _, t := range s.Tables {
+			for _, c := range t.Columns {
+				rt, err := resolve(s, c.Type.Type)
+				if err != nil {
+					return fmt.Errorf("column %q.%q: %w", t.Name, c.Name, err)
+				}
+				c.Type.Type = rt
+			}
+		}
+		for _, o := range s.Objects {
+			switch o := o.(type) {
+			case *Domain:
+				rt, err := resolve(s, o.Type)
+				if err != nil {
+					return fmt.Errorf("domain %q: %w", o.T, err)
+				}
+				o.Type = rt
+			case *CompositeType:
+				for _, f := range o.Fields {
+					rt, err := resolve(s, f.Type.Type)
+					if err != nil {
+						return fmt.Errorf("composite %q field %q: %w", o.T, f.Name, err)
+					}
+					f.Type.Type = rt
+				}
+			}
+		}
+	}
+	// Types that are referenced by their names, e.g. arrays.
+	linkTypes(r)
+	return nil
+}
+
+// specSchema returns the schema of a schema object spec from the realm.
+func specSchema(r *schema.Realm, ref *schemahcl.Ref, typ, name string) (*schema.Schema, error) {
+	n, err := specutil.SchemaName(ref)
+	if err != nil {
+		return nil, fmt.Errorf("extract schema name from %s reference: %w", typ, err)
+	}
+	s, ok := r.Schema(n)
+	if !ok {
+		return nil, fmt.Errorf("schema %q not found in realm for %s %q", n, typ, name)
+	}
+	return s, nil
+}
+
+// fromDomain converts a domain type to its spec.
+func fromDomain(d *Domain) (*domainSpec, error) {
+	c, err := specutil.FromColumn(&schema.Column{Name: d.T, Type: &schema.ColumnType{Type: d.Type, Null: d.Null}, Default: d.Default}, columnTypeSpec)
+	if err != nil {
+		return nil, err
+	}
+	spec := &domainSpec{Name: d.T, Type: c.Type, Null: c.Null, Default: c.Default}
+	if d.Schema != nil {
+		spec.Schema = specutil.SchemaRef(d.Schema.Name)
+	}
+	for _, ck := range d.Checks {
+		spec.Checks = append(spec.Checks, specutil.FromCheck(ck))
+	}
+	return spec, nil
+}
+
+// fromComposite converts a composite type to its spec.
+func fromComposite(c *CompositeType) (*compositeSpec, error) {
+	spec := &compositeSpec{Name: c.T}
+	if c.Schema != nil {
+		spec.Schema = specutil.SchemaRef(c.Schema.Name)
+	}
+	for _, f := range c.Fields {
+		ct, err := columnTypeSpec(f.Type.Type)
+		if err != nil {
+			return nil, err
+		}
+		spec.Fields = append(spec.Fields, &fieldSpec{Name: f.Name, Type: ct.Type})
+	}
+	return spec, nil
+}
+
+// fromSequence converts a standalone sequence to its spec. Options
+// that are equal to the defaults of the sequence type are omitted.
+func fromSequence(seq *Sequence) (*sequenceSpec, error) {
+	to, err := sequenceDefaults(seq)
+	if err != nil {
+		return nil, err
+	}
+	base, err := sequenceDefaults(&Sequence{Name: seq.Name, Type: to.Type, Increment: to.Increment})
+	if err != nil {
+		return nil, err
+	}
+	spec := &sequenceSpec{Name: seq.Name, Cycle: to.Cycle}
+	if seq.Schema != nil {
+		spec.Schema = specutil.SchemaRef(seq.Schema.Name)
+	}
+	if t := to.Type.(*schema.IntegerType); t.T != TypeBigInt {
+		if spec.Type, err = typeSpec(t); err != nil {
+			return nil, err
+		}
+	}
+	if to.Increment != 1 {
+		spec.Increment = to.Increment
+	}
+	if to.Start != base.Start {
+		spec.Start = to.Start
+	}
+	if to.Min != base.Min {
+		spec.MinValue = to.Min
+	}
+	if to.Max != base.Max {
+		spec.MaxValue = to.Max
+	}
+	if to.Cache != base.Cache {
+		spec.Cache = to.Cache
+	}
+	if seq.Owner.T != nil && seq.Owner.C != nil {
+		spec.OwnedBy = &schemahcl.Ref{V: "$table." + seq.Owner.T.Name + ".$column." + seq.Owner.C.Name}
+	}
+	return spec, nil
+}
+
+// qualifySequences qualifies the sequences that share their names with sequences
+// in other schemas, and the owner references of qualified tables.
+func qualifySequences(seqs []*sequenceSpec, tables []*sqlspec.Table) {
+	seen := make(map[string]*sequenceSpec, len(seqs))
+	for _, s := range seqs {
+		if prev, ok := seen[s.Name]; ok {
+			prev.Qualifier, _ = specutil.SchemaName(prev.Schema)
+			s.Qualifier, _ = specutil.SchemaName(s.Schema)
+		}
+		seen[s.Name] = s
+	}
+	for _, s := range seqs {
+		if s.OwnedBy == nil {
+			continue
+		}
+		n, _ := specutil.SchemaName(s.Schema)
+		for _, t := range tables {
+			if q := "$table." + t.Name + ".$column."; t.Qualifier != "" && t.Qualifier == n && strings.HasPrefix(s.OwnedBy.V, q) {
+				s.OwnedBy = &schemahcl.Ref{V: "$table." + n + "." + strings.TrimPrefix(s.OwnedBy.V, "$table.")}
+			}
+		}
+	}
+}
+
 // enumName extracts the name of the referenced Enum from the reference string.
 func enumName(ref *schemahcl.Type) (string, error) {
 	s := strings.Split(ref.T, "$enum.")
@@ -490,11 +1443,82 @@ func schemaSpec(schem *schema.Schema) (*doc, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Materialized views are printed as separate blocks.
+	var (
+		mats []*schema.View
+		vs   = *schem
+	)
+	vs.Views = make([]*schema.View, 0, len(schem.Views))
+	for _, v := range schem.Views {
+		if v.Materialized() {
+			mats = append(mats, v)
+		} else {
+			vs.Views = append(vs.Views, v)
+		}
+	}
+	views, err := specutil.FromViews(&vs, viewSpec)
+	if err != nil {
+		return nil, err
+	}
+	funcs, procs, err := specutil.FromFuncs(schem, funcSpec, procSpec)
+	if err != nil {
+		return nil, err
+	}
 	d := &doc{
 		Tables:  tbls,
+		Views:   views,
+		Funcs:   funcs,
+		Procs:   procs,
 		Schemas: []*sqlspec.Schema{s},
 	}
+	for _, v := range mats {
+		spec, err := fromMaterialized(v)
+		if err != nil {
+			return nil, err
+		}
+		spec.Schema = specutil.SchemaRef(s.Name)
+		d.Mats = append(d.Mats, spec)
+	}
+	for _, o := range schem.Objects {
+		switch o := o.(type) {
+		case *Extension:
+			d.Extensions = append(d.Extensions, &extensionSpec{
+				Name:    o.Name,
+				Schema:  specutil.SchemaRef(s.Name),
+				Version: o.Version,
+			})
+		case *Domain:
+			spec, err := fromDomain(o)
+			if err != nil {
+				return nil, err
+			}
+			d.Domains = append(d.Domains, spec)
+		case *CompositeType:
+			spec, err := fromComposite(o)
+			if err != nil {
+				return nil, err
+			}
+			d.Composites = append(d.Composites, spec)
+		case *Sequence:
+			spec, err := fromSequence(o)
+			if err != nil {
+				return nil, err
+			}
+			d.Sequences = append(d.Sequences, spec)
+		}
+	}
 	enums := make(map[string]bool)
+	for _, o := range schem.Objects {
+		if e, ok := o.(*schema.EnumType); ok && !enums[e.T] {
+			d.Enums = append(d.Enums, &Enum{
+				Name:   e.T,
+				Schema: specutil.SchemaRef(s.Name),
+				Values: e.Values,
+			})
+			enums[e.T] = true
+		}
+	}
+	// Enum types that are used by columns, but were not declared as schema objects.
 	for _, t := range schem.Tables {
 		for _, c := range t.Columns {
 			if e, ok := hasEnumType(c); ok && !enums[e.T] {
@@ -512,20 +1536,60 @@ func schemaSpec(schem *schema.Schema) (*doc, error) {
 
 // tableSpec converts from a concrete Postgres sqlspec.Table to a schema.Table.
 func tableSpec(table *schema.Table) (*sqlspec.Table, error) {
+	// Exclusion constraints are printed as separate blocks.
+	t := *table
+	t.Indexes = make([]*schema.Index, 0, len(table.Indexes))
+	for _, idx := range table.Indexes {
+		if !isExclusion(idx.Attrs) {
+			t.Indexes = append(t.Indexes, idx)
+		}
+	}
+	// Inherited columns and checks are defined by the parent tables.
+	if p := inheritsOf(table); p != nil {
+		t.Columns = make([]*schema.Column, 0, len(table.Columns))
+		for _, c := range table.Columns {
+			if !sqlx.Has(c.Attrs, &Inherited{}) {
+				t.Columns = append(t.Columns, c)
+			}
+		}
+		t.Attrs = make([]schema.Attr, 0, len(table.Attrs))
+		for _, a := range table.Attrs {
+			if c, ok := a.(*schema.Check); !ok || !sqlx.Has(c.Attrs, &Inherited{}) {
+				t.Attrs = append(t.Attrs, a)
+			}
+		}
+	}
 	spec, err := specutil.FromTable(
-		table,
+		&t,
 		columnSpec,
-		specutil.FromPrimaryKey,
+		pkSpec,
 		indexSpec,
-		specutil.FromForeignKey,
+		fkSpec,
 		specutil.FromCheck,
 	)
 	if err != nil {
 		return nil, err
 	}
+	exclusions, err := fromExclusions(table)
+	if err != nil {
+		return nil, err
+	}
+	spec.Extra.Children = append(spec.Extra.Children, exclusions...)
 	if p := (Partition{}); sqlx.Has(table.Attrs, &p) {
 		spec.Extra.Children = append(spec.Extra.Children, fromPartition(p))
 	}
+	if p := partitionOf(table); p != nil && p.Parent != nil {
+		spec.Extra.Children = append(spec.Extra.Children, fromPartitionOf(table, p))
+	}
+	if p := inheritsOf(table); p != nil && len(p.Parents) > 0 {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, fromInherits(table, p))
+	}
+	spec.Extra.Children = append(spec.Extra.Children, fromPolicies(table)...)
+	attrs, storage := fromStorage(table)
+	spec.Extra.Attrs = append(spec.Extra.Attrs, attrs...)
+	if storage != nil {
+		spec.Extra.Children = append(spec.Extra.Children, storage)
+	}
 	return spec, nil
 }
 
@@ -551,6 +1615,25 @@ func indexSpec(idx *schema.Index) (*sqlspec.Index, error) {
 	if p, ok := indexStorageParams(idx.Attrs); ok {
 		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.Int64Attr("page_per_range", p.PagesPerRange))
 	}
+	spec.Extra.Attrs = append(spec.Extra.Attrs, fromDeferrable(idx.Attrs)...)
+	return spec, nil
+}
+
+func pkSpec(idx *schema.Index) (*sqlspec.PrimaryKey, error) {
+	spec, err := specutil.FromPrimaryKey(idx)
+	if err != nil {
+		return nil, err
+	}
+	spec.Extra.Attrs = append(spec.Extra.Attrs, fromDeferrable(idx.Attrs)...)
+	return spec, nil
+}
+
+func fkSpec(fk *schema.ForeignKey) (*sqlspec.ForeignKey, error) {
+	spec, err := specutil.FromForeignKey(fk)
+	if err != nil {
+		return nil, err
+	}
+	spec.Extra.Attrs = append(spec.Extra.Attrs, fromDeferrable(fk.Attrs)...)
 	return spec, nil
 }
 
@@ -571,6 +1654,93 @@ func partAttr(idx *schema.Index, part *schema.IndexPart, spec *sqlspec.IndexPart
 	return nil
 }
 
+// funcSpec converts from a concrete Postgres schema.Func into a sqlspec.Func.
+func funcSpec(f *schema.Func) (*sqlspec.Func, error) {
+	spec, err := specutil.FromFunc(f, columnTypeSpec)
+	if err != nil {
+		return nil, err
+	}
+	if v := volatility(f.Attrs); v != VolatilityVolatile {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, specutil.VarAttr("volatility", v))
+	}
+	return spec, nil
+}
+
+// procSpec converts from a concrete Postgres schema.Proc into a sqlspec.Proc.
+func procSpec(p *schema.Proc) (*sqlspec.Proc, error) {
+	return specutil.FromProc(p, columnTypeSpec)
+}
+
+// viewSpec converts from a concrete Postgres schema.View into a sqlspec.View.
+func viewSpec(v *schema.View) (*sqlspec.View, error) {
+	return specutil.FromView(v, columnTypeSpec)
+}
+
+// convertMaterialized converts the materialized view specs to
+// materialized views, and adds them to the schemas of the realm.
+func convertMaterialized(specs []*materializedSpec, r *schema.Realm) error {
+	for _, spec := range specs {
+		s, err := specSchema(r, spec.Schema, "materialized view", spec.Name)
+		if err != nil {
+			return err
+		}
+		v, err := convertView(&sqlspec.View{
+			Name:             spec.Name,
+			Qualifier:        spec.Qualifier,
+			Schema:           spec.Schema,
+			Columns:          spec.Columns,
+			As:               spec.As,
+			DefaultExtension: spec.DefaultExtension,
+		}, s)
+		if err != nil {
+			return err
+		}
+		v.AddAttrs(&schema.Materialized{})
+		// Index columns are resolved from the view columns.
+		t := &schema.Table{Name: v.Name, Schema: s, Columns: v.Columns}
+		for _, is := range spec.Indexes {
+			idx, err := convertIndex(is, t)
+			if err != nil {
+				return fmt.Errorf("materialized view %q: %w", v.Name, err)
+			}
+			idx.Table = nil
+			v.AddIndexes(idx)
+		}
+		s.AddViews(v)
+	}
+	return nil
+}
+
+// fromMaterialized converts a materialized view into its spec.
+func fromMaterialized(v *schema.View) (*materializedSpec, error) {
+	// The materialized attribute is represented by the block type.
+	vc := *v
+	vc.Attrs = make([]schema.Attr, 0, len(v.Attrs))
+	for _, a := range v.Attrs {
+		if _, ok := a.(*schema.Materialized); !ok {
+			vc.Attrs = append(vc.Attrs, a)
+		}
+	}
+	vs, err := viewSpec(&vc)
+	if err != nil {
+		return nil, err
+	}
+	spec := &materializedSpec{
+		Name:             vs.Name,
+		Columns:          vs.Columns,
+		As:               vs.As,
+		DefaultExtension: vs.DefaultExtension,
+	}
+	for _, idx := range v.Indexes {
+		is, err := indexSpec(idx)
+		if err != nil {
+			return nil, err
+		}
+		spec.Indexes = append(spec.Indexes, is)
+	}
+	return spec, nil
+}
+
 // columnSpec converts from a concrete Postgres schema.Column into a sqlspec.Column.
 func columnSpec(c *schema.Column, _ *schema.Table) (*sqlspec.Column, error) {
 	s, err := specutil.FromColumn(c, columnTypeSpec)
@@ -614,6 +1784,13 @@ func columnTypeSpec(t schema.Type) (*sqlspec.Column, error) {
 			IsRef: true,
 		}}, nil
 	}
+	// Similarly, domains and composite types are referenced by their names.
+	switch t := t.(type) {
+	case *Domain:
+		return &sqlspec.Column{Type: &schemahcl.Type{T: "$domain." + t.T, IsRef: true}}, nil
+	case *CompositeType:
+		return &sqlspec.Column{Type: &schemahcl.Type{T: "$composite." + t.T, IsRef: true}}, nil
+	}
 	st, err := TypeRegistry.Convert(t)
 	if err != nil {
 		return nil, err
@@ -6,6 +6,7 @@ package postgres
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"testing"
 
@@ -258,6 +259,8 @@ enum "account_type" {
 			{SeqNo: 0, C: exp.Tables[1].Columns[0]},
 		},
 	}
+	// Enum types are shared by the columns that use them.
+	exp.AddObjects(exp.Tables[1].Columns[1].Type.Type.(*schema.EnumType))
 	exp.Realm = schema.NewRealm(exp)
 	require.EqualValues(t, exp, &s)
 }
@@ -509,6 +512,71 @@ table "logs" {
 		`), &schema.Schema{}, nil)
 		require.EqualError(t, err, `multiple definitions for logs.partition, use "columns" or "by"`)
 	})
+
+	t.Run("PartitionOf", func(t *testing.T) {
+		var (
+			s = &schema.Schema{}
+			f = `
+schema "test" {}
+table "logs_2020" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+	partition_of {
+		parent = table.logs
+		bound  = "FROM (1) TO (10)"
+	}
+}
+table "logs_rest" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+	partition_of {
+		parent  = table.logs
+		default = true
+	}
+}
+table "logs" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+	partition {
+		type    = RANGE
+		columns = [column.id]
+	}
+}
+`
+		)
+		err := EvalHCLBytes([]byte(f), s, nil)
+		require.NoError(t, err)
+		logs, ok := s.Table("logs")
+		require.True(t, ok)
+		t1, ok := s.Table("logs_2020")
+		require.True(t, ok)
+		require.Equal(t, []schema.Attr{&PartitionOf{Parent: logs, Bound: "FROM (1) TO (10)"}}, t1.Attrs)
+		t2, ok := s.Table("logs_rest")
+		require.True(t, ok)
+		require.Equal(t, []schema.Attr{&PartitionOf{Parent: logs, Default: true}}, t2.Attrs)
+
+		err = EvalHCLBytes([]byte(`
+			schema "test" {}
+			table "logs" {
+				schema = schema.test
+				column "id" { type = int }
+			}
+			table "logs_2020" {
+				schema = schema.test
+				column "id" { type = int }
+				partition_of {
+					parent = table.logs
+				}
+			}
+		`), &schema.Schema{}, nil)
+		require.EqualError(t, err, `missing "bound" or "default" for logs_2020.partition_of`)
+	})
 }
 
 func TestMarshalSpec_Partitioned(t *testing.T) {
@@ -560,6 +628,466 @@ schema "test" {
 }
 `, string(buf))
 	})
+
+	t.Run("PartitionOf", func(t *testing.T) {
+		s := schema.New("test")
+		logs := schema.NewTable("logs").AddColumns(schema.NewIntColumn("id", "int"))
+		s.AddTables(
+			logs,
+			schema.NewTable("logs_2020").AddColumns(schema.NewIntColumn("id", "int")).AddAttrs(&PartitionOf{Parent: logs, Bound: "FROM (1) TO (10)"}),
+			schema.NewTable("logs_rest").AddColumns(schema.NewIntColumn("id", "int")).AddAttrs(&PartitionOf{Parent: logs, Default: true}),
+		)
+		buf, err := MarshalHCL(s)
+		require.NoError(t, err)
+		require.Equal(t, `table "logs" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+}
+table "logs_2020" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+  partition_of {
+    parent = table.logs
+    bound  = "FROM (1) TO (10)"
+  }
+}
+table "logs_rest" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+  partition_of {
+    parent  = table.logs
+    default = true
+  }
+}
+schema "test" {
+}
+`, string(buf))
+	})
+}
+
+func TestUnmarshalSpec_Storage(t *testing.T) {
+	var (
+		s = &schema.Schema{}
+		f = `
+schema "test" {}
+table "logs" {
+	schema     = schema.test
+	unlogged   = true
+	tablespace = "fast"
+	column "id" {
+		type = int
+	}
+	storage_params {
+		fillfactor                     = 70
+		autovacuum_vacuum_scale_factor = 0.2
+		autovacuum_enabled             = false
+		toast {
+			autovacuum_enabled = true
+		}
+	}
+}
+`
+	)
+	err := EvalHCLBytes([]byte(f), s, nil)
+	require.NoError(t, err)
+	logs, ok := s.Table("logs")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{
+		&Unlogged{},
+		&Tablespace{Name: "fast"},
+		&TableStorageParams{Params: map[string]string{
+			"fillfactor":                     "70",
+			"autovacuum_vacuum_scale_factor": "0.2",
+			"autovacuum_enabled":             "false",
+			"toast.autovacuum_enabled":       "true",
+		}},
+	}, logs.Attrs)
+}
+
+func TestMarshalSpec_Storage(t *testing.T) {
+	s := schema.New("test").
+		AddTables(
+			schema.NewTable("logs").
+				AddColumns(schema.NewIntColumn("id", "int")).
+				AddAttrs(
+					&Unlogged{},
+					&Tablespace{Name: "fast"},
+					&TableStorageParams{Params: map[string]string{
+						"fillfactor":                     "70",
+						"autovacuum_vacuum_scale_factor": "0.2",
+						"toast.autovacuum_enabled":       "off",
+					}},
+				),
+		)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	require.Equal(t, `table "logs" {
+  schema     = schema.test
+  unlogged   = true
+  tablespace = "fast"
+  column "id" {
+    null = false
+    type = int
+  }
+  storage_params {
+    autovacuum_vacuum_scale_factor = 0.2
+    fillfactor                     = 70
+    toast {
+      autovacuum_enabled = "off"
+    }
+  }
+}
+schema "test" {
+}
+`, string(buf))
+}
+
+func TestUnmarshalSpec_Inherits(t *testing.T) {
+	var (
+		s = &schema.Schema{}
+		f = `
+schema "test" {}
+table "capitals" {
+	schema   = schema.test
+	inherits = [table.cities, table.regions]
+	column "state" {
+		type = text
+	}
+}
+table "cities" {
+	schema = schema.test
+	column "name" {
+		type = text
+	}
+}
+table "regions" {
+	schema = schema.test
+	column "region" {
+		type = text
+	}
+}
+`
+	)
+	err := EvalHCLBytes([]byte(f), s, nil)
+	require.NoError(t, err)
+	capitals, ok := s.Table("capitals")
+	require.True(t, ok)
+	cities, ok := s.Table("cities")
+	require.True(t, ok)
+	regions, ok := s.Table("regions")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&Inherits{Parents: []*schema.Table{cities, regions}}}, capitals.Attrs)
+	require.Empty(t, cities.Attrs)
+}
+
+func TestUnmarshalSpec_RenamedFrom(t *testing.T) {
+	var (
+		s = &schema.Schema{}
+		f = `
+schema "test" {}
+table "people" {
+	schema       = schema.test
+	renamed_from = "users"
+	column "full_name" {
+		type         = text
+		renamed_from = "name"
+	}
+	index "people_full_name" {
+		columns      = [column.full_name]
+		renamed_from = "users_name"
+	}
+}
+`
+	)
+	err := EvalHCLBytes([]byte(f), s, nil)
+	require.NoError(t, err)
+	people, ok := s.Table("people")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&schema.Renamed{From: "users"}}, people.Attrs)
+	require.Equal(t, []schema.Attr{&schema.Renamed{From: "name"}}, people.Columns[0].Attrs)
+	require.Equal(t, []schema.Attr{&schema.Renamed{From: "users_name"}}, people.Indexes[0].Attrs)
+}
+
+func TestMarshalSpec_Inherits(t *testing.T) {
+	cities := schema.NewTable("cities").
+		AddColumns(schema.NewStringColumn("name", "text")).
+		AddChecks(schema.NewCheck().SetName("name_check").SetExpr("(name <> '')"))
+	s := schema.New("test").
+		AddTables(
+			cities,
+			schema.NewTable("capitals").
+				AddColumns(
+					schema.NewStringColumn("name", "text").AddAttrs(&Inherited{}),
+					schema.NewStringColumn("state", "text"),
+				).
+				AddChecks(schema.NewCheck().SetName("name_check").SetExpr("(name <> '')").AddAttrs(&Inherited{})).
+				AddAttrs(&Inherits{Parents: []*schema.Table{cities}}),
+		)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	require.Equal(t, `table "cities" {
+  schema = schema.test
+  column "name" {
+    null = false
+    type = text
+  }
+  check "name_check" {
+    expr = "(name <> '')"
+  }
+}
+table "capitals" {
+  schema   = schema.test
+  inherits = [table.cities]
+  column "state" {
+    null = false
+    type = text
+  }
+}
+schema "test" {
+}
+`, string(buf))
+}
+
+func TestMarshalSpec_Materialized(t *testing.T) {
+	s := schema.New("public")
+	v := schema.NewMaterializedView("stats", "SELECT id, count(*) AS c FROM users GROUP BY id").
+		AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("c", "bigint"))
+	v.AddIndexes(schema.NewUniqueIndex("stats_id").AddColumns(v.Columns[0]))
+	s.AddViews(schema.NewView("active", "SELECT id FROM users"), v)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	require.Equal(t, `view "active" {
+  schema = schema.public
+  as     = "SELECT id FROM users"
+}
+materialized "stats" {
+  schema = schema.public
+  as     = "SELECT id, count(*) AS c FROM users GROUP BY id"
+  column "id" {
+    null = false
+    type = int
+  }
+  column "c" {
+    null = false
+    type = bigint
+  }
+  index "stats_id" {
+    unique  = true
+    columns = [column.id]
+  }
+}
+schema "public" {
+}
+`, string(buf))
+
+	got := &schema.Schema{}
+	require.NoError(t, EvalHCLBytes(buf, got, nil))
+	require.Len(t, got.Views, 2)
+	require.False(t, got.Views[0].Materialized())
+	mv := got.Views[1]
+	require.True(t, mv.Materialized())
+	require.Len(t, mv.Indexes, 1)
+	require.True(t, mv.Indexes[0].Unique)
+	require.Equal(t, mv, mv.Indexes[0].View)
+	require.Nil(t, mv.Indexes[0].Table)
+	require.Equal(t, mv.Columns[0], mv.Indexes[0].Parts[0].C)
+}
+
+func TestUnmarshalSpec_Policies(t *testing.T) {
+	var (
+		s = &schema.Schema{}
+		f = `
+schema "test" {}
+table "accounts" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+	row_security {
+		enabled = true
+		force   = true
+	}
+	policy "isolation" {
+		as    = RESTRICTIVE
+		to    = ["app"]
+		using = "id = current_setting('app.id')::int"
+	}
+	policy "writes" {
+		command = INSERT
+		check   = "id > 0"
+	}
+}
+`
+	)
+	err := EvalHCLBytes([]byte(f), s, nil)
+	require.NoError(t, err)
+	accounts, ok := s.Table("accounts")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{
+		&RowSecurity{Enabled: true, Forced: true},
+		&Policy{Name: "isolation", As: PolicyAsRestrictive, Roles: []string{"app"}, Using: "id = current_setting('app.id')::int"},
+		&Policy{Name: "writes", Command: PolicyCmdInsert, Check: "id > 0"},
+	}, accounts.Attrs)
+}
+
+func TestMarshalSpec_Policies(t *testing.T) {
+	s := schema.New("test").
+		AddTables(
+			schema.NewTable("accounts").
+				AddColumns(schema.NewIntColumn("id", "int")).
+				AddAttrs(
+					&RowSecurity{Enabled: true},
+					// Defaults are not printed.
+					&Policy{Name: "isolation", As: PolicyAsRestrictive, Command: PolicyCmdAll, Roles: []string{"app", "admin"}, Using: "(id > 0)"},
+					&Policy{Name: "writes", As: PolicyAsPermissive, Command: PolicyCmdInsert, Check: "true"},
+				),
+		)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	require.Equal(t, `table "accounts" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+  row_security {
+    enabled = true
+  }
+  policy "isolation" {
+    as    = RESTRICTIVE
+    to    = ["app", "admin"]
+    using = "(id > 0)"
+  }
+  policy "writes" {
+    command = INSERT
+    check   = "true"
+  }
+}
+schema "test" {
+}
+`, string(buf))
+}
+
+func TestUnmarshalSpec_Constraints(t *testing.T) {
+	var (
+		s = &schema.Schema{}
+		f = `
+schema "test" {}
+table "bookings" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+	column "room" {
+		type = int
+	}
+	primary_key {
+		columns    = [column.id]
+		deferrable = true
+	}
+	foreign_key "self" {
+		columns            = [column.room]
+		ref_columns        = [column.id]
+		deferrable         = true
+		initially_deferred = true
+	}
+	index "room_key" {
+		unique     = true
+		columns    = [column.room]
+		deferrable = true
+	}
+	exclude "no_overlap" {
+		type = GIST
+		on {
+			column = column.room
+			op     = "="
+		}
+		on {
+			expr = "int4range(id, id + 1)"
+			op   = "&&"
+		}
+		where = "id > 0"
+	}
+}
+`
+	)
+	err := EvalHCLBytes([]byte(f), s, nil)
+	require.NoError(t, err)
+	bookings, ok := s.Table("bookings")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&Deferrable{}}, bookings.PrimaryKey.Attrs)
+	require.Equal(t, []schema.Attr{&Deferrable{InitiallyDeferred: true}}, bookings.ForeignKeys[0].Attrs)
+	require.Len(t, bookings.Indexes, 2)
+	require.Equal(t, []schema.Attr{&Deferrable{}, &ConType{T: "u"}}, bookings.Indexes[0].Attrs)
+	excl := bookings.Indexes[1]
+	require.Equal(t, "no_overlap", excl.Name)
+	require.Equal(t, []schema.Attr{&IndexType{T: IndexTypeGiST}, &IndexPredicate{P: "id > 0"}, &ConType{T: "x"}}, excl.Attrs)
+	require.Equal(t, bookings.Columns[1], excl.Parts[0].C)
+	require.Equal(t, []schema.Attr{&ExcludeOp{Op: "="}}, excl.Parts[0].Attrs)
+	require.Equal(t, &schema.RawExpr{X: "int4range(id, id + 1)"}, excl.Parts[1].X)
+	require.Equal(t, []schema.Attr{&ExcludeOp{Op: "&&"}}, excl.Parts[1].Attrs)
+}
+
+func TestMarshalSpec_Constraints(t *testing.T) {
+	s := schema.New("test")
+	bookings := schema.NewTable("bookings").
+		AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("room", "int"))
+	bookings.SetPrimaryKey(schema.NewPrimaryKey(bookings.Columns[0]).AddAttrs(&Deferrable{}))
+	bookings.AddIndexes(
+		schema.NewUniqueIndex("room_key").AddColumns(bookings.Columns[1]).AddAttrs(&ConType{T: "u"}, &Deferrable{InitiallyDeferred: true}),
+		schema.NewIndex("no_overlap").
+			AddParts(schema.NewColumnPart(bookings.Columns[1]).AddAttrs(&ExcludeOp{Op: "="})).
+			AddAttrs(&ConType{T: "x"}, &IndexType{T: IndexTypeGiST}),
+	)
+	bookings.AddForeignKeys(schema.NewForeignKey("self").AddColumns(bookings.Columns[1]).SetRefTable(bookings).AddRefColumns(bookings.Columns[0]).AddAttrs(&Deferrable{}))
+	s.AddTables(bookings)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	require.Equal(t, `table "bookings" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+  column "room" {
+    null = false
+    type = int
+  }
+  primary_key {
+    columns    = [column.id]
+    deferrable = true
+  }
+  foreign_key "self" {
+    columns     = [column.room]
+    ref_columns = [column.id]
+    deferrable  = true
+  }
+  index "room_key" {
+    unique             = true
+    columns            = [column.room]
+    deferrable         = true
+    initially_deferred = true
+  }
+  exclude "no_overlap" {
+    type = GIST
+    on {
+      column = column.room
+      op     = "="
+    }
+  }
+}
+schema "test" {
+}
+`, string(buf))
 }
 
 func TestMarshalSpec_IndexPredicate(t *testing.T) {
@@ -1689,3 +2217,356 @@ schema "s2" {
 `,
 		string(got))
 }
+
+func TestMarshalSpec_View(t *testing.T) {
+	s := schema.New("public")
+	s.AddTables(
+		schema.NewTable("users").
+			AddColumns(schema.NewIntColumn("id", "integer")),
+	)
+	s.AddViews(
+		schema.NewView("active_users", "SELECT id FROM users WHERE id > 0").
+			SetCheckOption(schema.ViewCheckOptionLocal).
+			SetComment("active users").
+			AddColumns(schema.NewIntColumn("id", "integer")),
+	)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Views, 1)
+	v := got.Views[0]
+	require.Equal(t, "active_users", v.Name)
+	require.Equal(t, "SELECT id FROM users WHERE id > 0", v.Def)
+	require.Equal(t, []schema.Attr{&schema.ViewCheckOption{V: schema.ViewCheckOptionLocal}, &schema.Comment{Text: "active users"}}, v.Attrs)
+	require.Len(t, v.Columns, 1)
+	require.Equal(t, "id", v.Columns[0].Name)
+}
+
+func TestMarshalSpec_Func(t *testing.T) {
+	s := schema.New("public")
+	s.AddFuncs(
+		schema.NewFunc("add").
+			AddArgs(
+				schema.NewFuncArg("a", &schema.IntegerType{T: TypeInteger}),
+				schema.NewFuncArg("b", &schema.IntegerType{T: TypeInteger}).SetDefault(&schema.Literal{V: "1"}),
+			).
+			SetReturnType(&schema.IntegerType{T: TypeInteger}).
+			SetLang("sql").
+			SetBody("SELECT a + b").
+			AddAttrs(&Volatility{V: VolatilityImmutable}),
+	)
+	s.AddProcs(
+		schema.NewProc("log").
+			AddArgs(schema.NewFuncArg("msg", &schema.StringType{T: TypeText}).SetMode(schema.FuncArgModeInOut)).
+			SetLang("plpgsql").
+			SetBody("BEGIN RAISE NOTICE '%', msg; END").
+			SetComment("log a message"),
+	)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Funcs, 1)
+	f := got.Funcs[0]
+	require.Equal(t, "add", f.Name)
+	require.Equal(t, "sql", f.Lang)
+	require.Equal(t, "SELECT a + b", f.Body)
+	require.Equal(t, &schema.IntegerType{T: TypeInteger}, f.Ret)
+	require.Equal(t, []schema.Attr{&Volatility{V: VolatilityImmutable}}, f.Attrs)
+	require.Len(t, f.Args, 2)
+	require.Equal(t, "b", f.Args[1].Name)
+	require.Equal(t, &schema.Literal{V: "1"}, f.Args[1].Default)
+	require.Len(t, got.Procs, 1)
+	p := got.Procs[0]
+	require.Equal(t, "log", p.Name)
+	require.Equal(t, "plpgsql", p.Lang)
+	require.Equal(t, schema.FuncArgModeInOut, p.Args[0].Mode)
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "log a message"}}, p.Attrs)
+}
+
+func TestMarshalSpec_Trigger(t *testing.T) {
+	s := schema.New("public")
+	s.AddFuncs(
+		schema.NewFunc("audit").
+			SetReturnType(&schema.UnsupportedType{T: "trigger"}).
+			SetLang("plpgsql").
+			SetBody("BEGIN RETURN NEW; END"),
+	)
+	users := schema.NewTable("users").AddColumns(schema.NewStringColumn("name", TypeText))
+	users.AddTriggers(
+		schema.NewTrigger("users_audit").
+			SetActionTime(schema.TriggerTimeAfter).
+			AddEvents(
+				schema.TriggerEvent{Name: schema.TriggerEventInsert},
+				schema.TriggerEvent{Name: schema.TriggerEventUpdate, Columns: users.Columns},
+			).
+			SetFor(schema.TriggerForRow).
+			SetFunc(s.Funcs[0]),
+	)
+	s.AddTables(users)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	tg := got.Tables[0].Triggers[0]
+	require.Equal(t, "users_audit", tg.Name)
+	require.Equal(t, got.Tables[0], tg.Table)
+	require.Equal(t, schema.TriggerTimeAfter, tg.ActionTime)
+	require.Equal(t, schema.TriggerForRow, tg.For)
+	require.Equal(t, []schema.TriggerEvent{
+		{Name: schema.TriggerEventInsert},
+		{Name: schema.TriggerEventUpdate, Columns: got.Tables[0].Columns},
+	}, tg.Events)
+	require.Equal(t, got.Funcs[0], tg.Func)
+
+	// Triggers are FOR EACH STATEMENT by default.
+	require.NoError(t, EvalHCLBytes([]byte(`
+schema "public" {}
+table "users" {
+  schema = schema.public
+  column "id" {
+    type = int
+  }
+  trigger "users_truncate" {
+    timing  = BEFORE
+    events  = [TRUNCATE]
+    execute = function.audit
+  }
+}
+function "audit" {
+  schema = schema.public
+  return = sql("trigger")
+  lang   = "plpgsql"
+  as     = "BEGIN RETURN NULL; END"
+}
+`), &got, nil))
+	tg = got.Tables[0].Triggers[0]
+	require.Equal(t, schema.TriggerTimeBefore, tg.ActionTime)
+	require.Equal(t, schema.TriggerForStmt, tg.For)
+	require.Equal(t, []schema.TriggerEvent{{Name: schema.TriggerEventTruncate}}, tg.Events)
+	require.Equal(t, got.Funcs[0], tg.Func)
+}
+
+func TestMarshalSpec_Sequence(t *testing.T) {
+	s := schema.New("public")
+	users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", TypeBigInt))
+	users.Columns[0].Default = &schema.RawExpr{X: "nextval('users_seq')"}
+	s.AddTables(users)
+	seq := &Sequence{Name: "users_seq", Schema: s, Type: &schema.IntegerType{T: TypeInteger}, Start: 100, Increment: 1, Min: 1, Max: math.MaxInt32, Cache: 10}
+	seq.Owner.T, seq.Owner.C = users, users.Columns[0]
+	s.AddObjects(seq, &Sequence{Name: "counter", Schema: s, Type: &schema.IntegerType{T: TypeBigInt}, Start: -1, Increment: -1, Min: math.MinInt64, Max: -1, Cache: 1, Cycle: true})
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	require.Equal(t, `table "users" {
+  schema = schema.public
+  column "id" {
+    null    = false
+    type    = bigint
+    default = sql("nextval('users_seq')")
+  }
+}
+sequence "users_seq" {
+  schema   = schema.public
+  type     = integer
+  start    = 100
+  cache    = 10
+  owned_by = table.users.column.id
+}
+sequence "counter" {
+  schema    = schema.public
+  increment = -1
+  cycle     = true
+}
+schema "public" {
+}
+`, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Objects, 2)
+	users1 := got.Objects[0].(*Sequence)
+	require.Equal(t, "users_seq", users1.Name)
+	require.Equal(t, &got, users1.Schema)
+	require.Equal(t, &schema.IntegerType{T: TypeInteger}, users1.Type)
+	require.EqualValues(t, 100, users1.Start)
+	require.EqualValues(t, 10, users1.Cache)
+	require.Equal(t, got.Tables[0], users1.Owner.T)
+	require.Equal(t, got.Tables[0].Columns[0], users1.Owner.C)
+	changed, err := sequenceChanged(seq, users1)
+	require.NoError(t, err)
+	require.False(t, changed)
+	counter := got.Objects[1].(*Sequence)
+	require.EqualValues(t, -1, counter.Increment)
+	require.True(t, counter.Cycle)
+	require.Nil(t, counter.Owner.T)
+}
+
+func TestMarshalSpec_Extension(t *testing.T) {
+	s := schema.New("public")
+	s.AddObjects(
+		&Extension{Name: "citext", Schema: s, Version: "1.6"},
+		&Extension{Name: "pgcrypto", Schema: s},
+	)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	require.Equal(t, `extension "citext" {
+  schema  = schema.public
+  version = "1.6"
+}
+extension "pgcrypto" {
+  schema = schema.public
+}
+schema "public" {
+}
+`, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Objects, 2)
+	require.Equal(t, &Extension{Name: "citext", Schema: &got, Version: "1.6"}, got.Objects[0])
+	require.Equal(t, &Extension{Name: "pgcrypto", Schema: &got}, got.Objects[1])
+}
+
+func TestMarshalSpec_Types(t *testing.T) {
+	s := schema.New("public")
+	mood := &schema.EnumType{T: "mood", Schema: s, Values: []string{"sad", "happy"}}
+	posint := &Domain{
+		T:       "posint",
+		Schema:  s,
+		Type:    &schema.IntegerType{T: TypeInteger},
+		Default: &schema.Literal{V: "1"},
+		Checks:  []*schema.Check{{Name: "posint_check", Expr: "VALUE > 0"}},
+	}
+	feeling := &Domain{T: "feeling", Schema: s, Type: mood, Null: true}
+	address := &CompositeType{
+		T:      "address",
+		Schema: s,
+		Fields: []*schema.Column{
+			schema.NewStringColumn("street", TypeText),
+			schema.NewColumn("feeling").SetType(feeling),
+		},
+	}
+	s.AddObjects(mood, posint, feeling, address)
+	s.AddTables(
+		schema.NewTable("users").AddColumns(
+			schema.NewColumn("age").SetType(posint),
+			schema.NewNullColumn("address").SetType(address),
+		),
+	)
+	buf, err := MarshalSpec(s, hclState)
+	require.NoError(t, err)
+	require.Equal(t, `table "users" {
+  schema = schema.public
+  column "age" {
+    null = false
+    type = domain.posint
+  }
+  column "address" {
+    null = true
+    type = composite.address
+  }
+}
+enum "mood" {
+  schema = schema.public
+  values = ["sad", "happy"]
+}
+domain "posint" {
+  schema  = schema.public
+  type    = integer
+  default = 1
+  check "posint_check" {
+    expr = "VALUE > 0"
+  }
+}
+domain "feeling" {
+  schema = schema.public
+  type   = enum.mood
+  null   = true
+}
+composite "address" {
+  schema = schema.public
+  field "street" {
+    type = text
+  }
+  field "feeling" {
+    type = domain.feeling
+  }
+}
+schema "public" {
+}
+`, string(buf))
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Objects, 4)
+	mood1, posint1 := got.Objects[0].(*schema.EnumType), got.Objects[1].(*Domain)
+	require.Equal(t, "posint", posint1.T)
+	require.Equal(t, &schema.IntegerType{T: TypeInteger}, posint1.Type)
+	require.False(t, posint1.Null)
+	require.Equal(t, &schema.Literal{V: "1"}, posint1.Default)
+	require.Equal(t, posint.Checks, posint1.Checks)
+	feeling1 := got.Objects[2].(*Domain)
+	require.True(t, feeling1.Null)
+	require.Same(t, mood1, feeling1.Type)
+	address1 := got.Objects[3].(*CompositeType)
+	require.Len(t, address1.Fields, 2)
+	require.Equal(t, &schema.StringType{T: TypeText}, address1.Fields[0].Type.Type)
+	require.Same(t, feeling1, address1.Fields[1].Type.Type)
+	// Columns share the type objects of the schema.
+	require.Same(t, posint1, got.Tables[0].Columns[0].Type.Type)
+	require.Same(t, address1, got.Tables[0].Columns[1].Type.Type)
+
+	err = EvalHCLBytes([]byte(`
+schema "public" {}
+table "users" {
+  schema = schema.public
+  column "age" {
+    type = domain.posint
+  }
+}
+domain "posint" {
+  schema = schema.public
+  type   = domain.unknown
+}
+`), &got, nil)
+	require.Error(t, err)
+}
+
+func TestUnmarshalSpec_EnumObjects(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, EvalHCLBytes([]byte(`
+schema "public" {}
+enum "status" {
+  schema = schema.public
+  values = ["active", "inactive"]
+}
+enum "mood" {
+  schema = schema.public
+  values = ["sad", "happy"]
+}
+table "users" {
+  schema = schema.public
+  column "status" {
+    type = enum.status
+  }
+}
+table "pets" {
+  schema = schema.public
+  column "status" {
+    type = enum.status
+  }
+}
+`), &s, nil))
+	// Enum types are schema objects, even if they are not used by any column.
+	require.Equal(t, []schema.Object{
+		&schema.EnumType{T: "status", Schema: &s, Values: []string{"active", "inactive"}},
+		&schema.EnumType{T: "mood", Schema: &s, Values: []string{"sad", "happy"}},
+	}, s.Objects)
+	require.Same(t, s.Objects[0], s.Tables[0].Columns[0].Type.Type)
+	require.Same(t, s.Objects[0], s.Tables[1].Columns[0].Type.Type)
+
+	buf, err := MarshalSpec(&s, hclState)
+	require.NoError(t, err)
+	require.Contains(t, string(buf), `enum "mood" {
+  schema = schema.public
+  values = ["sad", "happy"]
+}`)
+}
@@ -70,6 +70,39 @@ func (s *Schema) AddTables(tables ...*Table) *Schema {
 	return s
 }
 
+// AddViews adds and links the given views to the schema.
+func (s *Schema) AddViews(views ...*View) *Schema {
+	for _, v := range views {
+		v.SetSchema(s)
+	}
+	s.Views = append(s.Views, views...)
+	return s
+}
+
+// AddFuncs adds and links the given functions to the schema.
+func (s *Schema) AddFuncs(funcs ...*Func) *Schema {
+	for _, f := range funcs {
+		f.SetSchema(s)
+	}
+	s.Funcs = append(s.Funcs, funcs...)
+	return s
+}
+
+// AddProcs adds and links the given procedures to the schema.
+func (s *Schema) AddProcs(procs ...*Proc) *Schema {
+	for _, p := range procs {
+		p.SetSchema(s)
+	}
+	s.Procs = append(s.Procs, procs...)
+	return s
+}
+
+// AddObjects adds the given objects to the schema.
+func (s *Schema) AddObjects(objs ...Object) *Schema {
+	s.Objects = append(s.Objects, objs...)
+	return s
+}
+
 // NewRealm creates a new Realm.
 func NewRealm(schemas ...*Schema) *Realm {
 	r := &Realm{Schemas: schemas}
@@ -88,6 +121,21 @@ func (r *Realm) AddSchemas(schemas ...*Schema) *Realm {
 	return r
 }
 
+// AddRoles adds and links the given roles to the realm.
+func (r *Realm) AddRoles(roles ...*Role) *Realm {
+	for _, ro := range roles {
+		ro.Realm = r
+	}
+	r.Roles = append(r.Roles, roles...)
+	return r
+}
+
+// AddPrivileges adds the given privileges to the realm.
+func (r *Realm) AddPrivileges(privs ...*Privilege) *Realm {
+	r.Privileges = append(r.Privileges, privs...)
+	return r
+}
+
 // SetCharset sets or appends the Charset attribute
 // to the realm with the given value.
 func (r *Realm) SetCharset(v string) *Realm {
@@ -114,6 +162,22 @@ func (r *Realm) UnsetCollation() *Realm {
 	return r
 }
 
+// NewRole creates a new Role.
+func NewRole(name string) *Role {
+	return &Role{Name: name}
+}
+
+// NewUser creates a new Role that can log in to the database.
+func NewUser(name string) *Role {
+	return &Role{Name: name, Login: true}
+}
+
+// AddAttrs adds and additional attributes to the role.
+func (r *Role) AddAttrs(attrs ...Attr) *Role {
+	r.Attrs = append(r.Attrs, attrs...)
+	return r
+}
+
 // NewTable creates a new Table.
 func NewTable(name string) *Table {
 	return &Table{Name: name}
@@ -196,6 +260,15 @@ func (t *Table) AddIndexes(indexes ...*Index) *Table {
 	return t
 }
 
+// AddTriggers appends the given triggers to the table triggers list.
+func (t *Table) AddTriggers(triggers ...*Trigger) *Table {
+	for _, tg := range triggers {
+		tg.Table = t
+	}
+	t.Triggers = append(t.Triggers, triggers...)
+	return t
+}
+
 // AddForeignKeys appends the given foreign-keys to the table foreign-key list.
 func (t *Table) AddForeignKeys(fks ...*ForeignKey) *Table {
 	for _, fk := range fks {
@@ -211,6 +284,165 @@ func (t *Table) AddAttrs(attrs ...Attr) *Table {
 	return t
 }
 
+// NewView creates a new View.
+func NewView(name, def string) *View {
+	return &View{Name: name, Def: def}
+}
+
+// NewMaterializedView creates a new materialized View.
+func NewMaterializedView(name, def string) *View {
+	return &View{Name: name, Def: def, Attrs: []Attr{&Materialized{}}}
+}
+
+// SetSchema sets the schema (named-database) of the view.
+func (v *View) SetSchema(s *Schema) *View {
+	v.Schema = s
+	return v
+}
+
+// SetComment sets or appends the Comment attribute
+// to the view with the given value.
+func (v *View) SetComment(c string) *View {
+	ReplaceOrAppend(&v.Attrs, &Comment{Text: c})
+	return v
+}
+
+// SetCheckOption sets or appends the ViewCheckOption attribute
+// to the view with the given value.
+func (v *View) SetCheckOption(opt string) *View {
+	ReplaceOrAppend(&v.Attrs, &ViewCheckOption{V: opt})
+	return v
+}
+
+// AddColumns appends the given columns to the view column list.
+func (v *View) AddColumns(columns ...*Column) *View {
+	v.Columns = append(v.Columns, columns...)
+	return v
+}
+
+// AddIndexes appends the given indexes to the view index list.
+// Indexes are supported only by materialized views.
+func (v *View) AddIndexes(indexes ...*Index) *View {
+	for _, idx := range indexes {
+		idx.View = v
+	}
+	v.Indexes = append(v.Indexes, indexes...)
+	return v
+}
+
+// AddAttrs adds and additional attributes to the view.
+func (v *View) AddAttrs(attrs ...Attr) *View {
+	v.Attrs = append(v.Attrs, attrs...)
+	return v
+}
+
+// NewFunc creates a new Func.
+func NewFunc(name string) *Func {
+	return &Func{Name: name}
+}
+
+// SetSchema sets the schema (named-database) of the function.
+func (f *Func) SetSchema(s *Schema) *Func {
+	f.Schema = s
+	return f
+}
+
+// AddArgs appends the given arguments to the function argument list.
+func (f *Func) AddArgs(args ...*FuncArg) *Func {
+	f.Args = append(f.Args, args...)
+	return f
+}
+
+// SetReturnType sets the return type of the function.
+func (f *Func) SetReturnType(t Type) *Func {
+	f.Ret = t
+	return f
+}
+
+// SetLang sets the language of the function.
+func (f *Func) SetLang(l string) *Func {
+	f.Lang = l
+	return f
+}
+
+// SetBody sets the body of the function.
+func (f *Func) SetBody(b string) *Func {
+	f.Body = b
+	return f
+}
+
+// SetComment sets or appends the Comment attribute
+// to the function with the given value.
+func (f *Func) SetComment(c string) *Func {
+	ReplaceOrAppend(&f.Attrs, &Comment{Text: c})
+	return f
+}
+
+// AddAttrs adds and additional attributes to the function.
+func (f *Func) AddAttrs(attrs ...Attr) *Func {
+	f.Attrs = append(f.Attrs, attrs...)
+	return f
+}
+
+// NewProc creates a new Proc.
+func NewProc(name string) *Proc {
+	return &Proc{Name: name}
+}
+
+// SetSchema sets the schema (named-database) of the procedure.
+func (p *Proc) SetSchema(s *Schema) *Proc {
+	p.Schema = s
+	return p
+}
+
+// AddArgs appends the given arguments to the procedure argument list.
+func (p *Proc) AddArgs(args ...*FuncArg) *Proc {
+	p.Args = append(p.Args, args...)
+	return p
+}
+
+// SetLang sets the language of the procedure.
+func (p *Proc) SetLang(l string) *Proc {
+	p.Lang = l
+	return p
+}
+
+// SetBody sets the body of the procedure.
+func (p *Proc) SetBody(b string) *Proc {
+	p.Body = b
+	return p
+}
+
+// SetComment sets or appends the Comment attribute
+// to the procedure with the given value.
+func (p *Proc) SetComment(c string) *Proc {
+	ReplaceOrAppend(&p.Attrs, &Comment{Text: c})
+	return p
+}
+
+// AddAttrs adds and additional attributes to the procedure.
+func (p *Proc) AddAttrs(attrs ...Attr) *Proc {
+	p.Attrs = append(p.Attrs, attrs...)
+	return p
+}
+
+// NewFuncArg creates a new function argument with the given name and type.
+func NewFuncArg(name string, t Type) *FuncArg {
+	return &FuncArg{Name: name, Type: t}
+}
+
+// SetMode sets the mode of the argument. e.g. IN or OUT.
+func (a *FuncArg) SetMode(m string) *FuncArg {
+	a.Mode = m
+	return a
+}
+
+// SetDefault sets the default value of the argument.
+func (a *FuncArg) SetDefault(x Expr) *FuncArg {
+	a.Default = x
+	return a
+}
+
 // NewColumn creates a new column with the given name.
 func NewColumn(name string) *Column {
 	return &Column{Name: name}
@@ -737,6 +969,12 @@ func (f *ForeignKey) SetOnDelete(o ReferenceOption) *ForeignKey {
 	return f
 }
 
+// AddAttrs adds additional attributes to the foreign key.
+func (f *ForeignKey) AddAttrs(attrs ...Attr) *ForeignKey {
+	f.Attrs = append(f.Attrs, attrs...)
+	return f
+}
+
 // ReplaceOrAppend searches an attribute of the same type as v in
 // the list and replaces it. Otherwise, v is appended to the list.
 func ReplaceOrAppend(attrs *[]Attr, v Attr) {
@@ -772,3 +1010,50 @@ func del(attrs *[]Attr, v Attr) {
 		}
 	}
 }
+
+// NewTrigger creates a new Trigger.
+func NewTrigger(name string) *Trigger {
+	return &Trigger{Name: name}
+}
+
+// SetTable sets the table of the trigger.
+func (t *Trigger) SetTable(tt *Table) *Trigger {
+	t.Table = tt
+	return t
+}
+
+// SetActionTime sets the action time of the trigger. e.g. BEFORE or AFTER.
+func (t *Trigger) SetActionTime(at string) *Trigger {
+	t.ActionTime = at
+	return t
+}
+
+// AddEvents appends the given events to the trigger events list.
+func (t *Trigger) AddEvents(events ...TriggerEvent) *Trigger {
+	t.Events = append(t.Events, events...)
+	return t
+}
+
+// SetFor sets the level of the trigger. i.e. ROW or STATEMENT.
+func (t *Trigger) SetFor(f string) *Trigger {
+	t.For = f
+	return t
+}
+
+// SetBody sets the body of the trigger.
+func (t *Trigger) SetBody(b string) *Trigger {
+	t.Body = b
+	return t
+}
+
+// SetFunc sets the function that is executed by the trigger.
+func (t *Trigger) SetFunc(f *Func) *Trigger {
+	t.Func = f
+	return t
+}
+
+// AddAttrs adds and additional attributes to the trigger.
+func (t *Trigger) AddAttrs(attrs ...Attr) *Trigger {
+	t.Attrs = append(t.Attrs, attrs...)
+	return t
+}
@@ -43,8 +43,34 @@ const (
 	// InspectTables enables schema tables inspection including
 	// all its child resources (e.g. columns or indexes).
 	InspectTables
+
+	// InspectViews enables schema views inspection. Unlike schemas and
+	// tables, it is not enabled by default, and must be set explicitly.
+	InspectViews
+
+	// InspectFuncs enables schema functions and procedures inspection.
+	// It is not enabled by default, and must be set explicitly.
+	InspectFuncs
+
+	// InspectTriggers enables table triggers inspection.
+	// It is not enabled by default, and must be set explicitly.
+	InspectTriggers
+
+	// InspectObjects enables driver-specific schema objects inspection.
+	// For example, standalone sequences in PostgreSQL. It is not enabled
+	// by default, and must be set explicitly.
+	InspectObjects
+
+	// InspectRoles enables roles, users and privileges inspection.
+	// It is not enabled by default, and must be set explicitly.
+	InspectRoles
 )
 
+// InspectAll enables the inspection of all schema resources. Note that
+// realm-level resources, such as roles, are not included and must be
+// requested explicitly using the InspectRoles mode.
+const InspectAll = InspectSchemas | InspectTables | InspectViews | InspectFuncs | InspectTriggers | InspectObjects
+
 // Is reports whether the given mode is enabled.
 func (m InspectMode) Is(i InspectMode) bool { return m&i != 0 }
 
@@ -7,6 +7,8 @@ package schema
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 	"time"
 )
 
@@ -86,6 +88,104 @@ type (
 		From, To *Table
 	}
 
+	// AddView describes a view creation change.
+	AddView struct {
+		V     *View
+		Extra []Clause // Extra clauses and options.
+	}
+
+	// DropView describes a view removal change.
+	DropView struct {
+		V     *View
+		Extra []Clause // Extra clauses.
+	}
+
+	// ModifyView describes a view modification change. For example,
+	// the view definition or its check option were changed.
+	ModifyView struct {
+		From, To *View
+	}
+
+	// AddObject describes a driver-specific object creation change.
+	AddObject struct {
+		O     Object
+		Extra []Clause // Extra clauses and options.
+	}
+
+	// DropObject describes a driver-specific object removal change.
+	DropObject struct {
+		O     Object
+		Extra []Clause // Extra clauses.
+	}
+
+	// ModifyObject describes a driver-specific object modification change.
+	ModifyObject struct {
+		From, To Object
+	}
+
+	// AddFunc describes a function creation change.
+	AddFunc struct {
+		F     *Func
+		Extra []Clause // Extra clauses and options.
+	}
+
+	// DropFunc describes a function removal change.
+	DropFunc struct {
+		F     *Func
+		Extra []Clause // Extra clauses.
+	}
+
+	// ModifyFunc describes a function modification change. For example,
+	// the function body or its return type were changed.
+	ModifyFunc struct {
+		From, To *Func
+	}
+
+	// AddProc describes a procedure creation change.
+	AddProc struct {
+		P     *Proc
+		Extra []Clause // Extra clauses and options.
+	}
+
+	// DropProc describes a procedure removal change.
+	DropProc struct {
+		P     *Proc
+		Extra []Clause // Extra clauses.
+	}
+
+	// ModifyProc describes a procedure modification change.
+	ModifyProc struct {
+		From, To *Proc
+	}
+
+	// AddRole describes a role (or a user) creation change.
+	AddRole struct {
+		R     *Role
+		Extra []Clause // Extra clauses and options.
+	}
+
+	// DropRole describes a role (or a user) removal change.
+	DropRole struct {
+		R     *Role
+		Extra []Clause // Extra clauses.
+	}
+
+	// ModifyRole describes a role modification change. For
+	// example, the role attributes or its login were changed.
+	ModifyRole struct {
+		From, To *Role
+	}
+
+	// Grant describes a change that grants privileges to a role.
+	Grant struct {
+		P *Privilege
+	}
+
+	// Revoke describes a change that revokes privileges from a role.
+	Revoke struct {
+		P *Privilege
+	}
+
 	// AddColumn describes a column creation change.
 	AddColumn struct {
 		C *Column
@@ -128,6 +228,22 @@ type (
 		From, To *Index
 	}
 
+	// AddTrigger describes a trigger creation change.
+	AddTrigger struct {
+		T *Trigger
+	}
+
+	// DropTrigger describes a trigger removal change.
+	DropTrigger struct {
+		T *Trigger
+	}
+
+	// ModifyTrigger describes a trigger modification change. For example,
+	// the trigger events or its body were changed.
+	ModifyTrigger struct {
+		From, To *Trigger
+	}
+
 	// AddForeignKey describes a foreign-key creation change.
 	AddForeignKey struct {
 		F *ForeignKey
@@ -239,6 +355,10 @@ const (
 	ChangeUpdateAction
 	// ChangeDeleteAction describes a change to the foreign-key delete action.
 	ChangeDeleteAction
+
+	// ChangePosition describes a change to the column position in the table.
+	// Reported only by drivers that support reordering columns (e.g. MySQL).
+	ChangePosition
 )
 
 // Is reports whether c is match the given change kind.
@@ -246,23 +366,212 @@ func (k ChangeKind) Is(c ChangeKind) bool {
 	return k == c || k&c != 0
 }
 
-// Differ is the interface implemented by the different
-// drivers for comparing and diffing schema top elements.
-type Differ interface {
-	// RealmDiff returns a diff report for migrating a realm
-	// (or a database) from state "from" to state "to". An error
-	// is returned if such step is not possible.
-	RealmDiff(from, to *Realm) ([]Change, error)
-
-	// SchemaDiff returns a diff report for migrating a schema
-	// from state "from" to state "to". An error is returned
-	// if such step is not possible.
-	SchemaDiff(from, to *Schema) ([]Change, error)
-
-	// TableDiff returns a diff report for migrating a table
-	// from state "from" to state "to". An error is returned
-	// if such step is not possible.
-	TableDiff(from, to *Table) ([]Change, error)
+type (
+	// Differ is the interface implemented by the different
+	// drivers for comparing and diffing schema top elements.
+	Differ interface {
+		// RealmDiff returns a diff report for migrating a realm
+		// (or a database) from state "from" to state "to". An error
+		// is returned if such step is not possible.
+		RealmDiff(from, to *Realm) ([]Change, error)
+
+		// SchemaDiff returns a diff report for migrating a schema
+		// from state "from" to state "to". An error is returned
+		// if such step is not possible.
+		SchemaDiff(from, to *Schema) ([]Change, error)
+
+		// TableDiff returns a diff report for migrating a table
+		// from state "from" to state "to". An error is returned
+		// if such step is not possible.
+		TableDiff(from, to *Table) ([]Change, error)
+	}
+
+	// OptionsDiffer is implemented by Differs that can be configured
+	// using DiffOptions. Use the RealmDiff, SchemaDiff and TableDiff
+	// functions for calling a Differ with options.
+	OptionsDiffer interface {
+		Differ
+
+		// RealmDiffWithOptions is like RealmDiff, but accepts DiffOptions.
+		RealmDiffWithOptions(from, to *Realm, opts ...DiffOption) ([]Change, error)
+
+		// SchemaDiffWithOptions is like SchemaDiff, but accepts DiffOptions.
+		SchemaDiffWithOptions(from, to *Schema, opts ...DiffOption) ([]Change, error)
+
+		// TableDiffWithOptions is like TableDiff, but accepts DiffOptions.
+		TableDiffWithOptions(from, to *Table, opts ...DiffOption) ([]Change, error)
+	}
+
+	// DiffOptions defines the options for the Differ.
+	DiffOptions struct {
+		// AskRename enables the detection of renamed tables, columns and indexes
+		// by their definitions. It is called with the rename change (RenameTable,
+		// RenameColumn or RenameIndex) of each dropped object that has an added
+		// counterpart with an identical definition, and reports if the two should
+		// be planned as a rename, or as a drop and an add. Renames that were declared
+		// using the Renamed attribute are detected regardless of this option.
+		AskRename func(Change) (bool, error)
+
+		// SkipChanges defines a list of change types (e.g. &DropTable{}) that
+		// are omitted from the diff report.
+		SkipChanges []Change
+
+		// DenyChanges defines a list of change types (e.g. &DropColumn{}) that
+		// are not allowed. A DeniedChangeError is returned if such a change is
+		// found in the diff report. Skipped changes are not checked.
+		DenyChanges []Change
+
+		// ColumnPosition enables the detection of columns that were moved to another
+		// position in the table. Moved columns are reported as ModifyColumn changes
+		// with the ChangePosition kind. The option is ignored by drivers that do not
+		// support reordering columns. Note, added columns are always planned at their
+		// position in the table, regardless of this option.
+		ColumnPosition bool
+	}
+
+	// DeniedChangeError is returned by the Differ when
+	// a change is denied by the DenyChanges option.
+	DeniedChangeError struct {
+		C Change
+		T *Table // Table of the change, if it is a table change.
+	}
+
+	// DiffOption allows configuring the DiffOptions using functional options.
+	DiffOption func(*DiffOptions)
+)
+
+// RealmDiff calls the RealmDiff method of the Differ. If options are given,
+// the Differ must implement the OptionsDiffer interface.
+func RealmDiff(d Differ, from, to *Realm, opts ...DiffOption) ([]Change, error) {
+	if len(opts) == 0 {
+		return d.RealmDiff(from, to)
+	}
+	od, ok := d.(OptionsDiffer)
+	if !ok {
+		return nil, fmt.Errorf("schema: differ %T does not support diff options", d)
+	}
+	return od.RealmDiffWithOptions(from, to, opts...)
+}
+
+// SchemaDiff calls the SchemaDiff method of the Differ. If options are
+// given, the Differ must implement the OptionsDiffer interface.
+func SchemaDiff(d Differ, from, to *Schema, opts ...DiffOption) ([]Change, error) {
+	if len(opts) == 0 {
+		return d.SchemaDiff(from, to)
+	}
+	od, ok := d.(OptionsDiffer)
+	if !ok {
+		return nil, fmt.Errorf("schema: differ %T does not support diff options", d)
+	}
+	return od.SchemaDiffWithOptions(from, to, opts...)
+}
+
+// TableDiff calls the TableDiff method of the Differ. If options are
+// given, the Differ must implement the OptionsDiffer interface.
+func TableDiff(d Differ, from, to *Table, opts ...DiffOption) ([]Change, error) {
+	if len(opts) == 0 {
+		return d.TableDiff(from, to)
+	}
+	od, ok := d.(OptionsDiffer)
+	if !ok {
+		return nil, fmt.Errorf("schema: differ %T does not support diff options", d)
+	}
+	return od.TableDiffWithOptions(from, to, opts...)
+}
+
+// NewDiffOptions creates a new DiffOptions from the given configuration.
+func NewDiffOptions(opts ...DiffOption) *DiffOptions {
+	o := &DiffOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// DiffAskRename returns a DiffOption that sets the function
+// used for confirming the renames detected by definitions.
+func DiffAskRename(f func(Change) (bool, error)) DiffOption {
+	return func(o *DiffOptions) {
+		o.AskRename = f
+	}
+}
+
+// DiffSkipChanges returns a DiffOption that skips
+// the given change types in the diff report.
+func DiffSkipChanges(changes ...Change) DiffOption {
+	return func(o *DiffOptions) {
+		o.SkipChanges = append(o.SkipChanges, changes...)
+	}
+}
+
+// DiffDenyChanges returns a DiffOption that fails the
+// diff if one of the given change types is found.
+func DiffDenyChanges(changes ...Change) DiffOption {
+	return func(o *DiffOptions) {
+		o.DenyChanges = append(o.DenyChanges, changes...)
+	}
+}
+
+// DiffColumnPosition returns a DiffOption that enables
+// the detection of column position changes.
+func DiffColumnPosition() DiffOption {
+	return func(o *DiffOptions) {
+		o.ColumnPosition = true
+	}
+}
+
+// Skipped reports whether the given change should be skipped.
+func (o *DiffOptions) Skipped(c Change) bool {
+	return changeOf(o.SkipChanges, c)
+}
+
+// Denied reports whether the given change is denied.
+func (o *DiffOptions) Denied(c Change) bool {
+	return changeOf(o.DenyChanges, c)
+}
+
+// changeOf reports if the change type is one of the given types.
+func changeOf(types []Change, c Change) bool {
+	for _, t := range types {
+		if reflect.TypeOf(t) == reflect.TypeOf(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Error implements the error interface.
+func (e *DeniedChangeError) Error() string {
+	var (
+		obj string
+		t   = e.T
+	)
+	switch c := e.C.(type) {
+	case *DropSchema:
+		obj = fmt.Sprintf("drop schema %q", c.S.Name)
+	case *DropTable:
+		obj = fmt.Sprintf("drop table %q", c.T.Name)
+	case *DropColumn:
+		obj = fmt.Sprintf("drop column %q", c.C.Name)
+	case *DropIndex:
+		obj = fmt.Sprintf("drop index %q", c.I.Name)
+		if t == nil {
+			t = c.I.Table
+		}
+	case *DropForeignKey:
+		obj = fmt.Sprintf("drop foreign key %q", c.F.Symbol)
+		if t == nil {
+			t = c.F.Table
+		}
+	case *DropView:
+		obj = fmt.Sprintf("drop view %q", c.V.Name)
+	default:
+		obj = fmt.Sprintf("%T", c)
+	}
+	if t != nil {
+		obj = fmt.Sprintf("%s of table %q", obj, t.Name)
+	}
+	return fmt.Sprintf("sql/schema: change %s is denied by the diff policy", obj)
 }
 
 // ErrLocked is returned on Lock calls which have failed to obtain the lock.
@@ -378,10 +687,30 @@ func (*AddTable) change()         {}
 func (*DropTable) change()        {}
 func (*ModifyTable) change()      {}
 func (*RenameTable) change()      {}
+func (*AddView) change()          {}
+func (*DropView) change()         {}
+func (*ModifyView) change()       {}
+func (*AddObject) change()        {}
+func (*DropObject) change()       {}
+func (*ModifyObject) change()     {}
+func (*AddFunc) change()          {}
+func (*DropFunc) change()         {}
+func (*ModifyFunc) change()       {}
+func (*AddProc) change()          {}
+func (*DropProc) change()         {}
+func (*ModifyProc) change()       {}
+func (*AddRole) change()          {}
+func (*DropRole) change()         {}
+func (*ModifyRole) change()       {}
+func (*Grant) change()            {}
+func (*Revoke) change()           {}
 func (*AddIndex) change()         {}
 func (*DropIndex) change()        {}
 func (*ModifyIndex) change()      {}
 func (*RenameIndex) change()      {}
+func (*AddTrigger) change()       {}
+func (*DropTrigger) change()      {}
+func (*ModifyTrigger) change()    {}
 func (*AddCheck) change()         {}
 func (*DropCheck) change()        {}
 func (*ModifyCheck) change()      {}
@@ -124,3 +124,65 @@ func ExampleChanges_Replace() {
 	// *schema.AddColumn(created_at)
 	// *schema.RenameColumn(old_name -> new_name)
 }
+
+type (
+	plainDiffer   struct{}
+	optionsDiffer struct{ plainDiffer }
+)
+
+func (plainDiffer) RealmDiff(_, _ *schema.Realm) ([]schema.Change, error) {
+	return []schema.Change{&schema.AddSchema{}}, nil
+}
+
+func (plainDiffer) SchemaDiff(_, _ *schema.Schema) ([]schema.Change, error) {
+	return []schema.Change{&schema.AddTable{}}, nil
+}
+
+func (plainDiffer) TableDiff(_, _ *schema.Table) ([]schema.Change, error) {
+	return []schema.Change{&schema.AddColumn{}}, nil
+}
+
+func (optionsDiffer) RealmDiffWithOptions(_, _ *schema.Realm, _ ...schema.DiffOption) ([]schema.Change, error) {
+	return []schema.Change{&schema.DropSchema{}}, nil
+}
+
+func (optionsDiffer) SchemaDiffWithOptions(_, _ *schema.Schema, _ ...schema.DiffOption) ([]schema.Change, error) {
+	return []schema.Change{&schema.DropTable{}}, nil
+}
+
+func (optionsDiffer) TableDiffWithOptions(_, _ *schema.Table, _ ...schema.DiffOption) ([]schema.Change, error) {
+	return []schema.Change{&schema.DropColumn{}}, nil
+}
+
+func TestDiffWithOptions(t *testing.T) {
+	var (
+		opt = schema.DiffSkipChanges(&schema.DropTable{})
+		pd  = plainDiffer{}
+		od  = optionsDiffer{}
+	)
+	// Without options, the plain methods are called.
+	changes, err := schema.RealmDiff(pd, nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, &schema.AddSchema{}, changes[0])
+	changes, err = schema.SchemaDiff(od, nil, nil)
+	require.NoError(t, err)
+	require.IsType(t, &schema.AddTable{}, changes[0])
+
+	// Options require an OptionsDiffer.
+	_, err = schema.RealmDiff(pd, nil, nil, opt)
+	require.EqualError(t, err, "schema: differ schema_test.plainDiffer does not support diff options")
+	_, err = schema.SchemaDiff(pd, nil, nil, opt)
+	require.Error(t, err)
+	_, err = schema.TableDiff(pd, nil, nil, opt)
+	require.Error(t, err)
+
+	changes, err = schema.RealmDiff(od, nil, nil, opt)
+	require.NoError(t, err)
+	require.IsType(t, &schema.DropSchema{}, changes[0])
+	changes, err = schema.SchemaDiff(od, nil, nil, opt)
+	require.NoError(t, err)
+	require.IsType(t, &schema.DropTable{}, changes[0])
+	changes, err = schema.TableDiff(od, nil, nil, opt)
+	require.NoError(t, err)
+	require.IsType(t, &schema.DropColumn{}, changes[0])
+}
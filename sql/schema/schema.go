@@ -8,16 +8,44 @@ type (
 	// A Realm or a database describes a domain of schema resources that are logically connected
 	// and can be accessed and queried in the same connection (e.g. a physical database instance).
 	Realm struct {
-		Schemas []*Schema
-		Attrs   []Attr
+		Schemas    []*Schema
+		Roles      []*Role      // Database roles and users.
+		Privileges []*Privilege // Privileges that were granted to the roles.
+		Attrs      []Attr
 	}
 
 	// A Schema describes a database schema (i.e. named database).
 	Schema struct {
-		Name   string
-		Realm  *Realm
-		Tables []*Table
-		Attrs  []Attr // Attrs and options.
+		Name    string
+		Realm   *Realm
+		Tables  []*Table
+		Views   []*View
+		Funcs   []*Func
+		Procs   []*Proc
+		Objects []Object // Driver-specific objects, e.g. sequences.
+		Attrs   []Attr   // Attrs and options.
+	}
+
+	// A Role represents a database role or a user. Users are roles that can
+	// log in to the database (e.g. CREATE USER in MySQL or LOGIN in PostgreSQL).
+	Role struct {
+		Name  string
+		Realm *Realm
+		Login bool   // Role is a user that can log in.
+		Attrs []Attr // Driver-specific attributes, e.g. SUPERUSER.
+	}
+
+	// A Privilege describes a set of privileges that were granted to a role
+	// on a database object. If the Schema is nil, the privileges were granted
+	// globally (e.g. ON *.* in MySQL). If the Table and the View are nil, the
+	// privileges were granted on the schema.
+	Privilege struct {
+		Grantee   *Role
+		Schema    *Schema  // Optional schema.
+		Table     *Table   // Optional table.
+		View      *View    // Optional view.
+		Privs     []string // Privilege types, e.g. SELECT or INSERT.
+		Grantable bool     // WITH GRANT OPTION.
 	}
 
 	// A Table represents a table definition.
@@ -28,9 +56,49 @@ type (
 		Indexes     []*Index
 		PrimaryKey  *Index
 		ForeignKeys []*ForeignKey
+		Triggers    []*Trigger
 		Attrs       []Attr // Attrs, constraints and options.
 	}
 
+	// A View represents a view definition.
+	View struct {
+		Name    string
+		Def     string // The view definition (e.g. SELECT statement).
+		Schema  *Schema
+		Columns []*Column
+		Indexes []*Index // Indexes of materialized views.
+		Attrs   []Attr   // Attrs and options.
+	}
+
+	// A Func represents a function definition.
+	Func struct {
+		Name   string
+		Schema *Schema
+		Args   []*FuncArg
+		Ret    Type   // The return type.
+		Lang   string // The function language. e.g. SQL or plpgsql.
+		Body   string // The function body.
+		Attrs  []Attr // Attrs and options.
+	}
+
+	// A Proc represents a procedure definition.
+	Proc struct {
+		Name   string
+		Schema *Schema
+		Args   []*FuncArg
+		Lang   string // The procedure language. e.g. SQL or plpgsql.
+		Body   string // The procedure body.
+		Attrs  []Attr // Attrs and options.
+	}
+
+	// A FuncArg represents a single argument of a function or a procedure.
+	FuncArg struct {
+		Name    string // Optional name.
+		Type    Type
+		Mode    string // Optional mode. e.g. IN, OUT, INOUT or VARIADIC.
+		Default Expr   // Optional default value.
+	}
+
 	// A Column represents a column definition.
 	Column struct {
 		Name    string
@@ -55,6 +123,7 @@ type (
 		Name   string
 		Unique bool
 		Table  *Table
+		View   *View // Set for indexes of materialized views.
 		Attrs  []Attr
 		Parts  []*IndexPart
 	}
@@ -73,6 +142,24 @@ type (
 		Attrs []Attr
 	}
 
+	// A Trigger represents a trigger definition.
+	Trigger struct {
+		Name       string
+		Table      *Table
+		ActionTime string         // BEFORE, AFTER or INSTEAD OF.
+		Events     []TriggerEvent // INSERT, UPDATE, DELETE, etc.
+		For        string         // ROW or STATEMENT.
+		Body       string         // The trigger body, if it does not execute a function.
+		Func       *Func          // The trigger function. e.g. EXECUTE FUNCTION in PostgreSQL.
+		Attrs      []Attr         // Attrs and options.
+	}
+
+	// A TriggerEvent represents a single event that fires a trigger.
+	TriggerEvent struct {
+		Name    string    // INSERT, UPDATE, DELETE or TRUNCATE.
+		Columns []*Column // Optional columns list of UPDATE OF events.
+	}
+
 	// A ForeignKey represents an index definition.
 	ForeignKey struct {
 		Symbol     string
@@ -82,6 +169,7 @@ type (
 		RefColumns []*Column
 		OnUpdate   ReferenceOption
 		OnDelete   ReferenceOption
+		Attrs      []Attr
 	}
 )
 
@@ -95,6 +183,16 @@ func (r *Realm) Schema(name string) (*Schema, bool) {
 	return nil, false
 }
 
+// Role returns the first role that matched the given name.
+func (r *Realm) Role(name string) (*Role, bool) {
+	for _, ro := range r.Roles {
+		if ro.Name == name {
+			return ro, true
+		}
+	}
+	return nil, false
+}
+
 // Table returns the first table that matched the given name.
 func (s *Schema) Table(name string) (*Table, bool) {
 	for _, t := range s.Tables {
@@ -105,6 +203,66 @@ func (s *Schema) Table(name string) (*Table, bool) {
 	return nil, false
 }
 
+// View returns the first view that matched the given name.
+func (s *Schema) View(name string) (*View, bool) {
+	for _, v := range s.Views {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Func returns the first function that matched the given name.
+func (s *Schema) Func(name string) (*Func, bool) {
+	for _, f := range s.Funcs {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// Proc returns the first procedure that matched the given name.
+func (s *Schema) Proc(name string) (*Proc, bool) {
+	for _, p := range s.Procs {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Index returns the first index that matched the given name.
+func (v *View) Index(name string) (*Index, bool) {
+	for _, i := range v.Indexes {
+		if i.Name == name {
+			return i, true
+		}
+	}
+	return nil, false
+}
+
+// Materialized reports if the view is a materialized view.
+func (v *View) Materialized() bool {
+	for _, a := range v.Attrs {
+		if _, ok := a.(*Materialized); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Column returns the first column that matched the given name.
+func (v *View) Column(name string) (*Column, bool) {
+	for _, c := range v.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
 // Column returns the first column that matched the given name.
 func (t *Table) Column(name string) (*Column, bool) {
 	for _, c := range t.Columns {
@@ -125,6 +283,16 @@ func (t *Table) Index(name string) (*Index, bool) {
 	return nil, false
 }
 
+// Trigger returns the first trigger that matched the given name.
+func (t *Table) Trigger(name string) (*Trigger, bool) {
+	for _, tg := range t.Triggers {
+		if tg.Name == name {
+			return tg, true
+		}
+	}
+	return nil, false
+}
+
 // ForeignKey returns the first foreign-key that matched the given symbol (constraint name).
 func (t *Table) ForeignKey(symbol string) (*ForeignKey, bool) {
 	for _, f := range t.ForeignKeys {
@@ -185,7 +353,9 @@ type (
 		typ()
 	}
 
-	// EnumType represents an enum type.
+	// EnumType represents an enum type. In drivers that support
+	// named enum types (e.g. PostgreSQL), it is also a schema
+	// object that is shared by the columns that use it.
 	EnumType struct {
 		T      string   // Optional type.
 		Values []string // Enum values.
@@ -272,6 +442,14 @@ type (
 	}
 )
 
+type (
+	// Object represents the interface that all driver-specific schema objects
+	// implement. For example, enum types and standalone sequences in PostgreSQL.
+	Object interface {
+		obj()
+	}
+)
+
 type (
 	// Attr represents the interface that all attributes implement.
 	Attr interface {
@@ -306,6 +484,57 @@ type (
 		Expr string
 		Type string // Optional type. e.g. STORED or VIRTUAL.
 	}
+
+	// ViewCheckOption describes the WITH CHECK OPTION clause of a view.
+	ViewCheckOption struct {
+		V string // LOCAL or CASCADED.
+	}
+
+	// Materialized describes a materialized view. i.e. a view
+	// whose query results are stored and can be indexed.
+	Materialized struct{}
+
+	// Renamed is a hint for the Differ that the table, column or index
+	// was renamed from the given name, and should not be dropped.
+	Renamed struct {
+		From string
+	}
+)
+
+// List of view check options.
+const (
+	ViewCheckOptionNone     = "NONE"
+	ViewCheckOptionLocal    = "LOCAL"
+	ViewCheckOptionCascaded = "CASCADED"
+)
+
+// List of function and procedure argument modes.
+const (
+	FuncArgModeIn       = "IN"
+	FuncArgModeOut      = "OUT"
+	FuncArgModeInOut    = "INOUT"
+	FuncArgModeVariadic = "VARIADIC"
+)
+
+// List of trigger action times.
+const (
+	TriggerTimeBefore  = "BEFORE"
+	TriggerTimeAfter   = "AFTER"
+	TriggerTimeInstead = "INSTEAD OF"
+)
+
+// List of trigger events.
+const (
+	TriggerEventInsert   = "INSERT"
+	TriggerEventUpdate   = "UPDATE"
+	TriggerEventDelete   = "DELETE"
+	TriggerEventTruncate = "TRUNCATE"
+)
+
+// List of trigger levels.
+const (
+	TriggerForRow  = "ROW"
+	TriggerForStmt = "STATEMENT"
 )
 
 // expressions.
@@ -326,8 +555,14 @@ func (*DecimalType) typ()     {}
 func (*UnsupportedType) typ() {}
 
 // attributes.
-func (*Check) attr()         {}
-func (*Comment) attr()       {}
-func (*Charset) attr()       {}
-func (*Collation) attr()     {}
-func (*GeneratedExpr) attr() {}
+func (*Check) attr()           {}
+func (*Comment) attr()         {}
+func (*Charset) attr()         {}
+func (*Collation) attr()       {}
+func (*GeneratedExpr) attr()   {}
+func (*ViewCheckOption) attr() {}
+func (*Materialized) attr()    {}
+func (*Renamed) attr()         {}
+
+// objects.
+func (*EnumType) obj() {}
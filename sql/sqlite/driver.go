@@ -89,19 +89,37 @@ func Open(db schema.ExecQuerier) (migrate.Driver, error) {
 	}, nil
 }
 
+// RealmDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Driver) RealmDiffWithOptions(from, to *schema.Realm, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return schema.RealmDiff(d.Differ, from, to, opts...)
+}
+
+// SchemaDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Driver) SchemaDiffWithOptions(from, to *schema.Schema, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return schema.SchemaDiff(d.Differ, from, to, opts...)
+}
+
+// TableDiffWithOptions implements the schema.OptionsDiffer interface.
+func (d *Driver) TableDiffWithOptions(from, to *schema.Table, opts ...schema.DiffOption) ([]schema.Change, error) {
+	return schema.TableDiff(d.Differ, from, to, opts...)
+}
+
 // Snapshot implements migrate.Snapshoter.
 func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
-	r, err := d.InspectRealm(ctx, nil)
+	r, err := d.InspectRealm(ctx, &schema.InspectRealmOption{Mode: schema.InspectAll})
 	if err != nil {
 		return nil, err
 	}
 	if !(r == nil || (len(r.Schemas) == 1 && r.Schemas[0].Name == mainFile && len(r.Schemas[0].Tables) == 0)) {
 		return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found table %q", r.Schemas[0].Tables[0].Name)}
 	}
+	if r != nil && len(r.Schemas[0].Views) > 0 {
+		return nil, migrate.NotCleanError{Reason: fmt.Sprintf("found view %q", r.Schemas[0].Views[0].Name)}
+	}
 	return func(ctx context.Context) error {
 		for _, stmt := range []string{
 			"PRAGMA writable_schema = 1;",
-			"DELETE FROM sqlite_master WHERE type IN ('table', 'index', 'trigger');",
+			"DELETE FROM sqlite_master WHERE type IN ('table', 'index', 'trigger', 'view');",
 			"PRAGMA writable_schema = 0;",
 			"VACUUM;",
 		} {
@@ -115,7 +133,7 @@ func (d *Driver) Snapshot(ctx context.Context) (migrate.RestoreFunc, error) {
 
 // CheckClean implements migrate.CleanChecker.
 func (d *Driver) CheckClean(ctx context.Context, revT *migrate.TableIdent) error {
-	r, err := d.InspectRealm(ctx, nil)
+	r, err := d.InspectRealm(ctx, &schema.InspectRealmOption{Mode: schema.InspectAll})
 	if err != nil {
 		return err
 	}
@@ -124,6 +142,8 @@ func (d *Driver) CheckClean(ctx context.Context, revT *migrate.TableIdent) error
 		return migrate.NotCleanError{Reason: fmt.Sprintf("found multiple schemas: %d", len(r.Schemas))}
 	case n == 1 && r.Schemas[0].Name != mainFile:
 		return migrate.NotCleanError{Reason: fmt.Sprintf("found schema %q", r.Schemas[0].Name)}
+	case n == 1 && len(r.Schemas[0].Views) > 0:
+		return migrate.NotCleanError{Reason: fmt.Sprintf("found view %q", r.Schemas[0].Views[0].Name)}
 	case n == 1 && len(r.Schemas[0].Tables) > 1:
 		return migrate.NotCleanError{Reason: fmt.Sprintf("found multiple tables: %d", len(r.Schemas[0].Tables))}
 	case n == 1 && len(r.Schemas[0].Tables) == 1 && (revT == nil || r.Schemas[0].Tables[0].Name != revT.Name):
@@ -35,22 +35,36 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 		opts = &schema.InspectRealmOption{}
 	}
 	r := schema.NewRealm(schemas...)
-	if !sqlx.ModeInspectRealm(opts).Is(schema.InspectTables) {
-		return sqlx.ExcludeRealm(r, opts.Exclude)
+	mode := sqlx.ModeInspectRealm(opts)
+	if mode.Is(schema.InspectTables) {
+		for _, s := range schemas {
+			tables, err := i.tables(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			s.AddTables(tables...)
+			for _, t := range tables {
+				if err := i.inspectTable(ctx, t); err != nil {
+					return nil, err
+				}
+			}
+		}
+		sqlx.LinkSchemaTables(r.Schemas)
 	}
-	for _, s := range schemas {
-		tables, err := i.tables(ctx, nil)
-		if err != nil {
-			return nil, err
+	if mode.Is(schema.InspectViews) {
+		for _, s := range schemas {
+			if err := i.views(ctx, s); err != nil {
+				return nil, err
+			}
 		}
-		s.AddTables(tables...)
-		for _, t := range tables {
-			if err := i.inspectTable(ctx, t); err != nil {
+	}
+	if mode.Is(schema.InspectTables) && mode.Is(schema.InspectTriggers) {
+		for _, s := range schemas {
+			if err := i.triggers(ctx, s); err != nil {
 				return nil, err
 			}
 		}
 	}
-	sqlx.LinkSchemaTables(r.Schemas)
 	return sqlx.ExcludeRealm(r, opts.Exclude)
 }
 
@@ -75,20 +89,31 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 		opts = &schema.InspectOptions{}
 	}
 	r := schema.NewRealm(schemas...)
-	if !sqlx.ModeInspectSchema(opts).Is(schema.InspectTables) {
-		return sqlx.ExcludeSchema(r.Schemas[0], opts.Exclude)
+	mode := sqlx.ModeInspectSchema(opts)
+	if mode.Is(schema.InspectTables) {
+		tables, err := i.tables(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		r.Schemas[0].AddTables(tables...)
+		for _, t := range tables {
+			if err := i.inspectTable(ctx, t); err != nil {
+				return nil, err
+			}
+		}
+		sqlx.LinkSchemaTables(schemas)
 	}
-	tables, err := i.tables(ctx, opts)
-	if err != nil {
-		return nil, err
+	// Views are skipped in case the inspection was limited to a set of tables.
+	if mode.Is(schema.InspectViews) && len(opts.Tables) == 0 {
+		if err := i.views(ctx, r.Schemas[0]); err != nil {
+			return nil, err
+		}
 	}
-	r.Schemas[0].AddTables(tables...)
-	for _, t := range tables {
-		if err := i.inspectTable(ctx, t); err != nil {
+	if mode.Is(schema.InspectTables) && mode.Is(schema.InspectTriggers) {
+		if err := i.triggers(ctx, r.Schemas[0]); err != nil {
 			return nil, err
 		}
 	}
-	sqlx.LinkSchemaTables(schemas)
 	return sqlx.ExcludeSchema(r.Schemas[0], opts.Exclude)
 }
 
@@ -386,6 +411,132 @@ func (i *inspect) tables(ctx context.Context, opts *schema.InspectOptions) ([]*s
 	return tables, nil
 }
 
+// views queries and appends the views of the given schema.
+func (i *inspect) views(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, viewsQuery)
+	if err != nil {
+		return fmt.Errorf("sqlite: querying schema views: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, stmt string
+		if err := rows.Scan(&name, &stmt); err != nil {
+			return fmt.Errorf("sqlite: scanning view: %w", err)
+		}
+		stmt = strings.TrimSpace(stmt)
+		def := stmt
+		if matches := reViewDef.FindStringSubmatch(stmt); len(matches) == 2 {
+			def = strings.TrimSpace(matches[1])
+		}
+		v := schema.NewView(name, def).AddAttrs(&CreateStmt{S: stmt})
+		s.AddViews(v)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	for _, v := range s.Views {
+		if err := i.viewColumns(ctx, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// viewColumns queries and appends the columns of the given view.
+func (i *inspect) viewColumns(ctx context.Context, v *schema.View) error {
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(viewColumnsQuery, v.Name))
+	if err != nil {
+		return fmt.Errorf("sqlite: querying view %q columns: %w", v.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			nullable bool
+			name     string
+			typ      sql.NullString
+		)
+		if err := rows.Scan(&name, &typ, &nullable); err != nil {
+			return fmt.Errorf("sqlite: scanning view column: %w", err)
+		}
+		t, err := ParseType(typ.String)
+		if err != nil {
+			t = &schema.UnsupportedType{T: typ.String}
+		}
+		v.AddColumns(&schema.Column{
+			Name: name,
+			Type: &schema.ColumnType{Type: t, Raw: typ.String, Null: nullable},
+		})
+	}
+	return rows.Close()
+}
+
+// reViewDef extracts the view definition (the SELECT statement) from its CREATE statement.
+var reViewDef = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?VIEW\s+(?:IF\s+NOT\s+EXISTS\s+)?.+?\s+AS\s+(.+)$`)
+
+// triggers queries and appends the triggers of the schema tables.
+func (i *inspect) triggers(ctx context.Context, s *schema.Schema) error {
+	if len(s.Tables) == 0 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, triggersQuery)
+	if err != nil {
+		return fmt.Errorf("sqlite: querying schema triggers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, table, stmt string
+		if err := rows.Scan(&name, &table, &stmt); err != nil {
+			return fmt.Errorf("sqlite: scanning trigger: %w", err)
+		}
+		t, ok := s.Table(table)
+		// Skip triggers of tables that were not inspected.
+		if !ok {
+			continue
+		}
+		tg, err := parseTrigger(t, name, strings.TrimSpace(stmt))
+		if err != nil {
+			return err
+		}
+		t.AddTriggers(tg)
+	}
+	return rows.Close()
+}
+
+// reTriggerDef parses the CREATE TRIGGER statement of a trigger. The captured groups are
+// the action time, the event, the UPDATE OF columns and the action, which holds the
+// optional WHEN clause and the BEGIN ... END block.
+var reTriggerDef = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?TRIGGER\s+(?:IF\s+NOT\s+EXISTS\s+)?.+?\s+(BEFORE\s+|AFTER\s+|INSTEAD\s+OF\s+)?(DELETE|INSERT|UPDATE)(?:\s+OF\s+(.+?))?\s+ON\s+.+?\s+(?:FOR\s+EACH\s+ROW\s+)?((?:WHEN\s+.+?\s+)?BEGIN\s.+)$`)
+
+// parseTrigger builds a trigger from its CREATE statement.
+func parseTrigger(t *schema.Table, name, stmt string) (*schema.Trigger, error) {
+	matches := reTriggerDef.FindStringSubmatch(stmt)
+	if len(matches) != 5 {
+		return nil, fmt.Errorf("sqlite: unexpected definition for trigger %q: %s", name, stmt)
+	}
+	// SQLite triggers are BEFORE triggers by default,
+	// and support only the FOR EACH ROW level.
+	tg := schema.NewTrigger(name).
+		SetActionTime(schema.TriggerTimeBefore).
+		SetFor(schema.TriggerForRow).
+		SetBody(strings.TrimSpace(matches[4])).
+		AddAttrs(&CreateStmt{S: stmt})
+	if at := strings.Join(strings.Fields(matches[1]), " "); at != "" {
+		tg.SetActionTime(strings.ToUpper(at))
+	}
+	event := schema.TriggerEvent{Name: strings.ToUpper(matches[2])}
+	if matches[3] != "" {
+		for _, n := range strings.Split(matches[3], ",") {
+			n = strings.Trim(strings.TrimSpace(n), "`\"[]")
+			c, ok := t.Column(n)
+			if !ok {
+				return nil, fmt.Errorf("sqlite: column %q was not found for trigger %q", n, name)
+			}
+			event.Columns = append(event.Columns, c)
+		}
+	}
+	return tg.AddEvents(event), nil
+}
+
 // schemas returns the list of the schemas in the database.
 func (i *inspect) databases(ctx context.Context, opts *schema.InspectRealmOption) ([]*schema.Schema, error) {
 	var (
@@ -708,6 +859,12 @@ const (
 	databasesQueryArgs = "SELECT `name`, `file` FROM pragma_database_list() WHERE `name` IN (%s)"
 	// Query to list database tables.
 	tablesQuery = "SELECT `name`, `sql` FROM sqlite_master WHERE `type` = 'table' AND `name` NOT LIKE 'sqlite_%'"
+	// Query to list database views.
+	viewsQuery = "SELECT `name`, `sql` FROM sqlite_master WHERE `type` = 'view' ORDER BY `name`"
+	// Query to list table triggers.
+	triggersQuery = "SELECT `name`, `tbl_name`, `sql` FROM sqlite_master WHERE `type` = 'trigger' ORDER BY `name`"
+	// Query to list view columns.
+	viewColumnsQuery = "SELECT `name`, `type`, (not `notnull`) AS `nullable` FROM pragma_table_info('%s') ORDER BY `cid`"
 	// Query to list table information.
 	columnsQuery = "SELECT `name`, `type`, (not `notnull`) AS `nullable`, `dflt_value`, (`pk` <> 0) AS `pk`, `hidden` FROM pragma_table_xinfo('%s') ORDER BY `pk`, `cid`"
 	// Query to list table indexes.
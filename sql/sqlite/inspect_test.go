@@ -240,6 +240,86 @@ CREATE TABLE users(
 	}
 }
 
+func TestDriver_InspectViews(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.systemVars("3.36.0")
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(databasesQueryArgs, "?"))).
+		WithArgs("main").
+		WillReturnRows(sqltest.Rows(`
+ name |   file
+------+-----------
+ main |
+`))
+	m.ExpectQuery(sqltest.Escape(tablesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "sql"}))
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "sql"}).
+			AddRow("active", "CREATE VIEW active(id) AS SELECT id FROM users WHERE active"))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewColumnsQuery, "active"))).
+		WillReturnRows(sqltest.Rows(`
+ name |  type   | nullable
+------+---------+----------
+ id   | integer |  1
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{Mode: schema.InspectAll})
+	require.NoError(t, err)
+	require.Empty(t, s.Tables)
+	require.Len(t, s.Views, 1)
+	v := s.Views[0]
+	require.Equal(t, "active", v.Name)
+	require.Equal(t, "SELECT id FROM users WHERE active", v.Def)
+	require.Equal(t, []*schema.Column{
+		{Name: "id", Type: &schema.ColumnType{Raw: "integer", Type: &schema.IntegerType{T: "integer"}, Null: true}},
+	}, v.Columns)
+}
+
+func TestDriver_InspectTriggers(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.systemVars("3.36.0")
+	mk.tableExists("users", true, "CREATE TABLE users(id INTEGER PRIMARY KEY, name TEXT)")
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "users"))).
+		WillReturnRows(sqltest.Rows(`
+ name |   type   | nullable | dflt_value  | primary  | hidden
+------+----------+----------+-------------+----------+----------
+ id   | integer  |  0       |             |  1       |  0
+ name | text     |  1       |             |  0       |  0
+`))
+	mk.noIndexes("users")
+	mk.noFKs("users")
+	m.ExpectQuery(sqltest.Escape(triggersQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "tbl_name", "sql"}).
+			AddRow("users_insert", "users", "CREATE TRIGGER users_insert AFTER INSERT ON users BEGIN SELECT 1; END").
+			AddRow("users_update", "users", "CREATE TRIGGER \"users_update\" UPDATE OF name ON \"users\" FOR EACH ROW WHEN new.name IS NULL BEGIN SELECT RAISE(ABORT, 'name'); END").
+			AddRow("pets_delete", "pets", "CREATE TRIGGER pets_delete DELETE ON pets BEGIN SELECT 1; END"))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode:   schema.InspectAll,
+		Tables: []string{"users"},
+	})
+	require.NoError(t, err)
+	users := s.Tables[0]
+	require.Len(t, users.Triggers, 2)
+	tg := users.Triggers[0]
+	require.Equal(t, "users_insert", tg.Name)
+	require.Equal(t, users, tg.Table)
+	require.Equal(t, schema.TriggerTimeAfter, tg.ActionTime)
+	require.Equal(t, schema.TriggerForRow, tg.For)
+	require.Equal(t, []schema.TriggerEvent{{Name: schema.TriggerEventInsert}}, tg.Events)
+	require.Equal(t, "BEGIN SELECT 1; END", tg.Body)
+	tg = users.Triggers[1]
+	require.Equal(t, "users_update", tg.Name)
+	require.Equal(t, schema.TriggerTimeBefore, tg.ActionTime)
+	require.Equal(t, []schema.TriggerEvent{{Name: schema.TriggerEventUpdate, Columns: users.Columns[1:]}}, tg.Events)
+	require.Equal(t, "WHEN new.name IS NULL BEGIN SELECT RAISE(ABORT, 'name'); END", tg.Body)
+}
+
 func TestRegex_TableFK(t *testing.T) {
 	tests := []struct {
 		input   string
@@ -66,8 +66,15 @@ type state struct {
 // Exec executes the changes on the database. An error is returned
 // if one of the operations fail, or a change is not supported.
 func (s *state) plan(ctx context.Context, changes []schema.Change) (err error) {
-	for _, c := range changes {
+	before, planned, after := sqlx.DetachViews(changes)
+	for _, c := range append(append(before, planned...), after...) {
 		switch c := c.(type) {
+		case *schema.AddView:
+			s.addView(c)
+		case *schema.DropView:
+			s.dropView(c)
+		case *schema.ModifyView:
+			s.modifyView(c)
 		case *schema.AddTable:
 			err = s.addTable(ctx, c)
 		case *schema.DropTable:
@@ -140,7 +147,10 @@ func (s *state) addTable(ctx context.Context, add *schema.AddTable) error {
 	if err := s.tableSeq(ctx, add); err != nil {
 		return err
 	}
-	return s.addIndexes(add.T, add.T.Indexes...)
+	if err := s.addIndexes(add.T, add.T.Indexes...); err != nil {
+		return err
+	}
+	return s.addTriggers(add.T.Triggers...)
 }
 
 // dropTable builds and executes the query for dropping a table from a schema.
@@ -169,7 +179,7 @@ func (s *state) modifyTable(ctx context.Context, modify *schema.ModifyTable) err
 	s.skipFKs = true
 	newT := *modify.T
 	indexes := newT.Indexes
-	newT.Indexes = nil
+	newT.Indexes, newT.Triggers = nil, nil
 	newT.Name = "new_" + newT.Name
 	// Create a new table with a temporary name, and copy the existing rows to it.
 	if err := s.addTable(ctx, &schema.AddTable{T: &newT}); err != nil {
@@ -195,7 +205,118 @@ func (s *state) modifyTable(ctx context.Context, modify *schema.ModifyTable) err
 		Source:  modify,
 		Comment: fmt.Sprintf("rename temporary table %q to %q", newT.Name, modify.T.Name),
 	})
-	return s.addIndexes(modify.T, indexes...)
+	if err := s.addIndexes(modify.T, indexes...); err != nil {
+		return err
+	}
+	// Triggers are dropped along with the table they are associated
+	// with. Hence, all triggers are recreated on the renamed table.
+	return s.addTriggers(modify.T.Triggers...)
+}
+
+// addView builds and executes the query for creating a view in a schema.
+func (s *state) addView(add *schema.AddView) {
+	s.append(&migrate.Change{
+		Cmd:     s.viewDef(add.V),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q view", add.V.Name),
+		Reverse: s.Build("DROP VIEW").Ident(add.V.Name).String(),
+	})
+}
+
+// dropView builds and executes the query for dropping a view from a schema.
+func (s *state) dropView(drop *schema.DropView) {
+	b := s.Build("DROP VIEW")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(drop.V.Name).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q view", drop.V.Name),
+		Reverse: s.viewDef(drop.V),
+	})
+}
+
+// modifyView builds and executes the queries for bringing the view into its modified
+// state. SQLite does not support altering views, and therefore, views are recreated.
+func (s *state) modifyView(modify *schema.ModifyView) {
+	s.append(&migrate.Change{
+		Cmd:     s.Build("DROP VIEW").Ident(modify.From.Name).String(),
+		Source:  modify,
+		Comment: fmt.Sprintf("drop %q view before recreating it", modify.From.Name),
+		Reverse: s.viewDef(modify.From),
+	})
+	s.append(&migrate.Change{
+		Cmd:     s.viewDef(modify.To),
+		Source:  modify,
+		Comment: fmt.Sprintf("recreate %q view", modify.To.Name),
+		Reverse: s.Build("DROP VIEW").Ident(modify.To.Name).String(),
+	})
+}
+
+// addTriggers builds and appends the migrate.Change
+// for creating the given triggers.
+func (s *state) addTriggers(triggers ...*schema.Trigger) error {
+	for _, t := range triggers {
+		cmd, err := s.triggerDef(t)
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     cmd,
+			Source:  &schema.AddTrigger{T: t},
+			Comment: fmt.Sprintf("create %q trigger", t.Name),
+			Reverse: s.Build("DROP TRIGGER").Ident(t.Name).String(),
+		})
+	}
+	return nil
+}
+
+// dropTriggers builds and appends the migrate.Change
+// for dropping the given triggers.
+func (s *state) dropTriggers(triggers ...*schema.Trigger) error {
+	for _, t := range triggers {
+		create, err := s.triggerDef(t)
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     s.Build("DROP TRIGGER").Ident(t.Name).String(),
+			Source:  &schema.DropTrigger{T: t},
+			Comment: fmt.Sprintf("drop %q trigger", t.Name),
+			Reverse: create,
+		})
+	}
+	return nil
+}
+
+// triggerDef returns the CREATE TRIGGER statement of the given trigger.
+// The trigger body holds its optional WHEN clause and its BEGIN...END block.
+func (s *state) triggerDef(t *schema.Trigger) (string, error) {
+	switch {
+	case len(t.Events) != 1:
+		return "", fmt.Errorf("sqlite: trigger %q must have exactly one event, got %d", t.Name, len(t.Events))
+	case t.Func != nil:
+		return "", fmt.Errorf("sqlite: trigger %q: executing functions is not supported", t.Name)
+	case t.For != "" && !strings.EqualFold(t.For, schema.TriggerForRow):
+		return "", fmt.Errorf("sqlite: trigger %q: only FOR EACH ROW triggers are supported", t.Name)
+	}
+	b := s.Build("CREATE TRIGGER").Ident(t.Name)
+	if t.ActionTime != "" {
+		b.P(strings.ToUpper(t.ActionTime))
+	}
+	b.P(strings.ToUpper(t.Events[0].Name))
+	if cs := t.Events[0].Columns; len(cs) > 0 {
+		b.P("OF").MapComma(cs, func(i int, b *sqlx.Builder) {
+			b.Ident(cs[i].Name)
+		})
+	}
+	return b.P("ON").Ident(t.Table.Name).P("FOR EACH ROW", strings.TrimSpace(t.Body)).String(), nil
+}
+
+// viewDef returns the statement for creating the given view.
+func (s *state) viewDef(v *schema.View) string {
+	return s.Build("CREATE VIEW").Ident(v.Name).P("AS", strings.TrimSuffix(strings.TrimSpace(v.Def), ";")).String()
 }
 
 func (s *state) renameTable(c *schema.RenameTable) {
@@ -428,6 +549,21 @@ func (s *state) alterTable(modify *schema.ModifyTable) error {
 			if err := s.dropIndexes(modify.T, change.From); err != nil {
 				return err
 			}
+		case *schema.AddTrigger:
+			if err := s.addTriggers(change.T); err != nil {
+				return err
+			}
+		case *schema.DropTrigger:
+			if err := s.dropTriggers(change.T); err != nil {
+				return err
+			}
+		case *schema.ModifyTrigger:
+			if err := s.dropTriggers(change.From); err != nil {
+				return err
+			}
+			if err := s.addTriggers(change.To); err != nil {
+				return err
+			}
 		case *schema.AddColumn:
 			b := s.Build("ALTER TABLE").Ident(modify.T.Name)
 			r := b.Clone()
@@ -494,7 +630,8 @@ func (s *state) append(c *migrate.Change) {
 func alterable(modify *schema.ModifyTable) bool {
 	for _, change := range modify.Changes {
 		switch change := change.(type) {
-		case *schema.RenameColumn, *schema.RenameIndex, *schema.DropIndex, *schema.AddIndex:
+		case *schema.RenameColumn, *schema.RenameIndex, *schema.DropIndex, *schema.AddIndex,
+			*schema.AddTrigger, *schema.DropTrigger, *schema.ModifyTrigger:
 		case *schema.AddColumn:
 			if len(change.C.Indexes) > 0 || len(change.C.ForeignKeys) > 0 || change.C.Default != nil {
 				return false
@@ -270,6 +270,54 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		{
+			changes: []schema.Change{
+				func() schema.Change {
+					users := schema.NewTable("users").
+						AddColumns(schema.NewIntColumn("id", "bigint"), schema.NewStringColumn("name", "text"))
+					users.AddTriggers(
+						schema.NewTrigger("users_name").
+							SetActionTime(schema.TriggerTimeBefore).
+							AddEvents(schema.TriggerEvent{Name: schema.TriggerEventUpdate, Columns: users.Columns[1:]}).
+							SetBody("WHEN new.name = '' BEGIN SELECT RAISE(ABORT, 'empty name'); END"),
+					)
+					return &schema.ModifyTable{
+						T: users,
+						Changes: []schema.Change{
+							&schema.AddTrigger{T: users.Triggers[0]},
+						},
+					}
+				}(),
+				func() schema.Change {
+					pets := schema.NewTable("pets").AddColumns(schema.NewIntColumn("id", "bigint"))
+					pets.AddTriggers(
+						schema.NewTrigger("pets_insert").
+							SetActionTime(schema.TriggerTimeAfter).
+							AddEvents(schema.TriggerEvent{Name: schema.TriggerEventInsert}).
+							SetBody("BEGIN SELECT 1; END"),
+					)
+					return &schema.ModifyTable{
+						T: pets,
+						Changes: []schema.Change{
+							&schema.DropColumn{C: schema.NewStringColumn("name", "text")},
+						},
+					}
+				}(),
+			},
+			plan: &migrate.Plan{
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{Cmd: "PRAGMA foreign_keys = off"},
+					{Cmd: "CREATE TRIGGER `users_name` BEFORE UPDATE OF `name` ON `users` FOR EACH ROW WHEN new.name = '' BEGIN SELECT RAISE(ABORT, 'empty name'); END", Reverse: "DROP TRIGGER `users_name`"},
+					{Cmd: "CREATE TABLE `new_pets` (`id` bigint NOT NULL)", Reverse: "DROP TABLE `new_pets`"},
+					{Cmd: "INSERT INTO `new_pets` (`id`) SELECT `id` FROM `pets`"},
+					{Cmd: "DROP TABLE `pets`"},
+					{Cmd: "ALTER TABLE `new_pets` RENAME TO `pets`"},
+					{Cmd: "CREATE TRIGGER `pets_insert` AFTER INSERT ON `pets` FOR EACH ROW BEGIN SELECT 1; END", Reverse: "DROP TRIGGER `pets_insert`"},
+					{Cmd: "PRAGMA foreign_keys = on"},
+				},
+			},
+		},
 		{
 			changes: []schema.Change{
 				&schema.RenameTable{
@@ -338,6 +386,39 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		{
+			changes: func() []schema.Change {
+				from := schema.NewView("v1", "SELECT a FROM t")
+				to := schema.NewView("v1", "SELECT a, b FROM t")
+				return []schema.Change{
+					&schema.DropView{V: schema.NewView("v2", "SELECT 1;"), Extra: []schema.Clause{&schema.IfExists{}}},
+					&schema.ModifyView{From: from, To: to},
+					&schema.AddView{V: schema.NewView("v3", "SELECT b FROM t")},
+				}
+			}(),
+			plan: &migrate.Plan{
+				Reversible:    true,
+				Transactional: true,
+				Changes: []*migrate.Change{
+					{
+						Cmd:     "DROP VIEW IF EXISTS `v2`",
+						Reverse: "CREATE VIEW `v2` AS SELECT 1",
+					},
+					{
+						Cmd:     "DROP VIEW `v1`",
+						Reverse: "CREATE VIEW `v1` AS SELECT a FROM t",
+					},
+					{
+						Cmd:     "CREATE VIEW `v1` AS SELECT a, b FROM t",
+						Reverse: "DROP VIEW `v1`",
+					},
+					{
+						Cmd:     "CREATE VIEW `v3` AS SELECT b FROM t",
+						Reverse: "DROP VIEW `v3`",
+					},
+				},
+			},
+		},
 		// The default is no qualifier.
 		{
 			changes: []schema.Change{
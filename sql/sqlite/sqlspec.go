@@ -32,6 +32,12 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 		if err != nil {
 			return fmt.Errorf("specutil: failed converting to *schema.Realm: %w", err)
 		}
+		if err := specutil.ScanViews(v, d.Views, convertView); err != nil {
+			return fmt.Errorf("specutil: failed converting views: %w", err)
+		}
+		if err := specutil.ScanTriggers(v, d.Tables, convertTrigger); err != nil {
+			return fmt.Errorf("specutil: failed converting triggers: %w", err)
+		}
 	case *schema.Schema:
 		if len(d.Schemas) != 1 {
 			return fmt.Errorf("specutil: expecting document to contain a single schema, got %d", len(d.Schemas))
@@ -40,6 +46,12 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 		if err := specutil.Scan(&r, d.Schemas, d.Tables, convertTable); err != nil {
 			return err
 		}
+		if err := specutil.ScanViews(&r, d.Views, convertView); err != nil {
+			return err
+		}
+		if err := specutil.ScanTriggers(&r, d.Tables, convertTrigger); err != nil {
+			return err
+		}
 		r.Schemas[0].Realm = nil
 		*v = *r.Schemas[0]
 	default:
@@ -50,7 +62,7 @@ func evalSpec(p *hclparse.Parser, v any, input map[string]cty.Value) error {
 
 // MarshalSpec marshals v into an Atlas DDL document using a schemahcl.Marshaler.
 func MarshalSpec(v any, marshaler schemahcl.Marshaler) ([]byte, error) {
-	return specutil.Marshal(v, marshaler, schemaSpec)
+	return specutil.Marshal(v, marshaler, schemaSpec, viewSpec, nil, nil)
 }
 
 // convertTable converts a sqlspec.Table to a schema.Table. Table conversion is done without converting
@@ -76,6 +88,16 @@ func convertIndex(spec *sqlspec.Index, t *schema.Table) (*schema.Index, error) {
 	return idx, nil
 }
 
+// convertView converts a sqlspec.View to a schema.View.
+func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error) {
+	return specutil.View(spec, parent, convertColumnType)
+}
+
+// convertTrigger converts a sqlspec.Trigger to a schema.Trigger.
+func convertTrigger(spec *sqlspec.Trigger, parent *schema.Table) (*schema.Trigger, error) {
+	return specutil.Trigger(spec, parent, schema.TriggerForRow)
+}
+
 // convertColumn converts a sqlspec.Column into a schema.Column.
 func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error) {
 	c, err := specutil.Column(spec, convertColumnType)
@@ -130,6 +152,11 @@ func indexSpec(idx *schema.Index) (*sqlspec.Index, error) {
 	return spec, nil
 }
 
+// viewSpec converts from a concrete SQLite schema.View into a sqlspec.View.
+func viewSpec(v *schema.View) (*sqlspec.View, error) {
+	return specutil.FromView(v, columnTypeSpec)
+}
+
 // columnSpec converts from a concrete SQLite schema.Column into a sqlspec.Column.
 func columnSpec(c *schema.Column, _ *schema.Table) (*sqlspec.Column, error) {
 	s, err := specutil.FromColumn(c, columnTypeSpec)
@@ -198,6 +225,9 @@ var (
 		schemahcl.WithScopedEnums("table.column.as.type", stored, virtual),
 		schemahcl.WithScopedEnums("table.foreign_key.on_update", specutil.ReferenceVars...),
 		schemahcl.WithScopedEnums("table.foreign_key.on_delete", specutil.ReferenceVars...),
+		schemahcl.WithScopedEnums("table.trigger.timing", schema.TriggerTimeBefore, schema.TriggerTimeAfter, specutil.Var(schema.TriggerTimeInstead)),
+		schemahcl.WithScopedEnums("table.trigger.events", schema.TriggerEventInsert, schema.TriggerEventUpdate, schema.TriggerEventDelete),
+		schemahcl.WithScopedEnums("table.trigger.foreach", schema.TriggerForRow),
 	)
 	// MarshalHCL marshals v into an Atlas HCL DDL document.
 	MarshalHCL = schemahcl.MarshalerFunc(func(v any) ([]byte, error) {
@@ -222,5 +252,6 @@ func storedOrVirtual(s string) string {
 
 type doc struct {
 	Tables  []*sqlspec.Table  `spec:"table"`
+	Views   []*sqlspec.View   `spec:"view"`
 	Schemas []*sqlspec.Schema `spec:"schema"`
 }
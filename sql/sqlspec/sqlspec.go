@@ -27,6 +27,48 @@ type (
 		ForeignKeys []*ForeignKey  `spec:"foreign_key"`
 		Indexes     []*Index       `spec:"index"`
 		Checks      []*Check       `spec:"check"`
+		Triggers    []*Trigger     `spec:"trigger"`
+		schemahcl.DefaultExtension
+	}
+
+	// View holds a specification for an SQL view.
+	View struct {
+		Name      string         `spec:",name"`
+		Qualifier string         `spec:",qualifier"`
+		Schema    *schemahcl.Ref `spec:"schema"`
+		Columns   []*Column      `spec:"column"`
+		As        string         `spec:"as"`
+		schemahcl.DefaultExtension
+	}
+
+	// Func holds a specification for an SQL function.
+	Func struct {
+		Name      string          `spec:",name"`
+		Qualifier string          `spec:",qualifier"`
+		Schema    *schemahcl.Ref  `spec:"schema"`
+		Args      []*FuncArg      `spec:"arg"`
+		Return    *schemahcl.Type `spec:"return"`
+		Lang      string          `spec:"lang,omitempty"`
+		As        string          `spec:"as"`
+		schemahcl.DefaultExtension
+	}
+
+	// Proc holds a specification for an SQL procedure.
+	Proc struct {
+		Name      string         `spec:",name"`
+		Qualifier string         `spec:",qualifier"`
+		Schema    *schemahcl.Ref `spec:"schema"`
+		Args      []*FuncArg     `spec:"arg"`
+		Lang      string         `spec:"lang,omitempty"`
+		As        string         `spec:"as"`
+		schemahcl.DefaultExtension
+	}
+
+	// FuncArg holds a specification for an argument of a function or a procedure.
+	FuncArg struct {
+		Name    string          `spec:",name"`
+		Type    *schemahcl.Type `spec:"type"`
+		Default cty.Value       `spec:"default"`
 		schemahcl.DefaultExtension
 	}
 
@@ -79,11 +121,26 @@ type (
 		schemahcl.DefaultExtension
 	}
 
+	// Trigger holds a specification for a trigger on a table.
+	Trigger struct {
+		Name     string           `spec:",name"`
+		Timing   *schemahcl.Ref   `spec:"timing"`
+		Events   []*schemahcl.Ref `spec:"events"`
+		UpdateOf []*schemahcl.Ref `spec:"update_of"`
+		ForEach  *schemahcl.Ref   `spec:"foreach"`
+		Execute  *schemahcl.Ref   `spec:"execute"`
+		As       string           `spec:"as,omitempty"`
+		schemahcl.DefaultExtension
+	}
+
 	// Type represents a database agnostic column type.
 	Type string
 )
 
 func init() {
 	schemahcl.Register("table", &Table{})
+	schemahcl.Register("view", &View{})
+	schemahcl.Register("function", &Func{})
+	schemahcl.Register("procedure", &Proc{})
 	schemahcl.Register("schema", &Schema{})
 }